@@ -20,6 +20,7 @@ package main
 
 import (
 	"flag"
+	nethttp "net/http"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -145,6 +146,7 @@ func main() {
 		LeaderElectionClient: leClient,
 		NewCableEngine:       cableengine.NewEngine,
 		NewNATDiscovery:      natdiscovery.New,
+		DebugServeMux:        nethttp.DefaultServeMux,
 	})
 	logger.FatalOnError(err, "Error creating gateway instance")
 
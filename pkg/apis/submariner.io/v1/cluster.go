@@ -0,0 +1,106 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DirectCableAllowed returns true if the topology policies of both clusters allow a direct cable between them.
+func DirectCableAllowed(a, b *ClusterSpec) bool {
+	return a.allowsDirectCableTo(b) && b.allowsDirectCableTo(a)
+}
+
+// IsHub returns true if the cluster is a hub according to its own topology policy.
+func (c *ClusterSpec) IsHub() bool {
+	return c.Topology != nil && c.Topology.Mode == TopologyHubSpoke && c.Topology.isHub(c)
+}
+
+// SelectsByLabels returns true if the policy selects any cluster by its labels, ie whether the clusters it allows a direct
+// cable to can't be decided from their IDs alone.
+func (p *TopologyPolicy) SelectsByLabels() bool {
+	if p == nil {
+		return false
+	}
+
+	for _, selectors := range [][]ClusterSelector{p.Hubs, p.Allow, p.Deny} {
+		if slices.ContainsFunc(selectors, func(s ClusterSelector) bool {
+			return s.Selector != nil
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *ClusterSpec) allowsDirectCableTo(remote *ClusterSpec) bool {
+	policy := c.Topology
+	if policy == nil {
+		return true
+	}
+
+	if matchesAny(policy.Deny, remote) {
+		return false
+	}
+
+	switch policy.Mode {
+	case TopologyHubSpoke:
+		if policy.isHub(c) || policy.isHub(remote) {
+			return true
+		}
+	case TopologyPartialMesh:
+	case TopologyFullMesh, "":
+		return true
+	}
+
+	return matchesAny(policy.Allow, remote)
+}
+
+func (p *TopologyPolicy) isHub(cluster *ClusterSpec) bool {
+	return matchesAny(p.Hubs, cluster)
+}
+
+func matchesAny(selectors []ClusterSelector, cluster *ClusterSpec) bool {
+	return slices.ContainsFunc(selectors, func(s ClusterSelector) bool {
+		return s.Matches(cluster)
+	})
+}
+
+// Matches returns true if the selector selects the given cluster.
+func (s *ClusterSelector) Matches(cluster *ClusterSpec) bool {
+	if slices.Contains(s.ClusterIDs, cluster.ClusterID) {
+		return true
+	}
+
+	if s.Selector == nil {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(s.Selector)
+	if err != nil {
+		logger.Errorf(err, "Invalid cluster label selector %v", s.Selector)
+		return false
+	}
+
+	return !selector.Empty() && selector.Matches(labels.Set(cluster.Labels))
+}
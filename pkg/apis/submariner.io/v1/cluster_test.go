@@ -0,0 +1,147 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DirectCableAllowed", func() {
+	var (
+		east *v1.ClusterSpec
+		west *v1.ClusterSpec
+	)
+
+	BeforeEach(func() {
+		east = &v1.ClusterSpec{ClusterID: "east", Labels: map[string]string{"region": "us"}}
+		west = &v1.ClusterSpec{ClusterID: "west", Labels: map[string]string{"region": "eu"}}
+	})
+
+	When("neither cluster has a topology policy", func() {
+		It("should return true", func() {
+			Expect(v1.DirectCableAllowed(east, west)).To(BeTrue())
+		})
+	})
+
+	When("a cluster's policy is a full mesh", func() {
+		It("should return true", func() {
+			east.Topology = &v1.TopologyPolicy{Mode: v1.TopologyFullMesh}
+			Expect(v1.DirectCableAllowed(east, west)).To(BeTrue())
+		})
+	})
+
+	When("a cluster's policy is hub-spoke", func() {
+		BeforeEach(func() {
+			east.Topology = &v1.TopologyPolicy{
+				Mode: v1.TopologyHubSpoke,
+				Hubs: []v1.ClusterSelector{{ClusterIDs: []string{"hub"}}},
+			}
+		})
+
+		It("should disallow a cable between spokes", func() {
+			Expect(v1.DirectCableAllowed(east, west)).To(BeFalse())
+			Expect(v1.DirectCableAllowed(west, east)).To(BeFalse())
+			Expect(east.IsHub()).To(BeFalse())
+		})
+
+		It("should allow a cable to a hub", func() {
+			west.ClusterID = "hub"
+			Expect(v1.DirectCableAllowed(east, west)).To(BeTrue())
+		})
+
+		It("should allow a cable from a hub", func() {
+			east.ClusterID = "hub"
+			Expect(v1.DirectCableAllowed(east, west)).To(BeTrue())
+			Expect(east.IsHub()).To(BeTrue())
+		})
+	})
+
+	When("a cluster's policy is a partial mesh", func() {
+		BeforeEach(func() {
+			east.Topology = &v1.TopologyPolicy{Mode: v1.TopologyPartialMesh}
+		})
+
+		It("should only allow a cable to the allowed clusters", func() {
+			Expect(v1.DirectCableAllowed(east, west)).To(BeFalse())
+
+			east.Topology.Allow = []v1.ClusterSelector{{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}},
+			}}
+
+			Expect(v1.DirectCableAllowed(east, west)).To(BeTrue())
+		})
+	})
+
+	When("a cluster is both allowed and denied", func() {
+		It("should return false", func() {
+			west.Topology = &v1.TopologyPolicy{
+				Mode:  v1.TopologyPartialMesh,
+				Allow: []v1.ClusterSelector{{ClusterIDs: []string{"east"}}},
+				Deny:  []v1.ClusterSelector{{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "us"}}}},
+			}
+
+			Expect(v1.DirectCableAllowed(east, west)).To(BeFalse())
+		})
+	})
+
+	When("a selector is empty", func() {
+		It("should not match any cluster", func() {
+			east.Topology = &v1.TopologyPolicy{
+				Mode:  v1.TopologyPartialMesh,
+				Allow: []v1.ClusterSelector{{Selector: &metav1.LabelSelector{}}},
+			}
+
+			Expect(v1.DirectCableAllowed(east, west)).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("TopologyPolicy SelectsByLabels", func() {
+	When("the policy is nil", func() {
+		It("should return false", func() {
+			Expect((*v1.TopologyPolicy)(nil).SelectsByLabels()).To(BeFalse())
+		})
+	})
+
+	When("the policy only selects clusters by their IDs", func() {
+		It("should return false", func() {
+			policy := &v1.TopologyPolicy{
+				Mode:  v1.TopologyPartialMesh,
+				Allow: []v1.ClusterSelector{{ClusterIDs: []string{"east"}}},
+				Deny:  []v1.ClusterSelector{{ClusterIDs: []string{"west"}}},
+			}
+
+			Expect(policy.SelectsByLabels()).To(BeFalse())
+		})
+	})
+
+	When("the policy selects clusters by their labels", func() {
+		It("should return true", func() {
+			policy := &v1.TopologyPolicy{
+				Mode: v1.TopologyHubSpoke,
+				Hubs: []v1.ClusterSelector{{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "hub"}}}},
+			}
+
+			Expect(policy.SelectsByLabels()).To(BeTrue())
+		})
+	})
+})
@@ -20,7 +20,9 @@ package v1
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
@@ -45,6 +47,43 @@ func (ep *EndpointSpec) GetBackendPort(configName string, defaultValue int32) (i
 	return defaultValue, nil
 }
 
+// IsActiveActive returns true if the Endpoint's gateway runs in active/active mode, ie it is one of several gateways in
+// its cluster that are active at the same time.
+func (ep *EndpointSpec) IsActiveActive() bool {
+	activeActive, _ := ep.GetBackendBool(ActiveActive, nil)
+	return activeActive != nil && *activeActive
+}
+
+// IsRelay returns true if the Endpoint's gateway relays the traffic between clusters which can't reach each other.
+func (ep *EndpointSpec) IsRelay() bool {
+	relay, _ := ep.GetBackendBool(RelayConfig, nil)
+	return relay != nil && *relay
+}
+
+// GetRelayedClusters returns the IDs of the remote clusters the Endpoint's gateway reaches through a relay.
+func (ep *EndpointSpec) GetRelayedClusters() []string {
+	return ep.getClusterList(RelayedClustersConfig)
+}
+
+// CanRelayTo returns true if the Endpoint's gateway is a relay with a connected cable to the given cluster.
+func (ep *EndpointSpec) CanRelayTo(clusterID string) bool {
+	return ep.IsRelay() && slices.Contains(ep.getClusterList(ConnectedClustersConfig), clusterID)
+}
+
+func (ep *EndpointSpec) getClusterList(configName string) []string {
+	if clusterIDs := ep.BackendConfig[configName]; clusterIDs != "" {
+		return strings.Split(clusterIDs, ",")
+	}
+
+	return nil
+}
+
+// IsRelayedWith returns true if the traffic between the Endpoint's cluster and the other Endpoint's cluster is relayed,
+// ie either one reaches the other through a relay.
+func (ep *EndpointSpec) IsRelayedWith(other *EndpointSpec) bool {
+	return slices.Contains(ep.GetRelayedClusters(), other.ClusterID) || slices.Contains(other.GetRelayedClusters(), ep.ClusterID)
+}
+
 func (ep *EndpointSpec) GetBackendBool(configName string, defaultValue *bool) (*bool, error) {
 	if boolStr := ep.BackendConfig[configName]; boolStr != "" {
 		boolValue, err := strconv.ParseBool(boolStr)
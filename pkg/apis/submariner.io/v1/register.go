@@ -63,6 +63,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NonGatewayRouteList{},
 		&RouteAgent{},
 		&RouteAgentList{},
+		&ConnectivityPolicy{},
+		&ConnectivityPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 
@@ -45,6 +45,51 @@ type ClusterSpec struct {
 	ServiceCIDR []string `json:"service_cidr"`
 	ClusterCIDR []string `json:"cluster_cidr"`
 	GlobalCIDR  []string `json:"global_cidr"`
+	// Labels identify the cluster in the topology policies' cluster selectors.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Topology is the cluster's topology policy, which decides the remote clusters it has direct cables to.
+	// +optional
+	Topology *TopologyPolicy `json:"topology,omitempty"`
+}
+
+// TopologyMode defines the pairs of clusters which are connected by direct cables.
+type TopologyMode string
+
+const (
+	// TopologyFullMesh connects every pair of clusters with a direct cable, except those denied by the policy.
+	TopologyFullMesh TopologyMode = "FullMesh"
+	// TopologyHubSpoke connects the hubs with direct cables to every other cluster, and the spokes only to the hubs and
+	// the clusters allowed by the policy.
+	TopologyHubSpoke TopologyMode = "HubSpoke"
+	// TopologyPartialMesh only connects the clusters allowed by the policy with direct cables.
+	TopologyPartialMesh TopologyMode = "PartialMesh"
+)
+
+// TopologyPolicy decides which remote clusters a cluster has direct cables to. Two clusters are only connected by a
+// direct cable if both their policies allow it; the traffic between clusters without a direct cable transits through a
+// hub.
+type TopologyPolicy struct {
+	// Mode defaults to FullMesh.
+	// +optional
+	Mode TopologyMode `json:"mode,omitempty"`
+	// Hubs selects the hub clusters in HubSpoke mode. The hubs' gateways relay the traffic between the spokes.
+	// +optional
+	Hubs []ClusterSelector `json:"hubs,omitempty"`
+	// Allow selects the clusters with direct cables in addition to those of the mode.
+	// +optional
+	Allow []ClusterSelector `json:"allow,omitempty"`
+	// Deny selects the clusters without direct cables, taking precedence over the other settings.
+	// +optional
+	Deny []ClusterSelector `json:"deny,omitempty"`
+}
+
+// ClusterSelector selects clusters by ID or by their labels. A cluster is selected if it matches either.
+type ClusterSelector struct {
+	// +optional
+	ClusterIDs []string `json:"clusterIDs,omitempty"`
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -104,18 +149,26 @@ type EndpointSpec struct {
 }
 
 const (
-	GatewayConfigPrefix     = "gateway.submariner.io/"
-	UDPPortConfig           = "udp-port"
-	NATTDiscoveryPortConfig = "natt-discovery-port"
-	PreferredServerConfig   = "preferred-server"
-	PublicIP                = "public-ip"
-	UsingLoadBalancer       = "using-loadbalancer"
-	TCPMssValue             = "submariner.io/tcp-clamp-mss"
+	GatewayConfigPrefix        = "gateway.submariner.io/"
+	UDPPortConfig              = "udp-port"
+	NATTDiscoveryPortConfig    = "natt-discovery-port"
+	NATTDiscoveryVersionConfig = "natt-discovery-version"
+	PreferredServerConfig      = "preferred-server"
+	PublicIP                   = "public-ip"
+	UsingLoadBalancer          = "using-loadbalancer"
+	ActiveActive               = "active-active"
+	CertificateSubject         = "certificate-subject"
+	RelayConfig                = "relay"
+	RelayedClustersConfig      = "relayed-clusters"
+	ConnectedClustersConfig    = "connected-clusters"
+	HealthCheckProbePortConfig = "healthcheck-probe-port"
+	TCPMssValue                = "submariner.io/tcp-clamp-mss"
 )
 
 // Valid PublicIP resolvers.
 const (
 	IPv4         = "ipv4" // ipv4:1.2.3.4
+	IPv6         = "ipv6" // ipv6:2001:db8::1
 	LoadBalancer = "lb"   // lb:external-gw-lb
 	API          = "api"  // api:api.ipify.org
 	DNS          = "dns"  // dns:mygateway.dns.name.com
@@ -127,6 +180,7 @@ var ValidGatewayNodeConfig = []string{
 	NATTDiscoveryPortConfig,
 	PublicIP,
 	PreferredServerConfig,
+	RelayConfig,
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -154,8 +208,25 @@ type GatewayStatus struct {
 	LocalEndpoint EndpointSpec `json:"localEndpoint"`
 	StatusFailure string       `json:"statusFailure"`
 	Connections   []Connection `json:"connections"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type GatewayConditionType string
+
+const (
+	// GatewayCableDriverReady indicates whether the cable driver is running and reports its connections.
+	GatewayCableDriverReady GatewayConditionType = "CableDriverReady"
+	// GatewayNATDiscoveryComplete indicates whether NAT discovery has completed for all the remote endpoints.
+	GatewayNATDiscoveryComplete GatewayConditionType = "NATDiscoveryComplete"
+	// GatewayBrokerSynced indicates whether the local Cluster and Endpoint are synced with the broker.
+	GatewayBrokerSynced GatewayConditionType = "BrokerSynced"
+	// GatewayPublicIPResolved indicates whether the public IP of the local endpoint has been resolved.
+	GatewayPublicIPResolved GatewayConditionType = "PublicIPResolved"
+	// GatewayHealthCheckPassing indicates whether the health checks of all the connections pass.
+	GatewayHealthCheckPassing GatewayConditionType = "HealthCheckPassing"
+)
+
 // LatencySpec describes the round trip time information for a packet
 // between the gateway pods of two clusters.
 type LatencyRTTSpec struct {
@@ -189,6 +260,23 @@ type Connection struct {
 	UsingNAT      bool             `json:"usingNAT,omitempty"`
 	// +optional
 	LatencyRTT *LatencyRTTSpec `json:"latencyRTT,omitempty"`
+	// State is the state of the cable in the gateway's cable engine.
+	// +optional
+	State CableState `json:"state,omitempty"`
+	// RelayedVia is the cable name of the relay gateway's Endpoint if the remote endpoint is reached through a relay.
+	// +optional
+	RelayedVia string `json:"relayedVia,omitempty"`
+	// Quality is the connection quality measured by the UDP probes, if enabled.
+	// +optional
+	Quality *ConnectionQualitySpec `json:"quality,omitempty"`
+}
+
+// ConnectionQualitySpec describes the packet loss, jitter and throughput measured by UDP probes between the gateway pods
+// of two clusters.
+type ConnectionQualitySpec struct {
+	PacketLoss string `json:"packetLoss,omitempty"`
+	Jitter     string `json:"jitter,omitempty"`
+	Throughput string `json:"throughput,omitempty"`
 }
 
 type ConnectionStatus string
@@ -200,6 +288,32 @@ const (
 	ConnectionNone  ConnectionStatus = "none"
 )
 
+// CableState is the state of a cable as tracked by the cable engine, which drives the connection attempts.
+type CableState string
+
+const (
+	// CableStatePendingNATDiscovery indicates the remote endpoint is waiting for NAT discovery to complete.
+	CableStatePendingNATDiscovery CableState = "pending-nat-discovery"
+	// CableStateConnecting indicates the cable driver is connecting to the remote endpoint.
+	CableStateConnecting CableState = "connecting"
+	// CableStateConnected indicates the cable is installed.
+	CableStateConnected CableState = "connected"
+	// CableStateBackoff indicates the last connection attempt failed and the next one is delayed.
+	CableStateBackoff CableState = "backoff"
+	// CableStateSuppressed indicates the cable flapped too often and is disconnected until the suppression expires.
+	CableStateSuppressed CableState = "suppressed"
+	// CableStateRelayed indicates the remote endpoint is unreachable and its traffic is relayed by another cluster's gateway.
+	CableStateRelayed CableState = "relayed"
+	// CableStateExcluded indicates the topology policy doesn't allow a direct cable and no relay is connected.
+	CableStateExcluded CableState = "excluded"
+)
+
+// CableStates lists all the cable states.
+var CableStates = []CableState{
+	CableStatePendingNATDiscovery, CableStateConnecting, CableStateConnected, CableStateBackoff, CableStateSuppressed,
+	CableStateRelayed, CableStateExcluded,
+}
+
 // +genclient
 // +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -213,8 +327,17 @@ type RouteAgentStatus struct {
 	Version         string           `json:"version"`
 	StatusFailure   string           `json:"statusFailure"`
 	RemoteEndpoints []RemoteEndpoint `json:"remoteEndpoints"`
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+type RouteAgentConditionType string
+
+const (
+	// RouteAgentHealthCheckPassing indicates whether the health checks of all the remote endpoints pass.
+	RouteAgentHealthCheckPassing RouteAgentConditionType = "HealthCheckPassing"
+)
+
 type RemoteEndpoint struct {
 	Status        ConnectionStatus `json:"status"`
 	StatusMessage string           `json:"statusMessage"`
@@ -272,6 +395,28 @@ type GlobalEgressIPSpec struct {
 	// GlobalIP will be assigned.
 	// +optional
 	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Selects the namespaces whose pods this GlobalEgressIP applies to, instead of only the namespace of this GlobalEgressIP.
+	// The PodSelector and ServiceAccountName, if specified, further restrict the selected pods. Other namespaces are only
+	// selected while the namespace of this GlobalEgressIP also matches the selector. Without a PodSelector or
+	// ServiceAccountName, the GlobalEgressIP of a selected namespace which applies to the whole namespace takes precedence.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selects only the pods running under this service account.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// The GlobalCIDR from which to allocate the GlobalIPs. It must be one of the Globalnet CIDRs assigned to the cluster.
+	// If not specified, the GlobalIPs are allocated from the first Globalnet CIDR with enough available IPs.
+	// +optional
+	GlobalCIDR string `json:"globalCIDR,omitempty"`
+
+	// The specific GlobalIPs to allocate. They must be contiguous and available in one of the Globalnet CIDRs assigned to
+	// the cluster. If specified, NumberOfIPs defaults to their count.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	RequestedIPs []string `json:"requestedIPs,omitempty"`
 }
 
 type GlobalEgressIPConditionType string
@@ -279,6 +424,8 @@ type GlobalEgressIPConditionType string
 const (
 	GlobalEgressIPAllocated GlobalEgressIPConditionType = "Allocated"
 	GlobalEgressIPUpdated   GlobalEgressIPConditionType = "Updated"
+	// GlobalEgressIPConflict indicates that some of the allocated global IPs are also allocated to another resource.
+	GlobalEgressIPConflict GlobalEgressIPConditionType = "Conflict"
 )
 
 type GlobalEgressIPStatus struct {
@@ -364,6 +511,15 @@ type GlobalIngressIPSpec struct {
 	// The reference to a targeted Pod, if applicable.
 	// +Optional
 	PodRef *corev1.LocalObjectReference `json:"podRef,omitempty"`
+
+	// The GlobalCIDR from which to allocate the GlobalIP. It must be one of the Globalnet CIDRs assigned to the cluster.
+	// If not specified, the GlobalIP is allocated from the first Globalnet CIDR with an available IP.
+	// +optional
+	GlobalCIDR string `json:"globalCIDR,omitempty"`
+
+	// The specific GlobalIP to allocate. It must be available in one of the Globalnet CIDRs assigned to the cluster.
+	// +optional
+	RequestedIP string `json:"requestedIP,omitempty"`
 }
 
 type TargetType string
@@ -372,6 +528,9 @@ const (
 	ClusterIPService         TargetType = "ClusterIPService"
 	HeadlessServicePod       TargetType = "HeadlessServicePod"
 	HeadlessServiceEndpoints TargetType = "HeadlessServiceEndpoints"
+	LoadBalancerService      TargetType = "LoadBalancerService"
+	NodePortService          TargetType = "NodePortService"
+	Pod                      TargetType = "Pod"
 )
 
 type GlobalIngressIPStatus struct {
@@ -440,6 +599,70 @@ type RoutePolicySpec struct {
 	RemoteCIDRs []string `json:"remoteCIDRs"`
 }
 
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName="cpol"
+
+// ConnectivityPolicy restricts the traffic from the selected pods in the namespace of the ConnectivityPolicy object to the
+// remote clusters. Once a pod is selected by a ConnectivityPolicy, its traffic to the remote clusters' subnets is only
+// allowed if an egress rule of a ConnectivityPolicy selecting it allows it.
+type ConnectivityPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the specification of the desired behavior.
+	Spec ConnectivityPolicySpec `json:"spec"`
+}
+
+type ConnectivityPolicySpec struct {
+	// Selects specific pods in the namespace of this ConnectivityPolicy to which this ConnectivityPolicy applies. If not
+	// specified, all pods in the namespace are selected.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// The destinations in the remote clusters to which the selected pods are allowed to connect. If empty, all the
+	// traffic from the selected pods to the remote clusters is denied.
+	// +optional
+	Egress []ConnectivityPolicyEgressRule `json:"egress,omitempty"`
+}
+
+type ConnectivityPolicyEgressRule struct {
+	// The IDs of the remote clusters to which the traffic is allowed. If empty, the rule applies to all the remote
+	// clusters.
+	// +optional
+	ClusterIDs []string `json:"clusterIDs,omitempty"`
+
+	// The destination CIDRs to which the traffic is allowed, within the subnets of the remote clusters. If empty, all
+	// the subnets of the remote clusters are allowed.
+	// +optional
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// The destination ports to which the traffic is allowed. If empty, all the ports and protocols are allowed.
+	// +optional
+	Ports []ConnectivityPolicyPort `json:"ports,omitempty"`
+}
+
+type ConnectivityPolicyPort struct {
+	// The protocol, TCP or UDP. If not specified, defaults to TCP.
+	// +kubebuilder:validation:Enum=TCP;UDP
+	// +optional
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+
+	// The destination port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ConnectivityPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ConnectivityPolicy `json:"items"`
+}
+
 var EndpointGVR = schema.GroupVersionResource{
 	Group:    SchemeGroupVersion.Group,
 	Version:  SchemeGroupVersion.Version,
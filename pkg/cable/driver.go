@@ -27,6 +27,7 @@ import (
 	"github.com/submariner-io/submariner/pkg/endpoint"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -55,8 +56,40 @@ type Driver interface {
 	Cleanup() error
 }
 
+// RelayingDriver is implemented by the drivers which can relay traffic between remote clusters, ie which configure the
+// RelayedSubnets passed to ConnectToEndpoint. The traffic is only relayed through gateways with such a driver.
+type RelayingDriver interface {
+	SupportsRelaying() bool
+}
+
+// SupportsRelaying returns true if the driver can relay traffic between remote clusters.
+func SupportsRelaying(driver Driver) bool {
+	relaying, ok := driver.(RelayingDriver)
+	return ok && relaying.SupportsRelaying()
+}
+
+// ReconnectingDriver is implemented by the drivers which need the cables to a remote cluster re-installed when something
+// changes outside of the engine's control, eg the cluster's pre-shared key.
+type ReconnectingDriver interface {
+	// SetReconnectHandler registers the function the driver calls, from its own goroutine, with the ID of each remote
+	// cluster whose cables must be re-installed. It's called once the driver is initialized.
+	SetReconnectHandler(reconnect func(clusterID string))
+}
+
+// DriverConfig holds the gateway settings passed to drivers when they're created.
+type DriverConfig struct {
+	// KubeClient is used by drivers that keep state in the cluster, eg to persist keys in a secret. It may be nil.
+	KubeClient kubernetes.Interface
+	// HaltOnCertError makes drivers fail to start on certificate errors rather than report the affected connections in
+	// error.
+	HaltOnCertError bool
+}
+
 // Function prototype to create a new driver.
-type DriverCreateFunc func(localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster) (Driver, error)
+type DriverCreateFunc func(localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster, config *DriverConfig) (Driver, error)
+
+// Function prototype to look up a driver that isn't compiled in, eg one provided by a plugin.
+type DriverResolveFunc func(name string) (DriverCreateFunc, bool)
 
 const (
 	InterfaceNameConfig = "interface-name"
@@ -66,6 +99,9 @@ const (
 // Static map of supported drivers.
 var drivers = map[string]DriverCreateFunc{}
 
+// Resolvers consulted for drivers which aren't in the static map.
+var driverResolvers []DriverResolveFunc
+
 // Default name of the cable driver.
 var defaultCableDriver string
 
@@ -80,12 +116,21 @@ func AddDriver(name string, driverCreate DriverCreateFunc) {
 	drivers[name] = driverCreate
 }
 
+// Adds a resolver for drivers which aren't registered with AddDriver.
+func AddDriverResolver(resolve DriverResolveFunc) {
+	driverResolvers = append(driverResolvers, resolve)
+}
+
 // Returns a new driver according the required Backend.
-func NewDriver(localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster) (Driver, error) {
-	// We'll panic if localEndpoint or localCluster are nil, this is intentional
+func NewDriver(localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster, config *DriverConfig) (Driver, error) {
+	// We'll panic if localEndpoint, localCluster or config are nil, this is intentional
 	spec := localEndpoint.Spec()
 
 	driverCreate, ok := drivers[spec.Backend]
+	for i := 0; !ok && i < len(driverResolvers); i++ {
+		driverCreate, ok = driverResolvers[i](spec.Backend)
+	}
+
 	if !ok {
 		var driverList strings.Builder
 
@@ -100,7 +145,7 @@ func NewDriver(localEndpoint *endpoint.Local, localCluster *types.SubmarinerClus
 		return nil, fmt.Errorf("unsupported cable type %s; supported types: %s", spec.Backend, driverList.String())
 	}
 
-	return driverCreate(localEndpoint, localCluster)
+	return driverCreate(localEndpoint, localCluster, config)
 }
 
 // Sets the default cable driver name, if it is not specified by user.
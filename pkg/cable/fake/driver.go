@@ -41,6 +41,9 @@ type Driver struct {
 	ErrOnConnectToEndpoint      error
 	disconnectFromEndpoint      chan *types.SubmarinerEndpoint
 	ErrOnDisconnectFromEndpoint error
+	RelayingUnsupported         bool
+	reconnect                   func(clusterID string)
+	cleanup                     chan struct{}
 }
 
 func New() *Driver {
@@ -49,6 +52,7 @@ func New() *Driver {
 		activeConnections:      map[string]v1.Connection{},
 		connectToEndpoint:      make(chan *natdiscovery.NATEndpointInfo, 50),
 		disconnectFromEndpoint: make(chan *types.SubmarinerEndpoint, 50),
+		cleanup:                make(chan struct{}, 50),
 	}
 }
 
@@ -126,6 +130,27 @@ func (d *Driver) GetName() string {
 	return DriverName
 }
 
+func (d *Driver) SupportsRelaying() bool {
+	return !d.RelayingUnsupported
+}
+
+func (d *Driver) SetReconnectHandler(reconnect func(clusterID string)) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.reconnect = reconnect
+}
+
+// Reconnect requests the cables to the given remote cluster be re-installed, via the registered reconnect handler.
+func (d *Driver) Reconnect(clusterID string) {
+	d.mutex.Lock()
+	reconnect := d.reconnect
+	d.mutex.Unlock()
+
+	Expect(reconnect).ToNot(BeNil(), "SetReconnectHandler was not called")
+	reconnect(clusterID)
+}
+
 func (d *Driver) AwaitInit() {
 	Eventually(d.init, 5).Should(BeClosed(), "Init was not called")
 }
@@ -146,6 +171,16 @@ func (d *Driver) AwaitNoDisconnectFromEndpoint() {
 	Consistently(d.disconnectFromEndpoint, 500*time.Millisecond).ShouldNot(Receive(), "DisconnectFromEndpoint was unexpectedly called")
 }
 
+func (d *Driver) AwaitCleanup() {
+	Eventually(d.cleanup, 5).Should(Receive(), "Cleanup was not called")
+}
+
+func (d *Driver) AwaitNoCleanup() {
+	Consistently(d.cleanup, 500*time.Millisecond).ShouldNot(Receive(), "Cleanup was unexpectedly called")
+}
+
 func (d *Driver) Cleanup() error {
+	d.cleanup <- struct{}{}
+
 	return nil
 }
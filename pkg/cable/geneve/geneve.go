@@ -0,0 +1,511 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geneve
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cidr"
+	"github.com/submariner-io/submariner/pkg/cni"
+	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	netlinkAPI "github.com/submariner-io/submariner/pkg/netlink"
+	"github.com/submariner-io/submariner/pkg/types"
+	"github.com/submariner-io/submariner/pkg/vxlan"
+	"github.com/vishvananda/netlink"
+	k8snet "k8s.io/utils/net"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	GeneveIfacePrefix       = "gnv-"
+	GeneveVTepNetworkPrefix = 242
+	CableDriverName         = "geneve"
+	TableID                 = 101
+	DefaultPort             = 4500
+	MTUOverhead             = 50
+	vniMask                 = 0xffffff
+)
+
+// The GENEVE driver creates a point-to-point GENEVE device per remote endpoint since, unlike VXLAN, GENEVE devices don't
+// support forwarding database entries: the device's remote address takes the place of the FDB entry used by the vxlan
+// driver. Each device uses a VNI derived from the local and remote cluster IDs, see VNI, so that the receiving gateway, and
+// anything downstream of it, can identify the cluster the traffic comes from. The active/active gateways of a remote
+// cluster share its VNI but each gets its own device, the kernel tells their traffic apart by the remote address.
+type geneve struct {
+	localEndpoint v1.EndpointSpec
+	localCluster  types.SubmarinerCluster
+	connections   []v1.Connection
+	mutex         sync.Mutex
+	netLink       netlinkAPI.Interface
+	vtepIP        net.IP
+	port          uint16
+	mtu           int
+	// Maps the VNI of each connected remote cluster to its ID, to detect VNI collisions.
+	vniClusters map[uint32]string
+	// A cluster's subnets can only be routed through a single device so, with active/active remote gateways, only one
+	// of each cluster's endpoints holds its routes at any time.
+	routesOwners map[string]string // clusterID -> cable name of the endpoint whose device holds the cluster's routes
+}
+
+var logger = log.Logger{Logger: logf.Log.WithName("geneve")}
+
+func init() {
+	cable.AddDriver(CableDriverName, NewDriver)
+}
+
+func NewDriver(localEndpoint *submendpoint.Local, localCluster *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
+	// We'll panic if localEndpoint or localCluster are nil, this is intentional
+	var err error
+
+	g := geneve{
+		localEndpoint: *localEndpoint.Spec(),
+		netLink:       netlinkAPI.New(),
+		localCluster:  *localCluster,
+		vniClusters:   map[uint32]string{},
+		routesOwners:  map[string]string{},
+	}
+
+	if g.localEndpoint.NATEnabled {
+		logger.Warning("The GENEVE cable driver is supported only with no NAT deployments")
+	}
+
+	port, err := g.localEndpoint.GetBackendPort(v1.UDPPortConfig, DefaultPort)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the UDP port configuration")
+	}
+
+	g.port = uint16(port) //nolint:gosec // The port is validated by GetBackendPort
+
+	ipAddr := g.localEndpoint.GetPrivateIP(k8snet.IPv4)
+
+	g.vtepIP, err = vxlan.GetVtepIPAddressFrom(ipAddr, GeneveVTepNetworkPrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to derive the GENEVE vtepIP for %s", ipAddr)
+	}
+
+	defaultHostIface, err := netlinkAPI.GetDefaultGatewayInterface()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to find the default interface on host: %s", g.localEndpoint.Hostname)
+	}
+
+	g.mtu = defaultHostIface.MTU - MTUOverhead
+
+	err = g.netLink.RuleAddIfNotPresent(netlinkAPI.NewTableRule(TableID))
+	if err != nil && !os.IsExist(err) {
+		return nil, errors.Wrap(err, "failed to add ip rule")
+	}
+
+	return &g, nil
+}
+
+// VNI returns the GENEVE VNI used for the tunnel between the given clusters. It's derived from both cluster IDs so that
+// both ends agree on it.
+func VNI(clusterID1, clusterID2 string) uint32 {
+	if clusterID1 > clusterID2 {
+		clusterID1, clusterID2 = clusterID2, clusterID1
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(clusterID1 + "\x00" + clusterID2))
+
+	// VNI 0 is valid but avoid it as it's commonly used as a default.
+	return max(h.Sum32()&vniMask, 1)
+}
+
+// IfaceName returns the name of the GENEVE device for the remote endpoint with the given cable name.
+func IfaceName(cableName string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cableName))
+
+	return fmt.Sprintf("%s%08x", GeneveIfacePrefix, h.Sum32())
+}
+
+func (g *geneve) ensureLink(name string, vni uint32, remoteIP net.IP) (netlink.Link, error) {
+	link := &netlink.Geneve{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:  name,
+			MTU:   g.mtu,
+			Flags: net.FlagUp,
+		},
+		ID:     vni,
+		Remote: remoteIP,
+		Dport:  g.port,
+	}
+
+	err := g.netLink.LinkAdd(link)
+	if errors.Is(err, syscall.EEXIST) {
+		existing, err := g.netLink.LinkByName(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving GENEVE link %q", name)
+		}
+
+		if e, ok := existing.(*netlink.Geneve); ok && e.ID == vni && e.Remote.Equal(remoteIP) && e.Dport == g.port {
+			return existing, nil
+		}
+
+		logger.Warningf("Re-creating GENEVE interface %q as its configuration differs", name)
+
+		if err = g.netLink.LinkDel(existing); err != nil {
+			return nil, errors.Wrapf(err, "error deleting existing GENEVE device %q", name)
+		}
+
+		err = g.netLink.LinkAdd(link)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error re-creating GENEVE device %q", name)
+		}
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "error creating GENEVE device %q", name)
+	}
+
+	return link, nil
+}
+
+func (g *geneve) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo) (string, error) {
+	// We'll panic if endpointInfo is nil, this is intentional
+	remoteEndpoint := endpointInfo.Endpoint
+	if g.localEndpoint.ClusterID == remoteEndpoint.Spec.ClusterID {
+		logger.V(log.DEBUG).Infof("Will not connect to self")
+		return "", nil
+	}
+
+	remoteIP := net.ParseIP(endpointInfo.UseIP)
+	if remoteIP == nil {
+		return "", fmt.Errorf("failed to parse remote IP %s", endpointInfo.UseIP)
+	}
+
+	vni := VNI(g.localEndpoint.ClusterID, remoteEndpoint.Spec.ClusterID)
+
+	logger.V(log.DEBUG).Infof("Connecting cluster %s endpoint %s with VNI %d", remoteEndpoint.Spec.ClusterID, remoteIP, vni)
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	// The VNIs are hashed so two remote clusters could end up with the same one, in which case the traffic from both
+	// would be indistinguishable.
+	if clusterID, ok := g.vniClusters[vni]; ok && clusterID != remoteEndpoint.Spec.ClusterID {
+		return endpointInfo.UseIP, fmt.Errorf("the GENEVE VNI %d for cluster %q collides with the VNI for cluster %q",
+			vni, remoteEndpoint.Spec.ClusterID, clusterID)
+	}
+
+	cable.RecordConnection(CableDriverName, &g.localEndpoint, &remoteEndpoint.Spec, string(v1.Connected), true)
+
+	remoteVtepIP, err := remoteVtepIPFor(&remoteEndpoint.Spec)
+	if err != nil {
+		return endpointInfo.UseIP, err
+	}
+
+	link, err := g.ensureLink(IfaceName(remoteEndpoint.Spec.CableName), vni, remoteIP)
+	if err != nil {
+		return endpointInfo.UseIP, err
+	}
+
+	// The devices are point-to-point so each one gets the local VTEP IP with the remote VTEP IP as its peer.
+	err = g.netLink.AddrAddIfNotPresent(link, &netlink.Addr{
+		IPNet: &net.IPNet{IP: g.vtepIP, Mask: net.CIDRMask(32, 32)},
+		Peer:  &net.IPNet{IP: remoteVtepIP, Mask: net.CIDRMask(32, 32)},
+	})
+	if err != nil {
+		return endpointInfo.UseIP, fmt.Errorf("failed to configure the address on GENEVE device %q: %w", link.Attrs().Name, err)
+	}
+
+	err = g.netLink.EnsureLooseModeIsConfigured(link.Attrs().Name)
+	if err != nil {
+		return endpointInfo.UseIP, fmt.Errorf("error while validating loose mode on %q: %w", link.Attrs().Name, err)
+	}
+
+	err = g.netLink.EnableForwarding(link.Attrs().Name)
+	if err != nil {
+		return endpointInfo.UseIP, fmt.Errorf("error enabling forwarding on the %q iface: %w", link.Attrs().Name, err)
+	}
+
+	err = g.netLink.LinkSetUp(link)
+	if err != nil {
+		return endpointInfo.UseIP, fmt.Errorf("error bringing up GENEVE device %q: %w", link.Attrs().Name, err)
+	}
+
+	// The other endpoints of an active/active cluster take over its routes when the endpoint holding them disconnects.
+	if owner, found := g.routesOwners[remoteEndpoint.Spec.ClusterID]; !found || owner == remoteEndpoint.Spec.CableName {
+		err = g.addRoutes(&remoteEndpoint.Spec, remoteVtepIP, link.Attrs().Index)
+		if err != nil {
+			return endpointInfo.UseIP, err
+		}
+
+		g.routesOwners[remoteEndpoint.Spec.ClusterID] = remoteEndpoint.Spec.CableName
+	}
+
+	g.vniClusters[vni] = remoteEndpoint.Spec.ClusterID
+
+	// The endpoint may be re-connected, e.g. if its IP changed, in which case its existing connection is replaced.
+	g.connections = append(removeConnectionForEndpoint(g.connections, remoteEndpoint.Spec.CableName), v1.Connection{
+		Endpoint: remoteEndpoint.Spec, Status: v1.Connected,
+		UsingIP: endpointInfo.UseIP, UsingNAT: endpointInfo.UseNAT,
+	})
+
+	logger.V(log.DEBUG).Infof("Done adding endpoint for cluster %s", remoteEndpoint.Spec.ClusterID)
+
+	return endpointInfo.UseIP, nil
+}
+
+func (g *geneve) DisconnectFromEndpoint(remoteEndpoint *types.SubmarinerEndpoint) error {
+	// We'll panic if remoteEndpoint is nil, this is intentional
+	logger.V(log.DEBUG).Infof("Removing endpoint %#v", remoteEndpoint)
+
+	if g.localEndpoint.ClusterID == remoteEndpoint.Spec.ClusterID {
+		logger.V(log.DEBUG).Infof("Will not disconnect self")
+		return nil
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	found := false
+
+	for i := range g.connections {
+		if g.connections[i].Endpoint.CableName == remoteEndpoint.Spec.CableName {
+			found = true
+		}
+	}
+
+	if !found {
+		logger.Errorf(nil, "Cannot disconnect remote endpoint %q - no prior connection entry found", remoteEndpoint.Spec.CableName)
+		return nil
+	}
+
+	clusterID := remoteEndpoint.Spec.ClusterID
+	ownsRoutes := g.routesOwners[clusterID] == remoteEndpoint.Spec.CableName
+	name := IfaceName(remoteEndpoint.Spec.CableName)
+
+	link, err := g.netLink.LinkByName(name)
+	if err == nil {
+		if ownsRoutes {
+			allowedIPs := parseSubnets(cidr.ExtractIPv4Subnets(remoteEndpoint.Spec.Subnets))
+
+			err = g.netLink.DeleteDestinationRoutes(allowedIPs, link.Attrs().Index, TableID)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove route for the CIDR %q: %w", allowedIPs, err)
+			}
+		}
+
+		err = g.netLink.LinkDel(link)
+		if err != nil {
+			return fmt.Errorf("failed to delete GENEVE device %q: %w", name, err)
+		}
+	} else if !errors.Is(err, netlink.LinkNotFoundError{}) {
+		return fmt.Errorf("failed to retrieve GENEVE device %q: %w", name, err)
+	}
+
+	g.connections = removeConnectionForEndpoint(g.connections, remoteEndpoint.Spec.CableName)
+	cable.RecordDisconnected(CableDriverName, &g.localEndpoint, &remoteEndpoint.Spec)
+
+	if ownsRoutes {
+		delete(g.routesOwners, clusterID)
+		g.reassignRoutes(clusterID)
+	}
+
+	if !slices.ContainsFunc(g.connections, func(c v1.Connection) bool {
+		return c.Endpoint.ClusterID == clusterID
+	}) {
+		delete(g.vniClusters, VNI(g.localEndpoint.ClusterID, clusterID))
+	}
+
+	logger.V(log.DEBUG).Infof("Done removing endpoint for cluster %s", clusterID)
+
+	return nil
+}
+
+// reassignRoutes moves the given cluster's routes to the device of one of its remaining endpoints, if any, after the
+// endpoint holding them was disconnected.
+func (g *geneve) reassignRoutes(clusterID string) {
+	for i := range g.connections {
+		remoteEndpoint := &g.connections[i].Endpoint
+		if remoteEndpoint.ClusterID != clusterID {
+			continue
+		}
+
+		link, err := g.netLink.LinkByName(IfaceName(remoteEndpoint.CableName))
+		if err != nil {
+			logger.Errorf(err, "Failed to retrieve the GENEVE device for endpoint %q", remoteEndpoint.CableName)
+			continue
+		}
+
+		remoteVtepIP, err := remoteVtepIPFor(remoteEndpoint)
+		if err == nil {
+			err = g.addRoutes(remoteEndpoint, remoteVtepIP, link.Attrs().Index)
+		}
+
+		if err != nil {
+			logger.Errorf(err, "Failed to move the routes for cluster %s to endpoint %q", clusterID, remoteEndpoint.CableName)
+			continue
+		}
+
+		logger.Infof("Moved the routes for cluster %s to endpoint %q", clusterID, remoteEndpoint.CableName)
+
+		g.routesOwners[clusterID] = remoteEndpoint.CableName
+
+		return
+	}
+}
+
+// addRoutes routes the given remote endpoint's subnets through the device with the given index.
+func (g *geneve) addRoutes(remoteEndpoint *v1.EndpointSpec, remoteVtepIP net.IP, linkIndex int) error {
+	// The VTEP addresses are derived from the IPv4 private IPs so only IPv4 subnets are routed over the GENEVE tunnel.
+	allowedIPs := parseSubnets(cidr.ExtractIPv4Subnets(remoteEndpoint.Subnets))
+
+	var ipAddress net.IP
+
+	cniIface, err := cni.Discover(cidr.ExtractIPv4Subnets(g.localCluster.Spec.ClusterCIDR))
+	if err == nil {
+		ipAddress = net.ParseIP(cniIface.IPAddress)
+	} else {
+		logger.Errorf(nil, "Failed to get the CNI interface IP for cluster CIDR %q, host-networking use-cases may not work",
+			g.localCluster.Spec.ClusterCIDR[0])
+	}
+
+	err = g.netLink.AddDestinationRoutes(allowedIPs, remoteVtepIP, ipAddress, linkIndex, TableID)
+	if err != nil {
+		return fmt.Errorf("failed to add route for the CIDR %q with remoteVtepIP %q: %w", allowedIPs, remoteVtepIP, err)
+	}
+
+	return nil
+}
+
+func remoteVtepIPFor(remoteEndpoint *v1.EndpointSpec) (net.IP, error) {
+	privateIP := remoteEndpoint.GetPrivateIP(k8snet.IPv4)
+
+	remoteVtepIP, err := vxlan.GetVtepIPAddressFrom(privateIP, GeneveVTepNetworkPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the GENEVE vtepIP for %s: %w", privateIP, err)
+	}
+
+	return remoteVtepIP, nil
+}
+
+func removeConnectionForEndpoint(connections []v1.Connection, cableName string) []v1.Connection {
+	for j := range connections {
+		if connections[j].Endpoint.CableName == cableName {
+			copy(connections[j:], connections[j+1:])
+			return connections[:len(connections)-1]
+		}
+	}
+
+	return connections
+}
+
+func (g *geneve) GetConnections() ([]v1.Connection, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for i := range g.connections {
+		g.recordTraffic(&g.connections[i].Endpoint)
+	}
+
+	return g.connections, nil
+}
+
+// recordTraffic records the traffic statistics of the remote endpoint's device, which only carries its traffic.
+func (g *geneve) recordTraffic(remoteEndpoint *v1.EndpointSpec) {
+	link, err := g.netLink.LinkByName(IfaceName(remoteEndpoint.CableName))
+	if err != nil || link.Attrs().Statistics == nil {
+		return
+	}
+
+	cable.RecordRxBytes(CableDriverName, &g.localEndpoint, remoteEndpoint, int(link.Attrs().Statistics.RxBytes)) //nolint:gosec // Overflow is unlikely
+	cable.RecordTxBytes(CableDriverName, &g.localEndpoint, remoteEndpoint, int(link.Attrs().Statistics.TxBytes)) //nolint:gosec // Overflow is unlikely
+	cable.RecordRxPackets(CableDriverName, &g.localEndpoint, remoteEndpoint, link.Attrs().Statistics.RxPackets)
+	cable.RecordTxPackets(CableDriverName, &g.localEndpoint, remoteEndpoint, link.Attrs().Statistics.TxPackets)
+}
+
+func (g *geneve) GetActiveConnections() ([]v1.Connection, error) {
+	return g.connections, nil
+}
+
+func (g *geneve) Init() error {
+	return nil
+}
+
+func (g *geneve) GetName() string {
+	return CableDriverName
+}
+
+// Parse CIDR string and skip errors.
+func parseSubnets(subnets []string) []net.IPNet {
+	nets := make([]net.IPNet, 0, len(subnets))
+
+	for _, sn := range subnets {
+		_, cidr, err := net.ParseCIDR(sn)
+		if err != nil {
+			// this should not happen. Log and continue
+			logger.Errorf(err, "Failed to parse subnet %s", sn)
+			continue
+		}
+
+		nets = append(nets, *cidr)
+	}
+
+	return nets
+}
+
+func (g *geneve) Cleanup() error {
+	logger.Infof("Uninstalling the GENEVE cable driver")
+
+	return DeleteDevicesAndRoutes(g.netLink)
+}
+
+// DeleteDevicesAndRoutes removes the GENEVE devices, routes and IP rule installed by the driver. It's also used by the
+// route agent when the node is no longer the gateway, since the driver may not have been cleaned up.
+func DeleteDevicesAndRoutes(netLink netlinkAPI.Interface) error {
+	err := netLink.FlushRouteTable(TableID)
+	if err != nil {
+		logger.Errorf(err, "Unable to flush the routes from table %d", TableID)
+	}
+
+	links, err := netLink.LinkList()
+	if err != nil {
+		logger.Errorf(err, "Unable to list the links to remove the GENEVE interfaces")
+	}
+
+	for _, link := range links {
+		if _, ok := link.(*netlink.Geneve); !ok || !strings.HasPrefix(link.Attrs().Name, GeneveIfacePrefix) {
+			continue
+		}
+
+		if err := netLink.LinkDel(link); err != nil {
+			logger.Errorf(err, "Unable to delete interface %s", link.Attrs().Name)
+		}
+	}
+
+	err = netLink.RuleDelIfPresent(netlinkAPI.NewTableRule(TableID))
+	if err != nil {
+		return errors.Wrapf(err, "unable to delete IP rule pointing to %d table", TableID)
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package geneve_test
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/log/kzerolog"
+	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/geneve"
+	"github.com/submariner-io/submariner/pkg/cni"
+	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	netlinkAPI "github.com/submariner-io/submariner/pkg/netlink"
+	fakeNetlink "github.com/submariner-io/submariner/pkg/netlink/fake"
+	"github.com/submariner-io/submariner/pkg/types"
+	"github.com/vishvananda/netlink"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func init() {
+	kzerolog.AddFlags(nil)
+}
+
+var _ = BeforeSuite(func() {
+	flags := flag.NewFlagSet("kzerolog", flag.ExitOnError)
+	kzerolog.AddFlags(flags)
+	_ = flags.Parse([]string{"-v=4"})
+
+	kzerolog.InitK8sLogging()
+})
+
+func TestGeneve(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Geneve Cable Driver Suite")
+}
+
+const (
+	cniIPAddress = "192.168.5.1"
+	linkIndex    = 5
+)
+
+var _ = Describe("Geneve", func() {
+	t := newTestDriver()
+
+	var (
+		natInfo   *natdiscovery.NATEndpointInfo
+		ifaceName string
+	)
+
+	BeforeEach(func() {
+		natInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:  "east",
+					CableName:  "submariner-cable-east-192-68-2-1",
+					PrivateIPs: []string{"192.68.2.1"},
+					Subnets:    []string{"20.0.0.0/16", "21.0.0.0/16"},
+				},
+			},
+			UseIP: "172.93.2.1",
+		}
+
+		ifaceName = geneve.IfaceName(natInfo.Endpoint.Spec.CableName)
+		t.netLink.SetLinkIndex(ifaceName, linkIndex)
+	})
+
+	JustBeforeEach(func() {
+		t.netLink.AwaitRule(geneve.TableID, "", "")
+	})
+
+	Specify("ConnectToEndpoint should create a Connection and add expected data-plane components", func() {
+		ip, err := t.driver.ConnectToEndpoint(natInfo)
+		Expect(err).To(Succeed())
+		Expect(ip).To(Equal(natInfo.UseIP))
+
+		t.assertConnection(natInfo)
+
+		link := t.netLink.AwaitLink(ifaceName)
+		gnv, ok := link.(*netlink.Geneve)
+		Expect(ok).To(BeTrue(), "Unexpected Link type: %T", link)
+		Expect(gnv.ID).To(Equal(geneve.VNI("local", "east")))
+		Expect(gnv.Remote.String()).To(Equal(natInfo.UseIP))
+		Expect(gnv.Dport).To(Equal(uint16(geneve.DefaultPort)))
+
+		t.netLink.AwaitGwRoutes(linkIndex, geneve.TableID, fmt.Sprintf("%d.68.2.1", geneve.GeneveVTepNetworkPrefix))
+		t.netLink.AwaitDstRoutes(linkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+	})
+
+	When("an endpoint is re-connected with a different IP", func() {
+		It("should replace its Connection", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			natInfo.UseIP = "172.93.2.2"
+
+			_, err = t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			t.assertConnection(natInfo)
+		})
+	})
+
+	When("the VNI for another remote cluster collides with a connected cluster's VNI", func() {
+		It("should fail to connect to it", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			// This cluster ID was found by brute force to hash to the same VNI as "east".
+			colliding := natInfo.Endpoint.DeepCopy()
+			colliding.Spec.ClusterID = "west-16854573"
+			colliding.Spec.CableName = "submariner-cable-west-192-68-3-1"
+			Expect(geneve.VNI("local", colliding.Spec.ClusterID)).To(Equal(geneve.VNI("local", "east")))
+
+			_, err = t.driver.ConnectToEndpoint(&natdiscovery.NATEndpointInfo{Endpoint: *colliding, UseIP: "172.93.3.1"})
+			Expect(err).To(HaveOccurred())
+
+			t.assertConnection(natInfo)
+		})
+	})
+
+	Specify("DisconnectFromEndpoint should remove the Connection and its data-plane components", func() {
+		_, err := t.driver.ConnectToEndpoint(natInfo)
+		Expect(err).To(Succeed())
+
+		Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+		t.assertNoConnection()
+		t.netLink.AwaitNoLink(ifaceName)
+		t.netLink.AwaitNoDstRoutes(linkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+	})
+
+	When("the remote cluster has active/active gateways", func() {
+		const otherLinkIndex = linkIndex + 1
+
+		var (
+			otherNATInfo   *natdiscovery.NATEndpointInfo
+			otherIfaceName string
+		)
+
+		BeforeEach(func() {
+			natInfo.Endpoint.Spec.BackendConfig = map[string]string{subv1.ActiveActive: "true"}
+
+			otherNATInfo = &natdiscovery.NATEndpointInfo{
+				Endpoint: *natInfo.Endpoint.DeepCopy(),
+				UseIP:    "172.93.2.2",
+			}
+			otherNATInfo.Endpoint.Spec.CableName = "submariner-cable-east-192-68-2-2"
+			otherNATInfo.Endpoint.Spec.PrivateIPs = []string{"192.68.2.2"}
+
+			otherIfaceName = geneve.IfaceName(otherNATInfo.Endpoint.Spec.CableName)
+			t.netLink.SetLinkIndex(otherIfaceName, otherLinkIndex)
+		})
+
+		JustBeforeEach(func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			_, err = t.driver.ConnectToEndpoint(otherNATInfo)
+			Expect(err).To(Succeed())
+		})
+
+		It("should create a device with the same VNI for each gateway and route the subnets through the first one", func() {
+			conns, err := t.driver.GetActiveConnections()
+			Expect(err).To(Succeed())
+			Expect(conns).To(HaveLen(2))
+
+			for name, info := range map[string]*natdiscovery.NATEndpointInfo{ifaceName: natInfo, otherIfaceName: otherNATInfo} {
+				gnv, ok := t.netLink.AwaitLink(name).(*netlink.Geneve)
+				Expect(ok).To(BeTrue())
+				Expect(gnv.ID).To(Equal(geneve.VNI("local", "east")))
+				Expect(gnv.Remote.String()).To(Equal(info.UseIP))
+			}
+
+			t.netLink.AwaitDstRoutes(linkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+			t.netLink.AwaitNoDstRoutes(otherLinkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+		})
+
+		Context("and the gateway holding the routes is disconnected", func() {
+			JustBeforeEach(func() {
+				Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+			})
+
+			It("should keep the other gateway's device and move the routes to it", func() {
+				t.assertConnection(otherNATInfo)
+				t.netLink.AwaitNoLink(ifaceName)
+				t.netLink.AwaitLink(otherIfaceName)
+
+				t.netLink.AwaitNoDstRoutes(linkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+				t.netLink.AwaitDstRoutes(otherLinkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+				t.netLink.AwaitGwRoutes(otherLinkIndex, geneve.TableID, fmt.Sprintf("%d.68.2.2", geneve.GeneveVTepNetworkPrefix))
+			})
+		})
+
+		Context("and the other gateway is disconnected", func() {
+			JustBeforeEach(func() {
+				Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: otherNATInfo.Endpoint.Spec})).To(Succeed())
+			})
+
+			It("should keep the routes through the first gateway's device", func() {
+				t.assertConnection(natInfo)
+				t.netLink.AwaitNoLink(otherIfaceName)
+				t.netLink.AwaitLink(ifaceName)
+				t.netLink.AwaitDstRoutes(linkIndex, geneve.TableID, natInfo.Endpoint.Spec.Subnets...)
+			})
+		})
+	})
+
+	Specify("Cleanup should remove the GENEVE link devices", func() {
+		_, err := t.driver.ConnectToEndpoint(natInfo)
+		Expect(err).To(Succeed())
+
+		Expect(t.driver.Cleanup()).To(Succeed())
+		t.netLink.AwaitNoLink(ifaceName)
+		t.netLink.AwaitNoRule(geneve.TableID, "", "")
+	})
+})
+
+var _ = Describe("VNI", func() {
+	It("should be the same for both ends of a tunnel", func() {
+		Expect(geneve.VNI("east", "west")).To(Equal(geneve.VNI("west", "east")))
+	})
+
+	It("should differ between remote clusters", func() {
+		Expect(geneve.VNI("east", "west")).ToNot(Equal(geneve.VNI("east", "north")))
+	})
+
+	It("should fit in 24 bits", func() {
+		Expect(geneve.VNI("east", "west")).To(BeNumerically("<", 1<<24))
+		Expect(geneve.VNI("east", "west")).To(BeNumerically(">", 0))
+	})
+})
+
+type testDriver struct {
+	localEndpoint subv1.EndpointSpec
+	localCluster  *types.SubmarinerCluster
+	netLink       *fakeNetlink.NetLink
+	driver        cable.Driver
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{}
+
+	BeforeEach(func() {
+		t.localCluster = &types.SubmarinerCluster{
+			Spec: subv1.ClusterSpec{
+				ClusterID:   "local",
+				ServiceCIDR: []string{"10.0.0.0/16"},
+				ClusterCIDR: []string{"11.0.0.0/16"},
+			},
+		}
+
+		t.localEndpoint = subv1.EndpointSpec{
+			ClusterID:  t.localCluster.Spec.ClusterID,
+			CableName:  "submariner-cable-local-192-68-1-1",
+			PrivateIPs: []string{"192.68.1.1"},
+			Subnets:    append(t.localCluster.Spec.ServiceCIDR, t.localCluster.Spec.ClusterCIDR...),
+		}
+
+		t.netLink = fakeNetlink.New()
+		netlinkAPI.NewFunc = func() netlinkAPI.Interface {
+			return t.netLink
+		}
+
+		cni.DiscoverFunc = func(_ []string) (*cni.Interface, error) {
+			return &cni.Interface{
+				Name:      "veth0",
+				IPAddress: cniIPAddress,
+			}, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		d, err := geneve.NewDriver(endpoint.NewLocal(&t.localEndpoint, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""),
+			t.localCluster, &cable.DriverConfig{})
+		Expect(err).To(Succeed())
+
+		Expect(d.Init()).To(Succeed())
+		Expect(d.GetName()).To(Equal(geneve.CableDriverName))
+
+		t.driver = d
+	})
+
+	return t
+}
+
+func (t *testDriver) assertConnection(natInfo *natdiscovery.NATEndpointInfo) {
+	conn := subv1.Connection{
+		Status:   subv1.Connected,
+		Endpoint: natInfo.Endpoint.Spec,
+		UsingIP:  natInfo.UseIP,
+		UsingNAT: natInfo.UseNAT,
+	}
+
+	conns, err := t.driver.GetActiveConnections()
+	Expect(err).To(Succeed())
+	Expect(conns).To(HaveExactElements(conn))
+
+	conns, err = t.driver.GetConnections()
+	Expect(err).To(Succeed())
+	Expect(conns).To(HaveExactElements(conn))
+}
+
+func (t *testDriver) assertNoConnection() {
+	conns, err := t.driver.GetActiveConnections()
+	Expect(err).To(Succeed())
+	Expect(conns).To(BeEmpty())
+}
@@ -0,0 +1,363 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package libreswan
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/command"
+	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/util/clusterfiles"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	nssDatabase     = "sql:/etc/ipsec.d"
+	nssDatabaseFile = "/etc/ipsec.d/cert9.db"
+	certNickname    = "submariner"
+	caNickname      = "submariner-ca"
+)
+
+// Short names used by Libreswan when printing and parsing distinguished names.
+var dnAttributeNames = map[string]string{
+	"2.5.4.3":                    "CN",
+	"2.5.4.6":                    "C",
+	"2.5.4.7":                    "L",
+	"2.5.4.8":                    "ST",
+	"2.5.4.10":                   "O",
+	"2.5.4.11":                   "OU",
+	"1.2.840.113549.1.9.1":       "E",
+	"0.9.2342.19200300.100.1.1":  "UID",
+	"0.9.2342.19200300.100.1.25": "DC",
+}
+
+// certificates holds the X.509 material used to authenticate the IKE sessions instead of the pre-shared keys.
+type certificates struct {
+	caFile   string
+	certFile string
+	keyFile  string
+	cert     *x509.Certificate
+	subject  string
+}
+
+// loadCertificates retrieves the CA bundle and the gateway's certificate and key from the configured cluster file URLs
+// and verifies that the certificate is currently valid and issued by the CA. The URLs are expanded using the environment,
+// so that per-gateway secrets can be referenced, eg secret://submariner-operator/${NODE_NAME}-ipsec/tls.crt.
+func loadCertificates(spec *specification, kubeClient kubernetes.Interface) (*certificates, error) {
+	if spec.CACertURL == "" || spec.CertKeyURL == "" {
+		return nil, errors.New("the CA bundle and the certificate key must be configured along with the certificate")
+	}
+
+	certs := &certificates{}
+
+	for _, file := range []struct {
+		url  string
+		path *string
+	}{
+		{url: spec.CACertURL, path: &certs.caFile},
+		{url: spec.CertURL, path: &certs.certFile},
+		{url: spec.CertKeyURL, path: &certs.keyFile},
+	} {
+		var err error
+
+		*file.path, err = clusterfiles.Get(context.TODO(), kubeClient, os.ExpandEnv(file.url))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error retrieving %q", file.url)
+		}
+	}
+
+	pair, err := tls.LoadX509KeyPair(certs.certFile, certs.keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading the gateway certificate and key")
+	}
+
+	certs.cert, err = x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing the gateway certificate")
+	}
+
+	caCerts, err := readPEMCertificates(certs.caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	for _, caCert := range caCerts {
+		roots.AddCert(caCert)
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, der := range pair.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing the gateway certificate chain")
+		}
+
+		intermediates.AddCert(intermediate)
+	}
+
+	_, err = certs.cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error verifying the gateway certificate against the CA bundle")
+	}
+
+	certs.subject, err = toLibreswanDN(certs.cert.RawSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
+func readPEMCertificates(file string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the CA bundle")
+	}
+
+	var certs []*x509.Certificate
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing the CA bundle")
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.Errorf("no certificates found in the CA bundle %q", file)
+	}
+
+	return certs, nil
+}
+
+// toLibreswanDN formats an ASN.1 distinguished name the way Libreswan does, ie in the order of the RDN sequence with the
+// attributes separated by ", ", so that it can be used as a connection identifier.
+func toLibreswanDN(raw []byte) (string, error) {
+	var rdns pkix.RDNSequence
+
+	if _, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		return "", errors.Wrap(err, "error parsing the certificate subject")
+	}
+
+	parts := []string{}
+
+	for _, rdn := range rdns {
+		for _, atv := range rdn {
+			name, ok := dnAttributeNames[atv.Type.String()]
+			if !ok {
+				name = atv.Type.String()
+			}
+
+			parts = append(parts, fmt.Sprintf("%s=%v", name, atv.Value))
+		}
+	}
+
+	return strings.Join(parts, ", "), nil
+}
+
+// validate checks that the gateway certificate is still within its validity period. The error is an
+// x509.CertificateInvalidError so that the certificate error handler recognizes it.
+func (c *certificates) validate(now time.Time) error {
+	if now.Before(c.cert.NotBefore) {
+		return x509.CertificateInvalidError{
+			Cert:   c.cert,
+			Reason: x509.Expired,
+			Detail: fmt.Sprintf("the gateway certificate %q is not valid before %s", c.subject, c.cert.NotBefore),
+		}
+	}
+
+	if now.After(c.cert.NotAfter) {
+		return x509.CertificateInvalidError{
+			Cert:   c.cert,
+			Reason: x509.Expired,
+			Detail: fmt.Sprintf("the gateway certificate %q expired on %s", c.subject, c.cert.NotAfter),
+		}
+	}
+
+	return nil
+}
+
+// importIntoNSS configures Pluto's NSS database with the CA bundle, trusted to issue peer certificates, and the gateway's
+// certificate and key.
+func (c *certificates) importIntoNSS() error {
+	if _, err := os.Stat(nssDatabaseFile); os.IsNotExist(err) {
+		if err := runCommand("certutil", "-N", "-d", nssDatabase, "--empty-password"); err != nil {
+			return errors.Wrap(err, "error creating the NSS database")
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "submariner-nss")
+	if err != nil {
+		return errors.Wrap(err, "error creating a temporary directory")
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	caCerts, err := readPEMCertificates(c.caFile)
+	if err != nil {
+		return err
+	}
+
+	for i, caCert := range caCerts {
+		nickname := fmt.Sprintf("%s-%d", caNickname, i)
+		caFile := filepath.Join(tmpDir, nickname+".pem")
+
+		err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}), 0o600)
+		if err != nil {
+			return errors.Wrap(err, "error writing the CA certificate")
+		}
+
+		// Remove any previous version, ignoring failures since it usually doesn't exist.
+		_ = runCommand("certutil", "-D", "-d", nssDatabase, "-n", nickname)
+
+		if err := runCommand("certutil", "-A", "-d", nssDatabase, "-n", nickname, "-t", "CT,,", "-a", "-i", caFile); err != nil {
+			return errors.Wrap(err, "error importing the CA certificate")
+		}
+	}
+
+	p12File := filepath.Join(tmpDir, certNickname+".p12")
+
+	if err := runCommand("openssl", "pkcs12", "-export", "-in", c.certFile, "-inkey", c.keyFile, "-name", certNickname,
+		"-out", p12File, "-passout", "pass:"); err != nil {
+		return errors.Wrap(err, "error converting the gateway certificate to PKCS#12")
+	}
+
+	_ = runCommand("certutil", "-F", "-d", nssDatabase, "-n", certNickname)
+
+	if err := runCommand("pk12util", "-i", p12File, "-d", nssDatabase, "-W", ""); err != nil {
+		return errors.Wrap(err, "error importing the gateway certificate")
+	}
+
+	logger.Infof("Imported the gateway certificate %q into the NSS database", c.subject)
+
+	return nil
+}
+
+func runCommand(name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), whackTimeout)
+	defer cancel()
+
+	output, err := command.New(exec.CommandContext(ctx, name, args...)).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "error running %s %v: %s", name, args, output)
+	}
+
+	return nil
+}
+
+func publishCertificateSubject(localEndpoint *submendpoint.Local, subject string) error {
+	err := localEndpoint.Update(context.TODO(), func(existing *subv1.EndpointSpec) {
+		if existing.BackendConfig == nil {
+			existing.BackendConfig = map[string]string{}
+		}
+
+		existing.BackendConfig[subv1.CertificateSubject] = subject
+	})
+
+	return errors.Wrap(err, "error publishing the certificate subject in the local endpoint")
+}
+
+// certificateError returns the error, if any, which prevents a certificate-authenticated connection to the given remote
+// endpoint: either a problem with our own certificate or the remote endpoint not publishing the subject to pin.
+func (i *libreswan) certificateError(remoteEndpoint *subv1.EndpointSpec) error {
+	if !i.certAuth {
+		return nil
+	}
+
+	if i.certErr != nil {
+		return i.certErr
+	}
+
+	if remoteEndpoint.BackendConfig[subv1.CertificateSubject] == "" {
+		return errors.Errorf("the remote endpoint %q does not publish a certificate subject", remoteEndpoint.CableName)
+	}
+
+	return nil
+}
+
+// setCertificateError records a certificate problem, which is reported in the status of the certificate-authenticated
+// connections. The error is also passed to the runtime error handlers: the certificate error handler installed by the
+// gateway exits if HaltOnCertError is set, so the flag is honoured when the certificate expires after startup.
+func (i *libreswan) setCertificateError(err error) {
+	utilruntime.HandleErrorWithContext(context.TODO(), err, "Certificate error - certificate-authenticated connections will fail")
+
+	i.certErr = err
+}
+
+// revalidateCertificate returns an error if the gateway certificate has expired since it was loaded.
+func (i *libreswan) revalidateCertificate() error {
+	if i.certs == nil || i.certErr != nil {
+		return nil
+	}
+
+	return i.certs.validate(time.Now())
+}
+
+// pinnedIdentifiers returns the identifiers to use for a connection: the certificate subjects when authenticating with
+// certificates, so that the remote gateway's certificate is pinned, or the given identifiers otherwise.
+func (i *libreswan) pinnedIdentifiers(localID, remoteID string, remoteEndpoint *subv1.EndpointSpec) (string, string) {
+	if i.certs == nil {
+		return localID, remoteID
+	}
+
+	return i.certs.subject, remoteEndpoint.BackendConfig[subv1.CertificateSubject]
+}
+
+func (i *libreswan) authArg() string {
+	if i.certAuth {
+		return "--rsasig"
+	}
+
+	return "--psk"
+}
+
+// localCertArgs returns the arguments selecting our certificate for the left-hand side of a connection.
+func (i *libreswan) localCertArgs() []string {
+	if !i.certAuth {
+		return nil
+	}
+
+	return []string{"--cert", certNickname, "--sendcert", "always"}
+}
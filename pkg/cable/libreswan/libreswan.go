@@ -23,11 +23,14 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -37,10 +40,13 @@ import (
 	"github.com/submariner-io/admiral/pkg/log"
 	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
-	"github.com/submariner-io/submariner/pkg/netlink"
+	netlinkAPI "github.com/submariner-io/submariner/pkg/netlink"
 	"github.com/submariner-io/submariner/pkg/types"
+	"github.com/vishvananda/netlink"
+	"k8s.io/client-go/kubernetes"
 	k8snet "k8s.io/utils/net"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -61,6 +67,8 @@ const (
 
 var logger = log.Logger{Logger: logf.Log.WithName("libreswan")}
 
+var secretsFile = "/etc/ipsec.d/submariner.secrets"
+
 func init() {
 	cable.AddDriver(cableDriverName, NewLibreswan)
 	cable.SetDefaultCableDriver(cableDriverName)
@@ -70,30 +78,62 @@ type libreswan struct {
 	localEndpoint subv1.EndpointSpec
 	// This tracks the requested connections
 	connections []subv1.Connection
+	// The subnets of other clusters relayed to each remote endpoint, by cable name, when the local gateway is a relay.
+	relayedSubnets map[string][]string
 
 	secretKey string
 	logFile   string
 
+	// pskSource provides the per-cluster pre-shared keys, if configured, which are written to the secrets file for
+	// the identifiers of each connection.
+	pskSource   *psk.Source
+	peerSecrets map[string]peerSecret
+
+	// stop is closed when the driver is cleaned up, to stop watching the pre-shared keys.
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	// certAuth is set when the IKE sessions are authenticated with X.509 certificates rather than pre-shared keys. The
+	// certificates are nil if they couldn't be loaded, in which case certErr is set. haltOnCertError fails the driver's
+	// creation and initialization on certificate errors; errors found later, eg the certificate expiring, are reported in
+	// the status of the connections and to the runtime error handlers, which stop the gateway if HaltOnCertError is set.
+	certAuth        bool
+	certs           *certificates
+	certErr         error
+	haltOnCertError bool
+
 	ipSecNATTPort   string
 	defaultNATTPort int32
 
 	debug                 bool
 	forceUDPEncapsulation bool
 	plutoStarted          bool
+
+	netLink netlinkAPI.Interface
 }
 
 type specification struct {
-	Debug       bool
-	ForceEncaps bool
-	PSK         string
-	PSKSecret   string
-	LogFile     string
-	NATTPort    string `default:"4500"`
+	Debug            bool
+	ForceEncaps      bool
+	PSK              string
+	PSKSecret        string
+	ClusterPSKSecret string
+	LogFile          string
+	NATTPort         string `default:"4500"`
+	CACertURL        string
+	CertURL          string
+	CertKeyURL       string
+}
+
+type peerSecret struct {
+	localID  string
+	remoteID string
+	psk      string
 }
 
 // NewLibreswan starts an IKE daemon using Libreswan and configures it to manage Submariner's endpoints.
-func NewLibreswan(localEndpoint *submendpoint.Local, _ *types.SubmarinerCluster) (cable.Driver, error) {
-	// We'll panic if localEndpoint is nil, this is intentional
+func NewLibreswan(localEndpoint *submendpoint.Local, _ *types.SubmarinerCluster, config *cable.DriverConfig) (cable.Driver, error) {
+	// We'll panic if localEndpoint or config are nil, this is intentional
 	ipSecSpec := specification{}
 
 	err := envconfig.Process(cable.IPSecEnvPrefix, &ipSecSpec)
@@ -121,35 +161,76 @@ func NewLibreswan(localEndpoint *submendpoint.Local, _ *types.SubmarinerCluster)
 	encodedPsk := ipSecSpec.PSK
 
 	if ipSecSpec.PSKSecret != "" {
-		pskBytes, err := os.ReadFile(fmt.Sprintf("/var/run/secrets/submariner.io/%s/psk", ipSecSpec.PSKSecret))
+		pskBytes, err := os.ReadFile(fmt.Sprintf("%s/%s/psk", psk.MountedSecretsDir, ipSecSpec.PSKSecret))
 		if err != nil {
 			return nil, errors.Wrapf(err, "error reading secret %s", ipSecSpec.PSKSecret)
 		}
-		var psk strings.Builder
-		encoder := base64.NewEncoder(base64.StdEncoding, &psk)
 
-		if _, err := encoder.Write(pskBytes); err != nil {
-			return nil, errors.Wrap(err, "error encoding secret")
-		}
+		encodedPsk = base64.StdEncoding.EncodeToString(pskBytes)
+	}
 
-		encoder.Close()
+	var pskSource *psk.Source
 
-		encodedPsk = psk.String()
+	if ipSecSpec.ClusterPSKSecret != "" {
+		logger.Infof("Using per-cluster pre-shared keys from secret %q", ipSecSpec.ClusterPSKSecret)
+
+		pskSource = psk.NewSourceForSecret(ipSecSpec.ClusterPSKSecret)
 	}
 
 	logger.Infof("Using NATT UDP port %d", nattPort)
 
-	return &libreswan{
+	driver := &libreswan{
 		secretKey:             encodedPsk,
+		pskSource:             pskSource,
+		peerSecrets:           map[string]peerSecret{},
+		stop:                  make(chan struct{}),
 		debug:                 ipSecSpec.Debug,
 		logFile:               ipSecSpec.LogFile,
 		ipSecNATTPort:         strconv.Itoa(int(nattPort)),
 		defaultNATTPort:       defaultNATTPort,
 		localEndpoint:         *localEndpoint.Spec(),
 		connections:           []subv1.Connection{},
+		relayedSubnets:        map[string][]string{},
 		forceUDPEncapsulation: ipSecSpec.ForceEncaps,
 		plutoStarted:          false,
-	}, nil
+		certAuth:              ipSecSpec.CertURL != "",
+		haltOnCertError:       config.HaltOnCertError,
+		netLink:               netlinkAPI.New(),
+	}
+
+	if driver.certAuth {
+		err = driver.loadCertificates(localEndpoint, &ipSecSpec, config.KubeClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return driver, nil
+}
+
+func (i *libreswan) loadCertificates(localEndpoint *submendpoint.Local, ipSecSpec *specification, kubeClient kubernetes.Interface) error {
+	certs, err := loadCertificates(ipSecSpec, kubeClient)
+	if err != nil {
+		if i.haltOnCertError {
+			return err
+		}
+
+		i.setCertificateError(err)
+
+		return nil
+	}
+
+	logger.Infof("Authenticating with the X.509 certificate %q", certs.subject)
+
+	err = publishCertificateSubject(localEndpoint, certs.subject)
+	if err != nil {
+		return err
+	}
+
+	i.certs = certs
+	i.localEndpoint = *localEndpoint.Spec()
+
+	return nil
 }
 
 // GetName returns driver's name.
@@ -157,11 +238,32 @@ func (i *libreswan) GetName() string {
 	return cableDriverName
 }
 
+// SupportsRelaying returns true as the relayed subnets are added to the IPsec policies of the connections.
+func (i *libreswan) SupportsRelaying() bool {
+	return true
+}
+
 // Init initializes the driver with any state it needs.
 func (i *libreswan) Init() error {
+	if i.certs != nil {
+		if err := i.certs.importIntoNSS(); err != nil {
+			if i.haltOnCertError {
+				return err
+			}
+
+			i.setCertificateError(err)
+		}
+	}
+
+	return i.writeSecrets()
+}
+
+func (i *libreswan) writeSecrets() error {
 	// Write the secrets file:
 	// %any %any : PSK "secret"
-	file, err := os.Create("/etc/ipsec.d/submariner.secrets")
+	// followed by the per-cluster secrets for the specific connection identifiers, which take precedence:
+	// <local id> <remote id> : PSK "secret"
+	file, err := os.Create(secretsFile)
 	if err != nil {
 		return errors.Wrap(err, "error creating the secrets file")
 	}
@@ -169,9 +271,63 @@ func (i *libreswan) Init() error {
 
 	fmt.Fprintf(file, "%%any %%any : PSK \"%s\"\n", i.secretKey)
 
+	for _, name := range slices.Sorted(maps.Keys(i.peerSecrets)) {
+		secret := i.peerSecrets[name]
+		fmt.Fprintf(file, "%s %s : PSK \"%s\"\n", secret.localID, secret.remoteID, secret.psk)
+	}
+
 	return nil
 }
 
+// ensurePeerSecret makes sure the secrets file contains the remote cluster's own pre-shared key, if it has one, for the
+// given connection identifiers.
+func (i *libreswan) ensurePeerSecret(connectionName, localID, remoteID, clusterID string) error {
+	if i.pskSource == nil || i.certAuth {
+		return nil
+	}
+
+	key, err := i.pskSource.Get(clusterID)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving the pre-shared key for cluster %q", clusterID)
+	}
+
+	existing, found := i.peerSecrets[connectionName]
+
+	if key == nil {
+		if !found {
+			return nil
+		}
+
+		delete(i.peerSecrets, connectionName)
+	} else {
+		secret := peerSecret{localID: localID, remoteID: remoteID, psk: base64.StdEncoding.EncodeToString(key)}
+		if found && existing == secret {
+			return nil
+		}
+
+		i.peerSecrets[connectionName] = secret
+	}
+
+	if err := i.writeSecrets(); err != nil {
+		return err
+	}
+
+	return whack("--rereadsecrets")
+}
+
+// SetReconnectHandler watches the per-cluster pre-shared keys, if configured, and has the engine re-install the cables
+// to the remote clusters whose key changes, since the established IKE sessions keep using the previous key.
+func (i *libreswan) SetReconnectHandler(reconnect func(clusterID string)) {
+	if i.pskSource == nil || i.certAuth {
+		return
+	}
+
+	i.pskSource.Watch(i.stop, func(clusterID string) {
+		logger.Infof("The pre-shared key for cluster %q has changed - reconnecting its cables", clusterID)
+		reconnect(clusterID)
+	})
+}
+
 // Line format:
 // 006 #3: "submariner-cable-cluster3-172-17-0-8-0-0", type=ESP, add_time=1590508783, inBytes=0, outBytes=0, id='172.17.0.8'
 // or:
@@ -236,6 +392,31 @@ func retrieveActiveConnectionStats() (map[string]int, map[string]int, error) {
 	return activeConnectionsRx, activeConnectionsTx, errors.Wrap(cmd.Wait(), "error waiting for whack to complete")
 }
 
+// retrieveSAPackets returns the packets received and transmitted on the ESP SAs, by the IP of the remote endpoint, which
+// whack doesn't report.
+func (i *libreswan) retrieveSAPackets() (map[string]uint64, map[string]uint64, bool) {
+	states, err := i.netLink.XfrmStateList(netlink.FAMILY_ALL)
+	if err != nil {
+		logger.Warningf("Error listing the IPsec SAs - the packet counts will not be recorded: %v", err)
+		return nil, nil, false
+	}
+
+	rxPackets := map[string]uint64{}
+	txPackets := map[string]uint64{}
+
+	for j := range states {
+		if states[j].Proto != netlink.XFRM_PROTO_ESP {
+			continue
+		}
+
+		// The inbound SAs are from the remote endpoint and the outbound SAs to it.
+		rxPackets[states[j].Src.String()] += states[j].Statistics.Packets
+		txPackets[states[j].Dst.String()] += states[j].Statistics.Packets
+	}
+
+	return rxPackets, txPackets, true
+}
+
 func toConnectionName(cableName string, lsi, rsi int) string {
 	return fmt.Sprintf("%s-%d-%d", cableName, lsi, rsi)
 }
@@ -246,11 +427,23 @@ func (i *libreswan) refreshConnectionStatus() error {
 		return err
 	}
 
-	localSubnets := extractSubnets(&i.localEndpoint)
+	if err := i.revalidateCertificate(); err != nil {
+		i.setCertificateError(err)
+	}
+
+	rxPackets, txPackets, packetsFound := i.retrieveSAPackets()
 
 	for j := range i.connections {
+		if err := i.certificateError(&i.connections[j].Endpoint); err != nil {
+			i.connections[j].SetStatus(subv1.ConnectionError, "Certificate error: %v", err)
+			cable.RecordConnection(cableDriverName, &i.localEndpoint, &i.connections[j].Endpoint, string(i.connections[j].Status), false)
+
+			continue
+		}
+
 		isConnected := false
 
+		localSubnets := i.leftSubnetsFor(i.connections[j].Endpoint.CableName)
 		remoteSubnets := extractSubnets(&i.connections[j].Endpoint)
 		rx, tx := 0, 0
 
@@ -276,6 +469,12 @@ func (i *libreswan) refreshConnectionStatus() error {
 		cable.RecordRxBytes(cableDriverName, &i.localEndpoint, &i.connections[j].Endpoint, rx)
 		cable.RecordTxBytes(cableDriverName, &i.localEndpoint, &i.connections[j].Endpoint, tx)
 
+		// The packets are counted on the SAs, by the IP of the remote endpoint.
+		if packetsFound {
+			cable.RecordRxPackets(cableDriverName, &i.localEndpoint, &i.connections[j].Endpoint, rxPackets[i.connections[j].UsingIP])
+			cable.RecordTxPackets(cableDriverName, &i.localEndpoint, &i.connections[j].Endpoint, txPackets[i.connections[j].UsingIP])
+		}
+
 		if !isConnected {
 			// Pluto should be connecting for us
 			i.connections[j].Status = subv1.Connecting
@@ -306,11 +505,17 @@ func (i *libreswan) GetConnections() ([]subv1.Connection, error) {
 	return i.connections, nil
 }
 
+// leftSubnetsFor returns the local subnets of the connections to the given remote endpoint, followed by the subnets the
+// local gateway relays to it.
+func (i *libreswan) leftSubnetsFor(cableName string) []string {
+	return append(extractSubnets(&i.localEndpoint), i.relayedSubnets[cableName]...)
+}
+
 func extractSubnets(endpoint *subv1.EndpointSpec) []string {
 	subnets := make([]string, 0, len(endpoint.Subnets))
 
 	for _, subnet := range endpoint.Subnets {
-		if !strings.HasPrefix(subnet, endpoint.GetPrivateIP(k8snet.IPv4)+"/") {
+		if !strings.HasPrefix(subnet, endpoint.GetPrivateIP(k8snet.IPFamilyOfCIDRString(subnet))+"/") {
 			subnets = append(subnets, subnet)
 		}
 	}
@@ -372,7 +577,27 @@ func (i *libreswan) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 			endpoint.Spec.CableName, i.defaultNATTPort, err)
 	}
 
-	leftSubnets := extractSubnets(&i.localEndpoint)
+	if err := i.certificateError(&endpoint.Spec); err != nil {
+		// The connection can't be authenticated so record it in error, it'll be re-established when the remote
+		// endpoint is updated.
+		logger.Errorf(err, "Unable to connect to %q", endpoint.Spec.CableName)
+
+		connection := subv1.Connection{Endpoint: endpoint.Spec, UsingIP: endpointInfo.UseIP, UsingNAT: endpointInfo.UseNAT}
+		connection.SetStatus(subv1.ConnectionError, "Certificate error: %v", err)
+		i.connections = append(removeConnectionForEndpoint(i.connections, &types.SubmarinerEndpoint{Spec: endpoint.Spec}),
+			connection)
+		cable.RecordConnection(cableDriverName, &i.localEndpoint, &endpoint.Spec, string(subv1.ConnectionError), true)
+
+		return endpointInfo.UseIP, nil
+	}
+
+	if len(endpointInfo.RelayedSubnets) > 0 {
+		i.relayedSubnets[endpoint.Spec.CableName] = endpointInfo.RelayedSubnets
+	} else {
+		delete(i.relayedSubnets, endpoint.Spec.CableName)
+	}
+
+	leftSubnets := i.leftSubnetsFor(endpoint.Spec.CableName)
 	rightSubnets := extractSubnets(&endpoint.Spec)
 
 	// Ensure we’re listening
@@ -387,6 +612,11 @@ func (i *libreswan) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 	if len(leftSubnets) > 0 && len(rightSubnets) > 0 {
 		for lsi, leftSubnet := range leftSubnets {
 			for rsi, rightSubnet := range rightSubnets {
+				// The tunnel may carry either family over the selected family but both subnets must be of the same family.
+				if k8snet.IPFamilyOfCIDRString(leftSubnet) != k8snet.IPFamilyOfCIDRString(rightSubnet) {
+					continue
+				}
+
 				connectionName := toConnectionName(endpoint.Spec.CableName, lsi, rsi)
 
 				switch connectionMode {
@@ -405,7 +635,7 @@ func (i *libreswan) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 		}
 	}
 
-	i.connections = append(i.connections,
+	i.connections = append(removeConnectionForEndpoint(i.connections, &types.SubmarinerEndpoint{Spec: endpoint.Spec}),
 		subv1.Connection{Endpoint: endpoint.Spec, Status: subv1.Connected, UsingIP: endpointInfo.UseIP, UsingNAT: endpointInfo.UseNAT})
 	cable.RecordConnection(cableDriverName, &i.localEndpoint, &endpoint.Spec, string(subv1.Connected), true)
 
@@ -415,13 +645,17 @@ func (i *libreswan) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 func (i *libreswan) bidirectionalConnectToEndpoint(connectionName string, endpointInfo *natdiscovery.NATEndpointInfo,
 	leftSubnet, rightSubnet string, rightNATTPort int32,
 ) error {
+	family := endpointInfo.UseFamily()
+
 	// Identifiers are used for authentication, they’re always the private IPs
-	localEndpointIdentifier := i.localEndpoint.GetPrivateIP(k8snet.IPv4)
-	remoteEndpointIdentifier := endpointInfo.Endpoint.Spec.GetPrivateIP(k8snet.IPv4)
+	localEndpointIdentifier := i.localEndpoint.GetPrivateIP(family)
+	remoteEndpointIdentifier := endpointInfo.Endpoint.Spec.GetPrivateIP(family)
+	localEndpointIdentifier, remoteEndpointIdentifier = i.pinnedIdentifiers(localEndpointIdentifier, remoteEndpointIdentifier,
+		&endpointInfo.Endpoint.Spec)
 
 	args := []string{}
 
-	args = append(args, "--psk", encryptArg)
+	args = append(args, i.authArg(), encryptArg)
 	if endpointInfo.UseNAT || i.forceUDPEncapsulation {
 		args = append(args, forceencapsArg)
 	}
@@ -430,12 +664,14 @@ func (i *libreswan) bidirectionalConnectToEndpoint(connectionName string, endpoi
 
 		// Left-hand side
 		"--id", localEndpointIdentifier,
-		hostArg, i.localEndpoint.GetPrivateIP(k8snet.IPv4),
+		hostArg, i.localEndpoint.GetPrivateIP(family),
 		clientArg, leftSubnet,
 
-		ikeportArg, i.ipSecNATTPort,
+		ikeportArg, i.ipSecNATTPort)
+
+	args = append(args, i.localCertArgs()...)
 
-		"--to",
+	args = append(args, "--to",
 
 		// Right-hand side
 		"--id", remoteEndpointIdentifier,
@@ -446,6 +682,11 @@ func (i *libreswan) bidirectionalConnectToEndpoint(connectionName string, endpoi
 		dpdactionHoldArg,
 		dpddelayArg, strconv.Itoa(dpdDelay))
 
+	if err := i.ensurePeerSecret(connectionName, localEndpointIdentifier, remoteEndpointIdentifier,
+		endpointInfo.Endpoint.Spec.ClusterID); err != nil {
+		return err
+	}
+
 	logger.Infof("bidirectionalConnectToEndpoint: executing whack with args: %v", args)
 
 	if err := whack(args...); err != nil {
@@ -466,12 +707,16 @@ func toEndpointIdentifier(ip string, lsi, rsi int) string {
 func (i *libreswan) serverConnectToEndpoint(connectionName string, endpointInfo *natdiscovery.NATEndpointInfo,
 	leftSubnet, rightSubnet string, lsi, rsi int,
 ) error {
-	localEndpointIdentifier := toEndpointIdentifier(i.localEndpoint.GetPrivateIP(k8snet.IPv4), lsi, rsi)
-	remoteEndpointIdentifier := toEndpointIdentifier(endpointInfo.Endpoint.Spec.GetPrivateIP(k8snet.IPv4), rsi, lsi)
+	family := endpointInfo.UseFamily()
+
+	localEndpointIdentifier := toEndpointIdentifier(i.localEndpoint.GetPrivateIP(family), lsi, rsi)
+	remoteEndpointIdentifier := toEndpointIdentifier(endpointInfo.Endpoint.Spec.GetPrivateIP(family), rsi, lsi)
+	localEndpointIdentifier, remoteEndpointIdentifier = i.pinnedIdentifiers(localEndpointIdentifier, remoteEndpointIdentifier,
+		&endpointInfo.Endpoint.Spec)
 
 	args := []string{}
 
-	args = append(args, "--psk", encryptArg)
+	args = append(args, i.authArg(), encryptArg)
 	if endpointInfo.UseNAT || i.forceUDPEncapsulation {
 		args = append(args, forceencapsArg)
 	}
@@ -480,12 +725,14 @@ func (i *libreswan) serverConnectToEndpoint(connectionName string, endpointInfo
 
 		// Left-hand side.
 		"--id", localEndpointIdentifier,
-		hostArg, i.localEndpoint.GetPrivateIP(k8snet.IPv4),
+		hostArg, i.localEndpoint.GetPrivateIP(family),
 		clientArg, leftSubnet,
 
-		ikeportArg, i.ipSecNATTPort,
+		ikeportArg, i.ipSecNATTPort)
 
-		"--to",
+	args = append(args, i.localCertArgs()...)
+
+	args = append(args, "--to",
 
 		// Right-hand side.
 		"--id", remoteEndpointIdentifier,
@@ -494,6 +741,11 @@ func (i *libreswan) serverConnectToEndpoint(connectionName string, endpointInfo
 		dpdactionHoldArg,
 		dpddelayArg, strconv.Itoa(dpdDelay))
 
+	if err := i.ensurePeerSecret(connectionName, localEndpointIdentifier, remoteEndpointIdentifier,
+		endpointInfo.Endpoint.Spec.ClusterID); err != nil {
+		return err
+	}
+
 	logger.Infof("serverConnectToEndpoint: executing whack with args: %v", args)
 
 	if err := whack(args...); err != nil {
@@ -508,13 +760,17 @@ func (i *libreswan) serverConnectToEndpoint(connectionName string, endpointInfo
 func (i *libreswan) clientConnectToEndpoint(connectionName string, endpointInfo *natdiscovery.NATEndpointInfo,
 	leftSubnet, rightSubnet string, rightNATTPort int32, lsi, rsi int,
 ) error {
+	family := endpointInfo.UseFamily()
+
 	// Identifiers are used for authentication, they’re always the private IPs.
-	localEndpointIdentifier := toEndpointIdentifier(i.localEndpoint.GetPrivateIP(k8snet.IPv4), lsi, rsi)
-	remoteEndpointIdentifier := toEndpointIdentifier(endpointInfo.Endpoint.Spec.GetPrivateIP(k8snet.IPv4), rsi, lsi)
+	localEndpointIdentifier := toEndpointIdentifier(i.localEndpoint.GetPrivateIP(family), lsi, rsi)
+	remoteEndpointIdentifier := toEndpointIdentifier(endpointInfo.Endpoint.Spec.GetPrivateIP(family), rsi, lsi)
+	localEndpointIdentifier, remoteEndpointIdentifier = i.pinnedIdentifiers(localEndpointIdentifier, remoteEndpointIdentifier,
+		&endpointInfo.Endpoint.Spec)
 
 	args := []string{}
 
-	args = append(args, "--psk", encryptArg)
+	args = append(args, i.authArg(), encryptArg)
 	if endpointInfo.UseNAT || i.forceUDPEncapsulation {
 		args = append(args, forceencapsArg)
 	}
@@ -523,10 +779,12 @@ func (i *libreswan) clientConnectToEndpoint(connectionName string, endpointInfo
 
 		// Left-hand side
 		"--id", localEndpointIdentifier,
-		hostArg, i.localEndpoint.GetPrivateIP(k8snet.IPv4),
-		clientArg, leftSubnet,
+		hostArg, i.localEndpoint.GetPrivateIP(family),
+		clientArg, leftSubnet)
+
+	args = append(args, i.localCertArgs()...)
 
-		"--to",
+	args = append(args, "--to",
 
 		// Right-hand side
 		"--id", remoteEndpointIdentifier,
@@ -537,6 +795,11 @@ func (i *libreswan) clientConnectToEndpoint(connectionName string, endpointInfo
 		dpdactionHoldArg,
 		dpddelayArg, strconv.Itoa(dpdDelay))
 
+	if err := i.ensurePeerSecret(connectionName, localEndpointIdentifier, remoteEndpointIdentifier,
+		endpointInfo.Endpoint.Spec.ClusterID); err != nil {
+		return err
+	}
+
 	logger.Infof("clientConnectToEndpoint: executing whack with args: %v", args)
 
 	if err := whack(args...); err != nil {
@@ -553,7 +816,7 @@ func (i *libreswan) clientConnectToEndpoint(connectionName string, endpointInfo
 // DisconnectFromEndpoint disconnects from the connection to the given endpoint.
 func (i *libreswan) DisconnectFromEndpoint(endpoint *types.SubmarinerEndpoint) error {
 	// We'll panic if endpoint is nil, this is intentional
-	leftSubnets := extractSubnets(&i.localEndpoint)
+	leftSubnets := i.leftSubnetsFor(endpoint.Spec.CableName)
 	rightSubnets := extractSubnets(&endpoint.Spec)
 
 	logger.Infof("Deleting connection to %v", endpoint)
@@ -564,6 +827,8 @@ func (i *libreswan) DisconnectFromEndpoint(endpoint *types.SubmarinerEndpoint) e
 				connectionName := toConnectionName(endpoint.Spec.CableName, lsi, rsi)
 				args := []string{"--delete", nameArg, connectionName}
 
+				delete(i.peerSecrets, connectionName)
+
 				if err := whack(args...); err != nil {
 					var exitError *exec.ExitError
 					if errors.As(err, &exitError) {
@@ -577,11 +842,57 @@ func (i *libreswan) DisconnectFromEndpoint(endpoint *types.SubmarinerEndpoint) e
 	}
 
 	i.connections = removeConnectionForEndpoint(i.connections, endpoint)
+	delete(i.relayedSubnets, endpoint.Spec.CableName)
 	cable.RecordDisconnected(cableDriverName, &i.localEndpoint, &endpoint.Spec)
 
+	i.rerouteRemainingConnections(&endpoint.Spec)
+
+	if i.pskSource != nil && !slices.ContainsFunc(i.connections, func(c subv1.Connection) bool {
+		return c.Endpoint.ClusterID == endpoint.Spec.ClusterID
+	}) {
+		i.pskSource.Forget(endpoint.Spec.ClusterID)
+	}
+
 	return nil
 }
 
+// rerouteRemainingConnections re-installs the IPsec policies of the remaining connections to the given endpoint's
+// cluster. The active/active gateways of a cluster share its subnets, so their connections share the same kernel
+// policies, which were removed along with the given endpoint's connections.
+func (i *libreswan) rerouteRemainingConnections(removed *subv1.EndpointSpec) {
+	for j := range i.connections {
+		remaining := &i.connections[j].Endpoint
+		if remaining.ClusterID != removed.ClusterID || i.connections[j].Status == subv1.ConnectionError ||
+			i.calculateOperationMode(remaining) == operationModeServer {
+			continue
+		}
+
+		leftSubnets := i.leftSubnetsFor(remaining.CableName)
+		rightSubnets := extractSubnets(remaining)
+
+		for lsi, leftSubnet := range leftSubnets {
+			for rsi, rightSubnet := range rightSubnets {
+				if k8snet.IPFamilyOfCIDRString(leftSubnet) != k8snet.IPFamilyOfCIDRString(rightSubnet) {
+					continue
+				}
+
+				connectionName := toConnectionName(remaining.CableName, lsi, rsi)
+
+				logger.Infof("Re-routing connection %q which shared subnets with %q", connectionName, removed.CableName)
+
+				if err := whack("--route", nameArg, connectionName); err != nil {
+					logger.Errorf(err, "Error re-routing connection %q", connectionName)
+					continue
+				}
+
+				if err := whack("--initiate", "--asynchronous", nameArg, connectionName); err != nil {
+					logger.Errorf(err, "Error re-initiating connection %q", connectionName)
+				}
+			}
+		}
+	}
+}
+
 func removeConnectionForEndpoint(connections []subv1.Connection, endpoint *types.SubmarinerEndpoint) []subv1.Connection {
 	for j := range connections {
 		if connections[j].Endpoint.CableName == endpoint.Spec.CableName {
@@ -674,5 +985,9 @@ func (i *libreswan) waitForControlSocket() error {
 func (i *libreswan) Cleanup() error {
 	logger.Info("Uninstalling the libreswan cable driver")
 
-	return netlink.DeleteXfrmRules() //nolint:wrapcheck  // No need to wrap this error
+	i.stopOnce.Do(func() {
+		close(i.stop)
+	})
+
+	return netlinkAPI.DeleteXfrmRules() //nolint:wrapcheck  // No need to wrap this error
 }
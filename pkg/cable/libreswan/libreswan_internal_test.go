@@ -19,17 +19,36 @@ limitations under the License.
 package libreswan
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
+	"net"
 	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 	fakecommand "github.com/submariner-io/admiral/pkg/command/fake"
 	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
 	"github.com/submariner-io/submariner/pkg/endpoint"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	fakeNetlink "github.com/submariner-io/submariner/pkg/netlink/fake"
 	"github.com/submariner-io/submariner/pkg/types"
+	"github.com/vishvananda/netlink"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	k8snet "k8s.io/utils/net"
@@ -42,6 +61,8 @@ var _ = Describe("Libreswan", func() {
 	Describe("DisconnectFromEndpoint", testDisconnectFromEndpoint)
 	Describe("GetConnections", testGetConnections)
 	Describe("Preferred server config", testPreferredServerConfig)
+	Describe("Per-cluster pre-shared keys", testPerClusterPSKs)
+	Describe("Certificate authentication", testCertificateAuthentication)
 })
 
 func testTrafficStatusRE() {
@@ -192,6 +213,26 @@ func testConnectToEndpoint() {
 			testServerMode()
 		})
 	})
+
+	When("the local gateway relays other clusters' subnets to the remote endpoint", func() {
+		const relayedSubnet = "30.0.0.0/16"
+
+		BeforeEach(func() {
+			natInfo.RelayedSubnets = []string{relayedSubnet}
+		})
+
+		It("should also create Connections from the relayed subnets and delete them on disconnect", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			t.cmdExecutor.AwaitCommand(nil, "whack", natInfo.UseIP, t.endpointSpec.Subnets[0], natInfo.Endpoint.Spec.Subnets[0])
+			t.cmdExecutor.AwaitCommand(nil, "whack", natInfo.UseIP, relayedSubnet, natInfo.Endpoint.Spec.Subnets[0])
+			t.cmdExecutor.Clear()
+
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+			t.cmdExecutor.AwaitCommand(nil, "whack", "--delete", toConnectionName(natInfo.Endpoint.Spec.CableName, 1, 0))
+		})
+	})
 }
 
 func testDisconnectFromEndpoint() {
@@ -238,6 +279,48 @@ func testDisconnectFromEndpoint() {
 		t.assertNoActiveConnection(natInfo2)
 		t.cmdExecutor.AwaitCommand(nil, "whack", "--delete")
 	})
+
+	When("one of two active/active endpoints of a cluster is removed", func() {
+		It("should re-route the remaining endpoint's Connection", func() {
+			natInfo1 := &natdiscovery.NATEndpointInfo{
+				Endpoint: subv1.Endpoint{
+					Spec: subv1.EndpointSpec{
+						ClusterID:  "remote1",
+						CableName:  "submariner-cable-remote1-192-68-2-1",
+						PrivateIPs: []string{"192.68.2.1"},
+						Subnets:    []string{"20.0.0.0/16"},
+					},
+				},
+				UseIP: "172.93.2.1",
+			}
+
+			_, err := t.driver.ConnectToEndpoint(natInfo1)
+			Expect(err).To(Succeed())
+
+			natInfo2 := &natdiscovery.NATEndpointInfo{
+				Endpoint: subv1.Endpoint{
+					Spec: subv1.EndpointSpec{
+						ClusterID:  "remote1",
+						CableName:  "submariner-cable-remote1-192-68-2-2",
+						PrivateIPs: []string{"192.68.2.2"},
+						Subnets:    []string{"20.0.0.0/16"},
+					},
+				},
+				UseIP: "172.93.2.2",
+			}
+
+			_, err = t.driver.ConnectToEndpoint(natInfo2)
+			Expect(err).To(Succeed())
+			t.cmdExecutor.Clear()
+
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo1.Endpoint.Spec})).To(Succeed())
+			t.assertNoActiveConnection(natInfo1)
+			t.assertActiveConnection(natInfo2)
+			t.cmdExecutor.AwaitCommand(nil, "whack", "--delete", toConnectionName(natInfo1.Endpoint.Spec.CableName, 0, 0))
+			t.cmdExecutor.AwaitCommand(nil, "whack", "--route", toConnectionName(natInfo2.Endpoint.Spec.CableName, 0, 0))
+			t.cmdExecutor.AwaitCommand(nil, "whack", "--initiate", toConnectionName(natInfo2.Endpoint.Spec.CableName, 0, 0))
+		})
+	})
 }
 
 func testGetConnections() {
@@ -294,6 +377,79 @@ func testGetConnections() {
 			UsingNAT: natInfo2.UseNAT,
 		}))
 	})
+
+	It("should record the packets counted on the ESP SAs", func() {
+		natInfo := &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:  "remote-packets",
+					CableName:  "submariner-cable-remote-packets-192-68-4-1",
+					Hostname:   "remote-packets-host",
+					PrivateIPs: []string{"192.68.4.1"},
+					Subnets:    []string{"40.0.0.0/16"},
+				},
+			},
+			UseIP: "174.93.4.1",
+		}
+
+		_, err := t.driver.ConnectToEndpoint(natInfo)
+		Expect(err).To(Succeed())
+
+		setPackets := func(rx, tx uint64) {
+			localIP := net.ParseIP(t.endpointSpec.PrivateIPs[0])
+			remoteIP := net.ParseIP(natInfo.UseIP)
+
+			t.netLink.SetXfrmStates(
+				netlink.XfrmState{
+					Src: remoteIP, Dst: localIP, Proto: netlink.XFRM_PROTO_ESP,
+					Statistics: netlink.XfrmStateStats{Packets: rx},
+				},
+				netlink.XfrmState{
+					Src: localIP, Dst: remoteIP, Proto: netlink.XFRM_PROTO_ESP,
+					Statistics: netlink.XfrmStateStats{Packets: tx},
+				})
+		}
+
+		t.cmdExecutor.SetupCommandStdOut(
+			fmt.Sprintf(" \"%s-0-0\", type=ESP, add_time=1590508783, inBytes=10, outBytes=20, id='192.68.4.1'",
+				natInfo.Endpoint.Spec.CableName),
+			nil, "whack", "--trafficstatus")
+
+		setPackets(5, 7)
+		_, err = t.driver.GetConnections()
+		Expect(err).To(Succeed())
+
+		setPackets(15, 10)
+		_, err = t.driver.GetConnections()
+		Expect(err).To(Succeed())
+
+		Expect(counterValue("submariner_gateway_rx_packets_total", natInfo.Endpoint.Spec.ClusterID)).To(Equal(10.0))
+		Expect(counterValue("submariner_gateway_tx_packets_total", natInfo.Endpoint.Spec.ClusterID)).To(Equal(3.0))
+	})
+}
+
+// counterValue returns the value of the given counter metric for the given remote cluster.
+func counterValue(name, remoteClusterID string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	Expect(err).To(Succeed())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "remote_cluster" && label.GetValue() == remoteClusterID {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	Fail(fmt.Sprintf("Counter %q not found for remote cluster %q", name, remoteClusterID))
+
+	return 0
 }
 
 func testPreferredServerConfig() {
@@ -338,10 +494,332 @@ func testPreferredServerConfig() {
 	})
 }
 
+func testPerClusterPSKs() {
+	t := newTestDriver()
+
+	var (
+		pskDir   string
+		eastInfo *natdiscovery.NATEndpointInfo
+		westInfo *natdiscovery.NATEndpointInfo
+	)
+
+	BeforeEach(func() {
+		pskDir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(pskDir, "east"), []byte("east-psk"), 0o600)).To(Succeed())
+
+		origSecretsFile := secretsFile
+		secretsFile = filepath.Join(GinkgoT().TempDir(), "submariner.secrets")
+
+		DeferCleanup(func() {
+			secretsFile = origSecretsFile
+		})
+
+		eastInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:  "east",
+					CableName:  "submariner-cable-east-192-68-2-1",
+					PrivateIPs: []string{"192.68.2.1"},
+					Subnets:    []string{"20.0.0.0/16"},
+				},
+			},
+			UseIP: "172.93.2.1",
+		}
+
+		westInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:  "west",
+					CableName:  "submariner-cable-west-192-68-3-1",
+					PrivateIPs: []string{"192.68.3.1"},
+					Subnets:    []string{"30.0.0.0/16"},
+				},
+			},
+			UseIP: "173.93.2.1",
+		}
+	})
+
+	JustBeforeEach(func() {
+		t.driver.pskSource = psk.NewSource(pskDir)
+		Expect(t.driver.Init()).To(Succeed())
+	})
+
+	readSecrets := func() string {
+		data, err := os.ReadFile(secretsFile)
+		Expect(err).To(Succeed())
+
+		return string(data)
+	}
+
+	It("should write the remote cluster's own key for its connection identifiers", func() {
+		_, err := t.driver.ConnectToEndpoint(eastInfo)
+		Expect(err).To(Succeed())
+
+		_, err = t.driver.ConnectToEndpoint(westInfo)
+		Expect(err).To(Succeed())
+
+		t.cmdExecutor.AwaitCommand(nil, "whack", "--rereadsecrets")
+		Expect(readSecrets()).To(Equal(fmt.Sprintf("%%any %%any : PSK \"\"\n192.68.1.1 192.68.2.1 : PSK \"%s\"\n",
+			base64.StdEncoding.EncodeToString([]byte("east-psk")))))
+	})
+
+	When("a remote cluster's key changes", func() {
+		var reconnected chan string
+
+		BeforeEach(func() {
+			origInterval := psk.WatchInterval
+			psk.WatchInterval = 10 * time.Millisecond
+
+			DeferCleanup(func() {
+				psk.WatchInterval = origInterval
+			})
+		})
+
+		JustBeforeEach(func() {
+			reconnected = make(chan string, 100)
+
+			t.driver.SetReconnectHandler(func(clusterID string) {
+				reconnected <- clusterID
+			})
+
+			DeferCleanup(func() {
+				close(t.driver.stop)
+			})
+		})
+
+		It("should request only that cluster's cables be re-installed, with the new key", func() {
+			_, err := t.driver.ConnectToEndpoint(eastInfo)
+			Expect(err).To(Succeed())
+
+			_, err = t.driver.ConnectToEndpoint(westInfo)
+			Expect(err).To(Succeed())
+
+			Consistently(reconnected, 100*time.Millisecond).ShouldNot(Receive())
+
+			Expect(os.WriteFile(filepath.Join(pskDir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			Eventually(reconnected).Should(Receive(Equal("east")))
+
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: eastInfo.Endpoint.Spec})).To(Succeed())
+			_, err = t.driver.ConnectToEndpoint(eastInfo)
+			Expect(err).To(Succeed())
+
+			Expect(readSecrets()).To(ContainSubstring(base64.StdEncoding.EncodeToString([]byte("new-east-psk"))))
+			Consistently(reconnected, 100*time.Millisecond).ShouldNot(Receive())
+		})
+	})
+}
+
+func testCertificateAuthentication() {
+	const (
+		caCertURLEnvVar  = "CE_IPSEC_CACERTURL"
+		certURLEnvVar    = "CE_IPSEC_CERTURL"
+		certKeyURLEnvVar = "CE_IPSEC_CERTKEYURL"
+		localSubject     = "O=submariner, CN=gateway-local"
+		remoteSubject    = "O=submariner, CN=gateway-east"
+	)
+
+	t := newTestDriver()
+
+	var (
+		certDir  string
+		notAfter time.Time
+		natInfo  *natdiscovery.NATEndpointInfo
+	)
+
+	BeforeEach(func() {
+		certDir = GinkgoT().TempDir()
+		notAfter = time.Now().Add(time.Hour)
+
+		os.Setenv(caCertURLEnvVar, "file://"+filepath.Join(certDir, "ca.crt"))
+		os.Setenv(certURLEnvVar, "file://"+filepath.Join(certDir, "tls.crt"))
+		os.Setenv(certKeyURLEnvVar, "file://"+filepath.Join(certDir, "tls.key"))
+
+		origSecretsFile := secretsFile
+		secretsFile = filepath.Join(GinkgoT().TempDir(), "submariner.secrets")
+
+		DeferCleanup(func() {
+			secretsFile = origSecretsFile
+
+			os.Unsetenv(caCertURLEnvVar)
+			os.Unsetenv(certURLEnvVar)
+			os.Unsetenv(certKeyURLEnvVar)
+		})
+
+		natInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:     "east",
+					CableName:     "submariner-cable-east-192-68-2-1",
+					PrivateIPs:    []string{"192.68.2.1"},
+					Subnets:       []string{"20.0.0.0/16"},
+					BackendConfig: map[string]string{subv1.CertificateSubject: remoteSubject},
+				},
+			},
+			UseIP: "172.93.2.1",
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(t.driver.Init()).To(Succeed())
+	})
+
+	Context("with a valid certificate", func() {
+		BeforeEach(func() {
+			writeTestCertificates(certDir, notAfter)
+		})
+
+		It("should publish the certificate subject in the local endpoint", func() {
+			Expect(t.localEndpoint.Spec().BackendConfig).To(HaveKeyWithValue(subv1.CertificateSubject, localSubject))
+		})
+
+		It("should import the CA bundle and certificate into the NSS database", func() {
+			t.cmdExecutor.AwaitCommand(nil, "certutil", "-A", caNickname+"-0", "CT,,")
+			t.cmdExecutor.AwaitCommand(nil, "openssl", "pkcs12", certNickname)
+			t.cmdExecutor.AwaitCommand(nil, "pk12util", nssDatabase)
+		})
+
+		It("should authenticate connections with the certificates and pin the remote subject", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			t.assertActiveConnection(natInfo)
+			t.cmdExecutor.AwaitCommand(nil, "whack", "--rsasig", "--cert", certNickname, localSubject, remoteSubject)
+			t.cmdExecutor.EnsureNoCommand(nil, "whack", "--psk")
+		})
+
+		When("the remote endpoint doesn't publish a certificate subject", func() {
+			BeforeEach(func() {
+				natInfo.Endpoint.Spec.BackendConfig = nil
+			})
+
+			It("should report the connection in error", func() {
+				_, err := t.driver.ConnectToEndpoint(natInfo)
+				Expect(err).To(Succeed())
+
+				conns, err := t.driver.GetConnections()
+				Expect(err).To(Succeed())
+				Expect(conns).To(HaveLen(1))
+				Expect(conns[0].Status).To(Equal(subv1.ConnectionError))
+				Expect(conns[0].StatusMessage).To(ContainSubstring("does not publish a certificate subject"))
+				t.cmdExecutor.EnsureNoCommand(nil, "whack", "--rsasig")
+			})
+		})
+
+		When("it then expires", func() {
+			var handledErrors chan error
+
+			BeforeEach(func() {
+				t.driverConfig.HaltOnCertError = true
+				handledErrors = make(chan error, 10)
+
+				origErrorHandlers := utilruntime.ErrorHandlers
+				utilruntime.ErrorHandlers = []utilruntime.ErrorHandler{
+					func(_ context.Context, err error, _ string, _ ...interface{}) {
+						handledErrors <- err
+					},
+				}
+
+				DeferCleanup(func() {
+					utilruntime.ErrorHandlers = origErrorHandlers
+				})
+			})
+
+			It("should report the connections in error and the certificate error to the runtime error handlers", func() {
+				_, err := t.driver.ConnectToEndpoint(natInfo)
+				Expect(err).To(Succeed())
+
+				t.driver.certs.cert.NotAfter = time.Now().Add(-time.Minute)
+
+				conns, err := t.driver.GetConnections()
+				Expect(err).To(Succeed())
+				Expect(conns).To(HaveLen(1))
+				Expect(conns[0].Status).To(Equal(subv1.ConnectionError))
+				Expect(conns[0].StatusMessage).To(ContainSubstring("expired"))
+
+				var handledErr error
+				Eventually(handledErrors).Should(Receive(&handledErr))
+
+				var certErr x509.CertificateInvalidError
+				Expect(errors.As(handledErr, &certErr)).To(BeTrue())
+				Expect(certErr.Reason).To(Equal(x509.Expired))
+			})
+		})
+	})
+
+	When("the certificate has expired", func() {
+		BeforeEach(func() {
+			writeTestCertificates(certDir, time.Now().Add(-time.Hour))
+		})
+
+		It("should report connections in error", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			conns, err := t.driver.GetConnections()
+			Expect(err).To(Succeed())
+			Expect(conns).To(HaveLen(1))
+			Expect(conns[0].Status).To(Equal(subv1.ConnectionError))
+			Expect(conns[0].StatusMessage).To(ContainSubstring("Certificate error"))
+			t.cmdExecutor.EnsureNoCommand(nil, "pk12util")
+		})
+
+		It("should fail to create the driver if HaltOnCertError is set", func() {
+			_, err := NewLibreswan(t.localEndpoint, &types.SubmarinerCluster{}, &cable.DriverConfig{HaltOnCertError: true})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+}
+
+// writeTestCertificates writes a CA certificate and a gateway certificate and key issued by it to the given directory.
+func writeTestCertificates(dir string, notAfter time.Time) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(Succeed())
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"submariner"}, CommonName: "ca"},
+		NotBefore:             time.Now().Add(-2 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	Expect(err).To(Succeed())
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(Succeed())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{Organization: []string{"submariner"}, CommonName: "gateway-local"},
+		NotBefore:    time.Now().Add(-2 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	Expect(err).To(Succeed())
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).To(Succeed())
+
+	for name, block := range map[string]*pem.Block{
+		"ca.crt":  {Type: "CERTIFICATE", Bytes: caDER},
+		"tls.crt": {Type: "CERTIFICATE", Bytes: certDER},
+		"tls.key": {Type: "PRIVATE KEY", Bytes: keyDER},
+	} {
+		Expect(os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600)).To(Succeed())
+	}
+}
+
 type testDriver struct {
 	endpointSpec  subv1.EndpointSpec
 	localEndpoint *endpoint.Local
 	cmdExecutor   *fakecommand.Executor
+	netLink       *fakeNetlink.NetLink
+	driverConfig  cable.DriverConfig
 	driver        *libreswan
 }
 
@@ -350,6 +828,7 @@ func newTestDriver() *testDriver {
 
 	BeforeEach(func() {
 		t.cmdExecutor = fakecommand.New()
+		t.driverConfig = cable.DriverConfig{}
 		t.endpointSpec = subv1.EndpointSpec{
 			ClusterID:  "local",
 			CableName:  "submariner-cable-local-192-68-1-1",
@@ -360,11 +839,14 @@ func newTestDriver() *testDriver {
 
 	JustBeforeEach(func() {
 		t.localEndpoint = endpoint.NewLocal(&t.endpointSpec, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), "")
-		ls, err := NewLibreswan(t.localEndpoint, &types.SubmarinerCluster{})
+		ls, err := NewLibreswan(t.localEndpoint, &types.SubmarinerCluster{}, &t.driverConfig)
 		Expect(err).NotTo(HaveOccurred())
 
 		t.driver = ls.(*libreswan)
 		t.driver.plutoStarted = true
+
+		t.netLink = fakeNetlink.New()
+		t.driver.netLink = t.netLink
 	})
 
 	return t
@@ -20,6 +20,7 @@ package cable
 
 import (
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -35,10 +36,12 @@ const (
 	remoteHostnameLabel    = "remote_hostname"
 	remoteEndpointIPLabel  = "remote_endpoint_ip"
 	connectionsStatusLabel = "status"
+	connectionStateLabel   = "state"
 )
 
 var (
-	// The following metrics are gauges because we want to set the absolute value  RX/TX metrics.
+	// The following metrics are gauges because we want to set the absolute value  RX/TX metrics. They're kept for
+	// compatibility, the counters below should be preferred to compute rates.
 	rxGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "submariner_gateway_rx_bytes",
@@ -69,6 +72,66 @@ var (
 			remoteEndpointIPLabel,
 		},
 	)
+	rxBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_gateway_rx_bytes_total",
+			Help: "Count of bytes received (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	txBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_gateway_tx_bytes_total",
+			Help: "Count of bytes transmitted (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	rxPacketsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_gateway_rx_packets_total",
+			Help: "Count of packets received (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	txPacketsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_gateway_tx_packets_total",
+			Help: "Count of packets transmitted (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
 	connectionsGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "submariner_connections",
@@ -110,6 +173,37 @@ var (
 			remoteEndpointIPLabel,
 		},
 	)
+	connectionStateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_connection_state",
+			Help: "State of connections in the cable engine, 1 for the current state (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+			connectionStateLabel,
+		},
+	)
+	connectionFlapsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_connection_flaps_total",
+			Help: "Count of connection flaps, ie connected cables going down (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
 	connectionLatencySecondsGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "submariner_connection_latency_seconds",
@@ -125,11 +219,174 @@ var (
 			remoteEndpointIPLabel,
 		},
 	)
+	connectionRTTHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "submariner_connection_rtt_seconds",
+			Help:    "RTT of the health check pings in seconds (by cable driver and cable)",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 16),
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionUptimeSecondsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_connection_uptime_seconds_total",
+			Help: "Time in seconds during which the connection was up (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionMonitoredSecondsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_connection_monitored_seconds_total",
+			Help: "Time in seconds during which the connection was monitored (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionAvailabilityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_connection_availability_ratio",
+			Help: "Ratio of the monitored time during which the connection was up, since the gateway started (by cable driver and cable)",
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionPacketLossHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "submariner_connection_packet_loss_ratio",
+			Help:    "Ratio of the UDP probes lost over each report interval (by cable driver and cable)",
+			Buckets: []float64{0, 0.001, 0.005, 0.01, 0.02, 0.05, 0.1, 0.25, 0.5, 1},
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionJitterHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "submariner_connection_jitter_seconds",
+			Help:    "Jitter of the UDP probes' RTT in seconds (by cable driver and cable)",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 2, 14),
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
+	connectionThroughputHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "submariner_connection_throughput_bytes_per_second",
+			Help:    "Throughput samples of the UDP probe bursts in bytes per second (by cable driver and cable)",
+			Buckets: prometheus.ExponentialBuckets(125000, 2, 14),
+		},
+		[]string{
+			cableDriverLabel,
+			localClusterLabel,
+			localHostnameLabel,
+			localEndpointIPLabel,
+			remoteClusterLabel,
+			remoteHostnameLabel,
+			remoteEndpointIPLabel,
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(rxGauge, txGauge, connectionsGauge, shortConnectionsGauge, connectionEstablishedTimestampGauge,
-		connectionLatencySecondsGauge)
+		connectionLatencySecondsGauge, connectionStateGauge, connectionFlapsCounter, connectionPacketLossHistogram,
+		connectionJitterHistogram, connectionThroughputHistogram, rxBytesCounter, txBytesCounter, rxPacketsCounter,
+		txPacketsCounter, connectionRTTHistogram, connectionUptimeSecondsCounter, connectionMonitoredSecondsCounter,
+		connectionAvailabilityGauge)
+}
+
+// cumulativeValues holds the last cumulative value reported for each counter series, to add the deltas to the counters.
+var cumulativeValues = struct {
+	sync.Mutex
+	values map[cumulativeKey]uint64
+}{values: map[cumulativeKey]uint64{}}
+
+type cumulativeKey struct {
+	counter *prometheus.CounterVec
+	labels  string
+}
+
+func newCumulativeKey(counter *prometheus.CounterVec, labels prometheus.Labels) cumulativeKey {
+	return cumulativeKey{
+		counter: counter,
+		labels: strings.Join([]string{
+			labels[cableDriverLabel], labels[localClusterLabel], labels[localHostnameLabel], labels[localEndpointIPLabel],
+			labels[remoteClusterLabel], labels[remoteHostnameLabel], labels[remoteEndpointIPLabel],
+		}, "|"),
+	}
+}
+
+// addCumulative adds the increase of a cumulative value, as reported by the cable drivers, to the counter. A value lower
+// than the previous one means the source was reset, eg the tunnel was re-created, in which case the whole value is added.
+func addCumulative(counter *prometheus.CounterVec, labels prometheus.Labels, value uint64) {
+	key := newCumulativeKey(counter, labels)
+
+	cumulativeValues.Lock()
+	defer cumulativeValues.Unlock()
+
+	last, found := cumulativeValues.values[key]
+	cumulativeValues.values[key] = value
+
+	switch {
+	case !found:
+		// Create the series without counting the traffic prior to the gateway start.
+		counter.With(labels)
+	case value >= last:
+		counter.With(labels).Add(float64(value - last))
+	default:
+		counter.With(labels).Add(float64(value))
+	}
+}
+
+func deleteCumulative(counter *prometheus.CounterVec, labels prometheus.Labels) {
+	cumulativeValues.Lock()
+	defer cumulativeValues.Unlock()
+
+	delete(cumulativeValues.values, newCumulativeKey(counter, labels))
+	counter.Delete(labels)
 }
 
 func getLabels(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec) prometheus.Labels {
@@ -150,18 +407,66 @@ func getShortLabels(cableDriverName string) prometheus.Labels {
 	}
 }
 
+// RecordRxBytes records the cumulative count of bytes received over a cable, as reported by the cable driver.
 func RecordRxBytes(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, bytes int) {
-	rxGauge.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Set(float64(bytes))
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+	rxGauge.With(labels).Set(float64(bytes))
+	addCumulative(rxBytesCounter, labels, uint64(max(bytes, 0)))
 }
 
+// RecordTxBytes records the cumulative count of bytes transmitted over a cable, as reported by the cable driver.
 func RecordTxBytes(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, bytes int) {
-	txGauge.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Set(float64(bytes))
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+	txGauge.With(labels).Set(float64(bytes))
+	addCumulative(txBytesCounter, labels, uint64(max(bytes, 0)))
+}
+
+// RecordRxPackets records the cumulative count of packets received over a cable, as reported by the cable driver.
+// Only drivers that expose per-cable packet counts record it; WireGuard only reports bytes per peer.
+func RecordRxPackets(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, packets uint64) {
+	addCumulative(rxPacketsCounter, getLabels(cableDriverName, localEndpoint, remoteEndpoint), packets)
+}
+
+// RecordTxPackets records the cumulative count of packets transmitted over a cable, as reported by the cable driver.
+// Only drivers that expose per-cable packet counts record it; WireGuard only reports bytes per peer.
+func RecordTxPackets(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, packets uint64) {
+	addCumulative(txPacketsCounter, getLabels(cableDriverName, localEndpoint, remoteEndpoint), packets)
 }
 
 func RecordConnectionLatency(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, latencySeconds float64) {
 	connectionLatencySecondsGauge.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Set(latencySeconds)
 }
 
+// RecordConnectionRTT records a health check RTT sample of a cable.
+func RecordConnectionRTT(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, rtt time.Duration) {
+	connectionRTTHistogram.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Observe(rtt.Seconds())
+}
+
+// RecordConnectionAvailability adds the time a cable was monitored, and up during that time, to its uptime counters, and
+// sets its availability ratio since the gateway started.
+func RecordConnectionAvailability(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, monitored,
+	up time.Duration, availability float64,
+) {
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+	connectionMonitoredSecondsCounter.With(labels).Add(monitored.Seconds())
+	connectionUptimeSecondsCounter.With(labels).Add(up.Seconds())
+	connectionAvailabilityGauge.With(labels).Set(availability)
+}
+
+func RecordConnectionQuality(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, packetLossRatio,
+	jitterSeconds float64,
+) {
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+	connectionPacketLossHistogram.With(labels).Observe(packetLossRatio)
+	connectionJitterHistogram.With(labels).Observe(jitterSeconds)
+}
+
+func RecordConnectionThroughput(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec,
+	bytesPerSecond float64,
+) {
+	connectionThroughputHistogram.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Observe(bytesPerSecond)
+}
+
 func RecordConnection(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, status string, isNew bool) {
 	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
 
@@ -185,6 +490,46 @@ func RecordDisconnected(cableDriverName string, localEndpoint, remoteEndpoint *s
 	connectionEstablishedTimestampGauge.Delete(labels)
 	rxGauge.Delete(labels)
 	txGauge.Delete(labels)
+	deleteCumulative(rxBytesCounter, labels)
+	deleteCumulative(txBytesCounter, labels)
+	deleteCumulative(rxPacketsCounter, labels)
+	deleteCumulative(txPacketsCounter, labels)
 	connectionsGauge.Delete(labels)
 	shortConnectionsGauge.Delete(shortLabels)
 }
+
+func RecordConnectionState(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec, state submv1.CableState) {
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+
+	for _, s := range submv1.CableStates {
+		labels[connectionStateLabel] = string(s)
+
+		if s == state {
+			connectionStateGauge.With(labels).Set(1)
+		} else {
+			connectionStateGauge.With(labels).Set(0)
+		}
+	}
+}
+
+func RecordConnectionFlap(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec) {
+	connectionFlapsCounter.With(getLabels(cableDriverName, localEndpoint, remoteEndpoint)).Inc()
+}
+
+func RecordConnectionStateRemoved(cableDriverName string, localEndpoint, remoteEndpoint *submv1.EndpointSpec) {
+	labels := getLabels(cableDriverName, localEndpoint, remoteEndpoint)
+
+	connectionFlapsCounter.Delete(labels)
+	connectionPacketLossHistogram.Delete(labels)
+	connectionJitterHistogram.Delete(labels)
+	connectionThroughputHistogram.Delete(labels)
+	connectionRTTHistogram.Delete(labels)
+	connectionUptimeSecondsCounter.Delete(labels)
+	connectionMonitoredSecondsCounter.Delete(labels)
+	connectionAvailabilityGauge.Delete(labels)
+
+	for _, s := range submv1.CableStates {
+		labels[connectionStateLabel] = string(s)
+		connectionStateGauge.Delete(labels)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable/plugin/proto"
+	"github.com/submariner-io/submariner/pkg/types"
+)
+
+// withDeprecated returns the IPs, falling back to the deprecated single IP field of older endpoints.
+func withDeprecated(ips []string, deprecated string) []string {
+	if len(ips) == 0 && deprecated != "" {
+		return []string{deprecated}
+	}
+
+	return ips
+}
+
+func toProtoEndpointSpec(spec *v1.EndpointSpec) *proto.EndpointSpec {
+	return &proto.EndpointSpec{
+		ClusterId:      spec.ClusterID,
+		CableName:      spec.CableName,
+		HealthCheckIps: withDeprecated(spec.HealthCheckIPs, spec.HealthCheckIP),
+		Hostname:       spec.Hostname,
+		Subnets:        spec.Subnets,
+		PrivateIps:     withDeprecated(spec.PrivateIPs, spec.PrivateIP),
+		PublicIps:      withDeprecated(spec.PublicIPs, spec.PublicIP),
+		NatEnabled:     spec.NATEnabled,
+		Backend:        spec.Backend,
+		BackendConfig:  spec.BackendConfig,
+	}
+}
+
+func fromProtoEndpointSpec(spec *proto.EndpointSpec) v1.EndpointSpec {
+	return v1.EndpointSpec{
+		ClusterID:      spec.GetClusterId(),
+		CableName:      spec.GetCableName(),
+		HealthCheckIPs: spec.GetHealthCheckIps(),
+		Hostname:       spec.GetHostname(),
+		Subnets:        spec.GetSubnets(),
+		PrivateIPs:     spec.GetPrivateIps(),
+		PublicIPs:      spec.GetPublicIps(),
+		NATEnabled:     spec.GetNatEnabled(),
+		Backend:        spec.GetBackend(),
+		BackendConfig:  spec.GetBackendConfig(),
+	}
+}
+
+func toProtoClusterSpec(spec *v1.ClusterSpec) *proto.ClusterSpec {
+	return &proto.ClusterSpec{
+		ClusterId:   spec.ClusterID,
+		ColorCodes:  spec.ColorCodes,
+		ServiceCidr: spec.ServiceCIDR,
+		ClusterCidr: spec.ClusterCIDR,
+		GlobalCidr:  spec.GlobalCIDR,
+	}
+}
+
+func fromProtoCluster(spec *proto.ClusterSpec) *types.SubmarinerCluster {
+	return &types.SubmarinerCluster{
+		ID: spec.GetClusterId(),
+		Spec: v1.ClusterSpec{
+			ClusterID:   spec.GetClusterId(),
+			ColorCodes:  spec.GetColorCodes(),
+			ServiceCIDR: spec.GetServiceCidr(),
+			ClusterCIDR: spec.GetClusterCidr(),
+			GlobalCIDR:  spec.GetGlobalCidr(),
+		},
+	}
+}
+
+func toProtoConnections(connections []v1.Connection) []*proto.Connection {
+	protoConnections := make([]*proto.Connection, len(connections))
+
+	for i := range connections {
+		protoConnections[i] = &proto.Connection{
+			Status:        string(connections[i].Status),
+			StatusMessage: connections[i].StatusMessage,
+			Endpoint:      toProtoEndpointSpec(&connections[i].Endpoint),
+			UsingIp:       connections[i].UsingIP,
+			UsingNat:      connections[i].UsingNAT,
+		}
+	}
+
+	return protoConnections
+}
+
+func fromProtoConnections(protoConnections []*proto.Connection) []v1.Connection {
+	connections := make([]v1.Connection, len(protoConnections))
+
+	for i, c := range protoConnections {
+		connections[i] = v1.Connection{
+			Status:        v1.ConnectionStatus(c.GetStatus()),
+			StatusMessage: c.GetStatusMessage(),
+			Endpoint:      fromProtoEndpointSpec(c.GetEndpoint()),
+			UsingIP:       c.GetUsingIp(),
+			UsingNAT:      c.GetUsingNat(),
+		}
+	}
+
+	return connections
+}
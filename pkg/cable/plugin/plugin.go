@@ -0,0 +1,190 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/plugin/proto"
+	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const rpcTimeout = 30 * time.Second
+
+// SocketDir is the directory in which cable driver plugins create their Unix socket, named after the driver with a
+// ".sock" extension. A plugin is used when the local endpoint's backend names it.
+var SocketDir = "/var/run/submariner/cable-plugins"
+
+var logger = log.Logger{Logger: logf.Log.WithName("CablePlugin")}
+
+func init() {
+	cable.AddDriverResolver(resolve)
+}
+
+// SocketPath returns the path of the Unix socket for the named plugin.
+func SocketPath(name string) string {
+	return filepath.Join(SocketDir, name+".sock")
+}
+
+func resolve(name string) (cable.DriverCreateFunc, bool) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, false
+	}
+
+	info, err := os.Stat(SocketPath(name))
+	if err != nil || info.Mode().Type() != os.ModeSocket {
+		return nil, false
+	}
+
+	return func(localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
+		return NewDriver(name, localEndpoint, localCluster)
+	}, true
+}
+
+type driver struct {
+	name          string
+	conn          *grpc.ClientConn
+	client        proto.CableDriverClient
+	localEndpoint *endpoint.Local
+	localCluster  *types.SubmarinerCluster
+}
+
+// NewDriver creates a cable driver which proxies the calls to the named plugin over its Unix socket.
+func NewDriver(name string, localEndpoint *endpoint.Local, localCluster *types.SubmarinerCluster) (cable.Driver, error) {
+	conn, err := grpc.NewClient("unix://"+SocketPath(name), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating the client for cable driver plugin %q", name)
+	}
+
+	logger.Infof("Using cable driver plugin %q at %s", name, SocketPath(name))
+
+	return &driver{
+		name:          name,
+		conn:          conn,
+		client:        proto.NewCableDriverClient(conn),
+		localEndpoint: localEndpoint,
+		localCluster:  localCluster,
+	}, nil
+}
+
+func (d *driver) Init() error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := d.client.Init(ctx, &proto.InitRequest{
+		LocalEndpoint: toProtoEndpointSpec(d.localEndpoint.Spec()),
+		LocalCluster:  toProtoClusterSpec(&d.localCluster.Spec),
+	})
+	if err != nil {
+		d.closeConnection()
+	}
+
+	return d.wrapError(err, "Init")
+}
+
+func (d *driver) GetActiveConnections() ([]v1.Connection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	resp, err := d.client.GetActiveConnections(ctx, &proto.GetConnectionsRequest{})
+	if err != nil {
+		return nil, d.wrapError(err, "GetActiveConnections")
+	}
+
+	return fromProtoConnections(resp.GetConnections()), nil
+}
+
+func (d *driver) GetConnections() ([]v1.Connection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	resp, err := d.client.GetConnections(ctx, &proto.GetConnectionsRequest{})
+	if err != nil {
+		return nil, d.wrapError(err, "GetConnections")
+	}
+
+	return fromProtoConnections(resp.GetConnections()), nil
+}
+
+func (d *driver) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	resp, err := d.client.ConnectToEndpoint(ctx, &proto.ConnectToEndpointRequest{
+		Endpoint:       toProtoEndpointSpec(&endpointInfo.Endpoint.Spec),
+		UseIp:          endpointInfo.UseIP,
+		UseNat:         endpointInfo.UseNAT,
+		RelayedSubnets: endpointInfo.RelayedSubnets,
+	})
+	if err != nil {
+		return "", d.wrapError(err, "ConnectToEndpoint")
+	}
+
+	return resp.GetIp(), nil
+}
+
+func (d *driver) DisconnectFromEndpoint(endpoint *types.SubmarinerEndpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := d.client.DisconnectFromEndpoint(ctx, &proto.DisconnectFromEndpointRequest{
+		Endpoint: toProtoEndpointSpec(&endpoint.Spec),
+	})
+
+	return d.wrapError(err, "DisconnectFromEndpoint")
+}
+
+func (d *driver) GetName() string {
+	return d.name
+}
+
+func (d *driver) Cleanup() error {
+	ctx, cancel := context.WithTimeout(context.Background(), rpcTimeout)
+	defer cancel()
+
+	_, err := d.client.Cleanup(ctx, &proto.CleanupRequest{})
+
+	d.closeConnection()
+
+	return d.wrapError(err, "Cleanup")
+}
+
+// closeConnection closes the connection to the plugin, after which the driver can't be used anymore.
+func (d *driver) closeConnection() {
+	if err := d.conn.Close(); err != nil {
+		logger.Warningf("Error closing the connection to cable driver plugin %q: %v", d.name, err)
+	}
+}
+
+func (d *driver) wrapError(err error, method string) error {
+	return errors.Wrapf(err, "error calling %s on cable driver plugin %q", method, d.name)
+}
@@ -0,0 +1,267 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/fake"
+	"github.com/submariner-io/submariner/pkg/cable/plugin"
+	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	pluginName           = "test-plugin"
+	connectionClosingMsg = "client connection is closing"
+)
+
+var _ = Describe("Cable driver plugin", func() {
+	var (
+		fakeDriver    *fake.Driver
+		fakeDrivers   chan *fake.Driver
+		localSpec     v1.EndpointSpec
+		localCluster  *types.SubmarinerCluster
+		initEndpoint  chan *v1.EndpointSpec
+		driver        cable.Driver
+		remoteNATInfo *natdiscovery.NATEndpointInfo
+	)
+
+	BeforeEach(func() {
+		origSocketDir := plugin.SocketDir
+		plugin.SocketDir = GinkgoT().TempDir()
+
+		DeferCleanup(func() {
+			plugin.SocketDir = origSocketDir
+		})
+
+		fakeDriver = fake.New()
+		fakeDrivers = make(chan *fake.Driver, 2)
+		fakeDrivers <- fakeDriver
+		initEndpoint = make(chan *v1.EndpointSpec, 2)
+
+		localSpec = v1.EndpointSpec{
+			ClusterID:  "local",
+			CableName:  "submariner-cable-local-192-68-1-1",
+			Hostname:   "gateway",
+			PrivateIPs: []string{"192.68.1.1"},
+			Subnets:    []string{"10.0.0.0/16"},
+			Backend:    pluginName,
+		}
+
+		localCluster = &types.SubmarinerCluster{
+			ID: "local",
+			Spec: v1.ClusterSpec{
+				ClusterID:   "local",
+				ServiceCIDR: []string{"100.0.0.0/16"},
+				ClusterCIDR: []string{"10.0.0.0/16"},
+			},
+		}
+
+		remoteNATInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: v1.Endpoint{
+				Spec: v1.EndpointSpec{
+					ClusterID:     "east",
+					CableName:     "submariner-cable-east-192-68-2-1",
+					Hostname:      "east-gateway",
+					PrivateIPs:    []string{"192.68.2.1"},
+					PublicIPs:     []string{"172.93.2.1"},
+					Subnets:       []string{"20.0.0.0/16"},
+					NATEnabled:    true,
+					Backend:       pluginName,
+					BackendConfig: map[string]string{"key": "value"},
+				},
+			},
+			UseIP:  "172.93.2.1",
+			UseNAT: true,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		served := make(chan error, 1)
+
+		go func() {
+			served <- plugin.Serve(ctx, pluginName, func(localEndpoint *v1.EndpointSpec, _ *types.SubmarinerCluster,
+			) (cable.Driver, error) {
+				initEndpoint <- localEndpoint
+				return <-fakeDrivers, nil
+			})
+		}()
+
+		DeferCleanup(func() {
+			cancel()
+			Eventually(served).Should(Receive())
+		})
+
+		Eventually(func() error {
+			_, err := os.Stat(plugin.SocketPath(pluginName))
+			return err
+		}).Should(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		var err error
+
+		driver, err = cable.NewDriver(endpoint.NewLocal(&localSpec, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""),
+			localCluster, &cable.DriverConfig{})
+		Expect(err).To(Succeed())
+		Expect(driver.GetName()).To(Equal(pluginName))
+	})
+
+	It("should proxy Init to the plugin", func() {
+		Expect(driver.Init()).To(Succeed())
+		fakeDriver.AwaitInit()
+		Eventually(initEndpoint).Should(Receive(Equal(&localSpec)))
+	})
+
+	When("Init fails", func() {
+		BeforeEach(func() {
+			fakeDriver.ErrOnInit = errors.New("mock init error")
+		})
+
+		It("should close the connection to the plugin", func() {
+			err := driver.Init()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("mock init error"))
+
+			_, err = driver.GetConnections()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(connectionClosingMsg))
+		})
+	})
+
+	Context("after Init", func() {
+		JustBeforeEach(func() {
+			Expect(driver.Init()).To(Succeed())
+		})
+
+		It("should proxy ConnectToEndpoint to the plugin", func() {
+			ip, err := driver.ConnectToEndpoint(remoteNATInfo)
+			Expect(err).To(Succeed())
+			Expect(ip).To(Equal(remoteNATInfo.UseIP))
+			fakeDriver.AwaitConnectToEndpoint(remoteNATInfo)
+
+			connections, err := driver.GetActiveConnections()
+			Expect(err).To(Succeed())
+			Expect(connections).To(HaveExactElements(v1.Connection{
+				Endpoint: remoteNATInfo.Endpoint.Spec,
+				UsingIP:  remoteNATInfo.UseIP,
+				UsingNAT: remoteNATInfo.UseNAT,
+			}))
+		})
+
+		It("should proxy DisconnectFromEndpoint to the plugin", func() {
+			Expect(driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: remoteNATInfo.Endpoint.Spec})).To(Succeed())
+			fakeDriver.AwaitDisconnectFromEndpoint(&remoteNATInfo.Endpoint.Spec)
+		})
+
+		It("should proxy GetConnections to the plugin", func() {
+			expected := []v1.Connection{{
+				Status:        v1.ConnectionError,
+				StatusMessage: "failed",
+				Endpoint:      remoteNATInfo.Endpoint.Spec,
+				UsingIP:       remoteNATInfo.UseIP,
+			}}
+			fakeDriver.Connections = expected
+
+			Expect(driver.GetConnections()).To(Equal(expected))
+		})
+
+		It("should proxy Cleanup to the plugin and close the connection", func() {
+			Expect(driver.Cleanup()).To(Succeed())
+			fakeDriver.AwaitCleanup()
+
+			_, err := driver.GetConnections()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(connectionClosingMsg))
+		})
+
+		When("Init is called again", func() {
+			It("should clean up the previous driver before initializing a new one", func() {
+				nextDriver := fake.New()
+				fakeDrivers <- nextDriver
+
+				Expect(driver.Init()).To(Succeed())
+				fakeDriver.AwaitCleanup()
+				nextDriver.AwaitInit()
+				nextDriver.AwaitNoCleanup()
+
+				_, err := driver.ConnectToEndpoint(remoteNATInfo)
+				Expect(err).To(Succeed())
+				nextDriver.AwaitConnectToEndpoint(remoteNATInfo)
+				fakeDriver.AwaitNoConnectToEndpoint()
+			})
+		})
+
+		When("the plugin returns an error", func() {
+			BeforeEach(func() {
+				fakeDriver.ErrOnConnectToEndpoint = errors.New("mock connect error")
+			})
+
+			It("should return it", func() {
+				_, err := driver.ConnectToEndpoint(remoteNATInfo)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("mock connect error"))
+			})
+		})
+	})
+
+	When("the driver hasn't been initialized", func() {
+		It("should fail the calls", func() {
+			_, err := driver.GetConnections()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+})
+
+var _ = Describe("Cable driver plugin resolution", func() {
+	BeforeEach(func() {
+		origSocketDir := plugin.SocketDir
+		plugin.SocketDir = GinkgoT().TempDir()
+
+		DeferCleanup(func() {
+			plugin.SocketDir = origSocketDir
+		})
+	})
+
+	When("no plugin socket exists for the backend", func() {
+		It("should fail to create the driver", func() {
+			_, err := cable.NewDriver(endpoint.NewLocal(&v1.EndpointSpec{Backend: pluginName},
+				dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""), &types.SubmarinerCluster{}, &cable.DriverConfig{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the backend isn't a valid plugin name", func() {
+		It("should fail to create the driver", func() {
+			_, err := cable.NewDriver(endpoint.NewLocal(&v1.EndpointSpec{Backend: "../" + pluginName},
+				dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""), &types.SubmarinerCluster{}, &cable.DriverConfig{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,334 @@
+//
+//SPDX-License-Identifier: Apache-2.0
+//
+//Copyright Contributors to the Submariner project.
+//
+//Licensed under the Apache License, Version 2.0 (the "License");
+//you may not use this file except in compliance with the License.
+//You may obtain a copy of the License at
+//
+//http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS,
+//WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//See the License for the specific language governing permissions and
+//limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.17.3
+// source: pkg/cable/plugin/proto/plugin.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CableDriver_Init_FullMethodName                   = "/submariner.cable.plugin.CableDriver/Init"
+	CableDriver_ConnectToEndpoint_FullMethodName      = "/submariner.cable.plugin.CableDriver/ConnectToEndpoint"
+	CableDriver_DisconnectFromEndpoint_FullMethodName = "/submariner.cable.plugin.CableDriver/DisconnectFromEndpoint"
+	CableDriver_GetConnections_FullMethodName         = "/submariner.cable.plugin.CableDriver/GetConnections"
+	CableDriver_GetActiveConnections_FullMethodName   = "/submariner.cable.plugin.CableDriver/GetActiveConnections"
+	CableDriver_Cleanup_FullMethodName                = "/submariner.cable.plugin.CableDriver/Cleanup"
+)
+
+// CableDriverClient is the client API for CableDriver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CableDriver is implemented by out-of-tree cable drivers. The gateway connects to it over the plugin's Unix socket and
+// proxies the calls it makes to the in-tree cable drivers.
+type CableDriverClient interface {
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error)
+	ConnectToEndpoint(ctx context.Context, in *ConnectToEndpointRequest, opts ...grpc.CallOption) (*ConnectToEndpointResponse, error)
+	DisconnectFromEndpoint(ctx context.Context, in *DisconnectFromEndpointRequest, opts ...grpc.CallOption) (*DisconnectFromEndpointResponse, error)
+	GetConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error)
+	GetActiveConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error)
+	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+}
+
+type cableDriverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCableDriverClient(cc grpc.ClientConnInterface) CableDriverClient {
+	return &cableDriverClient{cc}
+}
+
+func (c *cableDriverClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InitResponse)
+	err := c.cc.Invoke(ctx, CableDriver_Init_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cableDriverClient) ConnectToEndpoint(ctx context.Context, in *ConnectToEndpointRequest, opts ...grpc.CallOption) (*ConnectToEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectToEndpointResponse)
+	err := c.cc.Invoke(ctx, CableDriver_ConnectToEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cableDriverClient) DisconnectFromEndpoint(ctx context.Context, in *DisconnectFromEndpointRequest, opts ...grpc.CallOption) (*DisconnectFromEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisconnectFromEndpointResponse)
+	err := c.cc.Invoke(ctx, CableDriver_DisconnectFromEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cableDriverClient) GetConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConnectionsResponse)
+	err := c.cc.Invoke(ctx, CableDriver_GetConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cableDriverClient) GetActiveConnections(ctx context.Context, in *GetConnectionsRequest, opts ...grpc.CallOption) (*GetConnectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConnectionsResponse)
+	err := c.cc.Invoke(ctx, CableDriver_GetActiveConnections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cableDriverClient) Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupResponse)
+	err := c.cc.Invoke(ctx, CableDriver_Cleanup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CableDriverServer is the server API for CableDriver service.
+// All implementations must embed UnimplementedCableDriverServer
+// for forward compatibility.
+//
+// CableDriver is implemented by out-of-tree cable drivers. The gateway connects to it over the plugin's Unix socket and
+// proxies the calls it makes to the in-tree cable drivers.
+type CableDriverServer interface {
+	Init(context.Context, *InitRequest) (*InitResponse, error)
+	ConnectToEndpoint(context.Context, *ConnectToEndpointRequest) (*ConnectToEndpointResponse, error)
+	DisconnectFromEndpoint(context.Context, *DisconnectFromEndpointRequest) (*DisconnectFromEndpointResponse, error)
+	GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error)
+	GetActiveConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error)
+	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
+	mustEmbedUnimplementedCableDriverServer()
+}
+
+// UnimplementedCableDriverServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCableDriverServer struct{}
+
+func (UnimplementedCableDriverServer) Init(context.Context, *InitRequest) (*InitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (UnimplementedCableDriverServer) ConnectToEndpoint(context.Context, *ConnectToEndpointRequest) (*ConnectToEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectToEndpoint not implemented")
+}
+func (UnimplementedCableDriverServer) DisconnectFromEndpoint(context.Context, *DisconnectFromEndpointRequest) (*DisconnectFromEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectFromEndpoint not implemented")
+}
+func (UnimplementedCableDriverServer) GetConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnections not implemented")
+}
+func (UnimplementedCableDriverServer) GetActiveConnections(context.Context, *GetConnectionsRequest) (*GetConnectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveConnections not implemented")
+}
+func (UnimplementedCableDriverServer) Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cleanup not implemented")
+}
+func (UnimplementedCableDriverServer) mustEmbedUnimplementedCableDriverServer() {}
+func (UnimplementedCableDriverServer) testEmbeddedByValue()                     {}
+
+// UnsafeCableDriverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CableDriverServer will
+// result in compilation errors.
+type UnsafeCableDriverServer interface {
+	mustEmbedUnimplementedCableDriverServer()
+}
+
+func RegisterCableDriverServer(s grpc.ServiceRegistrar, srv CableDriverServer) {
+	// If the following call pancis, it indicates UnimplementedCableDriverServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CableDriver_ServiceDesc, srv)
+}
+
+func _CableDriver_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_Init_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CableDriver_ConnectToEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectToEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).ConnectToEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_ConnectToEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).ConnectToEndpoint(ctx, req.(*ConnectToEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CableDriver_DisconnectFromEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectFromEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).DisconnectFromEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_DisconnectFromEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).DisconnectFromEndpoint(ctx, req.(*DisconnectFromEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CableDriver_GetConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).GetConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_GetConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).GetConnections(ctx, req.(*GetConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CableDriver_GetActiveConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).GetActiveConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_GetActiveConnections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).GetActiveConnections(ctx, req.(*GetConnectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CableDriver_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CableDriverServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CableDriver_Cleanup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CableDriverServer).Cleanup(ctx, req.(*CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CableDriver_ServiceDesc is the grpc.ServiceDesc for CableDriver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CableDriver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "submariner.cable.plugin.CableDriver",
+	HandlerType: (*CableDriverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _CableDriver_Init_Handler,
+		},
+		{
+			MethodName: "ConnectToEndpoint",
+			Handler:    _CableDriver_ConnectToEndpoint_Handler,
+		},
+		{
+			MethodName: "DisconnectFromEndpoint",
+			Handler:    _CableDriver_DisconnectFromEndpoint_Handler,
+		},
+		{
+			MethodName: "GetConnections",
+			Handler:    _CableDriver_GetConnections_Handler,
+		},
+		{
+			MethodName: "GetActiveConnections",
+			Handler:    _CableDriver_GetActiveConnections_Handler,
+		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _CableDriver_Cleanup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/cable/plugin/proto/plugin.proto",
+}
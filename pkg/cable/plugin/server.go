@@ -0,0 +1,197 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/plugin/proto"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerDriverCreateFunc creates the driver served by a plugin when the gateway initializes it.
+type ServerDriverCreateFunc func(localEndpoint *v1.EndpointSpec, localCluster *types.SubmarinerCluster) (cable.Driver, error)
+
+var errNotInitialized = status.Error(codes.FailedPrecondition, "the driver has not been initialized")
+
+type server struct {
+	proto.UnimplementedCableDriverServer
+	create ServerDriverCreateFunc
+	mutex  sync.Mutex
+	driver cable.Driver
+}
+
+// Serve exposes the driver created by the given function as the named plugin, listening on its socket in SocketDir, until
+// the context is cancelled. This allows cable drivers written in Go to be shipped as plugins.
+func Serve(ctx context.Context, name string, create ServerDriverCreateFunc) error {
+	path := SocketPath(name)
+
+	// Remove the socket left by a previous instance, if any.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing the existing socket %q", path)
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "unix", path)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on %q", path)
+	}
+
+	grpcServer := grpc.NewServer()
+	proto.RegisterCableDriverServer(grpcServer, &server{create: create})
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
+	logger.Infof("Serving cable driver plugin %q on %s", name, path)
+
+	return errors.Wrap(grpcServer.Serve(listener), "error serving the cable driver plugin")
+}
+
+func (s *server) getDriver() (cable.Driver, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.driver == nil {
+		return nil, errNotInitialized
+	}
+
+	return s.driver, nil
+}
+
+// Init creates and initializes a new driver. The gateway calls it whenever it (re)starts so the previous driver, if any, is
+// cleaned up first to release the resources it set up.
+func (s *server) Init(_ context.Context, req *proto.InitRequest) (*proto.InitResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.driver != nil {
+		if err := s.driver.Cleanup(); err != nil {
+			return nil, errors.Wrap(err, "error cleaning up the previous driver")
+		}
+
+		s.driver = nil
+	}
+
+	localEndpoint := fromProtoEndpointSpec(req.GetLocalEndpoint())
+
+	driver, err := s.create(&localEndpoint, fromProtoCluster(req.GetLocalCluster()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := driver.Init(); err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	s.driver = driver
+
+	return &proto.InitResponse{}, nil
+}
+
+func (s *server) ConnectToEndpoint(_ context.Context, req *proto.ConnectToEndpointRequest) (*proto.ConnectToEndpointResponse, error) {
+	driver, err := s.getDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	ip, err := driver.ConnectToEndpoint(&natdiscovery.NATEndpointInfo{
+		Endpoint:       v1.Endpoint{Spec: fromProtoEndpointSpec(req.GetEndpoint())},
+		UseIP:          req.GetUseIp(),
+		UseNAT:         req.GetUseNat(),
+		RelayedSubnets: req.GetRelayedSubnets(),
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	return &proto.ConnectToEndpointResponse{Ip: ip}, nil
+}
+
+func (s *server) DisconnectFromEndpoint(_ context.Context, req *proto.DisconnectFromEndpointRequest,
+) (*proto.DisconnectFromEndpointResponse, error) {
+	driver, err := s.getDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	err = driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: fromProtoEndpointSpec(req.GetEndpoint())})
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	return &proto.DisconnectFromEndpointResponse{}, nil
+}
+
+func (s *server) GetConnections(_ context.Context, _ *proto.GetConnectionsRequest) (*proto.GetConnectionsResponse, error) {
+	driver, err := s.getDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := driver.GetConnections()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	return &proto.GetConnectionsResponse{Connections: toProtoConnections(connections)}, nil
+}
+
+func (s *server) GetActiveConnections(_ context.Context, _ *proto.GetConnectionsRequest) (*proto.GetConnectionsResponse, error) {
+	driver, err := s.getDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := driver.GetActiveConnections()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	return &proto.GetConnectionsResponse{Connections: toProtoConnections(connections)}, nil
+}
+
+func (s *server) Cleanup(_ context.Context, _ *proto.CleanupRequest) (*proto.CleanupResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.driver == nil {
+		return nil, errNotInitialized
+	}
+
+	if err := s.driver.Cleanup(); err != nil {
+		return nil, err //nolint:wrapcheck // The error is returned to the gateway as is
+	}
+
+	// Forget the driver so it isn't cleaned up again by the next Init.
+	s.driver = nil
+
+	return &proto.CleanupResponse{}, nil
+}
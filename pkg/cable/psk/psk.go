@@ -0,0 +1,139 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	"k8s.io/apimachinery/pkg/util/wait"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var logger = log.Logger{Logger: logf.Log.WithName("PSK")}
+
+// MountedSecretsDir is the directory in which the secrets used by the cable drivers are mounted.
+const MountedSecretsDir = "/var/run/secrets/submariner.io"
+
+// WatchInterval is the interval at which Watch checks the keys in use for changes. The kubelet takes up to a minute to
+// update a mounted secret anyway.
+var WatchInterval = 10 * time.Second
+
+// Source provides the pre-shared key to use with each remote cluster. The keys are read from files named by remote
+// cluster ID in a directory, typically a mounted secret keyed by cluster ID. The keys returned by Get are tracked so
+// that changes can be detected and only the affected cables reconnected.
+type Source struct {
+	dir   string
+	mutex sync.Mutex
+	inUse map[string][]byte
+}
+
+// NewSource returns a Source that reads the keys from the given directory.
+func NewSource(dir string) *Source {
+	return &Source{
+		dir:   dir,
+		inUse: map[string][]byte{},
+	}
+}
+
+// NewSourceForSecret returns a Source that reads the keys from the mounted secret with the given name.
+func NewSourceForSecret(name string) *Source {
+	return NewSource(filepath.Join(MountedSecretsDir, name))
+}
+
+// Get returns the pre-shared key for the given remote cluster, or nil if the cluster doesn't have its own key, in
+// which case the driver's default key should be used.
+func (s *Source) Get(clusterID string) ([]byte, error) {
+	key, err := s.read(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.inUse[clusterID] = key
+
+	return key, nil
+}
+
+// Lookup returns the pre-shared key for the given remote cluster like Get, without tracking it for changes. This is
+// intended for callers which don't hold a connection using the key.
+func (s *Source) Lookup(clusterID string) ([]byte, error) {
+	return s.read(clusterID)
+}
+
+// Forget stops tracking the key for the given remote cluster.
+func (s *Source) Forget(clusterID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.inUse, clusterID)
+}
+
+// Changed returns the IDs of the remote clusters whose key has changed since it was last returned by Get.
+func (s *Source) Changed() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var changed []string
+
+	for clusterID, inUse := range s.inUse {
+		key, err := s.read(clusterID)
+		if err != nil {
+			logger.Warningf("Unable to read the pre-shared key for cluster %q: %v", clusterID, err)
+			continue
+		}
+
+		if !bytes.Equal(key, inUse) {
+			changed = append(changed, clusterID)
+		}
+	}
+
+	return changed
+}
+
+// Watch checks the keys in use every WatchInterval until stop is closed, calling changed from its own goroutine with
+// the ID of each remote cluster whose key has changed. A cluster keeps being reported until its key is retrieved again
+// with Get, or it's forgotten.
+func (s *Source) Watch(stop <-chan struct{}, changed func(clusterID string)) {
+	go wait.Until(func() {
+		for _, clusterID := range s.Changed() {
+			changed(clusterID)
+		}
+	}, WatchInterval, stop)
+}
+
+func (s *Source) read(clusterID string) ([]byte, error) {
+	if clusterID == "" || filepath.Base(clusterID) != clusterID {
+		return nil, errors.Errorf("invalid cluster ID %q", clusterID)
+	}
+
+	key, err := os.ReadFile(filepath.Join(s.dir, clusterID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return key, errors.Wrapf(err, "error reading the pre-shared key for cluster %q", clusterID)
+}
@@ -0,0 +1,132 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package psk_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
+)
+
+var _ = Describe("Source", func() {
+	var (
+		dir    string
+		source *psk.Source
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "east"), []byte("east-psk"), 0o600)).To(Succeed())
+
+		source = psk.NewSource(dir)
+	})
+
+	When("the remote cluster has its own key", func() {
+		It("should return it", func() {
+			Expect(source.Get("east")).To(Equal([]byte("east-psk")))
+		})
+	})
+
+	When("the remote cluster doesn't have its own key", func() {
+		It("should return nil", func() {
+			Expect(source.Get("west")).To(BeNil())
+		})
+	})
+
+	When("the cluster ID is not a valid file name", func() {
+		It("should return an error", func() {
+			_, err := source.Get("../east")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("a key in use is changed", func() {
+		It("should report only the affected cluster as changed", func() {
+			Expect(source.Get("east")).To(Equal([]byte("east-psk")))
+			Expect(source.Get("west")).To(BeNil())
+			Expect(source.Changed()).To(BeEmpty())
+
+			Expect(os.WriteFile(filepath.Join(dir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			Expect(source.Changed()).To(Equal([]string{"east"}))
+
+			Expect(source.Get("east")).To(Equal([]byte("new-east-psk")))
+			Expect(source.Changed()).To(BeEmpty())
+		})
+	})
+
+	When("a key is added for a cluster that used the default key", func() {
+		It("should report the cluster as changed", func() {
+			Expect(source.Get("west")).To(BeNil())
+
+			Expect(os.WriteFile(filepath.Join(dir, "west"), []byte("west-psk"), 0o600)).To(Succeed())
+			Expect(source.Changed()).To(Equal([]string{"west"}))
+		})
+	})
+
+	When("a key is looked up", func() {
+		It("should return it without tracking it", func() {
+			Expect(source.Lookup("east")).To(Equal([]byte("east-psk")))
+
+			Expect(os.WriteFile(filepath.Join(dir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			Expect(source.Changed()).To(BeEmpty())
+		})
+	})
+
+	When("the keys are watched", func() {
+		It("should report the clusters whose key in use changes", func() {
+			origInterval := psk.WatchInterval
+			psk.WatchInterval = 10 * time.Millisecond
+
+			DeferCleanup(func() {
+				psk.WatchInterval = origInterval
+			})
+
+			stop := make(chan struct{})
+			DeferCleanup(func() {
+				close(stop)
+			})
+
+			changed := make(chan string, 100)
+
+			Expect(source.Get("east")).To(Equal([]byte("east-psk")))
+			source.Watch(stop, func(clusterID string) {
+				changed <- clusterID
+			})
+
+			Consistently(changed, 100*time.Millisecond).ShouldNot(Receive())
+
+			Expect(os.WriteFile(filepath.Join(dir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			Eventually(changed).Should(Receive(Equal("east")))
+		})
+	})
+
+	When("a cluster is forgotten", func() {
+		It("should no longer report it as changed", func() {
+			Expect(source.Get("east")).To(Equal([]byte("east-psk")))
+			source.Forget("east")
+
+			Expect(os.WriteFile(filepath.Join(dir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			Expect(source.Changed()).To(BeEmpty())
+		})
+	})
+})
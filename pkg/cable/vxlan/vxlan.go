@@ -29,6 +29,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/log"
 	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cidr"
 	"github.com/submariner-io/submariner/pkg/cni"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
@@ -41,20 +42,34 @@ import (
 
 const (
 	VxlanIface             = "vxlan-tunnel"
+	VxlanIfaceV6           = "vxlan-tunnel-v6"
 	VxlanVTepNetworkPrefix = 241
-	CableDriverName        = "vxlan"
-	TableID                = 100
-	DefaultPort            = 4500
+	// VxlanVTepIPv6NetworkPrefix replaces the first 16 bits of the IPv6 private IPs to derive the IPv6 VTEP IPs.
+	VxlanVTepIPv6NetworkPrefix = 0xfdf1
+	CableDriverName            = "vxlan"
+	TableID                    = 100
+	DefaultPort                = 4500
 )
 
+// The subnets of each IP family are routed through a vxlan device whose underlay is of the same family, so the remote
+// endpoints are reached over their private IPs of that family.
+var ipFamilies = []k8snet.IPFamily{k8snet.IPv4, k8snet.IPv6}
+
 type vxLan struct {
 	localEndpoint v1.EndpointSpec
 	localCluster  types.SubmarinerCluster
 	connections   []v1.Connection
 	mutex         sync.Mutex
-	vxlanIface    *vxlan.Interface
-	netLink       netlinkAPI.Interface
-	vtepIP        net.IP
+	// The tunnels by IP family, for the families the local endpoint has a private IP of.
+	tunnels map[k8snet.IPFamily]*tunnel
+	// The underlay IPs of the remote endpoints in the forwarding databases, by cable name and IP family.
+	remoteIPs map[string]map[k8snet.IPFamily]net.IP
+	netLink   netlinkAPI.Interface
+}
+
+type tunnel struct {
+	vxlanIface *vxlan.Interface
+	vtepIP     net.IP
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("vxlan")}
@@ -63,7 +78,7 @@ func init() {
 	cable.AddDriver(CableDriverName, NewDriver)
 }
 
-func NewDriver(localEndpoint *submendpoint.Local, localCluster *types.SubmarinerCluster) (cable.Driver, error) {
+func NewDriver(localEndpoint *submendpoint.Local, localCluster *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
 	// We'll panic if localEndpoint or localCluster are nil, this is intentional
 	var err error
 
@@ -71,6 +86,8 @@ func NewDriver(localEndpoint *submendpoint.Local, localCluster *types.Submariner
 		localEndpoint: *localEndpoint.Spec(),
 		netLink:       netlinkAPI.New(),
 		localCluster:  *localCluster,
+		tunnels:       map[k8snet.IPFamily]*tunnel{},
+		remoteIPs:     map[string]map[k8snet.IPFamily]net.IP{},
 	}
 
 	if strings.EqualFold(v.localEndpoint.CableName, CableDriverName) && v.localEndpoint.NATEnabled {
@@ -82,19 +99,52 @@ func NewDriver(localEndpoint *submendpoint.Local, localCluster *types.Submariner
 		return nil, errors.Wrap(err, "failed to get the UDP port configuration")
 	}
 
-	if err = v.createVxlanInterface(int(port)); err != nil {
-		return nil, errors.Wrap(err, "failed to setup Vxlan link")
+	for _, family := range ipFamilies {
+		if v.localEndpoint.GetPrivateIP(family) == "" {
+			continue
+		}
+
+		if err = v.createVxlanInterface(family, int(port)); err != nil {
+			return nil, errors.Wrapf(err, "failed to setup the IPv%s Vxlan link", family)
+		}
+	}
+
+	if len(v.tunnels) == 0 {
+		return nil, errors.Errorf("the local endpoint %q has no private IP", v.localEndpoint.CableName)
 	}
 
 	return &v, nil
 }
 
-func (v *vxLan) createVxlanInterface(port int) error {
-	ipAddr := v.localEndpoint.GetPrivateIP(k8snet.IPv4)
+func ifaceName(family k8snet.IPFamily) string {
+	if family == k8snet.IPv6 {
+		return VxlanIfaceV6
+	}
 
-	var err error
+	return VxlanIface
+}
 
-	v.vtepIP, err = vxlan.GetVtepIPAddressFrom(ipAddr, VxlanVTepNetworkPrefix)
+func vtepIPFrom(family k8snet.IPFamily, ipAddr string) (net.IP, error) {
+	if family == k8snet.IPv6 {
+		return vxlan.GetVtepIPv6AddressFrom(ipAddr, VxlanVTepIPv6NetworkPrefix) //nolint:wrapcheck // No need to wrap here
+	}
+
+	return vxlan.GetVtepIPAddressFrom(ipAddr, VxlanVTepNetworkPrefix) //nolint:wrapcheck // No need to wrap here
+}
+
+func vtepMask(family k8snet.IPFamily) net.IPMask {
+	if family == k8snet.IPv6 {
+		return net.CIDRMask(16, 128)
+	}
+
+	return net.CIDRMask(8, 32)
+}
+
+func (v *vxLan) createVxlanInterface(family k8snet.IPFamily, port int) error {
+	ipAddr := v.localEndpoint.GetPrivateIP(family)
+	name := ifaceName(family)
+
+	vtepIP, err := vtepIPFrom(family, ipAddr)
 	if err != nil {
 		return errors.Wrapf(err, "failed to derive the vxlan vtepIP for %s", ipAddr)
 	}
@@ -106,7 +156,7 @@ func (v *vxLan) createVxlanInterface(port int) error {
 	}
 
 	attrs := &vxlan.Attributes{
-		Name:     VxlanIface,
+		Name:     name,
 		VxlanID:  1000,
 		Group:    nil,
 		SrcAddr:  nil,
@@ -114,33 +164,47 @@ func (v *vxLan) createVxlanInterface(port int) error {
 		Mtu:      defaultHostIface.MTU,
 	}
 
-	v.vxlanIface, err = vxlan.NewInterface(attrs, v.netLink)
+	if family == k8snet.IPv6 {
+		// The device's local address makes its underlay IPv6.
+		attrs.SrcAddr = net.ParseIP(ipAddr)
+	}
+
+	vxlanIface, err := vxlan.NewInterface(attrs, v.netLink)
 	if err != nil {
 		return errors.Wrap(err, "failed to create vxlan interface on Gateway Node")
 	}
 
-	err = v.netLink.RuleAddIfNotPresent(netlinkAPI.NewTableRule(TableID))
+	err = v.netLink.RuleAddIfNotPresent(netlinkAPI.NewTableRuleForFamily(TableID, family))
 	if err != nil && !os.IsExist(err) {
 		return errors.Wrap(err, "failed to add ip rule")
 	}
 
-	err = v.netLink.EnsureLooseModeIsConfigured(VxlanIface)
-	if err != nil {
-		return errors.Wrap(err, "error while validating loose mode")
-	}
+	if family == k8snet.IPv4 {
+		err = v.netLink.EnsureLooseModeIsConfigured(name)
+		if err != nil {
+			return errors.Wrap(err, "error while validating loose mode")
+		}
 
-	logger.V(log.DEBUG).Infof("Successfully configured rp_filter to loose mode(2) on %s", VxlanIface)
+		logger.V(log.DEBUG).Infof("Successfully configured rp_filter to loose mode(2) on %s", name)
+	}
 
-	err = v.vxlanIface.ConfigureIPAddress(v.vtepIP, net.CIDRMask(8, 32))
+	err = vxlanIface.ConfigureIPAddress(vtepIP, vtepMask(family))
 	if err != nil {
 		return errors.Wrap(err, "failed to configure vxlan interface ipaddress on the Gateway Node")
 	}
 
-	err = v.netLink.EnableForwarding(VxlanIface)
+	if family == k8snet.IPv6 {
+		err = v.netLink.EnableIPv6Forwarding(name)
+	} else {
+		err = v.netLink.EnableForwarding(name)
+	}
+
 	if err != nil {
-		return errors.Wrapf(err, "error enabling forwarding on the %q iface", VxlanIface)
+		return errors.Wrapf(err, "error enabling forwarding on the %q iface", name)
 	}
 
+	v.tunnels[family] = &tunnel{vxlanIface: vxlanIface, vtepIP: vtepIP}
+
 	return nil
 }
 
@@ -152,56 +216,95 @@ func (v *vxLan) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo) (s
 		return "", nil
 	}
 
-	remoteIP := net.ParseIP(endpointInfo.UseIP)
-	if remoteIP == nil {
+	if net.ParseIP(endpointInfo.UseIP) == nil {
 		return "", fmt.Errorf("failed to parse remote IP %s", endpointInfo.UseIP)
 	}
 
-	allowedIPs := parseSubnets(remoteEndpoint.Spec.Subnets)
-
 	logger.V(log.DEBUG).Infof("Connecting cluster %s endpoint %s",
-		remoteEndpoint.Spec.ClusterID, remoteIP)
+		remoteEndpoint.Spec.ClusterID, endpointInfo.UseIP)
 	v.mutex.Lock()
 	defer v.mutex.Unlock()
 
+	remoteIPs := map[k8snet.IPFamily]net.IP{}
+
+	for _, family := range ipFamilies {
+		t := v.tunnels[family]
+		subnets := cidr.ExtractSubnets(family, remoteEndpoint.Spec.Subnets)
+
+		if t == nil || len(subnets) == 0 {
+			continue
+		}
+
+		privateIP := remoteEndpoint.Spec.GetPrivateIP(family)
+		if privateIP == "" {
+			logger.Warningf("The remote endpoint %q has no IPv%s private IP - its subnets %v aren't routed over the vxlan tunnel",
+				remoteEndpoint.Spec.CableName, family, subnets)
+
+			continue
+		}
+
+		// The IP selected by NAT discovery is used for its family, the private IP for the other.
+		remoteIP := net.ParseIP(privateIP)
+		if endpointInfo.UseFamily() == family {
+			remoteIP = net.ParseIP(endpointInfo.UseIP)
+		}
+
+		err := v.connectTunnel(family, t, remoteIP, privateIP, parseSubnets(subnets))
+		if err != nil {
+			return endpointInfo.UseIP, err
+		}
+
+		remoteIPs[family] = remoteIP
+	}
+
+	if len(remoteIPs) == 0 {
+		return "", fmt.Errorf("the remote endpoint %q has no subnets and private IP of the IP families of the local endpoint",
+			remoteEndpoint.Spec.CableName)
+	}
+
 	cable.RecordConnection(CableDriverName, &v.localEndpoint, &remoteEndpoint.Spec, string(v1.Connected), true)
 
-	privateIP := endpointInfo.Endpoint.Spec.GetPrivateIP(k8snet.IPv4)
+	v.remoteIPs[remoteEndpoint.Spec.CableName] = remoteIPs
+	v.connections = append(v.connections, v1.Connection{
+		Endpoint: remoteEndpoint.Spec, Status: v1.Connected,
+		UsingIP: endpointInfo.UseIP, UsingNAT: endpointInfo.UseNAT,
+	})
+
+	logger.V(log.DEBUG).Infof("Done adding endpoint for cluster %s", remoteEndpoint.Spec.ClusterID)
+
+	return endpointInfo.UseIP, nil
+}
 
-	remoteVtepIP, err := vxlan.GetVtepIPAddressFrom(privateIP, VxlanVTepNetworkPrefix)
+func (v *vxLan) connectTunnel(family k8snet.IPFamily, t *tunnel, remoteIP net.IP, privateIP string, allowedIPs []net.IPNet) error {
+	remoteVtepIP, err := vtepIPFrom(family, privateIP)
 	if err != nil {
-		return endpointInfo.UseIP, fmt.Errorf("failed to derive the vxlan vtepIP for %s: %w", privateIP, err)
+		return fmt.Errorf("failed to derive the vxlan vtepIP for %s: %w", privateIP, err)
 	}
 
-	err = v.vxlanIface.AddFDB(remoteIP, "00:00:00:00:00:00")
+	err = t.vxlanIface.AddFDB(remoteIP, "00:00:00:00:00:00")
 	if err != nil {
-		return endpointInfo.UseIP, fmt.Errorf("failed to add remoteIP %q to the forwarding database: %w", remoteIP, err)
+		return fmt.Errorf("failed to add remoteIP %q to the forwarding database: %w", remoteIP, err)
 	}
 
 	var ipAddress net.IP
 
-	cniIface, err := cni.Discover(v.localCluster.Spec.ClusterCIDR)
+	clusterCIDRs := cidr.ExtractSubnets(family, v.localCluster.Spec.ClusterCIDR)
+
+	cniIface, err := cni.Discover(clusterCIDRs)
 	if err == nil {
 		ipAddress = net.ParseIP(cniIface.IPAddress)
 	} else {
 		logger.Errorf(nil, "Failed to get the CNI interface IP for cluster CIDR %q, host-networking use-cases may not work",
-			v.localCluster.Spec.ClusterCIDR[0])
+			clusterCIDRs)
 	}
 
-	err = v.vxlanIface.AddRoutes(remoteVtepIP, ipAddress, TableID, allowedIPs...)
+	err = t.vxlanIface.AddRoutes(remoteVtepIP, ipAddress, TableID, allowedIPs...)
 	if err != nil {
-		return endpointInfo.UseIP, fmt.Errorf("failed to add route for the CIDR %q with remoteVtepIP %q and vxlanInterfaceIP %q: %w",
-			allowedIPs, remoteVtepIP, v.vtepIP, err)
+		return fmt.Errorf("failed to add route for the CIDR %q with remoteVtepIP %q and vxlanInterfaceIP %q: %w",
+			allowedIPs, remoteVtepIP, t.vtepIP, err)
 	}
 
-	v.connections = append(v.connections, v1.Connection{
-		Endpoint: remoteEndpoint.Spec, Status: v1.Connected,
-		UsingIP: endpointInfo.UseIP, UsingNAT: endpointInfo.UseNAT,
-	})
-
-	logger.V(log.DEBUG).Infof("Done adding endpoint for cluster %s", remoteEndpoint.Spec.ClusterID)
-
-	return endpointInfo.UseIP, nil
+	return nil
 }
 
 func (v *vxLan) DisconnectFromEndpoint(remoteEndpoint *types.SubmarinerEndpoint) error {
@@ -216,37 +319,33 @@ func (v *vxLan) DisconnectFromEndpoint(remoteEndpoint *types.SubmarinerEndpoint)
 	v.mutex.Lock()
 	defer v.mutex.Unlock()
 
-	var ip string
-
-	for i := range v.connections {
-		if v.connections[i].Endpoint.CableName == remoteEndpoint.Spec.CableName {
-			ip = v.connections[i].UsingIP
-		}
-	}
-
-	if ip == "" {
+	remoteIPs, found := v.remoteIPs[remoteEndpoint.Spec.CableName]
+	if !found {
 		logger.Errorf(nil, "Cannot disconnect remote endpoint %q - no prior connection entry found", remoteEndpoint.Spec.CableName)
 		return nil
 	}
 
-	remoteIP := net.ParseIP(ip)
-
-	if remoteIP == nil {
-		return fmt.Errorf("failed to parse remote IP %s", ip)
-	}
+	for _, family := range ipFamilies {
+		remoteIP, found := remoteIPs[family]
+		if !found {
+			continue
+		}
 
-	allowedIPs := parseSubnets(remoteEndpoint.Spec.Subnets)
+		t := v.tunnels[family]
+		allowedIPs := parseSubnets(cidr.ExtractSubnets(family, remoteEndpoint.Spec.Subnets))
 
-	err := v.vxlanIface.DelFDB(remoteIP, "00:00:00:00:00:00")
-	if err != nil {
-		return fmt.Errorf("failed to delete remoteIP %q from the forwarding database: %w", remoteIP, err)
-	}
+		err := t.vxlanIface.DelFDB(remoteIP, "00:00:00:00:00:00")
+		if err != nil {
+			return fmt.Errorf("failed to delete remoteIP %q from the forwarding database: %w", remoteIP, err)
+		}
 
-	err = v.vxlanIface.DelRoutes(TableID, allowedIPs...)
-	if err != nil {
-		return fmt.Errorf("failed to remove route for the CIDR %q: %w", allowedIPs, err)
+		err = t.vxlanIface.DelRoutes(TableID, allowedIPs...)
+		if err != nil {
+			return fmt.Errorf("failed to remove route for the CIDR %q: %w", allowedIPs, err)
+		}
 	}
 
+	delete(v.remoteIPs, remoteEndpoint.Spec.CableName)
 	v.connections = removeConnectionForEndpoint(v.connections, remoteEndpoint)
 	cable.RecordDisconnected(CableDriverName, &v.localEndpoint, &remoteEndpoint.Spec)
 
@@ -303,14 +402,20 @@ func parseSubnets(subnets []string) []net.IPNet {
 func (v *vxLan) Cleanup() error {
 	logger.Infof("Uninstalling the vxlan cable driver")
 
-	err := netlinkAPI.DeleteIfaceAndAssociatedRoutes(VxlanIface, TableID)
-	if err != nil {
-		logger.Errorf(nil, "Unable to delete interface %s and associated routes from table %d", VxlanIface, TableID)
-	}
+	for _, family := range ipFamilies {
+		err := netlinkAPI.DeleteIfaceAndAssociatedRoutes(ifaceName(family), TableID)
+		if err != nil {
+			logger.Errorf(nil, "Unable to delete interface %s and associated routes from table %d", ifaceName(family), TableID)
+		}
 
-	err = v.netLink.RuleDelIfPresent(netlinkAPI.NewTableRule(TableID))
-	if err != nil {
-		return errors.Wrapf(err, "unable to delete IP rule pointing to %d table", TableID)
+		if v.tunnels[family] == nil {
+			continue
+		}
+
+		err = v.netLink.RuleDelIfPresent(netlinkAPI.NewTableRuleForFamily(TableID, family))
+		if err != nil {
+			return errors.Wrapf(err, "unable to delete IPv%s IP rule pointing to %d table", family, TableID)
+		}
 	}
 
 	return nil
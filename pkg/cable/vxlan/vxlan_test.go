@@ -21,6 +21,7 @@ package vxlan_test
 import (
 	"flag"
 	"fmt"
+	"net"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -38,6 +39,7 @@ import (
 	"github.com/vishvananda/netlink"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	k8snet "k8s.io/utils/net"
 )
 
 func init() {
@@ -136,6 +138,134 @@ var _ = Describe("Vxlan", func() {
 	})
 })
 
+var _ = Describe("Vxlan with IPv6", func() {
+	const (
+		cniIPv6Address = "fd10::5"
+		linkIndexV4    = 1
+		linkIndexV6    = 2
+	)
+
+	t := newTestDriver()
+
+	var natInfo *natdiscovery.NATEndpointInfo
+
+	BeforeEach(func() {
+		t.netLink.SetLinkIndex(vxlan.VxlanIface, linkIndexV4)
+		t.netLink.SetLinkIndex(vxlan.VxlanIfaceV6, linkIndexV6)
+
+		cni.DiscoverFunc = func(clusterCIDRs []string) (*cni.Interface, error) {
+			ip := cniIPAddress
+			if k8snet.IsIPv6CIDRString(clusterCIDRs[0]) {
+				ip = cniIPv6Address
+			}
+
+			return &cni.Interface{
+				Name:      "veth0",
+				IPAddress: ip,
+			}, nil
+		}
+
+		natInfo = &natdiscovery.NATEndpointInfo{
+			Endpoint: subv1.Endpoint{
+				Spec: subv1.EndpointSpec{
+					ClusterID:  "east",
+					CableName:  "submariner-cable-east-fd00-68-2--1",
+					PrivateIPs: []string{"fd00:68:2::1"},
+					Subnets:    []string{"fd20::/64", "fd21::/64"},
+				},
+			},
+			UseIP: "fd00:68:2::1",
+		}
+	})
+
+	When("the endpoints are IPv6-only", func() {
+		BeforeEach(func() {
+			t.localCluster.Spec.ServiceCIDR = []string{"fd01::/64"}
+			t.localCluster.Spec.ClusterCIDR = []string{"fd10::/64"}
+			t.localEndpoint.PrivateIPs = []string{"fd00:68:1::1"}
+			t.localEndpoint.Subnets = append(t.localCluster.Spec.ServiceCIDR, t.localCluster.Spec.ClusterCIDR...)
+		})
+
+		It("should only create the IPv6 link device", func() {
+			link := t.netLink.AwaitLink(vxlan.VxlanIfaceV6).(*netlink.Vxlan)
+			Expect(link.SrcAddr).To(Equal(net.ParseIP("fd00:68:1::1")))
+			t.netLink.AwaitNoLink(vxlan.VxlanIface)
+
+			rules, err := t.netLink.RuleList(netlink.FAMILY_V6)
+			Expect(err).To(Succeed())
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].Table).To(Equal(vxlan.TableID))
+		})
+
+		Specify("ConnectToEndpoint should add the IPv6 forwarding database entry and routes", func() {
+			ip, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+			Expect(ip).To(Equal(natInfo.UseIP))
+
+			t.assertConnection(natInfo)
+			t.netLink.AwaitNeighbors(linkIndexV6, natInfo.UseIP)
+			t.assertRoutes(vxlan.VxlanIfaceV6, cniIPv6Address, "fdf1:68:2::1", natInfo.Endpoint.Spec.Subnets...)
+
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+			t.assertNoConnection(natInfo)
+			t.netLink.AwaitNoNeighbors(linkIndexV6, natInfo.UseIP)
+			t.assertRoutes(vxlan.VxlanIfaceV6, "", "")
+		})
+	})
+
+	When("the endpoints are dual-stack", func() {
+		BeforeEach(func() {
+			t.localCluster.Spec.ServiceCIDR = append(t.localCluster.Spec.ServiceCIDR, "fd01::/64")
+			t.localCluster.Spec.ClusterCIDR = append(t.localCluster.Spec.ClusterCIDR, "fd10::/64")
+			t.localEndpoint.PrivateIPs = append(t.localEndpoint.PrivateIPs, "fd00:68:1::1")
+			t.localEndpoint.Subnets = append(t.localCluster.Spec.ServiceCIDR, t.localCluster.Spec.ClusterCIDR...)
+
+			natInfo.Endpoint.Spec.PrivateIPs = []string{"192.68.2.1", "fd00:68:2::1"}
+			natInfo.Endpoint.Spec.Subnets = []string{"20.0.0.0/16", "fd20::/64"}
+			natInfo.UseIP = "192.68.2.1"
+		})
+
+		It("should create both link devices", func() {
+			t.netLink.AwaitLink(vxlan.VxlanIface)
+			t.netLink.AwaitLink(vxlan.VxlanIfaceV6)
+			t.netLink.AwaitRule(vxlan.TableID, "", "")
+
+			rules, err := t.netLink.RuleList(netlink.FAMILY_V6)
+			Expect(err).To(Succeed())
+			Expect(rules).To(HaveLen(1))
+		})
+
+		Specify("ConnectToEndpoint should route the subnets of each family over the link device of that family", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(Succeed())
+
+			t.assertConnection(natInfo)
+			t.netLink.AwaitNeighbors(linkIndexV4, "192.68.2.1")
+			t.netLink.AwaitNeighbors(linkIndexV6, "fd00:68:2::1")
+			t.assertRoutes(vxlan.VxlanIface, cniIPAddress, fmt.Sprintf("%d.68.2.1", vxlan.VxlanVTepNetworkPrefix), "20.0.0.0/16")
+			t.assertRoutes(vxlan.VxlanIfaceV6, cniIPv6Address, "fdf1:68:2::1", "fd20::/64")
+
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+			t.assertNoConnection(natInfo)
+			t.netLink.AwaitNoNeighbors(linkIndexV4, "192.68.2.1")
+			t.netLink.AwaitNoNeighbors(linkIndexV6, "fd00:68:2::1")
+			t.assertRoutes(vxlan.VxlanIface, "", "")
+			t.assertRoutes(vxlan.VxlanIfaceV6, "", "")
+		})
+
+		Specify("Cleanup should remove both link devices and rules", func() {
+			Expect(t.driver.Cleanup()).To(Succeed())
+			t.netLink.AwaitNoLink(vxlan.VxlanIface)
+			t.netLink.AwaitNoLink(vxlan.VxlanIfaceV6)
+			t.netLink.AwaitNoRule(vxlan.TableID, "", "")
+
+			rules, err := t.netLink.RuleList(netlink.FAMILY_V6)
+			Expect(err).To(Succeed())
+			Expect(rules).To(BeEmpty())
+		})
+	})
+})
+
 func routeFieldMap(src, gw, dst string) map[string]string {
 	return map[string]string{
 		"Src": src,
@@ -185,7 +315,7 @@ func newTestDriver() *testDriver {
 
 	JustBeforeEach(func() {
 		d, err := vxlan.NewDriver(endpoint.NewLocal(&t.localEndpoint, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""),
-			t.localCluster)
+			t.localCluster, &cable.DriverConfig{})
 		Expect(err).To(Succeed())
 
 		Expect(d.Init()).To(Succeed())
@@ -214,6 +344,26 @@ func (t *testDriver) assertConnection(natInfo *natdiscovery.NATEndpointInfo) {
 	Expect(conns).To(HaveExactElements(conn))
 }
 
+func (t *testDriver) assertRoutes(linkName, src, gw string, subnets ...string) {
+	link, err := t.netLink.LinkByName(linkName)
+	Expect(err).To(Succeed())
+
+	routes, err := t.netLink.RouteList(link, 0)
+	Expect(err).To(Succeed())
+
+	actualRoutes := []map[string]string{}
+	for i := range routes {
+		actualRoutes = append(actualRoutes, routeFieldMap(routes[i].Src.String(), routes[i].Gw.String(), routes[i].Dst.String()))
+	}
+
+	expRoutes := []map[string]string{}
+	for _, subnet := range subnets {
+		expRoutes = append(expRoutes, routeFieldMap(src, gw, subnet))
+	}
+
+	Expect(actualRoutes).To(Equal(expRoutes))
+}
+
 func (t *testDriver) assertNoConnection(natInfo *natdiscovery.NATEndpointInfo) {
 	conn, err := t.driver.GetActiveConnections()
 	Expect(err).To(Succeed())
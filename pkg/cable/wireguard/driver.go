@@ -22,6 +22,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"maps"
 	"net"
 	"os"
 	"slices"
@@ -33,12 +34,14 @@ import (
 	"github.com/submariner-io/admiral/pkg/log"
 	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
 	"github.com/submariner-io/submariner/pkg/endpoint"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
 	"github.com/vishvananda/netlink"
 	"golang.zx2c4.com/wireguard/wgctrl"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -50,6 +53,9 @@ const (
 	// PublicKey is name (key) of publicKey entry in back-end map.
 	PublicKey = "publicKey"
 
+	// NextPublicKey is name (key) of the back-end map entry announcing the public key an endpoint is rotating to.
+	NextPublicKey = "nextPublicKey"
+
 	// KeepAliveInterval to use for wg peers.
 	KeepAliveInterval = 10 * time.Second
 
@@ -71,38 +77,80 @@ func init() {
 type specification struct {
 	PSK      string `default:"default psk"`
 	NATTPort int32  `default:"4500"`
+	// KeyURL is a cluster file URL, eg secret://<namespace>/<secret-name>/<data-file>, at which the private key is kept
+	// so it survives restarts. It's expanded using the environment so that each gateway can have its own key, eg
+	// secret://submariner-operator/${NODE_NAME}-wireguard/private-key. If empty, a new key is generated on every start.
+	KeyURL string
+	// KeyRotationInterval is how often the private key is replaced. Zero disables rotation.
+	KeyRotationInterval time.Duration
+	// KeyRotationGrace is how long a new key is announced before it's used, and how long a replaced peer key is kept.
+	KeyRotationGrace time.Duration `default:"1m"`
+	// ClusterPSKSecret is the name of a mounted secret containing pre-shared keys keyed by remote cluster ID. Clusters
+	// without their own key use PSK.
+	ClusterPSKSecret string
+}
+
+// wgClient is the subset of the wgctrl client used by the driver.
+type wgClient interface {
+	Device(name string) (*wgtypes.Device, error)
+	ConfigureDevice(name string, cfg wgtypes.Config) error
+	Close() error
 }
 
 type wireguard struct {
-	localEndpoint v1.EndpointSpec
-	connections   map[string]*v1.Connection // clusterID -> remote ep connection
-	mutex         sync.Mutex
-	client        *wgctrl.Client
-	link          netlink.Link
-	spec          *specification
-	psk           *wgtypes.Key
+	localEndpoint   v1.EndpointSpec
+	local           *endpoint.Local
+	connections     map[string]*v1.Connection // cable name -> remote ep connection
+	nextPeers       map[string]string         // announced next public key -> clusterID
+	pendingRemovals map[string]*time.Timer    // public key -> timer removing the peer
+	mutex           sync.Mutex
+	client          wgClient
+	link            netlink.Link
+	spec            *specification
+	psk             *wgtypes.Key
+	pskSource       *psk.Source
+	kubeClient      kubernetes.Interface
+
+	// A subnet can only be allowed for a single peer so, with active/active remote gateways, only one of each cluster's
+	// peers holds its subnets at any time.
+	allowedIPsOwners map[string]string // clusterID -> cable name of the peer holding the cluster's subnets
+
+	// stop is closed when the driver is cleaned up, to stop rotating the private key and watching the pre-shared keys.
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 // NewDriver creates a new WireGuard driver.
-func NewDriver(localEndpoint *endpoint.Local, _ *types.SubmarinerCluster) (cable.Driver, error) {
-	// We'll panic if localEndpoint is nil, this is intentional
+func NewDriver(localEndpoint *endpoint.Local, _ *types.SubmarinerCluster, config *cable.DriverConfig) (cable.Driver, error) {
+	// We'll panic if localEndpoint or config are nil, this is intentional
 	var err error
 
 	w := wireguard{
-		connections: make(map[string]*v1.Connection),
-		spec:        new(specification),
+		local:            localEndpoint,
+		kubeClient:       config.KubeClient,
+		connections:      make(map[string]*v1.Connection),
+		nextPeers:        make(map[string]string),
+		pendingRemovals:  make(map[string]*time.Timer),
+		allowedIPsOwners: make(map[string]string),
+		stop:             make(chan struct{}),
+		spec:             new(specification),
 	}
 
 	if err = envconfig.Process(cable.IPSecEnvPrefix, w.spec); err != nil {
 		return nil, errors.Wrap(err, "error processing environment config for wireguard")
 	}
 
+	if w.spec.KeyURL, err = expandKeyURL(w.spec.KeyURL, localEndpoint.Spec()); err != nil {
+		return nil, err
+	}
+
 	if err = w.setWGLink(); err != nil {
 		return nil, errors.Wrap(err, "failed to setup WireGuard link")
 	}
 
 	// Create the controller.
-	if w.client, err = wgctrl.New(); err != nil {
+	client, err := wgctrl.New()
+	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, errors.New("wgctrl is not available on this system")
 		}
@@ -110,6 +158,8 @@ func NewDriver(localEndpoint *endpoint.Local, _ *types.SubmarinerCluster) (cable
 		return nil, errors.Wrap(err, "failed to open wgctl client")
 	}
 
+	w.client = client
+
 	defer func() {
 		if err != nil {
 			if e := w.client.Close(); e != nil {
@@ -120,17 +170,23 @@ func NewDriver(localEndpoint *endpoint.Local, _ *types.SubmarinerCluster) (cable
 		}
 	}()
 
-	// Generate local keys and set public key in BackendConfig.
-	var priv, pub, psk wgtypes.Key
+	// Load or generate local keys and set public key in BackendConfig.
+	var priv, pub, defaultPSK wgtypes.Key
 
-	if psk, err = genPsk(w.spec.PSK); err != nil {
+	if defaultPSK, err = genPsk(w.spec.PSK); err != nil {
 		return nil, errors.Wrap(err, "error generating pre-shared key")
 	}
 
-	w.psk = &psk
+	w.psk = &defaultPSK
 
-	if priv, err = wgtypes.GeneratePrivateKey(); err != nil {
-		return nil, errors.Wrap(err, "error generating private key")
+	if w.spec.ClusterPSKSecret != "" {
+		logger.Infof("Using per-cluster pre-shared keys from secret %q", w.spec.ClusterPSKSecret)
+
+		w.pskSource = psk.NewSourceForSecret(w.spec.ClusterPSKSecret)
+	}
+
+	if priv, err = w.loadPrivateKey(); err != nil {
+		return nil, errors.Wrap(err, "error loading private key")
 	}
 
 	port, err := localEndpoint.Spec().GetBackendPort(v1.UDPPortConfig, w.spec.NATTPort)
@@ -206,6 +262,14 @@ func (w *wireguard) Init() error {
 	logger.V(log.DEBUG).Infof("WireGuard device %s, is up on i/f number %d, listening on port :%d, with key %s",
 		w.link.Attrs().Name, l.Index, d.ListenPort, d.PublicKey)
 
+	if w.spec.KeyRotationInterval > 0 {
+		go w.runKeyRotation()
+	}
+
+	if w.pskSource != nil {
+		w.pskSource.Watch(w.stop, w.updatePSK)
+	}
+
 	return nil
 }
 
@@ -237,53 +301,69 @@ func (w *wireguard) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 		return "", errors.Wrap(err, "failed to parse peer public key")
 	}
 
+	port, err := remoteEndpoint.Spec.GetBackendPort(v1.UDPPortConfig, w.spec.NATTPort)
+	if err != nil {
+		logger.Warningf("Error parsing %q from remote endpoint %q - using port %dº instead: %v", v1.UDPPortConfig,
+			remoteEndpoint.Spec.CableName, w.spec.NATTPort, err)
+	}
+
+	peerEndpoint := &net.UDPAddr{
+		IP:   remoteIP,
+		Port: int(port),
+	}
+
+	presharedKey, err := w.pskFor(remoteEndpoint.Spec.ClusterID)
+	if err != nil {
+		return "", err
+	}
+
 	logger.V(log.DEBUG).Infof("Connecting cluster %s endpoint %s with publicKey %s",
 		remoteEndpoint.Spec.ClusterID, remoteIP, remoteKey)
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	// Delete or update old peers for ClusterID.
-	oldCon, found := w.connections[remoteEndpoint.Spec.ClusterID]
-	if found {
+	w.cancelPeerRemoval(remoteKey)
+	delete(w.nextPeers, remoteKey.String())
+
+	// Delete or update old peers for the endpoint, and for the other endpoints of its cluster unless these are
+	// active/active gateways which are kept alongside.
+	for cableName, oldCon := range w.connections {
+		if oldCon.Endpoint.ClusterID != remoteEndpoint.Spec.ClusterID ||
+			(cableName != remoteEndpoint.Spec.CableName && remoteEndpoint.Spec.IsActiveActive()) {
+			continue
+		}
+
 		if oldKey, err := keyFromSpec(&oldCon.Endpoint); err == nil {
 			if oldKey.String() == remoteKey.String() {
 				// Existing connection, update status and skip.
 				w.updatePeerStatus(oldCon, oldKey)
+				w.updateNextKey(oldCon, &remoteEndpoint.Spec)
+				w.configureNextPeer(&remoteEndpoint.Spec, peerEndpoint, presharedKey)
 				logger.V(log.DEBUG).Infof("Skipping connect for existing peer key %s", oldKey)
 
 				return ip, nil
 			}
-			// new peer will take over subnets so can ignore error
-			_ = w.removePeer(oldKey)
+
+			w.replacePeer(&oldCon.Endpoint, oldKey, remoteKey)
 		}
 
-		delete(w.connections, remoteEndpoint.Spec.ClusterID)
+		delete(w.connections, cableName)
 	}
 
 	// create connection, overwrite existing connection
 	connection := v1.NewConnection(&remoteEndpoint.Spec, ip, endpointInfo.UseNAT)
 	connection.SetStatus(v1.Connecting, "Connection has been created but not yet started")
 	logger.V(log.DEBUG).Infof("Adding connection for cluster %s, %v", remoteEndpoint.Spec.ClusterID, connection)
-	w.connections[remoteEndpoint.Spec.ClusterID] = connection
-
-	port, err := remoteEndpoint.Spec.GetBackendPort(v1.UDPPortConfig, w.spec.NATTPort)
-	if err != nil {
-		logger.Warningf("Error parsing %q from remote endpoint %q - using port %dº instead: %v", v1.UDPPortConfig,
-			remoteEndpoint.Spec.CableName, w.spec.NATTPort, err)
-	}
-
-	remotePort := int(port)
+	w.connections[remoteEndpoint.Spec.CableName] = connection
+	w.allowedIPsOwners[remoteEndpoint.Spec.ClusterID] = remoteEndpoint.Spec.CableName
 
 	// configure peer
 	peerCfg := []wgtypes.PeerConfig{{
-		PublicKey:    *remoteKey,
-		Remove:       false,
-		UpdateOnly:   false,
-		PresharedKey: w.psk,
-		Endpoint: &net.UDPAddr{
-			IP:   remoteIP,
-			Port: remotePort,
-		},
+		PublicKey:                   *remoteKey,
+		Remove:                      false,
+		UpdateOnly:                  false,
+		PresharedKey:                presharedKey,
+		Endpoint:                    peerEndpoint,
 		PersistentKeepaliveInterval: ptr.To(KeepAliveInterval),
 		ReplaceAllowedIPs:           true,
 		AllowedIPs:                  allowedIPs,
@@ -302,6 +382,8 @@ func (w *wireguard) ConnectToEndpoint(endpointInfo *natdiscovery.NATEndpointInfo
 		logger.Errorf(err, "Failed to verify peer configuration")
 	}
 
+	w.configureNextPeer(&remoteEndpoint.Spec, peerEndpoint, presharedKey)
+
 	logger.V(log.DEBUG).Infof("Done connecting endpoint peer %s@%s", *remoteKey, remoteIP)
 
 	cable.RecordConnection(cableDriverName, &w.localEndpoint, &connection.Endpoint, string(v1.Connected), true)
@@ -338,19 +420,33 @@ func (w *wireguard) DisconnectFromEndpoint(remoteEndpoint *types.SubmarinerEndpo
 		return errors.Wrap(err, "failed to parse peer public key")
 	}
 
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
 	// wg remove
+	w.cancelPeerRemoval(remoteKey)
 	_ = w.removePeer(remoteKey)
 
-	w.mutex.Lock()
-	defer w.mutex.Unlock()
+	if nextKey := nextKeyFromSpec(&remoteEndpoint.Spec); nextKey != nil {
+		w.removeNextPeer(nextKey)
+	}
 
-	if w.keyMismatch(remoteEndpoint.Spec.ClusterID, remoteKey) {
-		// ClusterID probably already associated with new spec. Do not remove connections.
-		logger.Warningf("Key mismatch for peer cluster %s, keeping existing spec", remoteEndpoint.Spec.ClusterID)
+	if w.keyMismatch(remoteEndpoint.Spec.CableName, remoteKey) {
+		// The cable is probably already associated with a new spec. Do not remove connections.
+		logger.Warningf("Key mismatch for peer cable %s, keeping existing spec", remoteEndpoint.Spec.CableName)
 		return nil
 	}
 
-	delete(w.connections, remoteEndpoint.Spec.ClusterID)
+	delete(w.connections, remoteEndpoint.Spec.CableName)
+
+	if w.allowedIPsOwners[remoteEndpoint.Spec.ClusterID] == remoteEndpoint.Spec.CableName {
+		delete(w.allowedIPsOwners, remoteEndpoint.Spec.ClusterID)
+		w.reassignAllowedIPs(remoteEndpoint.Spec.ClusterID)
+	}
+
+	if _, stillConnected := w.allowedIPsOwners[remoteEndpoint.Spec.ClusterID]; !stillConnected && w.pskSource != nil {
+		w.pskSource.Forget(remoteEndpoint.Spec.ClusterID)
+	}
 
 	logger.V(log.DEBUG).Infof("Done removing endpoint for cluster %s", remoteEndpoint.Spec.ClusterID)
 	cable.RecordDisconnected(cableDriverName, &w.localEndpoint, &remoteEndpoint.Spec)
@@ -437,10 +533,8 @@ func (w *wireguard) peerByKey(key *wgtypes.Key) (*wgtypes.Peer, error) {
 		return nil, errors.Wrapf(err, "failed to find device %s", DefaultDeviceName)
 	}
 
-	for i := range d.Peers {
-		if d.Peers[i].PublicKey.String() == key.String() {
-			return &d.Peers[i], nil
-		}
+	if p := findPeer(d.Peers, key); p != nil {
+		return p, nil
 	}
 
 	return nil, fmt.Errorf("peer not found for key %s", key)
@@ -471,37 +565,153 @@ func (w *wireguard) verifyNewPeer(peerCfg *wgtypes.PeerConfig) error {
 	return nil
 }
 
-// Find if key matches connection spec (from spec clusterID).
-func (w *wireguard) keyMismatch(cid string, key *wgtypes.Key) bool {
-	c, found := w.connections[cid]
+// Find if key matches connection spec (from spec cable name).
+func (w *wireguard) keyMismatch(cableName string, key *wgtypes.Key) bool {
+	c, found := w.connections[cableName]
 	if !found {
-		logger.Warningf("Could not find spec for cluster %s, mismatched endpoint key %s", cid, key)
+		logger.Warningf("Could not find spec for cable %s, mismatched endpoint key %s", cableName, key)
 		return true
 	}
 
 	oldKey, err := keyFromSpec(&c.Endpoint)
 	if err != nil {
-		logger.Warningf("Could not find old key of cluster %s, mismatched endpoint key %s", cid, key)
+		logger.Warningf("Could not find old key of cable %s, mismatched endpoint key %s", cableName, key)
 		return true
 	}
 
 	if oldKey.String() != key.String() {
-		logger.Warningf("Key mismatch, cluster %s key is %s, endpoint key is %s", cid, oldKey, key)
+		logger.Warningf("Key mismatch, cable %s key is %s, endpoint key is %s", cableName, oldKey, key)
 		return true
 	}
 
 	return false
 }
 
+// reassignAllowedIPs moves the given cluster's subnets to one of its remaining peers, if any, after the peer holding
+// them was removed.
+func (w *wireguard) reassignAllowedIPs(clusterID string) {
+	for _, cableName := range slices.Sorted(maps.Keys(w.connections)) {
+		connection := w.connections[cableName]
+		if connection.Endpoint.ClusterID != clusterID {
+			continue
+		}
+
+		key, err := keyFromSpec(&connection.Endpoint)
+		if err != nil {
+			continue
+		}
+
+		logger.Infof("Moving the subnets of cluster %s to peer %s", clusterID, key)
+
+		err = w.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{
+			Peers: []wgtypes.PeerConfig{{
+				PublicKey:         *key,
+				UpdateOnly:        true,
+				ReplaceAllowedIPs: true,
+				AllowedIPs:        parseSubnets(connection.Endpoint.Subnets),
+			}},
+		})
+		if err != nil {
+			logger.Errorf(err, "Failed to move the subnets of cluster %s to peer %s", clusterID, key)
+			continue
+		}
+
+		w.allowedIPsOwners[clusterID] = cableName
+
+		return
+	}
+}
+
 func genPsk(psk string) (wgtypes.Key, error) {
 	// Convert spec PSK string to right length byte array, using sha256.Size == wgtypes.KeyLen.
 	pskBytes := sha256.Sum256([]byte(psk))
 	return wgtypes.NewKey(pskBytes[:]) //nolint:wrapcheck // Let the caller wrap it
 }
 
+// pskFor returns the pre-shared key for the given remote cluster, which is its own key if it has one.
+func (w *wireguard) pskFor(clusterID string) (*wgtypes.Key, error) {
+	if w.pskSource == nil {
+		return w.psk, nil
+	}
+
+	clusterPSK, err := w.pskSource.Get(clusterID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving the pre-shared key for cluster %q", clusterID)
+	}
+
+	if clusterPSK == nil {
+		return w.psk, nil
+	}
+
+	key, err := genPsk(string(clusterPSK))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating the pre-shared key for cluster %q", clusterID)
+	}
+
+	return &key, nil
+}
+
+// updatePSK reconfigures the peers of the given remote cluster with its pre-shared key, which has changed. The peers are
+// updated in place so the cables don't need to be re-installed.
+func (w *wireguard) updatePSK(clusterID string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	presharedKey, err := w.pskFor(clusterID)
+	if err != nil {
+		logger.Errorf(err, "Error updating the pre-shared key of the peers of cluster %q", clusterID)
+		return
+	}
+
+	for _, connection := range w.connections {
+		if connection.Endpoint.ClusterID != clusterID {
+			continue
+		}
+
+		key, err := keyFromSpec(&connection.Endpoint)
+		if err != nil {
+			continue
+		}
+
+		logger.Infof("The pre-shared key for cluster %q has changed - updating peer %s", clusterID, key)
+
+		peers := []wgtypes.PeerConfig{{
+			PublicKey:    *key,
+			UpdateOnly:   true,
+			PresharedKey: presharedKey,
+		}}
+
+		if nextKey := nextKeyFromSpec(&connection.Endpoint); nextKey != nil {
+			peers = append(peers, wgtypes.PeerConfig{
+				PublicKey:    *nextKey,
+				UpdateOnly:   true,
+				PresharedKey: presharedKey,
+			})
+		}
+
+		err = w.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{Peers: peers})
+		if err != nil {
+			logger.Errorf(err, "Failed to update the pre-shared key of peer %s", key)
+		}
+	}
+}
+
 func (w *wireguard) Cleanup() error {
 	logger.Info("Uninstalling the wireguard cable driver")
 
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+
+	w.mutex.Lock()
+
+	for key, timer := range w.pendingRemovals {
+		timer.Stop()
+		delete(w.pendingRemovals, key)
+	}
+
+	w.mutex.Unlock()
+
 	link, err := netlink.LinkByName(DefaultDeviceName)
 	if err != nil && !errors.Is(err, netlink.LinkNotFoundError{}) {
 		return errors.Wrapf(err, "error retrieving the wireguard interface %q", DefaultDeviceName)
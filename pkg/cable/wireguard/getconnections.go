@@ -40,9 +40,15 @@ func (w *wireguard) GetConnections() ([]v1.Connection, error) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
+	w.promoteNextPeers(d.Peers)
+
 	for i := range d.Peers {
 		key := d.Peers[i].PublicKey
 
+		if w.isTransitionalPeer(&key) {
+			continue
+		}
+
 		connection, err := w.connectionByKey(&key)
 		if err != nil {
 			logger.Warningf("Found unknown peer with key %s, removing", key)
@@ -68,7 +74,7 @@ func (w *wireguard) connectionByKey(key *wgtypes.Key) (*v1.Connection, error) {
 				return w.connections[i], nil
 			}
 		} else {
-			logger.Errorf(err, "Could not compare key for cluster %s, skipping", i)
+			logger.Errorf(err, "Could not compare key for cable %s, skipping", i)
 		}
 	}
 
@@ -169,6 +175,7 @@ func peerTrafficDelta(c *v1.Connection, key string, newVal int64) int64 {
 }
 
 // Save backendConfig[key] and export the metrics to prometheus.
+// WireGuard only counts bytes per peer, so the packet metrics aren't recorded for its cables.
 func saveAndRecordPeerTraffic(localEndpoint, remoteEndpoint *v1.EndpointSpec, lc, tx, rx int64) {
 	remoteEndpoint.BackendConfig[lastChecked] = strconv.FormatInt(lc, 10)
 	remoteEndpoint.BackendConfig[transmitBytes] = strconv.FormatInt(tx, 10)
@@ -0,0 +1,101 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wireguard
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var _ = Describe("Traffic metrics", func() {
+	t := newTestDriver()
+
+	It("should record the bytes but not the packets, which WireGuard doesn't count", func() {
+		key := newPublicKey()
+
+		t.connect(&natdiscovery.NATEndpointInfo{
+			UseIP: "192.68.3.1",
+			Endpoint: v1.Endpoint{
+				Spec: v1.EndpointSpec{
+					ClusterID:     "traffic",
+					CableName:     "submariner-cable-traffic-192-68-3-1",
+					PrivateIPs:    []string{"192.68.3.1"},
+					Subnets:       []string{"30.0.0.0/16"},
+					BackendConfig: map[string]string{PublicKey: key.String()},
+				},
+			},
+		})
+
+		t.client.setLastHandshake(&key, time.Now())
+
+		for _, traffic := range []int64{100, 200, 300} {
+			t.client.setTraffic(&key, traffic, traffic)
+			t.expireLastChecked("submariner-cable-traffic-192-68-3-1")
+
+			_, err := t.driver.GetConnections()
+			Expect(err).To(Succeed())
+		}
+
+		Expect(metricNames("traffic")).To(ContainElements("submariner_gateway_rx_bytes_total", "submariner_gateway_tx_bytes_total"))
+		Expect(metricNames("traffic")).ToNot(ContainElements("submariner_gateway_rx_packets_total",
+			"submariner_gateway_tx_packets_total"))
+	})
+})
+
+// metricNames returns the names of the metrics with a series for the given remote cluster.
+func metricNames(remoteClusterID string) []string {
+	families, err := prometheus.DefaultGatherer.Gather()
+	Expect(err).To(Succeed())
+
+	var names []string
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "remote_cluster" && label.GetValue() == remoteClusterID {
+					names = append(names, family.GetName())
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+func (c *fakeClient) setTraffic(key *wgtypes.Key, rx, tx int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.device.Peers[c.indexOf(key)].ReceiveBytes = rx
+	c.device.Peers[c.indexOf(key)].TransmitBytes = tx
+}
+
+// expireLastChecked moves the connection's last check back past the keep-alive interval so the next poll re-evaluates it.
+func (t *testDriver) expireLastChecked(cableName string) {
+	t.driver.mutex.Lock()
+	defer t.driver.mutex.Unlock()
+
+	t.driver.connections[cableName].Endpoint.BackendConfig[lastChecked] = "0"
+}
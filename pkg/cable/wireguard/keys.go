@@ -0,0 +1,376 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wireguard
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/util/clusterfiles"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+)
+
+// expandKeyURL expands the environment variables in the configured KeyURL. The gateways of an active/active cluster
+// each need their own key, which they'd otherwise share and rotate concurrently, so the URL must then reference a
+// per-gateway variable, eg ${NODE_NAME}.
+func expandKeyURL(keyURL string, localEndpoint *v1.EndpointSpec) (string, error) {
+	if keyURL == "" {
+		return "", nil
+	}
+
+	expanded := os.ExpandEnv(keyURL)
+
+	if localEndpoint.IsActiveActive() && expanded == keyURL {
+		return "", errors.Errorf("the key URL %q is shared by the active/active gateways - it must reference a per-gateway"+
+			" variable, eg ${NODE_NAME}", keyURL)
+	}
+
+	return expanded, nil
+}
+
+// loadPrivateKey returns the private key stored at the configured KeyURL, generating and storing a new key if there's
+// none yet. Without a KeyURL, a new key is generated.
+func (w *wireguard) loadPrivateKey() (wgtypes.Key, error) {
+	if w.spec.KeyURL == "" {
+		return wgtypes.GeneratePrivateKey() //nolint:wrapcheck // Let the caller wrap it
+	}
+
+	if w.kubeClient == nil && !strings.HasPrefix(w.spec.KeyURL, "file:") {
+		return wgtypes.Key{}, errors.Errorf("no Kubernetes client is available to read the key from %q", w.spec.KeyURL)
+	}
+
+	file, err := clusterfiles.Get(context.TODO(), w.kubeClient, w.spec.KeyURL)
+	if apierrors.IsNotFound(err) || errors.Is(err, clusterfiles.ErrDataNotFound) {
+		logger.Infof("No private key found at %q - generating a new one", w.spec.KeyURL)
+
+		key, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return key, errors.Wrap(err, "error generating private key")
+		}
+
+		return key, w.storePrivateKey(&key)
+	}
+
+	if err != nil {
+		return wgtypes.Key{}, errors.Wrapf(err, "error retrieving the private key from %q", w.spec.KeyURL)
+	}
+
+	if !strings.HasPrefix(w.spec.KeyURL, "file:") {
+		defer os.Remove(file)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return wgtypes.Key{}, errors.Wrapf(err, "error reading the private key file %q", file)
+	}
+
+	key, err := wgtypes.ParseKey(strings.TrimSpace(string(data)))
+	if err != nil {
+		return key, errors.Wrapf(err, "error parsing the private key from %q", w.spec.KeyURL)
+	}
+
+	logger.Infof("Using the private key from %q", w.spec.KeyURL)
+
+	return key, nil
+}
+
+func (w *wireguard) storePrivateKey(key *wgtypes.Key) error {
+	if w.spec.KeyURL == "" {
+		return nil
+	}
+
+	err := clusterfiles.Put(context.TODO(), w.kubeClient, w.spec.KeyURL, []byte(key.String()))
+
+	return errors.Wrapf(err, "error storing the private key at %q", w.spec.KeyURL)
+}
+
+// runKeyRotation rotates the private key periodically until the driver is cleaned up.
+func (w *wireguard) runKeyRotation() {
+	logger.Infof("Rotating the private key every %v", w.spec.KeyRotationInterval)
+
+	ticker := time.NewTicker(w.spec.KeyRotationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			logger.Info("Stopped rotating the private key")
+			return
+		case <-ticker.C:
+			if err := w.rotateKey(); err != nil {
+				logger.Errorf(err, "Error rotating the private key")
+			}
+		}
+	}
+}
+
+// rotateKey replaces the private key. The new public key is first announced in the local endpoint so remote endpoints
+// can add it as a peer, and the device only switches to it once the grace period has passed. Remote endpoints then
+// keep the replaced key as a peer for another grace period.
+func (w *wireguard) rotateKey() error {
+	next, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "error generating private key")
+	}
+
+	pub := next.PublicKey()
+
+	logger.Infof("Announcing next public key %s", pub)
+
+	err = w.updateLocalEndpoint(func(existing *v1.EndpointSpec) {
+		existing.BackendConfig[NextPublicKey] = pub.String()
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-w.stop:
+		return errors.Errorf("the driver was stopped before switching to public key %s", pub)
+	case <-time.After(w.spec.KeyRotationGrace):
+	}
+
+	// Store the key before using it so a restart picks up the key the remote endpoints are switching to.
+	if err := w.storePrivateKey(&next); err != nil {
+		return err
+	}
+
+	if err := w.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{PrivateKey: &next}); err != nil {
+		return errors.Wrap(err, "failed to configure WireGuard device with the next private key")
+	}
+
+	err = w.updateLocalEndpoint(func(existing *v1.EndpointSpec) {
+		existing.BackendConfig[PublicKey] = pub.String()
+		delete(existing.BackendConfig, NextPublicKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Rotated to public key %s", pub)
+
+	return nil
+}
+
+func (w *wireguard) updateLocalEndpoint(mutate func(existing *v1.EndpointSpec)) error {
+	if err := w.local.Update(context.TODO(), mutate); err != nil {
+		return errors.Wrap(err, "error updating local endpoint")
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.localEndpoint = *w.local.Spec()
+
+	return nil
+}
+
+func nextKeyFromSpec(ep *v1.EndpointSpec) *wgtypes.Key {
+	s, found := ep.BackendConfig[NextPublicKey]
+	if !found {
+		return nil
+	}
+
+	key, err := wgtypes.ParseKey(s)
+	if err != nil {
+		logger.Warningf("Failed to parse next public key %q of endpoint %q: %v", s, ep.CableName, err)
+		return nil
+	}
+
+	return &key
+}
+
+// configureNextPeer adds a peer for the next key announced by a remote endpoint so the handshake succeeds as soon as the
+// remote endpoint switches to it. A subnet can only be allowed for a single peer so the peer only gets the remote
+// endpoint's subnets once it's in use, see promoteNextPeers.
+func (w *wireguard) configureNextPeer(spec *v1.EndpointSpec, peerEndpoint *net.UDPAddr, presharedKey *wgtypes.Key) {
+	nextKey := nextKeyFromSpec(spec)
+	if nextKey == nil {
+		return
+	}
+
+	w.cancelPeerRemoval(nextKey)
+
+	err := w.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{
+		ReplacePeers: false,
+		Peers: []wgtypes.PeerConfig{{
+			PublicKey:                   *nextKey,
+			PresharedKey:                presharedKey,
+			Endpoint:                    peerEndpoint,
+			PersistentKeepaliveInterval: ptr.To(KeepAliveInterval),
+			ReplaceAllowedIPs:           true,
+		}},
+	})
+	if err != nil {
+		logger.Errorf(err, "Failed to configure peer for next key %s of cluster %s", nextKey, spec.ClusterID)
+		return
+	}
+
+	w.nextPeers[nextKey.String()] = spec.ClusterID
+
+	logger.V(log.DEBUG).Infof("Configured peer for next key %s of cluster %s", nextKey, spec.ClusterID)
+}
+
+// schedulePeerRemoval removes the peer with the given key once the grace period has passed, unless it's re-added in the
+// meantime. This keeps a key replaced by a rotation working during the grace period.
+func (w *wireguard) schedulePeerRemoval(key *wgtypes.Key) {
+	keyStr := key.String()
+	if _, found := w.pendingRemovals[keyStr]; found {
+		return
+	}
+
+	toRemove := *key
+
+	var timer *time.Timer
+
+	timer = time.AfterFunc(w.spec.KeyRotationGrace, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		if w.pendingRemovals[keyStr] != timer {
+			return
+		}
+
+		delete(w.pendingRemovals, keyStr)
+
+		_ = w.removePeer(&toRemove)
+	})
+
+	w.pendingRemovals[keyStr] = timer
+}
+
+func (w *wireguard) cancelPeerRemoval(key *wgtypes.Key) {
+	if timer, found := w.pendingRemovals[key.String()]; found {
+		timer.Stop()
+		delete(w.pendingRemovals, key.String())
+	}
+}
+
+// isTransitionalPeer returns true if the peer with the given key is either an announced next key or a replaced key
+// that's pending removal.
+func (w *wireguard) isTransitionalPeer(key *wgtypes.Key) bool {
+	_, isNext := w.nextPeers[key.String()]
+	_, isPending := w.pendingRemovals[key.String()]
+
+	return isNext || isPending
+}
+
+// replacePeer removes the peer for the old key of a remote endpoint whose key changed. If the endpoint rotated to the
+// key it announced, the old peer is kept for the grace period as the endpoint may still be switching, otherwise it's
+// removed straight away, along with any abandoned next key.
+func (w *wireguard) replacePeer(oldSpec *v1.EndpointSpec, oldKey, newKey *wgtypes.Key) {
+	nextKey := nextKeyFromSpec(oldSpec)
+	if nextKey != nil && nextKey.String() == newKey.String() {
+		logger.Infof("Cluster %s rotated from key %s to %s", oldSpec.ClusterID, oldKey, newKey)
+		w.schedulePeerRemoval(oldKey)
+
+		return
+	}
+
+	// The new peer will take over the subnets so the error can be ignored.
+	_ = w.removePeer(oldKey)
+
+	if nextKey != nil {
+		w.removeNextPeer(nextKey)
+	}
+}
+
+// updateNextKey records the next key announced by a remote endpoint in its existing connection, removing the peer for a
+// previously announced key that was withdrawn.
+func (w *wireguard) updateNextKey(connection *v1.Connection, spec *v1.EndpointSpec) {
+	prevNextKey := nextKeyFromSpec(&connection.Endpoint)
+	nextKey := nextKeyFromSpec(spec)
+
+	if prevNextKey != nil && (nextKey == nil || *prevNextKey != *nextKey) {
+		w.removeNextPeer(prevNextKey)
+	}
+
+	if nextKey == nil {
+		delete(connection.Endpoint.BackendConfig, NextPublicKey)
+		return
+	}
+
+	connection.Endpoint.BackendConfig[NextPublicKey] = nextKey.String()
+}
+
+func (w *wireguard) removeNextPeer(key *wgtypes.Key) {
+	delete(w.nextPeers, key.String())
+	w.cancelPeerRemoval(key)
+	_ = w.removePeer(key)
+}
+
+// promoteNextPeers moves a remote endpoint's subnets to the peer for its announced next key once that peer has a more
+// recent handshake than the current one, ie the remote endpoint switched to the next key. The traffic from the remote
+// endpoint would otherwise be dropped, as it's not from the peer's allowed IPs, until its updated Endpoint is processed.
+func (w *wireguard) promoteNextPeers(peers []wgtypes.Peer) {
+	for cableName, connection := range w.connections {
+		if w.allowedIPsOwners[connection.Endpoint.ClusterID] != cableName {
+			continue
+		}
+
+		nextKey := nextKeyFromSpec(&connection.Endpoint)
+		if nextKey == nil {
+			continue
+		}
+
+		key, err := keyFromSpec(&connection.Endpoint)
+		if err != nil {
+			continue
+		}
+
+		current := findPeer(peers, key)
+		next := findPeer(peers, nextKey)
+
+		if current == nil || next == nil || len(next.AllowedIPs) > 0 || !next.LastHandshakeTime.After(current.LastHandshakeTime) {
+			continue
+		}
+
+		logger.Infof("Cluster %s switched to its next key %s - moving its subnets to the peer", connection.Endpoint.ClusterID, nextKey)
+
+		err = w.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{
+			Peers: []wgtypes.PeerConfig{{
+				PublicKey:         *nextKey,
+				UpdateOnly:        true,
+				ReplaceAllowedIPs: true,
+				AllowedIPs:        parseSubnets(connection.Endpoint.Subnets),
+			}},
+		})
+		if err != nil {
+			logger.Errorf(err, "Failed to move the subnets of cluster %s to peer %s", connection.Endpoint.ClusterID, nextKey)
+		}
+	}
+}
+
+func findPeer(peers []wgtypes.Peer, key *wgtypes.Key) *wgtypes.Peer {
+	for i := range peers {
+		if peers[i].PublicKey.String() == key.String() {
+			return &peers[i]
+		}
+	}
+
+	return nil
+}
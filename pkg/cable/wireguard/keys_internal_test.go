@@ -0,0 +1,500 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wireguard
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
+	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const remoteSubnet = "20.0.0.0/16"
+
+var _ = Describe("Key rotation", func() {
+	Describe("of the local key", testLocalKeyRotation)
+	Describe("of a remote key", testRemoteKeyRotation)
+})
+
+var _ = Describe("Per-cluster pre-shared keys", testPerClusterPSKs)
+
+var _ = Describe("expandKeyURL", func() {
+	const keyURL = "secret://submariner-operator/${NODE_NAME}-wireguard/private-key"
+
+	var localEndpoint *v1.EndpointSpec
+
+	BeforeEach(func() {
+		localEndpoint = &v1.EndpointSpec{BackendConfig: map[string]string{}}
+		GinkgoT().Setenv("NODE_NAME", "node1")
+	})
+
+	It("should expand the environment variables", func() {
+		Expect(expandKeyURL(keyURL, localEndpoint)).To(Equal("secret://submariner-operator/node1-wireguard/private-key"))
+	})
+
+	When("the gateway is active/active", func() {
+		BeforeEach(func() {
+			localEndpoint.BackendConfig[v1.ActiveActive] = "true"
+		})
+
+		It("should accept a per-gateway URL", func() {
+			Expect(expandKeyURL(keyURL, localEndpoint)).To(Equal("secret://submariner-operator/node1-wireguard/private-key"))
+		})
+
+		It("should reject a URL shared by the gateways", func() {
+			_, err := expandKeyURL("secret://submariner-operator/submariner-wireguard/private-key", localEndpoint)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func testLocalKeyRotation() {
+	t := newTestDriver()
+
+	It("should announce the next key and switch to it after the grace period", func() {
+		initialKey := t.client.privateKey()
+		rotateErr := make(chan error, 1)
+
+		go func() {
+			rotateErr <- t.driver.rotateKey()
+		}()
+
+		Eventually(func() string {
+			return t.driver.local.Spec().BackendConfig[NextPublicKey]
+		}).ShouldNot(BeEmpty())
+
+		nextPub := t.driver.local.Spec().BackendConfig[NextPublicKey]
+		Expect(t.client.privateKey()).To(Equal(initialKey))
+		Expect(t.driver.local.Spec().BackendConfig[PublicKey]).To(Equal(initialKey.PublicKey().String()))
+
+		Eventually(rotateErr).Should(Receive(Succeed()))
+		Expect(t.client.privateKey().PublicKey().String()).To(Equal(nextPub))
+		Expect(t.driver.local.Spec().BackendConfig).To(HaveKeyWithValue(PublicKey, nextPub))
+		Expect(t.driver.local.Spec().BackendConfig).ToNot(HaveKey(NextPublicKey))
+		Expect(t.driver.localEndpoint.BackendConfig).To(HaveKeyWithValue(PublicKey, nextPub))
+	})
+
+	When("the driver is cleaned up", func() {
+		BeforeEach(func() {
+			t.driver.spec.KeyRotationInterval = 10 * time.Millisecond
+			t.driver.spec.KeyRotationGrace = time.Hour
+		})
+
+		It("should stop rotating the key", func() {
+			initialKey := t.client.privateKey()
+			stopped := make(chan struct{})
+
+			go func() {
+				t.driver.runKeyRotation()
+				close(stopped)
+			}()
+
+			Eventually(func() string {
+				return t.driver.local.Spec().BackendConfig[NextPublicKey]
+			}).ShouldNot(BeEmpty())
+
+			_ = t.driver.Cleanup()
+
+			Eventually(stopped).Should(BeClosed())
+			Expect(t.client.privateKey()).To(Equal(initialKey))
+		})
+	})
+}
+
+func testRemoteKeyRotation() {
+	t := newTestDriver()
+
+	var (
+		natInfo *natdiscovery.NATEndpointInfo
+		key     wgtypes.Key
+		nextKey wgtypes.Key
+	)
+
+	BeforeEach(func() {
+		key = newPublicKey()
+		nextKey = newPublicKey()
+
+		natInfo = &natdiscovery.NATEndpointInfo{
+			UseIP: "192.68.2.1",
+			Endpoint: v1.Endpoint{
+				Spec: v1.EndpointSpec{
+					ClusterID:     "east",
+					CableName:     "submariner-cable-east-192-68-2-1",
+					PrivateIPs:    []string{"192.68.2.1"},
+					Subnets:       []string{remoteSubnet},
+					BackendConfig: map[string]string{PublicKey: key.String()},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		t.connect(natInfo)
+		Expect(t.client.allowedIPsOf(&key)).To(Equal([]string{remoteSubnet}))
+
+		natInfo.Endpoint.Spec.BackendConfig[NextPublicKey] = nextKey.String()
+		t.connect(natInfo)
+	})
+
+	It("should add a peer for the announced next key without allowed IPs", func() {
+		Expect(t.client.allowedIPsOf(&key)).To(Equal([]string{remoteSubnet}))
+		Expect(t.client.hasPeer(&nextKey)).To(BeTrue())
+		Expect(t.client.allowedIPsOf(&nextKey)).To(BeEmpty())
+	})
+
+	When("the next key's peer completes a handshake", func() {
+		It("should move the allowed IPs to it", func() {
+			t.client.setLastHandshake(&key, time.Now().Add(-time.Minute))
+			t.client.setLastHandshake(&nextKey, time.Now())
+
+			_, err := t.driver.GetConnections()
+			Expect(err).To(Succeed())
+
+			Expect(t.client.allowedIPsOf(&nextKey)).To(Equal([]string{remoteSubnet}))
+			Expect(t.client.allowedIPsOf(&key)).To(BeEmpty())
+		})
+	})
+
+	When("the remote endpoint switches to the next key", func() {
+		It("should keep the replaced key's peer for the grace period", func() {
+			natInfo.Endpoint.Spec.BackendConfig = map[string]string{PublicKey: nextKey.String()}
+			t.connect(natInfo)
+
+			Expect(t.client.allowedIPsOf(&nextKey)).To(Equal([]string{remoteSubnet}))
+			Expect(t.client.hasPeer(&key)).To(BeTrue())
+
+			Eventually(func() bool {
+				return t.client.hasPeer(&key)
+			}).Should(BeFalse())
+			Expect(t.client.hasPeer(&nextKey)).To(BeTrue())
+		})
+	})
+
+	When("the remote endpoint changes to a key it didn't announce", func() {
+		It("should remove the previous peers straight away", func() {
+			otherKey := newPublicKey()
+			natInfo.Endpoint.Spec.BackendConfig = map[string]string{PublicKey: otherKey.String()}
+			t.connect(natInfo)
+
+			Expect(t.client.allowedIPsOf(&otherKey)).To(Equal([]string{remoteSubnet}))
+			Expect(t.client.hasPeer(&key)).To(BeFalse())
+			Expect(t.client.hasPeer(&nextKey)).To(BeFalse())
+		})
+	})
+
+	When("the remote endpoint is removed", func() {
+		It("should remove its peers straight away", func() {
+			Expect(t.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: natInfo.Endpoint.Spec})).To(Succeed())
+
+			Expect(t.client.hasPeer(&key)).To(BeFalse())
+			Expect(t.client.hasPeer(&nextKey)).To(BeFalse())
+			Expect(t.driver.pendingRemovals).To(BeEmpty())
+		})
+	})
+}
+
+func testPerClusterPSKs() {
+	t := newTestDriver()
+
+	var (
+		pskDir  string
+		natInfo *natdiscovery.NATEndpointInfo
+		key     wgtypes.Key
+	)
+
+	BeforeEach(func() {
+		pskDir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(pskDir, "east"), []byte("east-psk"), 0o600)).To(Succeed())
+
+		t.driver.pskSource = psk.NewSource(pskDir)
+
+		key = newPublicKey()
+
+		natInfo = &natdiscovery.NATEndpointInfo{
+			UseIP: "192.68.2.1",
+			Endpoint: v1.Endpoint{
+				Spec: v1.EndpointSpec{
+					ClusterID:     "east",
+					CableName:     "submariner-cable-east-192-68-2-1",
+					PrivateIPs:    []string{"192.68.2.1"},
+					Subnets:       []string{remoteSubnet},
+					BackendConfig: map[string]string{PublicKey: key.String()},
+				},
+			},
+		}
+	})
+
+	expectedPSK := func(clusterPSK string) wgtypes.Key {
+		expected, err := genPsk(clusterPSK)
+		Expect(err).To(Succeed())
+
+		return expected
+	}
+
+	It("should configure the peer with the remote cluster's own key", func() {
+		t.connect(natInfo)
+		Expect(t.client.presharedKeyOf(&key)).To(Equal(expectedPSK("east-psk")))
+	})
+
+	When("the remote cluster's key can't be read", func() {
+		BeforeEach(func() {
+			Expect(os.Remove(filepath.Join(pskDir, "east"))).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(pskDir, "east"), 0o700)).To(Succeed())
+		})
+
+		It("should fail the connection rather than use the default key", func() {
+			_, err := t.driver.ConnectToEndpoint(natInfo)
+			Expect(err).To(HaveOccurred())
+			Expect(t.client.hasPeer(&key)).To(BeFalse())
+			Expect(t.driver.connections).To(BeEmpty())
+		})
+	})
+
+	When("the remote cluster's key changes", func() {
+		It("should update the peer's key in place", func() {
+			t.connect(natInfo)
+
+			Expect(os.WriteFile(filepath.Join(pskDir, "east"), []byte("new-east-psk"), 0o600)).To(Succeed())
+			t.driver.updatePSK("east")
+
+			Expect(t.client.presharedKeyOf(&key)).To(Equal(expectedPSK("new-east-psk")))
+			Expect(t.driver.pskSource.Changed()).To(BeEmpty())
+		})
+	})
+}
+
+type testDriver struct {
+	driver *wireguard
+	client *fakeClient
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{}
+
+	BeforeEach(func() {
+		privateKey, err := wgtypes.GeneratePrivateKey()
+		Expect(err).To(Succeed())
+
+		t.client = &fakeClient{}
+		Expect(t.client.ConfigureDevice(DefaultDeviceName, wgtypes.Config{PrivateKey: &privateKey})).To(Succeed())
+
+		psk, err := genPsk("test psk")
+		Expect(err).To(Succeed())
+
+		t.driver = &wireguard{
+			local: endpoint.NewLocal(&v1.EndpointSpec{
+				ClusterID:     "local",
+				CableName:     "submariner-cable-local-192-68-1-1",
+				PrivateIPs:    []string{"192.68.1.1"},
+				Subnets:       []string{"10.0.0.0/16"},
+				BackendConfig: map[string]string{PublicKey: privateKey.PublicKey().String()},
+			}, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""),
+			connections:      make(map[string]*v1.Connection),
+			nextPeers:        make(map[string]string),
+			pendingRemovals:  make(map[string]*time.Timer),
+			allowedIPsOwners: make(map[string]string),
+			stop:             make(chan struct{}),
+			client:           t.client,
+			psk:              &psk,
+			spec: &specification{
+				NATTPort:         4500,
+				KeyRotationGrace: 100 * time.Millisecond,
+			},
+		}
+
+		t.driver.localEndpoint = *t.driver.local.Spec()
+	})
+
+	return t
+}
+
+func (t *testDriver) connect(natInfo *natdiscovery.NATEndpointInfo) {
+	_, err := t.driver.ConnectToEndpoint(&natdiscovery.NATEndpointInfo{
+		UseIP:    natInfo.UseIP,
+		Endpoint: *natInfo.Endpoint.DeepCopy(),
+	})
+	Expect(err).To(Succeed())
+}
+
+func newPublicKey() wgtypes.Key {
+	key, err := wgtypes.GeneratePrivateKey()
+	Expect(err).To(Succeed())
+
+	return key.PublicKey()
+}
+
+// fakeClient emulates a WireGuard device, including that an allowed IP only belongs to a single peer.
+type fakeClient struct {
+	mutex  sync.Mutex
+	device wgtypes.Device
+}
+
+func (c *fakeClient) Device(name string) (*wgtypes.Device, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	d := c.device
+	d.Name = name
+	d.Peers = make([]wgtypes.Peer, len(c.device.Peers))
+
+	for i := range c.device.Peers {
+		d.Peers[i] = c.device.Peers[i]
+		d.Peers[i].AllowedIPs = slices.Clone(c.device.Peers[i].AllowedIPs)
+	}
+
+	return &d, nil
+}
+
+func (c *fakeClient) ConfigureDevice(_ string, cfg wgtypes.Config) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cfg.PrivateKey != nil {
+		c.device.PrivateKey = *cfg.PrivateKey
+		c.device.PublicKey = cfg.PrivateKey.PublicKey()
+	}
+
+	if cfg.ListenPort != nil {
+		c.device.ListenPort = *cfg.ListenPort
+	}
+
+	if cfg.ReplacePeers {
+		c.device.Peers = nil
+	}
+
+	for i := range cfg.Peers {
+		c.configurePeer(&cfg.Peers[i])
+	}
+
+	return nil
+}
+
+func (c *fakeClient) configurePeer(cfg *wgtypes.PeerConfig) {
+	i := c.indexOf(&cfg.PublicKey)
+
+	if cfg.Remove {
+		if i >= 0 {
+			c.device.Peers = slices.Delete(c.device.Peers, i, i+1)
+		}
+
+		return
+	}
+
+	if i < 0 {
+		if cfg.UpdateOnly {
+			return
+		}
+
+		c.device.Peers = append(c.device.Peers, wgtypes.Peer{PublicKey: cfg.PublicKey})
+		i = len(c.device.Peers) - 1
+	}
+
+	peer := &c.device.Peers[i]
+
+	if cfg.PresharedKey != nil {
+		peer.PresharedKey = *cfg.PresharedKey
+	}
+
+	if cfg.Endpoint != nil {
+		peer.Endpoint = cfg.Endpoint
+	}
+
+	if cfg.ReplaceAllowedIPs {
+		peer.AllowedIPs = nil
+	}
+
+	for _, allowedIP := range cfg.AllowedIPs {
+		for j := range c.device.Peers {
+			c.device.Peers[j].AllowedIPs = slices.DeleteFunc(c.device.Peers[j].AllowedIPs, func(ipNet net.IPNet) bool {
+				return ipNet.String() == allowedIP.String()
+			})
+		}
+
+		peer.AllowedIPs = append(peer.AllowedIPs, allowedIP)
+	}
+}
+
+func (c *fakeClient) Close() error {
+	return nil
+}
+
+func (c *fakeClient) indexOf(key *wgtypes.Key) int {
+	return slices.IndexFunc(c.device.Peers, func(p wgtypes.Peer) bool {
+		return p.PublicKey == *key
+	})
+}
+
+func (c *fakeClient) privateKey() wgtypes.Key {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.device.PrivateKey
+}
+
+func (c *fakeClient) hasPeer(key *wgtypes.Key) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.indexOf(key) >= 0
+}
+
+func (c *fakeClient) allowedIPsOf(key *wgtypes.Key) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.indexOf(key)
+	Expect(i).To(BeNumerically(">=", 0), "Peer %s not found", key)
+
+	allowedIPs := []string{}
+	for _, ipNet := range c.device.Peers[i].AllowedIPs {
+		allowedIPs = append(allowedIPs, ipNet.String())
+	}
+
+	return allowedIPs
+}
+
+func (c *fakeClient) presharedKeyOf(key *wgtypes.Key) wgtypes.Key {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	i := c.indexOf(key)
+	Expect(i).To(BeNumerically(">=", 0), "Peer %s not found", key)
+
+	return c.device.Peers[i].PresharedKey
+}
+
+func (c *fakeClient) setLastHandshake(key *wgtypes.Key, t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.device.Peers[c.indexOf(key)].LastHandshakeTime = t
+}
@@ -20,7 +20,9 @@ package cableengine
 
 //nolint:gci // The supported driver imports are kept separate.
 import (
+	"maps"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -28,13 +30,18 @@ import (
 	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cable"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	// Add supported drivers.
+	_ "github.com/submariner-io/submariner/pkg/cable/geneve"
 	_ "github.com/submariner-io/submariner/pkg/cable/libreswan"
+	_ "github.com/submariner-io/submariner/pkg/cable/plugin"
 	_ "github.com/submariner-io/submariner/pkg/cable/vxlan"
 	_ "github.com/submariner-io/submariner/pkg/cable/wireguard"
 )
@@ -62,6 +69,17 @@ type Engine interface {
 	GetHAStatus() v1.HAStatus
 	// SetupNATDiscovery configures the handler for nat discovery of the endpoints.
 	SetupNATDiscovery(natDiscovery natdiscovery.Interface)
+	// SetEventRecorder configures the recorder of the Events for the cable lifecycle transitions.
+	SetEventRecorder(recorder *recorder.Recorder)
+	// SetRemoteCluster records the remote cluster's topology policy and labels, installs its cables deferred until it was
+	// known, and re-installs those the topology policies now allow or disallow.
+	SetRemoteCluster(remote *v1.Cluster) error
+	// RemoveRemoteCluster forgets the remote cluster's topology policy and labels. Its installed cables are kept as is,
+	// while the new ones are deferred until it's known again if the local topology policy selects clusters by their labels.
+	RemoveRemoteCluster(remote *v1.Cluster) error
+	// GetDebugState returns a snapshot of the engine's internal state, for troubleshooting. It doesn't poll the driver, whose
+	// connections are reported as of the last ListCableConnections call.
+	GetDebugState() *DebugState
 
 	// Cleanup performs the necessary steps to uninstall the cable driver.
 	Cleanup() error
@@ -77,18 +95,40 @@ type engine struct {
 	natEndpointInfoCh   chan *natdiscovery.NATEndpointInfo
 	natDiscoveryPending map[string]int
 	installedCables     map[string]metav1.Time
+	cables              map[string]*cableState
+	remoteClusters      map[string]*v1.ClusterSpec
+	clusterPending      map[string]*v1.Endpoint
+	retrySpec           retrySpec
+	driverConfig        cable.DriverConfig
+	recorder            *recorder.Recorder
+	// The result of the last driver GetConnections call, served in the debug state so it doesn't poll the driver, which
+	// updates the connections' status and metrics.
+	driverConnections       []v1.Connection
+	driverConnectionsErr    error
+	driverConnectionsPolled metav1.Time
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("CableEngine")}
 
-// NewEngine creates a new Engine for the local cluster.
-func NewEngine(localCluster *types.SubmarinerCluster, localEndpoint *submendpoint.Local) Engine {
-	// We'll panic if localCluster or localEndpoint are nil, this is intentional
+// NewEngine creates a new Engine for the local cluster, configured by the given Submariner specification. The Kubernetes
+// client, which may be nil, is passed on to the cable driver.
+func NewEngine(localCluster *types.SubmarinerCluster, localEndpoint *submendpoint.Local, spec *types.SubmarinerSpecification,
+	kubeClient kubernetes.Interface,
+) Engine {
+	// We'll panic if localCluster, localEndpoint or spec are nil, this is intentional
 	return &engine{
 		localCluster:        *localCluster,
 		localEndpoint:       localEndpoint,
 		natDiscoveryPending: map[string]int{},
 		installedCables:     map[string]metav1.Time{},
+		cables:              map[string]*cableState{},
+		remoteClusters:      map[string]*v1.ClusterSpec{},
+		clusterPending:      map[string]*v1.Endpoint{},
+		retrySpec:           newRetrySpec(spec),
+		driverConfig: cable.DriverConfig{
+			KubeClient:      kubeClient,
+			HaltOnCertError: spec.HaltOnCertError,
+		},
 	}
 }
 
@@ -129,11 +169,21 @@ func (i *engine) startDriver() error {
 
 	var err error
 
-	if i.driver, err = cable.NewDriver(i.localEndpoint, &i.localCluster); err != nil {
+	if i.driver, err = cable.NewDriver(i.localEndpoint, &i.localCluster, &i.driverConfig); err != nil {
 		return errors.Wrap(err, "error creating the cable driver")
 	}
 
-	return errors.Wrap(i.driver.Init(), "error initializing the cable driver")
+	if err = i.driver.Init(); err != nil {
+		// A driver may release its resources when Init fails, eg a plugin's connection, so it's re-created on the next start.
+		i.driver = nil
+		return errors.Wrap(err, "error initializing the cable driver")
+	}
+
+	if reconnecting, ok := i.driver.(cable.ReconnectingDriver); ok {
+		reconnecting.SetReconnectHandler(i.reconnectCluster)
+	}
+
+	return i.disableRelayIfUnsupported()
 }
 
 func (i *engine) SetupNATDiscovery(natDiscovery natdiscovery.Interface) {
@@ -150,25 +200,69 @@ func (i *engine) SetupNATDiscovery(natDiscovery natdiscovery.Interface) {
 	}()
 }
 
-func (i *engine) installCableWithNATInfo(rnat *natdiscovery.NATEndpointInfo) error {
-	endpoint := &rnat.Endpoint
+func (i *engine) SetEventRecorder(recorder *recorder.Recorder) {
+	i.recorder = recorder
+}
 
+func (i *engine) installCableWithNATInfo(rnat *natdiscovery.NATEndpointInfo) error {
 	i.Lock()
 	defer i.Unlock()
 
-	if _, ok := i.natDiscoveryPending[rnat.Endpoint.Spec.CableName]; !ok {
+	if _, ok := i.natDiscoveryPending[rnat.Endpoint.Spec.CableName]; ok {
+		i.natDiscoveryPending[rnat.Endpoint.Spec.CableName]--
+		if i.natDiscoveryPending[rnat.Endpoint.Spec.CableName] == 0 {
+			delete(i.natDiscoveryPending, rnat.Endpoint.Spec.CableName)
+		}
+	} else if _, ok := i.cables[rnat.Endpoint.Spec.CableName]; !ok {
+		// NAT discovery keeps re-validating the paths of established cables and reports any change, which is only
+		// relevant while the cable hasn't been removed.
 		return nil
 	}
 
-	i.natDiscoveryPending[rnat.Endpoint.Spec.CableName]--
-	if i.natDiscoveryPending[rnat.Endpoint.Spec.CableName] == 0 {
-		delete(i.natDiscoveryPending, rnat.Endpoint.Spec.CableName)
+	if !i.running {
+		return nil
 	}
 
-	if !i.running {
+	defer i.refreshRelays()
+
+	return i.installCable(rnat)
+}
+
+// installCable connects to the remote endpoint, driving its cable state machine. It must be called with the lock held.
+func (i *engine) installCable(rnat *natdiscovery.NATEndpointInfo) error {
+	endpoint := &rnat.Endpoint
+
+	c := i.cableStateFor(&endpoint.Spec)
+	c.natInfo = rnat
+
+	if c.isDeferred() {
+		logger.V(log.DEBUG).Infof("Cable %q is in state %q until %s - deferring its installation", endpoint.Spec.CableName,
+			c.state, c.retryAt)
+		return nil
+	}
+
+	direct, known := i.directCableAllowed(&endpoint.Spec)
+	if !known {
+		i.deferUntilClusterKnown(endpoint)
 		return nil
 	}
 
+	if rnat.DiscoveryFailed || !direct {
+		if relay := i.relayFor(&endpoint.Spec); relay != nil {
+			return i.relayCable(c, relay)
+		}
+	}
+
+	if !direct {
+		return i.excludeCable(c)
+	}
+
+	if c.relayedVia != "" {
+		i.stopRelaying(c)
+	}
+
+	driverInfo := i.toDriverEndpointInfo(rnat)
+
 	activeConnections, err := i.driver.GetActiveConnections()
 	if err != nil {
 		return errors.Wrap(err, "error getting the active connections")
@@ -182,6 +276,12 @@ func (i *engine) installCableWithNATInfo(rnat *natdiscovery.NATEndpointInfo) err
 			continue
 		}
 
+		// An active/active remote cluster has several gateways with their own Endpoints so the cables to the other
+		// gateways are kept.
+		if endpoint.Spec.IsActiveActive() && active.Endpoint.CableName != endpoint.Spec.CableName {
+			continue
+		}
+
 		prevTimestamp := i.installedCables[active.Endpoint.CableName]
 
 		logger.V(log.TRACE).Infof("Found a pre-existing cable %q with timestamp %q that belongs to this cluster %s",
@@ -190,16 +290,29 @@ func (i *engine) installCableWithNATInfo(rnat *natdiscovery.NATEndpointInfo) err
 		if endpoint.CreationTimestamp.Before(&prevTimestamp) {
 			logger.Warningf("The timestamp (%s) for new cable %q is older than the timestamp (%s) of the pre-existing "+
 				"cable %q - not replacing", endpoint.CreationTimestamp, endpoint.Spec.CableName, prevTimestamp, active.Endpoint.CableName)
+
+			if active.Endpoint.CableName != endpoint.Spec.CableName {
+				i.removeCableState(endpoint.Spec.CableName)
+			}
+
 			return nil
 		}
 
 		if endpoint.CreationTimestamp.Equal(&prevTimestamp) && active.Endpoint.CableName == endpoint.Spec.CableName {
 			// There could be scenarios where the cableName would be the same but the endpoint IP or specific driver
 			// config has changed.
-			if active.UsingIP == rnat.UseIP && active.UsingNAT == rnat.UseNAT &&
-				reflect.DeepEqual(active.Endpoint.BackendConfig, endpoint.Spec.BackendConfig) {
+			connectionChanged := active.UsingIP != rnat.UseIP || active.UsingNAT != rnat.UseNAT ||
+				driverConfigChanged(active.Endpoint.BackendConfig, endpoint.Spec.BackendConfig)
+
+			if !connectionChanged && slices.Equal(active.Endpoint.Subnets, driverInfo.Endpoint.Spec.Subnets) &&
+				slices.Equal(c.relayedSubnets, driverInfo.RelayedSubnets) {
 				logger.V(log.TRACE).Infof("Connection info (IP: %s, NAT: %v, BackendConfig: %v) for cable %q is unchanged"+
 					" - not re-installing", active.UsingIP, active.UsingNAT, active.Endpoint.BackendConfig, active.Endpoint.CableName)
+
+				if c.state != v1.CableStateConnected {
+					i.connected(c)
+				}
+
 				return nil
 			}
 
@@ -214,22 +327,93 @@ func (i *engine) installCableWithNATInfo(rnat *natdiscovery.NATEndpointInfo) err
 		if err != nil {
 			return errors.Wrapf(err, "error disconnecting previous Endpoint cable %#v", active.Endpoint)
 		}
+
+		if active.Endpoint.CableName != endpoint.Spec.CableName {
+			i.removeCableState(active.Endpoint.CableName)
+		}
 	}
 
 	logger.Infof("Installing Endpoint cable %q", endpoint.Spec.CableName)
 
-	remoteEndpointIP, err := i.driver.ConnectToEndpoint(rnat)
+	// Re-installing a connected cable, eg due to a changed IP or NAT path, isn't a flap but failing to do so is.
+	wasConnected := c.state == v1.CableStateConnected
+
+	i.setCableState(c, v1.CableStateConnecting)
+
+	remoteEndpointIP, err := i.driver.ConnectToEndpoint(driverInfo)
 	if err != nil {
+		if wasConnected && i.recordFlap(c) {
+			c.lastError = err
+			delete(i.installedCables, endpoint.Spec.CableName)
+		} else {
+			i.connectFailed(c, err)
+		}
+
 		return errors.Wrapf(err, "error installing Endpoint cable %q", endpoint.Spec.CableName)
 	}
 
 	logger.Infof("Successfully installed Endpoint cable %q with remote IP %s", endpoint.Spec.CableName, remoteEndpointIP)
 
+	i.recorder.OnGateway(endpoint.Spec.CableName, corev1.EventTypeNormal, recorder.CableConnected,
+		"Connected cable %q to cluster %q with remote IP %s", endpoint.Spec.CableName, endpoint.Spec.ClusterID, remoteEndpointIP)
+
+	i.connected(c)
 	i.installedCables[rnat.Endpoint.Spec.CableName] = endpoint.CreationTimestamp
+	c.driverEndpoint = driverInfo.Endpoint.Spec
+	c.relayedSubnets = driverInfo.RelayedSubnets
 
 	return nil
 }
 
+// reconnectCluster re-installs the cables to the given remote cluster at the driver's request, eg because the cluster's
+// pre-shared key changed. The cables go through the state machine as if their connection info had changed.
+func (i *engine) reconnectCluster(clusterID string) {
+	i.Lock()
+	defer i.Unlock()
+
+	if !i.running {
+		return
+	}
+
+	defer i.refreshRelays()
+
+	for _, name := range slices.Sorted(maps.Keys(i.cables)) {
+		c := i.cables[name]
+		if c.endpoint.ClusterID != clusterID || c.natInfo == nil {
+			continue
+		}
+
+		if _, ok := i.installedCables[name]; !ok {
+			continue
+		}
+
+		logger.Infof("The driver requested the cable %q to cluster %q be re-installed", name, clusterID)
+
+		if err := i.disconnectCable(c); err != nil {
+			logger.Errorf(err, "Error disconnecting cable %q", name)
+			continue
+		}
+
+		if err := i.installCable(c.natInfo); err != nil {
+			logger.Errorf(err, "Error re-installing cable %q", name)
+		}
+	}
+}
+
+// driverConfigChanged returns true if the backend configurations differ, ignoring the settings published by the remote
+// gateway about its own connections, which don't require the cable to be re-installed.
+func driverConfigChanged(prev, updated map[string]string) bool {
+	return !maps.Equal(withoutConnectionStateConfig(prev), withoutConnectionStateConfig(updated))
+}
+
+func withoutConnectionStateConfig(config map[string]string) map[string]string {
+	filtered := maps.Clone(config)
+	delete(filtered, v1.RelayedClustersConfig)
+	delete(filtered, v1.ConnectedClustersConfig)
+
+	return filtered
+}
+
 func (i *engine) InstallCable(endpoint *v1.Endpoint) error {
 	if endpoint.Spec.ClusterID == i.localCluster.ID {
 		logger.V(log.TRACE).Infof("Not installing cable for local cluster")
@@ -242,7 +426,31 @@ func (i *engine) InstallCable(endpoint *v1.Endpoint) error {
 	}
 
 	i.Lock()
+
+	direct, known := i.directCableAllowed(&endpoint.Spec)
+	if !known {
+		i.deferUntilClusterKnown(endpoint)
+		i.Unlock()
+
+		return nil
+	}
+
+	delete(i.clusterPending, endpoint.Spec.CableName)
+
+	if !direct {
+		i.Unlock()
+		return i.installExcludedCable(endpoint)
+	}
+
 	i.natDiscoveryPending[endpoint.Spec.CableName]++
+
+	if c := i.cableStateFor(&endpoint.Spec); c.state == v1.CableStateExcluded {
+		i.setCableState(c, v1.CableStatePendingNATDiscovery)
+	}
+
+	// The clusters a relay is connected to may have changed.
+	i.refreshRelays()
+
 	i.Unlock()
 
 	i.natDiscovery.AddEndpoint(endpoint)
@@ -263,13 +471,24 @@ func (i *engine) RemoveCable(endpoint *v1.Endpoint) error {
 	i.Lock()
 	defer i.Unlock()
 
+	defer i.refreshRelays()
+
 	delete(i.natDiscoveryPending, endpoint.Spec.CableName)
+	delete(i.clusterPending, endpoint.Spec.CableName)
+
+	// The driver was connected with the endpoint's subnets and those relayed through it.
+	driverEndpoint := endpoint.Spec
+	if c, ok := i.cables[endpoint.Spec.CableName]; ok && c.driverEndpoint.CableName != "" {
+		driverEndpoint = c.driverEndpoint
+	}
+
+	i.removeCableState(endpoint.Spec.CableName)
 
 	if _, ok := i.installedCables[endpoint.Spec.CableName]; !ok {
 		return nil
 	}
 
-	err := i.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: endpoint.Spec})
+	err := i.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: driverEndpoint})
 	if err != nil {
 		return errors.Wrapf(err, "error disconnecting Endpoint cable %q", endpoint.Spec.CableName)
 	}
@@ -278,6 +497,9 @@ func (i *engine) RemoveCable(endpoint *v1.Endpoint) error {
 
 	logger.Infof("Successfully removed Endpoint cable %q", endpoint.Spec.CableName)
 
+	i.recorder.OnGateway(endpoint.Spec.CableName, corev1.EventTypeNormal, recorder.CableRemoved,
+		"Removed cable %q to cluster %q", endpoint.Spec.CableName, endpoint.Spec.ClusterID)
+
 	return nil
 }
 
@@ -289,9 +511,7 @@ func (i *engine) GetHAStatus() v1.HAStatus {
 		return v1.HAStatusPassive
 	}
 
-	// we may want to add a call to the driver in the future, for situations where
-	// the driver is running from the start, but could be in passive status, or
-	// in active/active.
+	// In active/active mode every running engine holds cables so it's reported as active as well.
 	return v1.HAStatusActive
 }
 
@@ -299,12 +519,57 @@ func (i *engine) ListCableConnections() ([]v1.Connection, error) {
 	i.Lock()
 	defer i.Unlock()
 
-	if i.running {
-		return i.driver.GetConnections() //nolint:wrapcheck  // Let the caller wrap it
+	if !i.running {
+		// if not running, we can safely report that no connections exist.
+		return []v1.Connection{}, nil
+	}
+
+	driverConnections, err := i.driver.GetConnections()
+
+	i.driverConnections = make([]v1.Connection, len(driverConnections))
+	for j := range driverConnections {
+		driverConnections[j].DeepCopyInto(&i.driverConnections[j])
+	}
+	i.driverConnectionsErr = err
+	i.driverConnectionsPolled = metav1.Now()
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck  // Let the caller wrap it
+	}
+
+	connections := make([]v1.Connection, 0, len(driverConnections))
+	listed := map[string]bool{}
+
+	for j := range driverConnections {
+		conn := driverConnections[j]
+
+		if c, ok := i.cables[conn.Endpoint.CableName]; ok {
+			i.observeDriverStatus(c, conn.Status)
+
+			if c.state == v1.CableStateSuppressed {
+				continue
+			}
+
+			conn.State = c.state
+		}
+
+		listed[conn.Endpoint.CableName] = true
+		connections = append(connections, conn)
+	}
+
+	i.recordAvailability()
+	i.publishConnectedClusters()
+
+	// Also report the cables the driver isn't connected to because they're backing off, suppressed, relayed or excluded.
+	for _, name := range i.sortedCableNames() {
+		c := i.cables[name]
+		if !listed[name] && (c.state == v1.CableStateBackoff || c.state == v1.CableStateSuppressed ||
+			c.state == v1.CableStateRelayed || c.state == v1.CableStateExcluded) {
+			connections = append(connections, c.toConnection())
+		}
 	}
 
-	// if not running, we can safely report that no connections exist.
-	return []v1.Connection{}, nil
+	return connections, nil
 }
 
 func (i *engine) Cleanup() error {
@@ -19,6 +19,7 @@ limitations under the License.
 package cableengine_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -26,6 +27,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 	. "github.com/submariner-io/admiral/pkg/gomega"
 	"github.com/submariner-io/admiral/pkg/log/kzerolog"
 	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
@@ -33,11 +35,13 @@ import (
 	"github.com/submariner-io/submariner/pkg/cable/fake"
 	"github.com/submariner-io/submariner/pkg/cableengine"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	k8snet "k8s.io/utils/net"
 )
 
@@ -49,7 +53,8 @@ var fakeDriver *fake.Driver
 
 var _ = BeforeSuite(func() {
 	kzerolog.InitK8sLogging()
-	cable.AddDriver(fake.DriverName, func(_ *submendpoint.Local, _ *types.SubmarinerCluster) (cable.Driver, error) {
+
+	cable.AddDriver(fake.DriverName, func(_ *submendpoint.Local, _ *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
 		return fakeDriver, nil
 	})
 })
@@ -62,12 +67,16 @@ var _ = Describe("Cable Engine", func() {
 		engine         cableengine.Engine
 		natDiscovery   *fakeNATDiscovery
 		localEndpoint  *subv1.Endpoint
+		local          *submendpoint.Local
 		remoteEndpoint *subv1.Endpoint
+		localTopology  *subv1.TopologyPolicy
 		skipStart      bool
+		eventRecorder  *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		skipStart = false
+		localTopology = &subv1.TopologyPolicy{}
 
 		localEndpoint = &subv1.Endpoint{
 			ObjectMeta: metav1.ObjectMeta{
@@ -96,15 +105,30 @@ var _ = Describe("Cable Engine", func() {
 		}
 
 		fakeDriver = fake.New()
+		local = submendpoint.NewLocal(&localEndpoint.Spec, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), "")
 		engine = cableengine.NewEngine(&types.SubmarinerCluster{
 			ID: localClusterID,
 			Spec: subv1.ClusterSpec{
 				ClusterID: localClusterID,
+				Topology:  localTopology,
 			},
-		}, submendpoint.NewLocal(&localEndpoint.Spec, dynamicfake.NewSimpleDynamicClient(scheme.Scheme), ""))
+		}, local, &types.SubmarinerSpecification{
+			CableRetryInitialBackoff: 200 * time.Millisecond,
+			CableRetryMaxBackoff:     5 * time.Minute,
+			CableFlapThreshold:       2,
+			CableFlapWindow:          5 * time.Minute,
+			CableSuppressDuration:    500 * time.Millisecond,
+		}, nil)
 
 		natDiscovery = &fakeNATDiscovery{removeEndpoint: make(chan string, 20), readyChannel: make(chan *natdiscovery.NATEndpointInfo, 100)}
 		engine.SetupNATDiscovery(natDiscovery)
+
+		eventRecorder = record.NewFakeRecorder(20)
+		engine.SetEventRecorder(recorder.New(eventRecorder, "", "gateway", "endpoint"))
+
+		for _, clusterID := range []string{remoteClusterID, "other", "relay", "hub", "availability"} {
+			Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{ClusterID: clusterID}})).To(Succeed())
+		}
 	})
 
 	JustBeforeEach(func() {
@@ -130,6 +154,7 @@ var _ = Describe("Cable Engine", func() {
 			It("should connect to the endpoint", func() {
 				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
 				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+				Eventually(eventRecorder.Events).Should(Receive(HavePrefix("Normal " + recorder.CableConnected)))
 			})
 		})
 
@@ -185,6 +210,18 @@ var _ = Describe("Cable Engine", func() {
 				testTimestamps()
 			})
 
+			Context("with a different cable name from an active/active gateway", func() {
+				BeforeEach(func() {
+					newEndpoint.Spec.CableName = "new cable"
+					newEndpoint.Spec.BackendConfig = map[string]string{subv1.ActiveActive: "true"}
+				})
+
+				It("should connect to the new endpoint and not disconnect from the previous one", func() {
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(newEndpoint))
+					fakeDriver.AwaitNoDisconnectFromEndpoint()
+				})
+			})
+
 			Context("with the same cable name", func() {
 				testTimestamps()
 
@@ -235,6 +272,417 @@ var _ = Describe("Cable Engine", func() {
 			})
 		})
 
+		Context("and the driver requests the cluster's cables be re-installed", func() {
+			It("should disconnect and reconnect only that cluster's cables", func() {
+				otherEndpoint := subv1.Endpoint{Spec: subv1.EndpointSpec{
+					ClusterID: "other",
+					CableName: "submariner-cable-other-1.1.1.1",
+				}}
+
+				Expect(engine.InstallCable(&otherEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(&otherEndpoint))
+
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				fakeDriver.Reconnect(remoteClusterID)
+
+				fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+				fakeDriver.AwaitNoDisconnectFromEndpoint()
+			})
+		})
+
+		Context("and the driver fails to connect", func() {
+			BeforeEach(func() {
+				fakeDriver.ErrOnConnectToEndpoint = errors.New("fake connect error")
+			})
+
+			It("should back off and retry", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+
+				Eventually(engine.ListCableConnections).Should(HaveExactElements(And(
+					HaveField("Endpoint", remoteEndpoint.Spec),
+					HaveField("Status", subv1.ConnectionError),
+					HaveField("State", subv1.CableStateBackoff))))
+				Eventually(eventRecorder.Events).Should(Receive(HavePrefix("Warning " + recorder.CableFailed)))
+
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				fakeDriver.Connections = []subv1.Connection{{Endpoint: remoteEndpoint.Spec, Status: subv1.Connected}}
+				Expect(engine.ListCableConnections()).To(HaveExactElements(HaveField("State", subv1.CableStateConnected)))
+			})
+		})
+
+		Context("and the connection flaps", func() {
+			It("should suppress the cable and reconnect after the suppression expires", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				for _, status := range []subv1.ConnectionStatus{subv1.ConnectionError, subv1.Connected} {
+					fakeDriver.Connections = []subv1.Connection{{Endpoint: remoteEndpoint.Spec, Status: status}}
+					Expect(engine.ListCableConnections()).To(HaveExactElements(HaveField("State", subv1.CableStateConnected)))
+				}
+
+				fakeDriver.Connections = []subv1.Connection{{Endpoint: remoteEndpoint.Spec, Status: subv1.ConnectionError}}
+				Expect(engine.ListCableConnections()).To(HaveExactElements(And(
+					HaveField("Endpoint", remoteEndpoint.Spec),
+					HaveField("Status", subv1.ConnectionError),
+					HaveField("State", subv1.CableStateSuppressed))))
+				fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+			})
+		})
+
+		Context("and the connection goes down", func() {
+			BeforeEach(func() {
+				// The metrics are global so use a distinct remote cluster to not account for the other tests' cables.
+				remoteEndpoint.Spec.ClusterID = "availability"
+			})
+
+			It("should record the availability of the cable", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				for _, status := range []subv1.ConnectionStatus{
+					subv1.Connected, subv1.Connected, subv1.Connected, subv1.ConnectionError,
+				} {
+					fakeDriver.Connections = []subv1.Connection{{Endpoint: remoteEndpoint.Spec, Status: status}}
+					_, err := engine.ListCableConnections()
+					Expect(err).To(Succeed())
+					time.Sleep(50 * time.Millisecond)
+				}
+
+				_, err := engine.ListCableConnections()
+				Expect(err).To(Succeed())
+
+				clusterID := remoteEndpoint.Spec.ClusterID
+				Expect(connectionMetricValue("submariner_connection_uptime_seconds_total", clusterID)).To(
+					BeNumerically("~", 0.1, 0.05))
+				Expect(connectionMetricValue("submariner_connection_monitored_seconds_total", clusterID)).To(
+					BeNumerically("~", 0.2, 0.05))
+				Expect(connectionMetricValue("submariner_connection_availability_ratio", clusterID)).To(
+					BeNumerically("~", 0.5, 0.15))
+			})
+		})
+
+		Context("and NAT discovery later reports a different path", func() {
+			It("should re-install the cable with the new path", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				newPath := &natdiscovery.NATEndpointInfo{
+					UseIP:    remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4),
+					UseNAT:   false,
+					Endpoint: *remoteEndpoint,
+				}
+
+				natDiscovery.readyChannel <- newPath
+
+				fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+				fakeDriver.AwaitConnectToEndpoint(newPath)
+			})
+		})
+
+		Context("and NAT discovery repeatedly reports a different path", func() {
+			It("should re-install the cable without suppressing it", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				for _, useNAT := range []bool{false, true, false} {
+					newPath := natEndpointInfoFor(remoteEndpoint)
+					newPath.UseNAT = useNAT
+
+					if !useNAT {
+						newPath.UseIP = remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4)
+					}
+
+					natDiscovery.readyChannel <- newPath
+
+					fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+					fakeDriver.AwaitConnectToEndpoint(newPath)
+				}
+
+				Expect(engine.GetDebugState().Cables).To(HaveExactElements(And(
+					HaveField("State", subv1.CableStateConnected),
+					HaveField("RecentFlaps", 0))))
+			})
+		})
+
+		Context("and NAT discovery fails while a relay is connected", func() {
+			var relayEndpoint *subv1.Endpoint
+
+			BeforeEach(func() {
+				remoteEndpoint.Spec.Subnets = []string{"10.2.0.0/16"}
+
+				relayEndpoint = &subv1.Endpoint{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Now(),
+					},
+					Spec: subv1.EndpointSpec{
+						ClusterID:  "relay",
+						CableName:  "submariner-cable-relay-3.3.3.3",
+						PrivateIPs: []string{"3.3.3.3"},
+						PublicIPs:  []string{"4.4.4.4"},
+						Subnets:    []string{"10.3.0.0/16"},
+						BackendConfig: map[string]string{
+							subv1.RelayConfig:             "true",
+							subv1.ConnectedClustersConfig: "other," + remoteClusterID,
+						},
+					},
+				}
+			})
+
+			relayInfoWith := func(subnets ...string) *natdiscovery.NATEndpointInfo {
+				info := natEndpointInfoFor(relayEndpoint)
+				info.Endpoint = *relayEndpoint.DeepCopy()
+				info.Endpoint.Spec.Subnets = append(info.Endpoint.Spec.Subnets, subnets...)
+
+				return info
+			}
+
+			JustBeforeEach(func() {
+				Expect(engine.InstallCable(relayEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(relayEndpoint))
+
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				failed := natEndpointInfoFor(remoteEndpoint)
+				failed.DiscoveryFailed = true
+				natDiscovery.readyChannel <- failed
+
+				fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+				fakeDriver.AwaitDisconnectFromEndpoint(&relayEndpoint.Spec)
+				fakeDriver.AwaitConnectToEndpoint(relayInfoWith(remoteEndpoint.Spec.Subnets...))
+			})
+
+			It("should relay the traffic through the relay's cable and report the cable as relayed", func() {
+				fakeDriver.Connections = []subv1.Connection{{Endpoint: relayInfoWith("10.2.0.0/16").Endpoint.Spec, Status: subv1.Connected}}
+
+				Expect(engine.ListCableConnections()).To(HaveExactElements(
+					HaveField("State", subv1.CableStateConnected),
+					And(
+						HaveField("Endpoint", remoteEndpoint.Spec),
+						HaveField("Status", subv1.Connected),
+						HaveField("State", subv1.CableStateRelayed),
+						HaveField("RelayedVia", relayEndpoint.Spec.CableName))))
+
+				Expect(engine.GetLocalEndpoint().Spec.BackendConfig).To(HaveKeyWithValue(subv1.RelayedClustersConfig,
+					remoteClusterID))
+			})
+
+			Context("and NAT discovery later succeeds", func() {
+				It("should connect to the endpoint directly and stop relaying its traffic", func() {
+					natDiscovery.notifyReady(remoteEndpoint)
+
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+					fakeDriver.AwaitDisconnectFromEndpoint(&relayInfoWith(remoteEndpoint.Spec.Subnets...).Endpoint.Spec)
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(relayEndpoint))
+
+					Eventually(func() map[string]string {
+						return engine.GetLocalEndpoint().Spec.BackendConfig
+					}).ShouldNot(HaveKey(subv1.RelayedClustersConfig))
+				})
+			})
+		})
+
+		Context("and the topology policies don't allow a direct cable", func() {
+			var relayEndpoint *subv1.Endpoint
+
+			BeforeEach(func() {
+				remoteEndpoint.Spec.Subnets = []string{"10.2.0.0/16"}
+
+				relayEndpoint = &subv1.Endpoint{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: metav1.Now(),
+					},
+					Spec: subv1.EndpointSpec{
+						ClusterID:  "hub",
+						CableName:  "submariner-cable-hub-3.3.3.3",
+						PrivateIPs: []string{"3.3.3.3"},
+						PublicIPs:  []string{"4.4.4.4"},
+						Subnets:    []string{"10.3.0.0/16"},
+						BackendConfig: map[string]string{
+							subv1.RelayConfig:             "true",
+							subv1.ConnectedClustersConfig: "other," + remoteClusterID,
+						},
+					},
+				}
+			})
+
+			hubInfoWithRemoteSubnets := func() *natdiscovery.NATEndpointInfo {
+				info := natEndpointInfoFor(relayEndpoint)
+				info.Endpoint = *relayEndpoint.DeepCopy()
+				info.Endpoint.Spec.Subnets = append(info.Endpoint.Spec.Subnets, remoteEndpoint.Spec.Subnets...)
+
+				return info
+			}
+
+			JustBeforeEach(func() {
+				Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{
+					ClusterID: remoteClusterID,
+					Topology: &subv1.TopologyPolicy{
+						Mode: subv1.TopologyHubSpoke,
+						Hubs: []subv1.ClusterSelector{{ClusterIDs: []string{"hub"}}},
+					},
+				}})).To(Succeed())
+
+				Expect(engine.InstallCable(relayEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(relayEndpoint))
+
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+			})
+
+			It("should relay the traffic through the hub's cable", func() {
+				fakeDriver.AwaitDisconnectFromEndpoint(&relayEndpoint.Spec)
+				fakeDriver.AwaitConnectToEndpoint(hubInfoWithRemoteSubnets())
+				fakeDriver.AwaitNoConnectToEndpoint()
+
+				Expect(engine.GetLocalEndpoint().Spec.BackendConfig).To(HaveKeyWithValue(subv1.RelayedClustersConfig,
+					remoteClusterID))
+			})
+
+			Context("and the hub's cable is removed", func() {
+				It("should report the cable as excluded", func() {
+					fakeDriver.AwaitConnectToEndpoint(hubInfoWithRemoteSubnets())
+
+					Expect(engine.RemoveCable(relayEndpoint)).To(Succeed())
+					fakeDriver.AwaitDisconnectFromEndpoint(&hubInfoWithRemoteSubnets().Endpoint.Spec)
+					fakeDriver.AwaitNoConnectToEndpoint()
+
+					fakeDriver.Connections = []subv1.Connection{}
+
+					Expect(engine.ListCableConnections()).To(HaveExactElements(And(
+						HaveField("Endpoint", remoteEndpoint.Spec),
+						HaveField("Status", subv1.ConnectionNone),
+						HaveField("State", subv1.CableStateExcluded))))
+				})
+			})
+
+			Context("and the remote cluster's policy later allows it", func() {
+				It("should connect to the endpoint directly", func() {
+					Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{ClusterID: remoteClusterID}})).To(Succeed())
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+				})
+			})
+
+			Context("and the hub has no connected cable to the remote cluster", func() {
+				BeforeEach(func() {
+					relayEndpoint.Spec.BackendConfig[subv1.ConnectedClustersConfig] = "other"
+				})
+
+				It("should only relay the traffic through the hub's cable once it's connected", func() {
+					fakeDriver.AwaitNoConnectToEndpoint()
+					Expect(engine.GetLocalEndpoint().Spec.BackendConfig).ToNot(HaveKey(subv1.RelayedClustersConfig))
+
+					relayEndpoint.Spec.BackendConfig[subv1.ConnectedClustersConfig] = remoteClusterID
+					Expect(engine.InstallCable(relayEndpoint)).To(Succeed())
+					fakeDriver.AwaitConnectToEndpoint(hubInfoWithRemoteSubnets())
+				})
+			})
+
+			Context("and the cable driver doesn't support relaying", func() {
+				BeforeEach(func() {
+					fakeDriver.RelayingUnsupported = true
+				})
+
+				It("should not relay the traffic", func() {
+					fakeDriver.AwaitNoConnectToEndpoint()
+					Expect(engine.GetLocalEndpoint().Spec.BackendConfig).ToNot(HaveKey(subv1.RelayedClustersConfig))
+				})
+			})
+		})
+
+		Context("and the remote cluster isn't known yet", func() {
+			JustBeforeEach(func() {
+				Expect(engine.RemoveRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{ClusterID: remoteClusterID}})).To(Succeed())
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+			})
+
+			It("should install the cable assuming the remote cluster has no topology policy", func() {
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+				Expect(engine.GetDebugState().ClusterPending).To(BeEmpty())
+			})
+
+			Context("and its topology policy doesn't allow a direct cable", func() {
+				It("should exclude the cable once the remote cluster is known", func() {
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+					Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{
+						ClusterID: remoteClusterID,
+						Topology:  &subv1.TopologyPolicy{Mode: subv1.TopologyPartialMesh},
+					}})).To(Succeed())
+					fakeDriver.AwaitDisconnectFromEndpoint(&remoteEndpoint.Spec)
+
+					fakeDriver.Connections = []subv1.Connection{}
+
+					Expect(engine.ListCableConnections()).To(HaveExactElements(And(
+						HaveField("Endpoint", remoteEndpoint.Spec),
+						HaveField("State", subv1.CableStateExcluded))))
+				})
+			})
+
+			Context("and the local topology policy selects clusters by their labels", func() {
+				BeforeEach(func() {
+					*localTopology = subv1.TopologyPolicy{
+						Mode:  subv1.TopologyPartialMesh,
+						Allow: []subv1.ClusterSelector{{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}}},
+					}
+				})
+
+				It("should defer the cable until the remote cluster is known", func() {
+					fakeDriver.AwaitNoConnectToEndpoint()
+					Expect(engine.GetDebugState().ClusterPending).To(HaveExactElements(remoteEndpoint.Spec.CableName))
+
+					Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{
+						ClusterID: remoteClusterID,
+						Labels:    map[string]string{"region": "eu"},
+					}})).To(Succeed())
+					fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+					Expect(engine.GetDebugState().ClusterPending).To(BeEmpty())
+				})
+
+				Context("and the remote cluster isn't selected", func() {
+					It("should exclude the cable once the remote cluster is known", func() {
+						fakeDriver.AwaitNoConnectToEndpoint()
+
+						Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{ClusterID: remoteClusterID}})).To(Succeed())
+						fakeDriver.AwaitNoConnectToEndpoint()
+
+						fakeDriver.Connections = []subv1.Connection{}
+
+						Expect(engine.ListCableConnections()).To(HaveExactElements(And(
+							HaveField("Endpoint", remoteEndpoint.Spec),
+							HaveField("State", subv1.CableStateExcluded))))
+					})
+				})
+
+				Context("and the cable is removed", func() {
+					It("should not install it once the remote cluster is known", func() {
+						Expect(engine.RemoveCable(remoteEndpoint)).To(Succeed())
+
+						Expect(engine.SetRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{
+							ClusterID: remoteClusterID,
+							Labels:    map[string]string{"region": "eu"},
+						}})).To(Succeed())
+						fakeDriver.AwaitNoConnectToEndpoint()
+					})
+				})
+			})
+		})
+
+		Context("and the remote cluster is later removed", func() {
+			It("should keep the cable", func() {
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+				Expect(engine.RemoveRemoteCluster(&subv1.Cluster{Spec: subv1.ClusterSpec{ClusterID: remoteClusterID}})).To(Succeed())
+				fakeDriver.AwaitNoDisconnectFromEndpoint()
+			})
+		})
+
 		Context("followed by remove cable before NAT discovery is complete", func() {
 			BeforeEach(func() {
 				natDiscovery.captureAddEndpoint = make(chan *subv1.Endpoint, 10)
@@ -254,6 +702,40 @@ var _ = Describe("Cable Engine", func() {
 		})
 	})
 
+	When("the local gateway is a relay", func() {
+		BeforeEach(func() {
+			Expect(local.Update(context.TODO(), func(existing *subv1.EndpointSpec) {
+				existing.BackendConfig = map[string]string{subv1.RelayConfig: "true"}
+			})).To(Succeed())
+		})
+
+		It("should publish the clusters it has a connected cable to", func() {
+			Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+			fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+
+			Eventually(func() map[string]string {
+				return engine.GetLocalEndpoint().Spec.BackendConfig
+			}).Should(HaveKeyWithValue(subv1.ConnectedClustersConfig, remoteClusterID))
+
+			Expect(engine.RemoveCable(remoteEndpoint)).To(Succeed())
+			Expect(engine.GetLocalEndpoint().Spec.BackendConfig).ToNot(HaveKey(subv1.ConnectedClustersConfig))
+		})
+
+		Context("and the cable driver doesn't support relaying", func() {
+			BeforeEach(func() {
+				fakeDriver.RelayingUnsupported = true
+			})
+
+			It("should no longer advertise the gateway as a relay", func() {
+				Expect(engine.GetLocalEndpoint().Spec.BackendConfig).ToNot(HaveKey(subv1.RelayConfig))
+
+				Expect(engine.InstallCable(remoteEndpoint)).To(Succeed())
+				fakeDriver.AwaitConnectToEndpoint(natEndpointInfoFor(remoteEndpoint))
+				Expect(engine.GetLocalEndpoint().Spec.BackendConfig).ToNot(HaveKey(subv1.ConnectedClustersConfig))
+			})
+		})
+	})
+
 	When("install cable for a local endpoint", func() {
 		It("should not connect to the endpoint", func() {
 			Expect(engine.InstallCable(localEndpoint)).To(Succeed())
@@ -306,6 +788,20 @@ var _ = Describe("Cable Engine", func() {
 			Expect(engine.ListCableConnections()).To(Equal(fakeDriver.Connections))
 		})
 
+		It("should report the last retrieved connections in the debug state without polling the driver", func() {
+			Expect(engine.GetDebugState().DriverConnectionsPolled).To(BeNil())
+
+			connections := fakeDriver.Connections
+			Expect(engine.ListCableConnections()).To(Equal(connections))
+
+			fakeDriver.Connections = errors.New("the driver shouldn't be polled")
+
+			state := engine.GetDebugState()
+			Expect(state.DriverConnections).To(Equal(connections))
+			Expect(state.DriverConnectionsError).To(BeEmpty())
+			Expect(state.DriverConnectionsPolled).ToNot(BeNil())
+		})
+
 		Context("and retrieval of the driver's connections fails", func() {
 			JustBeforeEach(func() {
 				fakeDriver.Connections = errors.New("fake connections error")
@@ -314,6 +810,7 @@ var _ = Describe("Cable Engine", func() {
 			It("should return an error", func() {
 				_, err := engine.ListCableConnections()
 				Expect(err).To(ContainErrorSubstring(fakeDriver.Connections.(error)))
+				Expect(engine.GetDebugState().DriverConnectionsError).To(Equal(err.Error()))
 			})
 		})
 	})
@@ -331,6 +828,15 @@ var _ = Describe("Cable Engine", func() {
 
 		It("should fail to start", func() {
 		})
+
+		Context("and later succeeds", func() {
+			It("should re-create and initialize the driver on the next start", func() {
+				fakeDriver = fake.New()
+
+				Expect(engine.StartEngine()).To(Succeed())
+				fakeDriver.AwaitInit()
+			})
+		})
 	})
 
 	When("not started", func() {
@@ -402,6 +908,9 @@ func (n *fakeNATDiscovery) RemoveEndpoint(endpointName string) {
 	n.removeEndpoint <- endpointName
 }
 
+func (n *fakeNATDiscovery) SetEventRecorder(_ *recorder.Recorder) {
+}
+
 func (n *fakeNATDiscovery) GetReadyChannel() chan *natdiscovery.NATEndpointInfo {
 	return n.readyChannel
 }
@@ -410,6 +919,29 @@ func (n *fakeNATDiscovery) notifyReady(endpoint *subv1.Endpoint) {
 	n.readyChannel <- natEndpointInfoFor(endpoint)
 }
 
+func connectionMetricValue(name, remoteClusterID string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	Expect(err).To(Succeed())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "remote_cluster" && label.GetValue() == remoteClusterID {
+					return metric.GetCounter().GetValue() + metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	Fail(fmt.Sprintf("Metric %q not found for remote cluster %q", name, remoteClusterID))
+
+	return 0
+}
+
 func natEndpointInfoFor(endpoint *subv1.Endpoint) *natdiscovery.NATEndpointInfo {
 	return &natdiscovery.NATEndpointInfo{
 		UseIP:    endpoint.Spec.GetPublicIP(k8snet.IPv4),
@@ -0,0 +1,355 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cableengine
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// retrySpec configures the connection retries and flap damping, from the Cable* fields of the Submariner specification.
+// Flap damping is disabled if the flap threshold is 0.
+type retrySpec struct {
+	CableRetryInitialBackoff time.Duration
+	CableRetryMaxBackoff     time.Duration
+	CableFlapThreshold       int
+	CableFlapWindow          time.Duration
+	CableSuppressDuration    time.Duration
+}
+
+var defaultRetrySpec = retrySpec{
+	CableRetryInitialBackoff: time.Second,
+	CableRetryMaxBackoff:     5 * time.Minute,
+	CableFlapThreshold:       5,
+	CableFlapWindow:          5 * time.Minute,
+	CableSuppressDuration:    10 * time.Minute,
+}
+
+const backoffJitter = 0.1
+
+// cableState tracks the state machine of a cable:
+//
+//	pending-nat-discovery -> connecting -> connected
+//	connecting -> backoff -> connecting
+//	connected -> suppressed -> connecting, when the cable flaps more than the threshold within the flap window
+type cableState struct {
+	state        v1.CableState
+	endpoint     v1.EndpointSpec
+	natInfo      *natdiscovery.NATEndpointInfo
+	failures     int
+	lastError    error
+	retryAt      time.Time
+	retryTimer   *time.Timer
+	flaps        []time.Time
+	driverStatus v1.ConnectionStatus
+	// The remote endpoint as passed to the driver, including the subnets of the clusters relayed through it.
+	driverEndpoint v1.EndpointSpec
+	// The subnets of the clusters relayed to the remote endpoint when the local gateway is a relay.
+	relayedSubnets []string
+	// The cable name of the relay's Endpoint when the cable is relayed.
+	relayedVia string
+	// The time the cable's availability was last accounted, and the accounted time it was monitored and up.
+	availabilityCheckedAt time.Time
+	monitoredTime         time.Duration
+	upTime                time.Duration
+}
+
+func newRetrySpec(submSpec *types.SubmarinerSpecification) retrySpec {
+	spec := retrySpec{
+		CableRetryInitialBackoff: submSpec.CableRetryInitialBackoff,
+		CableRetryMaxBackoff:     submSpec.CableRetryMaxBackoff,
+		CableFlapThreshold:       submSpec.CableFlapThreshold,
+		CableFlapWindow:          submSpec.CableFlapWindow,
+		CableSuppressDuration:    submSpec.CableSuppressDuration,
+	}
+
+	if spec.CableRetryInitialBackoff <= 0 || spec.CableRetryMaxBackoff < spec.CableRetryInitialBackoff {
+		logger.Warningf("Invalid cable retry backoff configuration %s/%s - using the defaults", spec.CableRetryInitialBackoff,
+			spec.CableRetryMaxBackoff)
+
+		spec.CableRetryInitialBackoff = defaultRetrySpec.CableRetryInitialBackoff
+		spec.CableRetryMaxBackoff = defaultRetrySpec.CableRetryMaxBackoff
+	}
+
+	if spec.CableFlapThreshold > 0 && (spec.CableFlapWindow <= 0 || spec.CableSuppressDuration <= 0) {
+		logger.Warningf("Invalid cable flap damping configuration %s/%s - using the defaults", spec.CableFlapWindow,
+			spec.CableSuppressDuration)
+
+		spec.CableFlapWindow = defaultRetrySpec.CableFlapWindow
+		spec.CableSuppressDuration = defaultRetrySpec.CableSuppressDuration
+	}
+
+	return spec
+}
+
+func (c *cableState) isDeferred() bool {
+	return (c.state == v1.CableStateBackoff || c.state == v1.CableStateSuppressed) && time.Now().Before(c.retryAt)
+}
+
+func (c *cableState) statusMessage() string {
+	if c.state == v1.CableStateSuppressed {
+		return fmt.Sprintf("Connection suppressed after flapping, retrying at %s", c.retryAt.Format(time.RFC3339))
+	}
+
+	if c.state == v1.CableStateRelayed {
+		return fmt.Sprintf("Remote endpoint is unreachable, relayed through %q", c.relayedVia)
+	}
+
+	if c.state == v1.CableStateExcluded {
+		return "The topology policy doesn't allow a direct cable and no relay is connected"
+	}
+
+	return fmt.Sprintf("Connection attempt %d failed, retrying at %s: %v", c.failures, c.retryAt.Format(time.RFC3339), c.lastError)
+}
+
+// The following methods must be called with the engine's lock held.
+
+func (i *engine) cableStateFor(endpoint *v1.EndpointSpec) *cableState {
+	c, ok := i.cables[endpoint.CableName]
+	if !ok {
+		c = &cableState{state: v1.CableStatePendingNATDiscovery, endpoint: *endpoint}
+		i.cables[endpoint.CableName] = c
+		i.setCableState(c, v1.CableStatePendingNATDiscovery)
+
+		return c
+	}
+
+	if !reflect.DeepEqual(c.endpoint, *endpoint) {
+		// The metrics are labeled with some of the endpoint's fields.
+		cable.RecordConnectionStateRemoved(i.localEndpoint.Spec().Backend, i.localEndpoint.Spec(), &c.endpoint)
+		c.endpoint = *endpoint
+		c.monitoredTime = 0
+		c.upTime = 0
+		i.setCableState(c, c.state)
+	}
+
+	return c
+}
+
+func (i *engine) setCableState(c *cableState, state v1.CableState) {
+	if c.state != state {
+		logger.V(log.DEBUG).Infof("Cable %q transitioning from %q to %q", c.endpoint.CableName, c.state, state)
+	}
+
+	c.state = state
+	cable.RecordConnectionState(i.localEndpoint.Spec().Backend, i.localEndpoint.Spec(), &c.endpoint, state)
+}
+
+func (i *engine) removeCableState(cableName string) {
+	c, ok := i.cables[cableName]
+	if !ok {
+		return
+	}
+
+	if c.retryTimer != nil {
+		c.retryTimer.Stop()
+	}
+
+	cable.RecordConnectionStateRemoved(i.localEndpoint.Spec().Backend, i.localEndpoint.Spec(), &c.endpoint)
+	delete(i.cables, cableName)
+}
+
+func (i *engine) scheduleRetry(c *cableState, delay time.Duration) {
+	if c.retryTimer != nil {
+		c.retryTimer.Stop()
+	}
+
+	c.retryAt = time.Now().Add(delay)
+	c.retryTimer = time.AfterFunc(delay, func() {
+		i.retryCable(c)
+	})
+}
+
+func (i *engine) retryCable(c *cableState) {
+	i.Lock()
+	defer i.Unlock()
+
+	if i.cables[c.endpoint.CableName] != c || !i.running || c.natInfo == nil {
+		return
+	}
+
+	logger.Infof("Retrying to install Endpoint cable %q after %s", c.endpoint.CableName, c.state)
+
+	if err := i.installCable(c.natInfo); err != nil {
+		logger.Errorf(err, "Error retrying to install cable %q", c.endpoint.CableName)
+	}
+
+	i.refreshRelays()
+}
+
+// connectFailed moves the cable to the backoff state, doubling the delay before the next attempt with each consecutive
+// failure, up to the maximum backoff.
+func (i *engine) connectFailed(c *cableState, err error) {
+	c.failures++
+	c.lastError = err
+
+	delay := i.retrySpec.CableRetryInitialBackoff
+	for n := 1; n < c.failures && delay < i.retrySpec.CableRetryMaxBackoff; n++ {
+		delay *= 2
+	}
+
+	delay = wait.Jitter(min(delay, i.retrySpec.CableRetryMaxBackoff), backoffJitter)
+
+	i.setCableState(c, v1.CableStateBackoff)
+	i.scheduleRetry(c, delay)
+
+	logger.Warningf("Connection attempt %d for cable %q failed - retrying in %s", c.failures, c.endpoint.CableName, delay)
+
+	i.recorder.OnGateway(c.endpoint.CableName, corev1.EventTypeWarning, recorder.CableFailed,
+		"Connection attempt %d for cable %q failed - retrying in %s: %v", c.failures, c.endpoint.CableName, delay, err)
+}
+
+func (i *engine) connected(c *cableState) {
+	c.failures = 0
+	c.lastError = nil
+	c.driverStatus = ""
+
+	i.setCableState(c, v1.CableStateConnected)
+}
+
+// recordFlap records that a connected cable went down and returns true if that suppresses the cable, in which case the
+// caller must disconnect it.
+func (i *engine) recordFlap(c *cableState) bool {
+	cable.RecordConnectionFlap(i.localEndpoint.Spec().Backend, i.localEndpoint.Spec(), &c.endpoint)
+
+	if i.retrySpec.CableFlapThreshold <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	windowStart := now.Add(-i.retrySpec.CableFlapWindow)
+
+	flaps := c.flaps[:0]
+
+	for _, t := range c.flaps {
+		if t.After(windowStart) {
+			flaps = append(flaps, t)
+		}
+	}
+
+	c.flaps = append(flaps, now)
+
+	if len(c.flaps) < i.retrySpec.CableFlapThreshold {
+		return false
+	}
+
+	logger.Warningf("Cable %q flapped %d times within %s - suppressing it for %s", c.endpoint.CableName, len(c.flaps),
+		i.retrySpec.CableFlapWindow, i.retrySpec.CableSuppressDuration)
+
+	c.flaps = nil
+	i.setCableState(c, v1.CableStateSuppressed)
+	i.scheduleRetry(c, i.retrySpec.CableSuppressDuration)
+
+	return true
+}
+
+// observeDriverStatus tracks the connection status reported by the driver to detect connected cables going down.
+func (i *engine) observeDriverStatus(c *cableState, status v1.ConnectionStatus) {
+	prevStatus := c.driverStatus
+	c.driverStatus = status
+
+	if c.state != v1.CableStateConnected || status != v1.ConnectionError || prevStatus == v1.ConnectionError {
+		return
+	}
+
+	if !i.recordFlap(c) {
+		return
+	}
+
+	err := i.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: c.endpoint})
+	if err != nil {
+		logger.Errorf(err, "Error disconnecting suppressed cable %q", c.endpoint.CableName)
+	}
+
+	delete(i.installedCables, c.endpoint.CableName)
+}
+
+// isUp returns true if the traffic to the remote endpoint flows, either directly or through a relay.
+func (c *cableState) isUp() bool {
+	return c.state == v1.CableStateRelayed || (c.state == v1.CableStateConnected && c.driverStatus == v1.Connected)
+}
+
+// recordAvailability adds the time elapsed since the previous call to the monitored time of the cables, and to their
+// uptime if they're up, for the availability metrics. Excluded cables aren't monitored as they're not expected to be up.
+func (i *engine) recordAvailability() {
+	now := time.Now()
+
+	for _, c := range i.cables {
+		if c.state == v1.CableStateExcluded {
+			c.availabilityCheckedAt = time.Time{}
+			continue
+		}
+
+		if c.availabilityCheckedAt.IsZero() {
+			c.availabilityCheckedAt = now
+			continue
+		}
+
+		elapsed := now.Sub(c.availabilityCheckedAt)
+		c.availabilityCheckedAt = now
+
+		var up time.Duration
+		if c.isUp() {
+			up = elapsed
+		}
+
+		c.monitoredTime += elapsed
+		c.upTime += up
+
+		if c.monitoredTime > 0 {
+			cable.RecordConnectionAvailability(i.localEndpoint.Spec().Backend, i.localEndpoint.Spec(), &c.endpoint, elapsed, up,
+				c.upTime.Seconds()/c.monitoredTime.Seconds())
+		}
+	}
+}
+
+func (c *cableState) toConnection() v1.Connection {
+	conn := v1.Connection{
+		Status:        v1.ConnectionError,
+		StatusMessage: c.statusMessage(),
+		Endpoint:      c.endpoint,
+		State:         c.state,
+	}
+
+	if c.state == v1.CableStateRelayed {
+		conn.Status = v1.Connected
+		conn.RelayedVia = c.relayedVia
+	}
+
+	if c.state == v1.CableStateExcluded {
+		conn.Status = v1.ConnectionNone
+	}
+
+	if c.natInfo != nil {
+		conn.UsingIP = c.natInfo.UseIP
+		conn.UsingNAT = c.natInfo.UseNAT
+	}
+
+	return conn
+}
@@ -0,0 +1,137 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cableengine
+
+import (
+	"sort"
+
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DebugState is a snapshot of the cable engine's internal state.
+type DebugState struct {
+	Running bool   `json:"running"`
+	Driver  string `json:"driver,omitempty"`
+	// InstalledCables are the cables the engine installed, with the creation timestamp of their Endpoint.
+	InstalledCables []InstalledCable `json:"installedCables"`
+	// NATDiscoveryPending maps the cables awaiting NAT discovery to the number of pending discoveries.
+	NATDiscoveryPending map[string]int `json:"natDiscoveryPending"`
+	// ClusterPending are the cables deferred until their remote cluster's topology policy is known.
+	ClusterPending []string `json:"clusterPending,omitempty"`
+	// Cables are the states of the cables tracked by the engine.
+	Cables []CableDebugState `json:"cables"`
+	// DriverConnections is the output of the cable driver's GetConnections when the engine last polled it, at
+	// DriverConnectionsPolled.
+	DriverConnections       []v1.Connection `json:"driverConnections"`
+	DriverConnectionsError  string          `json:"driverConnectionsError,omitempty"`
+	DriverConnectionsPolled *metav1.Time    `json:"driverConnectionsPolled,omitempty"`
+}
+
+type InstalledCable struct {
+	CableName string      `json:"cableName"`
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+type CableDebugState struct {
+	CableName   string        `json:"cableName"`
+	ClusterID   string        `json:"clusterID"`
+	State       v1.CableState `json:"state"`
+	Failures    int           `json:"failures,omitempty"`
+	LastError   string        `json:"lastError,omitempty"`
+	RetryAt     *metav1.Time  `json:"retryAt,omitempty"`
+	RecentFlaps int           `json:"recentFlaps,omitempty"`
+	RelayedVia  string        `json:"relayedVia,omitempty"`
+}
+
+func (i *engine) GetDebugState() *DebugState {
+	i.Lock()
+	defer i.Unlock()
+
+	state := &DebugState{
+		Running:             i.running,
+		InstalledCables:     make([]InstalledCable, 0, len(i.installedCables)),
+		NATDiscoveryPending: make(map[string]int, len(i.natDiscoveryPending)),
+		Cables:              make([]CableDebugState, 0, len(i.cables)),
+	}
+
+	for name, timestamp := range i.installedCables {
+		state.InstalledCables = append(state.InstalledCables, InstalledCable{CableName: name, Timestamp: timestamp})
+	}
+
+	sort.Slice(state.InstalledCables, func(a, b int) bool {
+		return state.InstalledCables[a].CableName < state.InstalledCables[b].CableName
+	})
+
+	for name, count := range i.natDiscoveryPending {
+		state.NATDiscoveryPending[name] = count
+	}
+
+	for name := range i.clusterPending {
+		state.ClusterPending = append(state.ClusterPending, name)
+	}
+
+	sort.Strings(state.ClusterPending)
+
+	for name, c := range i.cables {
+		cableState := CableDebugState{
+			CableName:   name,
+			ClusterID:   c.endpoint.ClusterID,
+			State:       c.state,
+			Failures:    c.failures,
+			RecentFlaps: len(c.flaps),
+			RelayedVia:  c.relayedVia,
+		}
+
+		if c.lastError != nil {
+			cableState.LastError = c.lastError.Error()
+		}
+
+		if c.state == v1.CableStateBackoff || c.state == v1.CableStateSuppressed {
+			cableState.RetryAt = &metav1.Time{Time: c.retryAt}
+		}
+
+		state.Cables = append(state.Cables, cableState)
+	}
+
+	sort.Slice(state.Cables, func(a, b int) bool {
+		return state.Cables[a].CableName < state.Cables[b].CableName
+	})
+
+	if i.driver == nil {
+		return state
+	}
+
+	state.Driver = i.driver.GetName()
+
+	if i.driverConnectionsPolled.IsZero() {
+		return state
+	}
+
+	state.DriverConnectionsPolled = i.driverConnectionsPolled.DeepCopy()
+
+	if i.driverConnectionsErr != nil {
+		state.DriverConnectionsError = i.driverConnectionsErr.Error()
+	} else {
+		// The cached connections are replaced rather than updated so they can be shared.
+		state.DriverConnections = i.driverConnections
+	}
+
+	return state
+}
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cableengine"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
 )
@@ -86,6 +87,14 @@ func (e *Engine) RemoveCable(endpoint *v1.Endpoint) error {
 	return nil
 }
 
+func (e *Engine) SetRemoteCluster(_ *v1.Cluster) error {
+	return nil
+}
+
+func (e *Engine) RemoveRemoteCluster(_ *v1.Cluster) error {
+	return nil
+}
+
 func (e *Engine) GetLocalEndpoint() *types.SubmarinerEndpoint {
 	return e.LocalEndPoint
 }
@@ -120,6 +129,19 @@ func (e *Engine) VerifyRemoveCable(expected *v1.EndpointSpec) {
 func (e *Engine) SetupNATDiscovery(_ natdiscovery.Interface) {
 }
 
+func (e *Engine) SetEventRecorder(_ *recorder.Recorder) {
+}
+
+func (e *Engine) GetDebugState() *cableengine.DebugState {
+	e.Lock()
+	defer e.Unlock()
+
+	return &cableengine.DebugState{
+		Running:           e.HAStatus == v1.HAStatusActive,
+		DriverConnections: e.Connections,
+	}
+}
+
 func (e *Engine) Cleanup() error {
 	close(e.onCleanup)
 	return e.ErrOnCleanup
@@ -27,6 +27,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/watcher"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/pinger"
+	"github.com/submariner-io/submariner/pkg/prober"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8snet "k8s.io/utils/net"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,6 +36,10 @@ import (
 type Interface interface {
 	Start(stopCh <-chan struct{}) error
 	GetLatencyInfo(endpoint *submarinerv1.EndpointSpec) *pinger.LatencyInfo
+	// ListLatencyInfo returns the latency information of all the remote endpoints, keyed by cable name.
+	ListLatencyInfo() map[string]*pinger.LatencyInfo
+	// GetProbeResult returns the last result of the UDP probes of the remote endpoint, if probed.
+	GetProbeResult(endpoint *submarinerv1.EndpointSpec) *prober.Result
 	Stop()
 }
 
@@ -45,11 +50,22 @@ type Config struct {
 	PingInterval       int
 	MaxPacketLossCount int
 	NewPinger          func(pinger.Config) pinger.Interface
+	// ProbePort, if non-zero, enables the UDP probes measuring the packet loss, jitter and throughput: the probe
+	// responder listens on this port, and the remote endpoints publishing their probe port are probed.
+	ProbePort int
+	// ProbeIPs are the local IPs the probe responder listens on, typically the local endpoint's health check IPs.
+	ProbeIPs  []string
+	NewProber func(prober.Config) prober.Interface
+	// OnProbeResult, if set, is called with each new probe result of a remote endpoint.
+	OnProbeResult func(remote *submarinerv1.EndpointSpec, result *prober.Result)
+	// OnRTT, if set, is called with the RTT of each ping reply received from a remote endpoint.
+	OnRTT func(remote *submarinerv1.EndpointSpec, rtt time.Duration)
 }
 
 type controller struct {
 	sync.RWMutex
 	pingers map[string]pinger.Interface
+	probers map[string]prober.Interface
 	config  *Config
 }
 
@@ -59,6 +75,7 @@ func New(config *Config) (Interface, error) {
 	controller := &controller{
 		config:  config,
 		pingers: map[string]pinger.Interface{},
+		probers: map[string]prober.Interface{},
 	}
 
 	config.WatcherConfig.ResourceConfigs = []watcher.ResourceConfig{
@@ -88,12 +105,43 @@ func (h *controller) GetLatencyInfo(endpoint *submarinerv1.EndpointSpec) *pinger
 	return nil
 }
 
+func (h *controller) ListLatencyInfo() map[string]*pinger.LatencyInfo {
+	h.RLock()
+	defer h.RUnlock()
+
+	latencies := make(map[string]*pinger.LatencyInfo, len(h.pingers))
+	for cableName, pingerObject := range h.pingers {
+		latencies[cableName] = pingerObject.GetLatencyInfo()
+	}
+
+	return latencies
+}
+
+func (h *controller) GetProbeResult(endpoint *submarinerv1.EndpointSpec) *prober.Result {
+	h.RLock()
+	defer h.RUnlock()
+
+	if proberObject, found := h.probers[endpoint.CableName]; found {
+		return proberObject.GetResult()
+	}
+
+	return nil
+}
+
 func (h *controller) Start(stopCh <-chan struct{}) error {
 	endpointWatcher, err := watcher.New(h.config.WatcherConfig)
 	if err != nil {
 		return errors.Wrapf(err, "error creating watcher")
 	}
 
+	if h.config.ProbePort != 0 && len(h.config.ProbeIPs) == 0 {
+		logger.Warning("No local IP to listen on for probes - the probe responder will not be started")
+	} else if h.config.ProbePort != 0 {
+		if err := prober.NewResponder(h.config.ProbePort, h.config.ProbeIPs...).Run(stopCh); err != nil {
+			return errors.Wrap(err, "error starting the probe responder")
+		}
+	}
+
 	if err := endpointWatcher.Start(stopCh); err != nil {
 		return errors.Wrapf(err, "error starting watcher")
 	}
@@ -113,6 +161,12 @@ func (h *controller) Stop() {
 	}
 
 	h.pingers = map[string]pinger.Interface{}
+
+	for _, p := range h.probers {
+		p.Stop()
+	}
+
+	h.probers = map[string]prober.Interface{}
 }
 
 func (h *controller) endpointCreatedOrUpdated(obj runtime.Object, _ int) bool {
@@ -123,15 +177,22 @@ func (h *controller) endpointCreatedOrUpdated(obj runtime.Object, _ int) bool {
 		return false
 	}
 
-	if endpointCreated.Spec.GetHealthCheckIP(k8snet.IPv4) == "" || endpointCreated.Spec.CableName == "" {
-		logger.Infof("HealthCheckIP (%q) and/or CableName (%q) for Endpoint %q empty - will not monitor endpoint health",
-			endpointCreated.Spec.GetHealthCheckIP(k8snet.IPv4), endpointCreated.Spec.CableName, endpointCreated.Name)
+	if endpointCreated.Spec.CableName == "" {
+		logger.Infof("CableName for Endpoint %q empty - will not monitor endpoint health", endpointCreated.Name)
 		return false
 	}
 
 	h.Lock()
 	defer h.Unlock()
 
+	h.startProber(&endpointCreated.Spec)
+
+	// The pinger only supports IPv4.
+	if endpointCreated.Spec.GetHealthCheckIP(k8snet.IPv4) == "" {
+		logger.Infof("IPv4 HealthCheckIP for Endpoint %q empty - will not ping it", endpointCreated.Name)
+		return false
+	}
+
 	if pingerObject, found := h.pingers[endpointCreated.Spec.CableName]; found {
 		if pingerObject.GetIP() == endpointCreated.Spec.GetHealthCheckIP(k8snet.IPv4) {
 			return false
@@ -147,6 +208,13 @@ func (h *controller) endpointCreatedOrUpdated(obj runtime.Object, _ int) bool {
 		MaxPacketLossCount: h.config.MaxPacketLossCount,
 	}
 
+	if h.config.OnRTT != nil {
+		remote := endpointCreated.Spec.DeepCopy()
+		pingerConfig.OnRTT = func(rtt time.Duration) {
+			h.config.OnRTT(remote, rtt)
+		}
+	}
+
 	if h.config.PingInterval != 0 {
 		pingerConfig.Interval = time.Second * time.Duration(h.config.PingInterval)
 	}
@@ -177,5 +245,62 @@ func (h *controller) endpointDeleted(obj runtime.Object, _ int) bool {
 		delete(h.pingers, endpointDeleted.Spec.CableName)
 	}
 
+	if proberObject, found := h.probers[endpointDeleted.Spec.CableName]; found {
+		proberObject.Stop()
+		delete(h.probers, endpointDeleted.Spec.CableName)
+	}
+
 	return false
 }
+
+func (h *controller) startProber(endpoint *submarinerv1.EndpointSpec) {
+	if h.config.ProbePort == 0 {
+		return
+	}
+
+	ip := endpoint.GetHealthCheckIP(k8snet.IPv4)
+	if ip == "" {
+		ip = endpoint.GetHealthCheckIP(k8snet.IPv6)
+	}
+
+	port, err := endpoint.GetBackendPort(submarinerv1.HealthCheckProbePortConfig, 0)
+	if err != nil {
+		logger.Errorf(err, "Error parsing the probe port of endpoint %q", endpoint.CableName)
+	}
+
+	proberObject, found := h.probers[endpoint.CableName]
+	if found {
+		if proberObject.GetIP() == ip && port != 0 {
+			return
+		}
+
+		logger.V(log.DEBUG).Infof("Prober is already running for %q - stopping", endpoint.CableName)
+		proberObject.Stop()
+		delete(h.probers, endpoint.CableName)
+	}
+
+	if port == 0 || ip == "" {
+		logger.V(log.DEBUG).Infof("Endpoint %q doesn't publish a probe port and health check IP - will not probe it",
+			endpoint.CableName)
+		return
+	}
+
+	newProberFunc := h.config.NewProber
+	if newProberFunc == nil {
+		newProberFunc = prober.NewProber
+	}
+
+	remote := endpoint.DeepCopy()
+
+	proberObject = newProberFunc(prober.Config{
+		IP:   ip,
+		Port: int(port),
+		OnResult: func(result *prober.Result) {
+			if h.config.OnProbeResult != nil {
+				h.config.OnProbeResult(remote, result)
+			}
+		},
+	})
+	h.probers[endpoint.CableName] = proberObject
+	proberObject.Start()
+}
@@ -21,6 +21,8 @@ package healthchecker_test
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -31,6 +33,8 @@ import (
 	"github.com/submariner-io/submariner/pkg/cableengine/healthchecker"
 	"github.com/submariner-io/submariner/pkg/pinger"
 	"github.com/submariner-io/submariner/pkg/pinger/fake"
+	"github.com/submariner-io/submariner/pkg/prober"
+	fakeprober "github.com/submariner-io/submariner/pkg/prober/fake"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
@@ -51,10 +55,21 @@ var _ = Describe("Controller", func() {
 		healthChecker healthchecker.Interface
 		endpoints     dynamic.ResourceInterface
 		pingerMap     map[string]*fake.Pinger
+		proberMap     map[string]*fakeprober.Prober
+		probeResults  chan *prober.Result
+		rtts          chan time.Duration
+		probePort     int
+		backendConfig map[string]string
 		stopCh        chan struct{}
 	)
 
 	BeforeEach(func() {
+		proberMap = map[string]*fakeprober.Prober{}
+		probeResults = make(chan *prober.Result, 10)
+		rtts = make(chan time.Duration, 10)
+		probePort = 0
+		backendConfig = nil
+
 		pingerMap = map[string]*fake.Pinger{
 			healthCheckIP1: fake.NewPinger(healthCheckIP1),
 			healthCheckIP2: fake.NewPinger(healthCheckIP2),
@@ -83,6 +98,25 @@ var _ = Describe("Controller", func() {
 			ClusterID:          localClusterID,
 			PingInterval:       3,
 			MaxPacketLossCount: 4,
+			ProbePort:          probePort,
+			ProbeIPs:           []string{"127.0.0.1"},
+			OnProbeResult: func(_ *submarinerv1.EndpointSpec, result *prober.Result) {
+				probeResults <- result
+			},
+			OnRTT: func(_ *submarinerv1.EndpointSpec, rtt time.Duration) {
+				rtts <- rtt
+			},
+		}
+
+		config.NewProber = func(proberCfg prober.Config) prober.Interface {
+			defer GinkgoRecover()
+			Expect(proberCfg.Port).To(Equal(probePort))
+
+			p, ok := proberMap[proberCfg.IP]
+			Expect(ok).To(BeTrue())
+			p.SetOnResult(proberCfg.OnResult)
+
+			return p
 		}
 
 		config.NewPinger = func(pingerCfg pinger.Config) pinger.Interface {
@@ -92,6 +126,8 @@ var _ = Describe("Controller", func() {
 
 			p, ok := pingerMap[pingerCfg.IP]
 			Expect(ok).To(BeTrue())
+			p.SetOnRTT(pingerCfg.OnRTT)
+
 			return p
 		}
 
@@ -110,6 +146,7 @@ var _ = Describe("Controller", func() {
 			ClusterID:      clusterID,
 			CableName:      fmt.Sprintf("submariner-cable-%s-192-68-1-20", clusterID),
 			HealthCheckIPs: []string{healthCheckIP},
+			BackendConfig:  backendConfig,
 		}
 
 		endpointName, err := endpointSpec.GenerateName()
@@ -168,6 +205,11 @@ var _ = Describe("Controller", func() {
 			Eventually(func() *pinger.LatencyInfo { return healthChecker.GetLatencyInfo(&endpoint2.Spec) }).
 				Should(Equal(latencyInfo2))
 
+			Expect(healthChecker.ListLatencyInfo()).To(Equal(map[string]*pinger.LatencyInfo{
+				endpoint1.Spec.CableName: latencyInfo1,
+				endpoint2.Spec.CableName: latencyInfo2,
+			}))
+
 			By("Stopping health checker")
 
 			close(stopCh)
@@ -190,6 +232,16 @@ var _ = Describe("Controller", func() {
 		})
 	})
 
+	When("a Pinger receives a ping reply", func() {
+		It("should report the RTT", func() {
+			createEndpoint(remoteClusterID1, healthCheckIP1)
+			pingerMap[healthCheckIP1].AwaitStart()
+
+			pingerMap[healthCheckIP1].RecordRTT(93 * time.Millisecond)
+			Eventually(rtts).Should(Receive(Equal(93 * time.Millisecond)))
+		})
+	})
+
 	When("a local Endpoint is created", func() {
 		It("should not start a Pinger", func() {
 			createEndpoint(localClusterID, healthCheckIP1)
@@ -246,6 +298,58 @@ var _ = Describe("Controller", func() {
 		})
 	})
 
+	When("UDP probing is enabled", func() {
+		BeforeEach(func() {
+			conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+			Expect(err).To(Succeed())
+
+			// Use a free port for the responder.
+			probePort = conn.LocalAddr().(*net.UDPAddr).Port
+			Expect(conn.Close()).To(Succeed())
+
+			proberMap[healthCheckIP1] = fakeprober.NewProber(healthCheckIP1)
+		})
+
+		Context("and a remote Endpoint publishes its probe port", func() {
+			BeforeEach(func() {
+				backendConfig = map[string]string{submarinerv1.HealthCheckProbePortConfig: strconv.Itoa(probePort)}
+			})
+
+			It("should start a Prober and return its results", func() {
+				endpoint := createEndpoint(remoteClusterID1, healthCheckIP1)
+				proberMap[healthCheckIP1].AwaitStart()
+
+				result := &prober.Result{IP: healthCheckIP1, PacketLoss: 0.01, Jitter: time.Millisecond}
+				proberMap[healthCheckIP1].SetResult(result)
+
+				Eventually(probeResults).Should(Receive(Equal(result)))
+				Expect(healthChecker.GetProbeResult(&endpoint.Spec)).To(Equal(result))
+
+				Expect(endpoints.Delete(context.TODO(), endpoint.Name, metav1.DeleteOptions{})).To(Succeed())
+				proberMap[healthCheckIP1].AwaitStop()
+			})
+
+			It("should probe an IPv6-only Endpoint", func() {
+				const healthCheckIPv6 = "fd00::1:1"
+
+				proberMap[healthCheckIPv6] = fakeprober.NewProber(healthCheckIPv6)
+
+				createEndpoint(remoteClusterID1, healthCheckIPv6)
+				proberMap[healthCheckIPv6].AwaitStart()
+			})
+		})
+
+		Context("and a remote Endpoint doesn't publish its probe port", func() {
+			It("should not start a Prober", func() {
+				endpoint := createEndpoint(remoteClusterID1, healthCheckIP1)
+				pingerMap[healthCheckIP1].AwaitStart()
+
+				proberMap[healthCheckIP1].AwaitNoStart()
+				Expect(healthChecker.GetProbeResult(&endpoint.Spec)).To(BeNil())
+			})
+		})
+	})
+
 	When("a remote Endpoint has no HealthCheckIP", func() {
 		It("should not start a Pinger", func() {
 			createEndpoint(remoteClusterID1, "")
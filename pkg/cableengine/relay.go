@@ -0,0 +1,257 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cableengine
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+)
+
+// When NAT discovery gets no response from a remote endpoint, or the topology policies don't allow a direct cable, its
+// traffic is relayed through the gateway of a connected cluster whose Endpoint is configured as a relay: the remote
+// endpoint's subnets are added to the cable to the relay, and the relayed clusters are published in the local Endpoint.
+// A relay gateway publishes the clusters it has a connected cable to in its Endpoint, and adds the subnets of the
+// clusters it relays between to the cables to each of them, so that the traffic between them is forwarded through it.
+// The traffic is only relayed if the cable driver supports it.
+
+// The following methods must be called with the engine's lock held.
+
+// relayFor returns the state of a connected relay cable which can relay the traffic to the given remote endpoint, ie
+// whose relay has a connected cable to the remote endpoint's cluster.
+func (i *engine) relayFor(endpoint *v1.EndpointSpec) *cableState {
+	if !cable.SupportsRelaying(i.driver) {
+		return nil
+	}
+
+	for _, name := range i.sortedCableNames() {
+		r := i.cables[name]
+		if r.state == v1.CableStateConnected && r.endpoint.ClusterID != endpoint.ClusterID && r.endpoint.CanRelayTo(endpoint.ClusterID) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// relayCable relays the traffic to the remote endpoint of the given cable through the relay cable.
+func (i *engine) relayCable(c, relay *cableState) error {
+	if c.state == v1.CableStateRelayed && c.relayedVia == relay.endpoint.CableName {
+		return nil
+	}
+
+	logger.Infof("Relaying the traffic to endpoint %q through %q", c.endpoint.CableName, relay.endpoint.CableName)
+
+	if err := i.disconnectCable(c); err != nil {
+		return err
+	}
+
+	c.relayedVia = relay.endpoint.CableName
+	i.setCableState(c, v1.CableStateRelayed)
+
+	return nil
+}
+
+// stopRelaying stops relaying the traffic of the given cable, which is then connected directly.
+func (i *engine) stopRelaying(c *cableState) {
+	logger.Infof("No longer relaying the traffic to endpoint %q through %q", c.endpoint.CableName, c.relayedVia)
+
+	c.relayedVia = ""
+	i.setCableState(c, v1.CableStatePendingNATDiscovery)
+}
+
+// toDriverEndpointInfo returns the endpoint information to pass to the driver, with the subnets of the clusters
+// relayed through the remote endpoint and, if the local gateway is a relay, the subnets it relays to the remote endpoint.
+func (i *engine) toDriverEndpointInfo(rnat *natdiscovery.NATEndpointInfo) *natdiscovery.NATEndpointInfo {
+	var remoteSubnets, relayedSubnets []string
+
+	localIsRelay := i.localEndpoint.Spec().IsRelay()
+
+	for _, name := range i.sortedCableNames() {
+		other := i.cables[name]
+		if name == rnat.Endpoint.Spec.CableName {
+			continue
+		}
+
+		if other.state == v1.CableStateRelayed && other.relayedVia == rnat.Endpoint.Spec.CableName {
+			remoteSubnets = append(remoteSubnets, other.endpoint.Subnets...)
+		}
+
+		if _, installed := i.installedCables[name]; localIsRelay && installed && rnat.Endpoint.Spec.IsRelayedWith(&other.endpoint) {
+			relayedSubnets = append(relayedSubnets, other.endpoint.Subnets...)
+		}
+	}
+
+	if len(remoteSubnets) == 0 && len(relayedSubnets) == 0 {
+		return rnat
+	}
+
+	driverInfo := *rnat
+	driverInfo.Endpoint = *rnat.Endpoint.DeepCopy()
+	driverInfo.Endpoint.Spec.Subnets = append(driverInfo.Endpoint.Spec.Subnets, remoteSubnets...)
+	driverInfo.RelayedSubnets = relayedSubnets
+
+	return &driverInfo
+}
+
+// refreshRelays re-installs the cables affected by changes to the relays: unreachable endpoints for which a relay is now
+// available, relayed endpoints whose relay isn't connected anymore, and cables whose relayed subnets changed.
+func (i *engine) refreshRelays() {
+	if !i.running {
+		return
+	}
+
+	// Re-installing a cable can change the subnets of its relay's cable, so this is repeated until nothing changes.
+	for range len(i.cables) + 1 {
+		changed := false
+
+		for _, name := range i.sortedCableNames() {
+			c, ok := i.cables[name]
+			if !ok || c.natInfo == nil || c.isDeferred() || i.natDiscoveryPending[name] > 0 || !i.relayChanged(c) {
+				continue
+			}
+
+			changed = true
+
+			if err := i.installCable(c.natInfo); err != nil {
+				logger.Errorf(err, "Error re-installing cable %q after a relay change", name)
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	i.publishRelayedClusters()
+	i.publishConnectedClusters()
+}
+
+func (i *engine) relayChanged(c *cableState) bool {
+	if c.state == v1.CableStateRelayed {
+		relay, ok := i.cables[c.relayedVia]
+		return !ok || relay.state != v1.CableStateConnected || !relay.endpoint.CanRelayTo(c.endpoint.ClusterID)
+	}
+
+	direct, known := i.directCableAllowed(&c.endpoint)
+	if !known {
+		// The cable stays as is until its cluster's topology policy is known.
+		return false
+	}
+
+	if (c.natInfo.DiscoveryFailed || !direct) && i.relayFor(&c.endpoint) != nil {
+		return true
+	}
+
+	if _, installed := i.installedCables[c.endpoint.CableName]; !installed {
+		return false
+	}
+
+	driverInfo := i.toDriverEndpointInfo(c.natInfo)
+
+	return !slices.Equal(driverInfo.Endpoint.Spec.Subnets, c.driverEndpoint.Subnets) ||
+		!slices.Equal(driverInfo.RelayedSubnets, c.relayedSubnets)
+}
+
+// publishRelayedClusters publishes the IDs of the clusters reached through a relay in the local Endpoint, so that the
+// relays forward their traffic.
+func (i *engine) publishRelayedClusters() {
+	clusterIDs := []string{}
+
+	for _, c := range i.cables {
+		if c.state == v1.CableStateRelayed && !slices.Contains(clusterIDs, c.endpoint.ClusterID) {
+			clusterIDs = append(clusterIDs, c.endpoint.ClusterID)
+		}
+	}
+
+	i.publishClusters(v1.RelayedClustersConfig, clusterIDs)
+}
+
+// publishConnectedClusters publishes the IDs of the clusters to which the local gateway, if it's a relay, has a
+// connected cable in the local Endpoint, so that the other clusters only relay the traffic to these through it.
+func (i *engine) publishConnectedClusters() {
+	clusterIDs := []string{}
+
+	if i.localEndpoint.Spec().IsRelay() {
+		for _, c := range i.cables {
+			if c.state == v1.CableStateConnected && c.driverStatus != v1.ConnectionError &&
+				!slices.Contains(clusterIDs, c.endpoint.ClusterID) {
+				clusterIDs = append(clusterIDs, c.endpoint.ClusterID)
+			}
+		}
+	}
+
+	i.publishClusters(v1.ConnectedClustersConfig, clusterIDs)
+}
+
+func (i *engine) publishClusters(configName string, clusterIDs []string) {
+	sort.Strings(clusterIDs)
+
+	value := strings.Join(clusterIDs, ",")
+	if i.localEndpoint.Spec().BackendConfig[configName] == value {
+		return
+	}
+
+	err := i.localEndpoint.Update(context.TODO(), func(existing *v1.EndpointSpec) {
+		if existing.BackendConfig == nil {
+			existing.BackendConfig = map[string]string{}
+		}
+
+		if value == "" {
+			delete(existing.BackendConfig, configName)
+		} else {
+			existing.BackendConfig[configName] = value
+		}
+	})
+	if err != nil {
+		logger.Errorf(err, "Error publishing %q %q in the local endpoint", configName, value)
+	}
+}
+
+// disableRelayIfUnsupported stops advertising the local gateway as a relay if the cable driver can't relay traffic.
+func (i *engine) disableRelayIfUnsupported() error {
+	if !i.localEndpoint.Spec().IsRelay() || cable.SupportsRelaying(i.driver) {
+		return nil
+	}
+
+	logger.Warningf("The %q cable driver doesn't support relaying traffic - the gateway won't act as a relay", i.driver.GetName())
+
+	err := i.localEndpoint.Update(context.TODO(), func(existing *v1.EndpointSpec) {
+		delete(existing.BackendConfig, v1.RelayConfig)
+	})
+
+	return errors.Wrap(err, "error updating the local endpoint")
+}
+
+func (i *engine) sortedCableNames() []string {
+	names := make([]string, 0, len(i.cables))
+	for name := range i.cables {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,133 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncer
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8snet "k8s.io/utils/net"
+)
+
+func newCondition(condType v1.GatewayConditionType, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    string(condType),
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func (gs *GatewaySyncer) generateConditions(gateway *v1.Gateway, connectionsErr error) {
+	conditions := []metav1.Condition{
+		cableDriverCondition(gs.statusError, connectionsErr),
+		natDiscoveryCondition(gateway.Status.Connections),
+		publicIPCondition(&gateway.Status.LocalEndpoint),
+		gs.healthCheckCondition(gateway.Status.Connections),
+		gs.brokerSynced,
+	}
+
+	for i := range conditions {
+		meta.SetStatusCondition(&gateway.Status.Conditions, conditions[i])
+	}
+}
+
+func cableDriverCondition(statusError, connectionsErr error) metav1.Condition {
+	if statusError != nil {
+		return newCondition(v1.GatewayCableDriverReady, metav1.ConditionFalse, "GatewayError", statusError.Error())
+	}
+
+	if connectionsErr != nil {
+		return newCondition(v1.GatewayCableDriverReady, metav1.ConditionFalse, "ConnectionsUnavailable", connectionsErr.Error())
+	}
+
+	return newCondition(v1.GatewayCableDriverReady, metav1.ConditionTrue, "Ready", "")
+}
+
+func natDiscoveryCondition(connections []v1.Connection) metav1.Condition {
+	pending := cableNames(connections, func(c *v1.Connection) bool {
+		return c.State == v1.CableStatePendingNATDiscovery
+	})
+
+	if len(pending) > 0 {
+		return newCondition(v1.GatewayNATDiscoveryComplete, metav1.ConditionFalse, "Pending",
+			"NAT discovery is pending for "+strings.Join(pending, ", "))
+	}
+
+	return newCondition(v1.GatewayNATDiscoveryComplete, metav1.ConditionTrue, "Complete", "")
+}
+
+func publicIPCondition(localEndpoint *v1.EndpointSpec) metav1.Condition {
+	if localEndpoint.GetPublicIP(k8snet.IPv4) == "" && localEndpoint.GetPublicIP(k8snet.IPv6) == "" {
+		return newCondition(v1.GatewayPublicIPResolved, metav1.ConditionFalse, "NotResolved",
+			"The local endpoint has no public IP")
+	}
+
+	return newCondition(v1.GatewayPublicIPResolved, metav1.ConditionTrue, "Resolved", "")
+}
+
+func (gs *GatewaySyncer) healthCheckCondition(connections []v1.Connection) metav1.Condition {
+	if gs.healthCheck == nil {
+		return newCondition(v1.GatewayHealthCheckPassing, metav1.ConditionUnknown, "HealthCheckDisabled",
+			"Health check is not enabled")
+	}
+
+	failing := cableNames(connections, func(c *v1.Connection) bool {
+		return c.Status == v1.ConnectionError
+	})
+
+	if len(failing) > 0 {
+		return newCondition(v1.GatewayHealthCheckPassing, metav1.ConditionFalse, "ConnectionError",
+			"Health check failed for "+strings.Join(failing, ", "))
+	}
+
+	return newCondition(v1.GatewayHealthCheckPassing, metav1.ConditionTrue, "Passing", "")
+}
+
+func brokerSyncCondition(err error) metav1.Condition {
+	if err != nil {
+		return newCondition(v1.GatewayBrokerSynced, metav1.ConditionFalse, "SyncFailed", err.Error())
+	}
+
+	return newCondition(v1.GatewayBrokerSynced, metav1.ConditionTrue, "Synced", "")
+}
+
+func cableNames(connections []v1.Connection, include func(c *v1.Connection) bool) []string {
+	var names []string
+
+	for i := range connections {
+		if include(&connections[i]) {
+			names = append(names, fmt.Sprintf("%q", connections[i].Endpoint.CableName))
+		}
+	}
+
+	return names
+}
+
+// mergeConditions sets the given conditions in the existing ones, preserving the transition times of those whose status
+// didn't change.
+func mergeConditions(existing *[]metav1.Condition, conditions []metav1.Condition) {
+	for i := range conditions {
+		conditions[i].LastTransitionTime = metav1.Time{}
+		meta.SetStatusCondition(existing, conditions[i])
+	}
+}
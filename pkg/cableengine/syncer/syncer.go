@@ -43,12 +43,13 @@ import (
 )
 
 type GatewaySyncer struct {
-	mutex       sync.Mutex
-	client      v1typed.GatewayInterface
-	engine      cableengine.Engine
-	version     string
-	statusError error
-	healthCheck healthchecker.Interface
+	mutex        sync.Mutex
+	client       v1typed.GatewayInterface
+	engine       cableengine.Engine
+	version      string
+	statusError  error
+	brokerSynced metav1.Condition
+	healthCheck  healthchecker.Interface
 }
 
 var (
@@ -79,6 +80,8 @@ func NewGatewaySyncer(engine cableengine.Engine, client v1typed.GatewayInterface
 		engine:      engine,
 		version:     version,
 		healthCheck: healthCheck,
+		brokerSynced: newCondition(v1.GatewayBrokerSynced, metav1.ConditionUnknown, "Pending",
+			"The datastore syncer hasn't started"),
 	}
 }
 
@@ -104,6 +107,15 @@ func (gs *GatewaySyncer) SetGatewayStatusError(ctx context.Context, err error) {
 	gs.syncGatewayStatusSafe(ctx)
 }
 
+// SetBrokerSyncStatus sets the BrokerSynced condition according to the datastore syncer's sync status.
+func (gs *GatewaySyncer) SetBrokerSyncStatus(ctx context.Context, err error) {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	gs.brokerSynced = brokerSyncCondition(err)
+	gs.syncGatewayStatusSafe(ctx)
+}
+
 func (gs *GatewaySyncer) gatewayResourceInterface() resource.Interface[*v1.Gateway] {
 	return &resource.InterfaceFuncs[*v1.Gateway]{
 		GetFunc:    gs.client.Get,
@@ -121,7 +133,10 @@ func (gs *GatewaySyncer) syncGatewayStatusSafe(ctx context.Context) {
 
 	result, err := util.CreateOrUpdate(ctx, gs.gatewayResourceInterface(), gatewayObj,
 		func(existing *v1.Gateway) (*v1.Gateway, error) {
+			conditions := existing.Status.Conditions
 			existing.Status = gatewayObj.Status
+			existing.Status.Conditions = conditions
+			mergeConditions(&existing.Status.Conditions, gatewayObj.Status.Conditions)
 
 			if existing.Annotations == nil {
 				existing.Annotations = map[string]string{}
@@ -217,13 +232,14 @@ func (gs *GatewaySyncer) generateGatewayObject() *v1.Gateway {
 
 	gateway.Status.HAStatus = gs.engine.GetHAStatus()
 
-	var connections []v1.Connection
+	var (
+		connections []v1.Connection
+		err         error
+	)
 
 	if gs.statusError != nil {
 		gateway.Status.StatusFailure = gs.statusError.Error()
 	} else {
-		var err error
-
 		connections, err = gs.engine.ListCableConnections()
 		if err != nil {
 			msg := fmt.Sprintf("Error retrieving driver connections: %s", err)
@@ -240,6 +256,10 @@ func (gs *GatewaySyncer) generateGatewayObject() *v1.Gateway {
 		for index := range connections {
 			connection := &connections[index]
 
+			if probeResult := gs.healthCheck.GetProbeResult(&connection.Endpoint); probeResult != nil {
+				connection.Quality = probeResult.ToSpec()
+			}
+
 			latencyInfo := gs.healthCheck.GetLatencyInfo(&connection.Endpoint)
 			if latencyInfo != nil {
 				connection.LatencyRTT = latencyInfo.Spec
@@ -269,6 +289,8 @@ func (gs *GatewaySyncer) generateGatewayObject() *v1.Gateway {
 
 	gateway.Status.Connections = connections
 
+	gs.generateConditions(&gateway, err)
+
 	logger.V(log.TRACE).Infof("Generated Gateway object: %+v", gateway)
 
 	return &gateway
@@ -46,6 +46,7 @@ import (
 	"github.com/submariner-io/submariner/pkg/pinger"
 	"github.com/submariner-io/submariner/pkg/pinger/fake"
 	"github.com/submariner-io/submariner/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -75,6 +76,7 @@ var _ = Describe("", func() {
 	Context("Stale Gateway cleanup", testStaleGatewayCleanup)
 	Context("Gateway sync errors", testGatewaySyncErrors)
 	Context("Gateway latency info", testGatewayLatencyInfo)
+	Context("Gateway conditions", testGatewayConditions)
 })
 
 func testGatewaySyncing() {
@@ -470,6 +472,126 @@ func testGatewayLatencyInfo() {
 	})
 }
 
+func testGatewayConditions() {
+	var t *testDriver
+
+	BeforeEach(func() {
+		t = newTestDriver()
+		t.expectedGateway.Status.Conditions = []metav1.Condition{
+			newCondition(submarinerv1.GatewayCableDriverReady, metav1.ConditionTrue, "Ready"),
+			newCondition(submarinerv1.GatewayNATDiscoveryComplete, metav1.ConditionTrue, "Complete"),
+			newCondition(submarinerv1.GatewayBrokerSynced, metav1.ConditionUnknown, "Pending"),
+			newCondition(submarinerv1.GatewayPublicIPResolved, metav1.ConditionFalse, "NotResolved"),
+			newCondition(submarinerv1.GatewayHealthCheckPassing, metav1.ConditionTrue, "Passing"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		t.run()
+	})
+
+	AfterEach(func() {
+		t.stop()
+	})
+
+	It("should initially set the conditions", func() {
+		t.awaitGatewayUpdated(t.expectedGateway)
+	})
+
+	When("the local endpoint has a public IP", func() {
+		BeforeEach(func() {
+			t.engine.LocalEndPoint.Spec.SetPublicIP("1.2.3.4")
+			t.expectedGateway.Status.LocalEndpoint = t.engine.LocalEndPoint.Spec
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayPublicIPResolved, metav1.ConditionTrue, "Resolved"))
+		})
+
+		It("should set the PublicIPResolved condition", func() {
+			t.awaitGatewayUpdated(t.expectedGateway)
+		})
+	})
+
+	When("the broker sync status is set", func() {
+		It("should update the BrokerSynced condition", func() {
+			t.awaitGatewayUpdated(t.expectedGateway)
+
+			t.syncer.SetBrokerSyncStatus(context.Background(), nil)
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayBrokerSynced, metav1.ConditionTrue, "Synced"))
+			t.awaitGatewayUpdated(t.expectedGateway)
+
+			t.syncer.SetBrokerSyncStatus(context.Background(), errors.New("fake error"))
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayBrokerSynced, metav1.ConditionFalse, "SyncFailed"))
+			t.awaitGatewayUpdated(t.expectedGateway)
+		})
+	})
+
+	When("a status error is set", func() {
+		It("should set the CableDriverReady condition to false", func() {
+			t.awaitGatewayUpdated(t.expectedGateway)
+
+			statusErr := errors.New("fake error")
+			t.expectedGateway.Status.StatusFailure = statusErr.Error()
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayCableDriverReady, metav1.ConditionFalse, "GatewayError"))
+
+			t.syncer.SetGatewayStatusError(context.Background(), statusErr)
+			t.awaitGatewayUpdated(t.expectedGateway)
+		})
+	})
+
+	When("NAT discovery is pending for a connection", func() {
+		BeforeEach(func() {
+			t.engine.Connections = []submarinerv1.Connection{{
+				Status: submarinerv1.Connecting,
+				State:  submarinerv1.CableStatePendingNATDiscovery,
+				Endpoint: submarinerv1.EndpointSpec{
+					ClusterID: "west",
+					CableName: "submariner-cable-west-192-68-1-10",
+				},
+			}}
+
+			t.expectedGateway.Status.Connections = t.engine.Connections
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayNATDiscoveryComplete, metav1.ConditionFalse, "Pending"))
+		})
+
+		It("should set the NATDiscoveryComplete condition to false", func() {
+			t.awaitGatewayUpdated(t.expectedGateway)
+		})
+	})
+
+	When("a connection reports a health check error", func() {
+		BeforeEach(func() {
+			t.engine.Connections = []submarinerv1.Connection{{
+				Status:        submarinerv1.ConnectionError,
+				StatusMessage: "Ping failed",
+				Endpoint: submarinerv1.EndpointSpec{
+					ClusterID: "west",
+					CableName: "submariner-cable-west-192-68-1-10",
+				},
+			}}
+
+			t.expectedGateway.Status.Connections = t.engine.Connections
+			meta.SetStatusCondition(&t.expectedGateway.Status.Conditions,
+				newCondition(submarinerv1.GatewayHealthCheckPassing, metav1.ConditionFalse, "ConnectionError"))
+		})
+
+		It("should set the HealthCheckPassing condition to false", func() {
+			t.awaitGatewayUpdated(t.expectedGateway)
+		})
+	})
+}
+
+func newCondition(condType submarinerv1.GatewayConditionType, status metav1.ConditionStatus, reason string) metav1.Condition {
+	return metav1.Condition{
+		Type:   string(condType),
+		Status: status,
+		Reason: reason,
+	}
+}
+
 type testDriver struct {
 	engine               *fakeEngine.Engine
 	client               *fakeClientset.Clientset
@@ -666,12 +788,32 @@ func (m *equalGatewayMatcher) Match(x interface{}) (bool, error) {
 		actual.Annotations = map[string]string{}
 	}
 
+	if m.expected.Status.Conditions == nil {
+		actual.Status.Conditions = nil
+	} else if !conditionsMatch(actual.Status.Conditions, m.expected.Status.Conditions) {
+		return false, nil
+	} else {
+		actual.Status.Conditions = m.expected.Status.Conditions
+	}
+
 	delete(m.expected.Annotations, syncer.UpdateTimestampAnnotation)
 	delete(actual.Annotations, syncer.UpdateTimestampAnnotation)
 
 	return reflect.DeepEqual(actual.Status, m.expected.Status) && reflect.DeepEqual(actual.Annotations, m.expected.Annotations), nil
 }
 
+// conditionsMatch returns true if the actual conditions contain the expected conditions' types, statuses and reasons.
+func conditionsMatch(actual, expected []metav1.Condition) bool {
+	for i := range expected {
+		c := meta.FindStatusCondition(actual, expected[i].Type)
+		if c == nil || c.Status != expected[i].Status || c.Reason != expected[i].Reason || c.LastTransitionTime.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (m *equalGatewayMatcher) FailureMessage(actual interface{}) string {
 	return format.Message(actual, "to equal", m.expected)
 }
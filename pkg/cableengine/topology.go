@@ -0,0 +1,191 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cableengine
+
+import (
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/natdiscovery"
+	"github.com/submariner-io/submariner/pkg/types"
+)
+
+// The clusters' topology policies decide which remote endpoints get direct cables. The traffic to the other remote
+// endpoints is relayed through a hub, ie a relay, and their cables are excluded while no relay is connected.
+
+func (i *engine) SetRemoteCluster(cluster *v1.Cluster) error {
+	if cluster.Spec.ClusterID == i.localCluster.ID {
+		return nil
+	}
+
+	i.Lock()
+	endpoints := i.updateRemoteCluster(cluster.Spec.ClusterID, cluster.Spec.DeepCopy())
+	i.Unlock()
+
+	return i.reinstallCables(endpoints)
+}
+
+func (i *engine) RemoveRemoteCluster(cluster *v1.Cluster) error {
+	if cluster.Spec.ClusterID == i.localCluster.ID {
+		return nil
+	}
+
+	i.Lock()
+	endpoints := i.updateRemoteCluster(cluster.Spec.ClusterID, nil)
+	i.Unlock()
+
+	return i.reinstallCables(endpoints)
+}
+
+// updateRemoteCluster records the remote cluster's spec, and returns the endpoints of its cables which must be
+// re-installed: those deferred until the cluster is known, and those the topology policies now allow or disallow. The
+// installed cables are kept as is while the cluster is unknown. It must be called with the lock held.
+func (i *engine) updateRemoteCluster(clusterID string, spec *v1.ClusterSpec) []*v1.Endpoint {
+	var endpoints []*v1.Endpoint
+
+	prevAllowed := map[string]bool{}
+
+	for name, c := range i.cables {
+		if c.endpoint.ClusterID != clusterID || c.natInfo == nil {
+			continue
+		}
+
+		if allowed, known := i.directCableAllowed(&c.endpoint); known {
+			prevAllowed[name] = allowed
+		}
+	}
+
+	if spec == nil {
+		delete(i.remoteClusters, clusterID)
+		return nil
+	}
+
+	i.remoteClusters[clusterID] = spec
+
+	for name, allowed := range prevAllowed {
+		c := i.cables[name]
+		if now, _ := i.directCableAllowed(&c.endpoint); now != allowed {
+			endpoints = append(endpoints, c.natInfo.Endpoint.DeepCopy())
+		}
+	}
+
+	for name, endpoint := range i.clusterPending {
+		if endpoint.Spec.ClusterID == clusterID {
+			delete(i.clusterPending, name)
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints
+}
+
+func (i *engine) reinstallCables(endpoints []*v1.Endpoint) error {
+	for _, endpoint := range endpoints {
+		logger.Infof("The topology policy for the cable to endpoint %q changed - re-installing it", endpoint.Spec.CableName)
+
+		if err := i.InstallCable(endpoint); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// directCableAllowed returns whether the local and remote clusters' topology policies allow a direct cable to the given
+// endpoint, and whether that can be decided yet. While the remote cluster isn't known, the decision is deferred only if
+// the local policy selects clusters by their labels; otherwise the remote cluster is assumed to have neither labels nor
+// a policy, and its cables are re-installed by SetRemoteCluster if its actual policy disallows them. It must be called
+// with the lock held.
+func (i *engine) directCableAllowed(endpoint *v1.EndpointSpec) (allowed, known bool) {
+	remote, ok := i.remoteClusters[endpoint.ClusterID]
+	if !ok {
+		if i.localCluster.Spec.Topology.SelectsByLabels() {
+			return false, false
+		}
+
+		remote = &v1.ClusterSpec{ClusterID: endpoint.ClusterID}
+	}
+
+	return v1.DirectCableAllowed(&i.localCluster.Spec, remote), true
+}
+
+// deferUntilClusterKnown defers the installation of the cable to an endpoint whose cluster isn't known yet, while the
+// local topology policy selects clusters by their labels. It's installed by SetRemoteCluster once its Cluster is synced.
+// It must be called with the lock held.
+func (i *engine) deferUntilClusterKnown(endpoint *v1.Endpoint) {
+	logger.Infof("The Cluster of endpoint %q isn't known yet - deferring its cable until its topology policy is known",
+		endpoint.Spec.CableName)
+
+	i.clusterPending[endpoint.Spec.CableName] = endpoint.DeepCopy()
+	i.cableStateFor(&endpoint.Spec)
+}
+
+// installExcludedCable installs the cable to an endpoint without a direct cable, which doesn't go through NAT discovery.
+func (i *engine) installExcludedCable(endpoint *v1.Endpoint) error {
+	logger.V(log.DEBUG).Infof("The topology policy doesn't allow a direct cable to endpoint %q", endpoint.Spec.CableName)
+
+	i.natDiscovery.RemoveEndpoint(endpoint.Spec.CableName)
+
+	i.Lock()
+	defer i.Unlock()
+
+	delete(i.natDiscoveryPending, endpoint.Spec.CableName)
+	i.cableStateFor(&endpoint.Spec)
+
+	if !i.running {
+		return nil
+	}
+
+	defer i.refreshRelays()
+
+	return i.installCable(&natdiscovery.NATEndpointInfo{Endpoint: *endpoint})
+}
+
+// excludeCable disconnects the cable to an endpoint without a direct cable, until a relay is connected. It must be
+// called with the lock held.
+func (i *engine) excludeCable(c *cableState) error {
+	if c.state != v1.CableStateExcluded {
+		logger.Infof("No relay is connected for endpoint %q which doesn't have a direct cable", c.endpoint.CableName)
+	}
+
+	c.relayedVia = ""
+
+	if err := i.disconnectCable(c); err != nil {
+		return err
+	}
+
+	i.setCableState(c, v1.CableStateExcluded)
+
+	return nil
+}
+
+// disconnectCable disconnects the driver from the cable's remote endpoint if it's installed. It must be called with the
+// lock held.
+func (i *engine) disconnectCable(c *cableState) error {
+	if _, ok := i.installedCables[c.endpoint.CableName]; !ok {
+		return nil
+	}
+
+	if err := i.driver.DisconnectFromEndpoint(&types.SubmarinerEndpoint{Spec: c.driverEndpoint}); err != nil {
+		return err //nolint:wrapcheck  // No need to wrap this error
+	}
+
+	delete(i.installedCables, c.endpoint.CableName)
+
+	return nil
+}
@@ -21,6 +21,7 @@ package cidr
 import (
 	"fmt"
 	"net"
+	"slices"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
@@ -88,13 +89,36 @@ func IsOverlapping(cidrList []string, cidr string) (bool, error) {
 }
 
 func ExtractIPv4Subnets(cidrList []string) []string {
-	var ipv4Cidrs []string
+	return ExtractSubnets(k8snet.IPv4, cidrList)
+}
+
+func ExtractIPv6Subnets(cidrList []string) []string {
+	return ExtractSubnets(k8snet.IPv6, cidrList)
+}
+
+// ExtractSubnets returns the CIDRs in cidrList that belong to the given IP family.
+func ExtractSubnets(family k8snet.IPFamily, cidrList []string) []string {
+	var cidrs []string
+
+	for _, subnet := range cidrList {
+		if k8snet.IPFamilyOfCIDRString(subnet) == family {
+			cidrs = append(cidrs, subnet)
+		}
+	}
+
+	return cidrs
+}
+
+// ExtractIPFamilies returns the distinct IP families of the CIDRs in cidrList, in the order in which they first appear.
+func ExtractIPFamilies(cidrList []string) []k8snet.IPFamily {
+	var families []k8snet.IPFamily
 
 	for _, subnet := range cidrList {
-		if k8snet.IsIPv4CIDRString(subnet) {
-			ipv4Cidrs = append(ipv4Cidrs, subnet)
+		family := k8snet.IPFamilyOfCIDRString(subnet)
+		if family != k8snet.IPFamilyUnknown && !slices.Contains(families, family) {
+			families = append(families, family)
 		}
 	}
 
-	return ipv4Cidrs
+	return families
 }
@@ -0,0 +1,68 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// ConnectivityPolicyEgressRuleApplyConfiguration represents a declarative configuration of the ConnectivityPolicyEgressRule type for use
+// with apply.
+type ConnectivityPolicyEgressRuleApplyConfiguration struct {
+	ClusterIDs []string                                   `json:"clusterIDs,omitempty"`
+	CIDRs      []string                                   `json:"cidrs,omitempty"`
+	Ports      []ConnectivityPolicyPortApplyConfiguration `json:"ports,omitempty"`
+}
+
+// ConnectivityPolicyEgressRuleApplyConfiguration constructs a declarative configuration of the ConnectivityPolicyEgressRule type for use with
+// apply.
+func ConnectivityPolicyEgressRule() *ConnectivityPolicyEgressRuleApplyConfiguration {
+	return &ConnectivityPolicyEgressRuleApplyConfiguration{}
+}
+
+// WithClusterIDs adds the given value to the ClusterIDs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ClusterIDs field.
+func (b *ConnectivityPolicyEgressRuleApplyConfiguration) WithClusterIDs(values ...string) *ConnectivityPolicyEgressRuleApplyConfiguration {
+	for i := range values {
+		b.ClusterIDs = append(b.ClusterIDs, values[i])
+	}
+	return b
+}
+
+// WithCIDRs adds the given value to the CIDRs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CIDRs field.
+func (b *ConnectivityPolicyEgressRuleApplyConfiguration) WithCIDRs(values ...string) *ConnectivityPolicyEgressRuleApplyConfiguration {
+	for i := range values {
+		b.CIDRs = append(b.CIDRs, values[i])
+	}
+	return b
+}
+
+// WithPorts adds the given value to the Ports field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Ports field.
+func (b *ConnectivityPolicyEgressRuleApplyConfiguration) WithPorts(values ...*ConnectivityPolicyPortApplyConfiguration) *ConnectivityPolicyEgressRuleApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPorts")
+		}
+		b.Ports = append(b.Ports, *values[i])
+	}
+	return b
+}
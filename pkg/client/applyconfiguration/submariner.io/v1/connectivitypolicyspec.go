@@ -0,0 +1,59 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// ConnectivityPolicySpecApplyConfiguration represents a declarative configuration of the ConnectivityPolicySpec type for use
+// with apply.
+type ConnectivityPolicySpecApplyConfiguration struct {
+	PodSelector *metav1.LabelSelectorApplyConfiguration          `json:"podSelector,omitempty"`
+	Egress      []ConnectivityPolicyEgressRuleApplyConfiguration `json:"egress,omitempty"`
+}
+
+// ConnectivityPolicySpecApplyConfiguration constructs a declarative configuration of the ConnectivityPolicySpec type for use with
+// apply.
+func ConnectivityPolicySpec() *ConnectivityPolicySpecApplyConfiguration {
+	return &ConnectivityPolicySpecApplyConfiguration{}
+}
+
+// WithPodSelector sets the PodSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSelector field is set to the value of the last call.
+func (b *ConnectivityPolicySpecApplyConfiguration) WithPodSelector(value *metav1.LabelSelectorApplyConfiguration) *ConnectivityPolicySpecApplyConfiguration {
+	b.PodSelector = value
+	return b
+}
+
+// WithEgress adds the given value to the Egress field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Egress field.
+func (b *ConnectivityPolicySpecApplyConfiguration) WithEgress(values ...*ConnectivityPolicyEgressRuleApplyConfiguration) *ConnectivityPolicySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithEgress")
+		}
+		b.Egress = append(b.Egress, *values[i])
+	}
+	return b
+}
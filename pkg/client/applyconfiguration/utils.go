@@ -44,6 +44,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &submarineriov1.ClusterSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Connection"):
 		return &submarineriov1.ConnectionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConnectivityPolicy"):
+		return &submarineriov1.ConnectivityPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConnectivityPolicyEgressRule"):
+		return &submarineriov1.ConnectivityPolicyEgressRuleApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConnectivityPolicyPort"):
+		return &submarineriov1.ConnectivityPolicyPortApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConnectivityPolicySpec"):
+		return &submarineriov1.ConnectivityPolicySpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Endpoint"):
 		return &submarineriov1.EndpointApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EndpointSpec"):
@@ -0,0 +1,72 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	context "context"
+
+	submarineriov1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	applyconfigurationsubmarineriov1 "github.com/submariner-io/submariner/pkg/client/applyconfiguration/submariner.io/v1"
+	scheme "github.com/submariner-io/submariner/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// ConnectivityPoliciesGetter has a method to return a ConnectivityPolicyInterface.
+// A group's client should implement this interface.
+type ConnectivityPoliciesGetter interface {
+	ConnectivityPolicies(namespace string) ConnectivityPolicyInterface
+}
+
+// ConnectivityPolicyInterface has methods to work with ConnectivityPolicy resources.
+type ConnectivityPolicyInterface interface {
+	Create(ctx context.Context, connectivityPolicy *submarineriov1.ConnectivityPolicy, opts metav1.CreateOptions) (*submarineriov1.ConnectivityPolicy, error)
+	Update(ctx context.Context, connectivityPolicy *submarineriov1.ConnectivityPolicy, opts metav1.UpdateOptions) (*submarineriov1.ConnectivityPolicy, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*submarineriov1.ConnectivityPolicy, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*submarineriov1.ConnectivityPolicyList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *submarineriov1.ConnectivityPolicy, err error)
+	Apply(ctx context.Context, connectivityPolicy *applyconfigurationsubmarineriov1.ConnectivityPolicyApplyConfiguration, opts metav1.ApplyOptions) (result *submarineriov1.ConnectivityPolicy, err error)
+	ConnectivityPolicyExpansion
+}
+
+// connectivityPolicies implements ConnectivityPolicyInterface
+type connectivityPolicies struct {
+	*gentype.ClientWithListAndApply[*submarineriov1.ConnectivityPolicy, *submarineriov1.ConnectivityPolicyList, *applyconfigurationsubmarineriov1.ConnectivityPolicyApplyConfiguration]
+}
+
+// newConnectivityPolicies returns a ConnectivityPolicies
+func newConnectivityPolicies(c *SubmarinerV1Client, namespace string) *connectivityPolicies {
+	return &connectivityPolicies{
+		gentype.NewClientWithListAndApply[*submarineriov1.ConnectivityPolicy, *submarineriov1.ConnectivityPolicyList, *applyconfigurationsubmarineriov1.ConnectivityPolicyApplyConfiguration](
+			"connectivitypolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *submarineriov1.ConnectivityPolicy { return &submarineriov1.ConnectivityPolicy{} },
+			func() *submarineriov1.ConnectivityPolicyList { return &submarineriov1.ConnectivityPolicyList{} },
+		),
+	}
+}
@@ -0,0 +1,55 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	submarineriov1 "github.com/submariner-io/submariner/pkg/client/applyconfiguration/submariner.io/v1"
+	typedsubmarineriov1 "github.com/submariner-io/submariner/pkg/client/clientset/versioned/typed/submariner.io/v1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeConnectivityPolicies implements ConnectivityPolicyInterface
+type fakeConnectivityPolicies struct {
+	*gentype.FakeClientWithListAndApply[*v1.ConnectivityPolicy, *v1.ConnectivityPolicyList, *submarineriov1.ConnectivityPolicyApplyConfiguration]
+	Fake *FakeSubmarinerV1
+}
+
+func newFakeConnectivityPolicies(fake *FakeSubmarinerV1, namespace string) typedsubmarineriov1.ConnectivityPolicyInterface {
+	return &fakeConnectivityPolicies{
+		gentype.NewFakeClientWithListAndApply[*v1.ConnectivityPolicy, *v1.ConnectivityPolicyList, *submarineriov1.ConnectivityPolicyApplyConfiguration](
+			fake.Fake,
+			namespace,
+			v1.SchemeGroupVersion.WithResource("connectivitypolicies"),
+			v1.SchemeGroupVersion.WithKind("ConnectivityPolicy"),
+			func() *v1.ConnectivityPolicy { return &v1.ConnectivityPolicy{} },
+			func() *v1.ConnectivityPolicyList { return &v1.ConnectivityPolicyList{} },
+			func(dst, src *v1.ConnectivityPolicyList) { dst.ListMeta = src.ListMeta },
+			func(list *v1.ConnectivityPolicyList) []*v1.ConnectivityPolicy {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1.ConnectivityPolicyList, items []*v1.ConnectivityPolicy) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -38,6 +38,10 @@ func (c *FakeSubmarinerV1) ClusterGlobalEgressIPs(namespace string) v1.ClusterGl
 	return newFakeClusterGlobalEgressIPs(c, namespace)
 }
 
+func (c *FakeSubmarinerV1) ConnectivityPolicies(namespace string) v1.ConnectivityPolicyInterface {
+	return newFakeConnectivityPolicies(c, namespace)
+}
+
 func (c *FakeSubmarinerV1) Endpoints(namespace string) v1.EndpointInterface {
 	return newFakeEndpoints(c, namespace)
 }
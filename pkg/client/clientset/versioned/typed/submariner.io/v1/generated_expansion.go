@@ -24,6 +24,8 @@ type ClusterExpansion interface{}
 
 type ClusterGlobalEgressIPExpansion interface{}
 
+type ConnectivityPolicyExpansion interface{}
+
 type EndpointExpansion interface{}
 
 type GatewayExpansion interface{}
@@ -32,6 +32,7 @@ type SubmarinerV1Interface interface {
 	RESTClient() rest.Interface
 	ClustersGetter
 	ClusterGlobalEgressIPsGetter
+	ConnectivityPoliciesGetter
 	EndpointsGetter
 	GatewaysGetter
 	GatewayRoutesGetter
@@ -54,6 +55,10 @@ func (c *SubmarinerV1Client) ClusterGlobalEgressIPs(namespace string) ClusterGlo
 	return newClusterGlobalEgressIPs(c, namespace)
 }
 
+func (c *SubmarinerV1Client) ConnectivityPolicies(namespace string) ConnectivityPolicyInterface {
+	return newConnectivityPolicies(c, namespace)
+}
+
 func (c *SubmarinerV1Client) Endpoints(namespace string) EndpointInterface {
 	return newEndpoints(c, namespace)
 }
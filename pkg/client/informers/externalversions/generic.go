@@ -59,6 +59,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Submariner().V1().Clusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterglobalegressips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Submariner().V1().ClusterGlobalEgressIPs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("connectivitypolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Submariner().V1().ConnectivityPolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("endpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Submariner().V1().Endpoints().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gateways"):
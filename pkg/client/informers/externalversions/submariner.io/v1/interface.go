@@ -30,6 +30,8 @@ type Interface interface {
 	Clusters() ClusterInformer
 	// ClusterGlobalEgressIPs returns a ClusterGlobalEgressIPInformer.
 	ClusterGlobalEgressIPs() ClusterGlobalEgressIPInformer
+	// ConnectivityPolicies returns a ConnectivityPolicyInformer.
+	ConnectivityPolicies() ConnectivityPolicyInformer
 	// Endpoints returns a EndpointInformer.
 	Endpoints() EndpointInformer
 	// Gateways returns a GatewayInformer.
@@ -67,6 +69,11 @@ func (v *version) ClusterGlobalEgressIPs() ClusterGlobalEgressIPInformer {
 	return &clusterGlobalEgressIPInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ConnectivityPolicies returns a ConnectivityPolicyInformer.
+func (v *version) ConnectivityPolicies() ConnectivityPolicyInformer {
+	return &connectivityPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Endpoints returns a EndpointInformer.
 func (v *version) Endpoints() EndpointInformer {
 	return &endpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,72 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	submarineriov1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ConnectivityPolicyLister helps list ConnectivityPolicies.
+// All objects returned here must be treated as read-only.
+type ConnectivityPolicyLister interface {
+	// List lists all ConnectivityPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*submarineriov1.ConnectivityPolicy, err error)
+	// ConnectivityPolicies returns an object that can list and get ConnectivityPolicies.
+	ConnectivityPolicies(namespace string) ConnectivityPolicyNamespaceLister
+	ConnectivityPolicyListerExpansion
+}
+
+// connectivityPolicyLister implements the ConnectivityPolicyLister interface.
+type connectivityPolicyLister struct {
+	listers.ResourceIndexer[*submarineriov1.ConnectivityPolicy]
+}
+
+// NewConnectivityPolicyLister returns a new ConnectivityPolicyLister.
+func NewConnectivityPolicyLister(indexer cache.Indexer) ConnectivityPolicyLister {
+	return &connectivityPolicyLister{listers.New[*submarineriov1.ConnectivityPolicy](indexer, submarineriov1.Resource("connectivitypolicy"))}
+}
+
+// ConnectivityPolicies returns an object that can list and get ConnectivityPolicies.
+func (s *connectivityPolicyLister) ConnectivityPolicies(namespace string) ConnectivityPolicyNamespaceLister {
+	return connectivityPolicyNamespaceLister{listers.NewNamespaced[*submarineriov1.ConnectivityPolicy](s.ResourceIndexer, namespace)}
+}
+
+// ConnectivityPolicyNamespaceLister helps list and get ConnectivityPolicies.
+// All objects returned here must be treated as read-only.
+type ConnectivityPolicyNamespaceLister interface {
+	// List lists all ConnectivityPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*submarineriov1.ConnectivityPolicy, err error)
+	// Get retrieves the ConnectivityPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*submarineriov1.ConnectivityPolicy, error)
+	ConnectivityPolicyNamespaceListerExpansion
+}
+
+// connectivityPolicyNamespaceLister implements the ConnectivityPolicyNamespaceLister
+// interface.
+type connectivityPolicyNamespaceLister struct {
+	listers.ResourceIndexer[*submarineriov1.ConnectivityPolicy]
+}
@@ -36,6 +36,14 @@ type ClusterGlobalEgressIPListerExpansion interface{}
 // ClusterGlobalEgressIPNamespaceLister.
 type ClusterGlobalEgressIPNamespaceListerExpansion interface{}
 
+// ConnectivityPolicyListerExpansion allows custom methods to be added to
+// ConnectivityPolicyLister.
+type ConnectivityPolicyListerExpansion interface{}
+
+// ConnectivityPolicyNamespaceListerExpansion allows custom methods to be added to
+// ConnectivityPolicyNamespaceLister.
+type ConnectivityPolicyNamespaceListerExpansion interface{}
+
 // EndpointListerExpansion allows custom methods to be added to
 // EndpointLister.
 type EndpointListerExpansion interface{}
@@ -66,12 +66,11 @@ func discover(clusterCIDRs []string) (*Interface, error) {
 				ipAddr, _, err := net.ParseCIDR(addrs[i].String())
 				if err != nil {
 					logger.Errorf(err, "Unable to ParseCIDR : %q", addrs[i].String())
-				} else if ipAddr.To4() != nil {
+				} else {
 					logger.V(log.DEBUG).Infof("Interface %q has %q address", iface.Name, ipAddr)
-					address := net.ParseIP(ipAddr.String())
 
 					// Verify that interface has an address from cluster CIDR
-					if clusterNetwork.Contains(address) {
+					if clusterNetwork.Contains(ipAddr) {
 						logger.V(log.DEBUG).Infof("Found CNI Interface %q that has IP %q from ClusterCIDR %q",
 							iface.Name, ipAddr, clusterCIDR)
 						return &Interface{IPAddress: ipAddr.String(), Name: iface.Name}, nil
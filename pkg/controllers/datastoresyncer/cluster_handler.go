@@ -0,0 +1,119 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastoresyncer
+
+import (
+	"context"
+	"net"
+	"slices"
+
+	"github.com/submariner-io/admiral/pkg/syncer/broker"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cidr"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// handleCreateOrUpdateCluster advertises the GlobalCIDRs added to the local Cluster at runtime in the local Endpoint's
+// subnets so remote clusters route the traffic destined to the global IPs allocated from them.
+func (d *DatastoreSyncer) handleCreateOrUpdateCluster(obj runtime.Object, _ int) bool {
+	err := d.addGlobalCIDRs(context.TODO(), obj.(*submarinerv1.Cluster).Spec.GlobalCIDR)
+	if err != nil {
+		logger.Warningf("Error updating the local submariner Endpoint with the added GlobalCIDRs: %v", err)
+		return true
+	}
+
+	return false
+}
+
+// retainAddedGlobalCIDRs retains the GlobalCIDRs appended at runtime to the configured ones in the existing local
+// Cluster so they aren't lost when the Cluster is re-created on startup.
+func (d *DatastoreSyncer) retainAddedGlobalCIDRs(ctx context.Context, syncer *broker.Syncer) error {
+	clusters := syncer.ListLocalResources(&submarinerv1.Cluster{})
+	for i := range clusters {
+		existing := clusters[i].(*submarinerv1.Cluster)
+		if existing.Spec.ClusterID != d.localCluster.Spec.ClusterID {
+			continue
+		}
+
+		configured := d.globalCIDRs()
+		if len(existing.Spec.GlobalCIDR) > len(configured) &&
+			slices.Equal(existing.Spec.GlobalCIDR[:len(configured)], configured) {
+			return d.addGlobalCIDRs(ctx, existing.Spec.GlobalCIDR)
+		}
+	}
+
+	return nil
+}
+
+func (d *DatastoreSyncer) addGlobalCIDRs(ctx context.Context, globalCIDRs []string) error {
+	existing := d.globalCIDRs()
+
+	var added []string
+
+	for _, globalCIDR := range globalCIDRs {
+		if slices.Contains(existing, globalCIDR) {
+			continue
+		}
+
+		// Globalnet ignores invalid and overlapping GlobalCIDRs so don't advertise them either.
+		if _, _, err := net.ParseCIDR(globalCIDR); err != nil {
+			logger.Errorf(err, "Ignoring invalid GlobalCIDR %q added to the local Cluster", globalCIDR)
+			continue
+		}
+
+		if overlap, _ := cidr.IsOverlapping(existing, globalCIDR); overlap {
+			logger.Errorf(nil, "Ignoring GlobalCIDR %q added to the local Cluster that overlaps with %v", globalCIDR, existing)
+			continue
+		}
+
+		existing = append(existing, globalCIDR)
+		added = append(added, globalCIDR)
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	logger.Infof("Advertising the GlobalCIDRs %v added to the local Cluster in the local Endpoint", added)
+
+	err := d.localEndpoint.Update(ctx, func(spec *submarinerv1.EndpointSpec) {
+		for _, globalCIDR := range added {
+			if !slices.Contains(spec.Subnets, globalCIDR) {
+				spec.Subnets = append(spec.Subnets, globalCIDR)
+			}
+		}
+	})
+	if err != nil {
+		return err //nolint:wrapcheck  // Let the caller wrap it
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.localCluster.Spec.GlobalCIDR = append(slices.Clone(d.localCluster.Spec.GlobalCIDR), added...)
+
+	return nil
+}
+
+func (d *DatastoreSyncer) globalCIDRs() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return slices.Clone(d.localCluster.Spec.GlobalCIDR)
+}
@@ -59,6 +59,40 @@ func testClusterSyncing() {
 			})
 		})
 
+		When("the existing local Cluster has GlobalCIDRs appended at runtime", func() {
+			var expCluster *submarinerv1.ClusterSpec
+
+			BeforeEach(func() {
+				expCluster = t.localCluster.Spec.DeepCopy()
+				expCluster.GlobalCIDR = append(expCluster.GlobalCIDR, "201.0.0.0/16")
+				test.CreateResource(t.localClusters, newCluster(expCluster))
+			})
+
+			It("should retain them and advertise them in the local Endpoint", func() {
+				awaitCluster(t.localClusters, expCluster)
+				awaitCluster(t.brokerClusters, expCluster)
+
+				expEndpoint := t.localEndpoint.DeepCopy()
+				expEndpoint.Subnets = append(expEndpoint.Subnets, "201.0.0.0/16")
+				awaitEndpoint(t.localEndpoints, expEndpoint)
+			})
+		})
+
+		When("the local Cluster has labels and a topology policy", func() {
+			BeforeEach(func() {
+				t.localCluster.Spec.Labels = map[string]string{"region": "east"}
+				t.localCluster.Spec.Topology = &submarinerv1.TopologyPolicy{
+					Mode: submarinerv1.TopologyHubSpoke,
+					Hubs: []submarinerv1.ClusterSelector{{ClusterIDs: []string{"hub"}}},
+				}
+			})
+
+			It("should sync them to the broker", func() {
+				awaitCluster(t.localClusters, &t.localCluster.Spec)
+				awaitCluster(t.brokerClusters, &t.localCluster.Spec)
+			})
+		})
+
 		When("creation of the local Cluster fails", func() {
 			BeforeEach(func() {
 				t.expectedStartErr = errors.New("mock Create error")
@@ -70,6 +104,22 @@ func testClusterSyncing() {
 		})
 	})
 
+	When("a GlobalCIDR is added to the local Cluster", func() {
+		It("should advertise it in the local Endpoint and sync to the broker", func() {
+			awaitEndpoint(t.localEndpoints, t.localEndpoint)
+
+			cluster := newCluster(t.localCluster.Spec.DeepCopy())
+			cluster.Spec.GlobalCIDR = append(cluster.Spec.GlobalCIDR, "201.0.0.0/16", "201.0.1.0/24")
+			test.UpdateResource(t.localClusters, cluster)
+
+			expEndpoint := t.localEndpoint.DeepCopy()
+			expEndpoint.Subnets = append(expEndpoint.Subnets, "201.0.0.0/16")
+			awaitEndpoint(t.localEndpoints, expEndpoint)
+			awaitEndpoint(t.brokerEndpoints, expEndpoint)
+			awaitCluster(t.brokerClusters, &cluster.Spec)
+		})
+	})
+
 	When("a local Cluster is deleted", func() {
 		It("should delete it from the broker", func() {
 			awaitCluster(t.brokerClusters, &t.localCluster.Spec)
@@ -50,6 +50,7 @@ func testEndpointSyncing() {
 		It("should create a new Endpoint locally and sync to the broker", func() {
 			awaitEndpoint(t.localEndpoints, t.localEndpoint)
 			awaitEndpoint(t.brokerEndpoints, t.localEndpoint)
+			t.awaitSyncStatus(Equal("<nil>"))
 		})
 
 		When("creation of the local Endpoint fails", func() {
@@ -58,7 +59,26 @@ func testEndpointSyncing() {
 				fake.FailOnAction(&t.localClient.Fake, "endpoints", "create", t.expectedStartErr, false)
 			})
 
-			It("Start should return an error", func() {
+			It("Start should return an error and report it", func() {
+				t.awaitSyncStatus(ContainSubstring(t.expectedStartErr.Error()))
+			})
+		})
+
+		When("syncing the local Endpoint to the broker fails", func() {
+			var reactor *fake.FailOnActionReactor
+
+			BeforeEach(func() {
+				reactor = fake.FailOnAction(&t.brokerClient.Fake, "endpoints", "create", nil, false)
+			})
+
+			It("should report the sync failure until it succeeds", func() {
+				awaitEndpoint(t.localEndpoints, t.localEndpoint)
+				t.awaitSyncStatus(ContainSubstring("error syncing Endpoint"))
+
+				reactor.Fail(false)
+
+				awaitEndpoint(t.brokerEndpoints, t.localEndpoint)
+				t.awaitSyncStatus(Equal("<nil>"))
 			})
 		})
 
@@ -218,6 +238,42 @@ func testEndpointExclusivity() {
 		})
 	})
 
+	When("the local Endpoint is active/active and Endpoints initially exist for other gateways", func() {
+		var (
+			otherGatewayEndpoint *submarinerv1.Endpoint
+			staleEndpoint        *submarinerv1.Endpoint
+		)
+
+		BeforeEach(func() {
+			t.localEndpoint.BackendConfig = map[string]string{submarinerv1.ActiveActive: "true"}
+
+			otherGatewayEndpoint = newEndpoint(&submarinerv1.EndpointSpec{
+				CableName:     "submariner-cable-east-1-2-3-4",
+				ClusterID:     clusterID,
+				Hostname:      "yankees",
+				BackendConfig: map[string]string{submarinerv1.ActiveActive: "true"},
+			})
+
+			staleEndpoint = newEndpoint(&submarinerv1.EndpointSpec{
+				CableName: "submariner-cable-east-5-6-7-8",
+				ClusterID: clusterID,
+				Hostname:  t.localEndpoint.Hostname,
+			})
+
+			test.CreateResource(t.localEndpoints, otherGatewayEndpoint)
+			test.CreateResource(t.localEndpoints, staleEndpoint)
+		})
+
+		AfterEach(func() {
+			t.localEndpoint.BackendConfig = nil
+		})
+
+		It("should only delete the previous Endpoint for the local host", func() {
+			test.AwaitNoResource(t.localEndpoints, staleEndpoint.GetName())
+			test.AwaitResource(t.localEndpoints, otherGatewayEndpoint.GetName())
+		})
+	})
+
 	When("an Endpoint from another cluster initially exists", func() {
 		var remoteEndpointName string
 
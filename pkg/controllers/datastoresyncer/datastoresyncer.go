@@ -20,6 +20,7 @@ package datastoresyncer
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
@@ -44,15 +45,25 @@ import (
 )
 
 type DatastoreSyncer struct {
+	mutex         sync.Mutex // Protects localCluster.Spec.GlobalCIDR
 	localCluster  types.SubmarinerCluster
 	localEndpoint *endpoint.Local
 	syncerConfig  broker.SyncerConfig
+	onSyncStatus  func(err error)
+	statusMutex   sync.Mutex // Protects the fields below
+	started       bool
+	startErr      error
+	syncFailures  map[string]error
+	// The last status reported to onSyncStatus, nil if none was reported yet.
+	reportedStatus *string
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("DSSyncer")}
 
+// New creates a DatastoreSyncer. If set, onSyncStatus is called with the broker sync status, nil if synced, once started
+// and whenever it changes.
 func New(syncerConfig *broker.SyncerConfig, localCluster *types.SubmarinerCluster,
-	localEndpoint *endpoint.Local,
+	localEndpoint *endpoint.Local, onSyncStatus func(err error),
 ) *DatastoreSyncer {
 	// We'll panic if syncerConfig, localCluster or localEndpoint are nil, this is intentional
 	syncerConfig.LocalClusterID = localCluster.Spec.ClusterID
@@ -61,12 +72,23 @@ func New(syncerConfig *broker.SyncerConfig, localCluster *types.SubmarinerCluste
 		localCluster:  *localCluster,
 		localEndpoint: localEndpoint,
 		syncerConfig:  *syncerConfig,
+		onSyncStatus:  onSyncStatus,
+		syncFailures:  map[string]error{},
 	}
 }
 
 func (d *DatastoreSyncer) Start(ctx context.Context) error {
 	defer utilruntime.HandleCrash()
 
+	err := d.start(ctx)
+	if !errors.Is(err, context.Canceled) {
+		d.setStarted(err)
+	}
+
+	return err
+}
+
+func (d *DatastoreSyncer) start(ctx context.Context) error {
 	logger.Info("Starting the datastore syncer")
 
 	syncer, err := d.createSyncer()
@@ -83,6 +105,12 @@ func (d *DatastoreSyncer) Start(ctx context.Context) error {
 		return errors.WithMessage(err, "could not ensure exclusive submariner Endpoint")
 	}
 
+	if len(d.localCluster.Spec.GlobalCIDR) > 0 {
+		if err := d.retainAddedGlobalCIDRs(ctx, syncer); err != nil {
+			return errors.WithMessage(err, "error retaining the GlobalCIDRs added to the local submariner Cluster")
+		}
+	}
+
 	if err := d.createLocalCluster(ctx, syncer.GetLocalFederator()); err != nil {
 		return errors.WithMessage(err, "error creating the local submariner Cluster")
 	}
@@ -95,6 +123,10 @@ func (d *DatastoreSyncer) Start(ctx context.Context) error {
 		if err := d.startGatewayWatcher(ctx.Done()); err != nil {
 			return errors.WithMessage(err, "startGatewayWatcher returned error")
 		}
+
+		if err := d.startClusterWatcher(ctx.Done()); err != nil {
+			return errors.WithMessage(err, "startClusterWatcher returned error")
+		}
 	}
 
 	logger.Info("Datastore syncer started")
@@ -166,15 +198,19 @@ func (d *DatastoreSyncer) cleanupResources(ctx context.Context, client dynamic.N
 func (d *DatastoreSyncer) createSyncer() (*broker.Syncer, error) {
 	d.syncerConfig.ResourceConfigs = []broker.ResourceConfig{
 		{
-			LocalSourceNamespace: d.syncerConfig.LocalNamespace,
-			LocalResourceType:    &submarinerv1.Cluster{},
-			BrokerResourceType:   &submarinerv1.Cluster{},
+			LocalSourceNamespace:     d.syncerConfig.LocalNamespace,
+			LocalResourceType:        &submarinerv1.Cluster{},
+			TransformLocalToBroker:   d.syncingToBroker,
+			OnSuccessfulSyncToBroker: d.syncedToBroker,
+			BrokerResourceType:       &submarinerv1.Cluster{},
 		},
 		{
-			LocalSourceNamespace:   d.syncerConfig.LocalNamespace,
-			LocalResourceType:      &submarinerv1.Endpoint{},
-			TransformBrokerToLocal: d.shouldSyncRemoteEndpoint,
-			BrokerResourceType:     &submarinerv1.Endpoint{},
+			LocalSourceNamespace:     d.syncerConfig.LocalNamespace,
+			LocalResourceType:        &submarinerv1.Endpoint{},
+			TransformLocalToBroker:   d.syncingToBroker,
+			OnSuccessfulSyncToBroker: d.syncedToBroker,
+			TransformBrokerToLocal:   d.shouldSyncRemoteEndpoint,
+			BrokerResourceType:       &submarinerv1.Endpoint{},
 		},
 	}
 
@@ -205,7 +241,7 @@ func (d *DatastoreSyncer) shouldSyncRemoteEndpoint(obj runtime.Object, _ int,
 }
 
 func (d *DatastoreSyncer) ensureExclusiveEndpoint(ctx context.Context, syncer *broker.Syncer) error {
-	logger.Info("Ensuring we are the only endpoint active for this cluster")
+	logger.Info("Ensuring we are the only endpoint active for this cluster or host")
 
 	endpoints := syncer.ListLocalResources(&submarinerv1.Endpoint{})
 	for i := range endpoints {
@@ -218,6 +254,11 @@ func (d *DatastoreSyncer) ensureExclusiveEndpoint(ctx context.Context, syncer *b
 			continue
 		}
 
+		// In active/active mode the other gateways' Endpoints are expected - only remove previous Endpoints for this host.
+		if d.localEndpoint.Spec().IsActiveActive() && existing.Spec.Hostname != d.localEndpoint.Spec().Hostname {
+			continue
+		}
+
 		err := syncer.GetLocalFederator().Delete(ctx, existing)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return errors.Wrapf(err, "error deleting submariner Endpoint %q from the local datastore", existing.Name)
@@ -262,15 +303,51 @@ func (d *DatastoreSyncer) startGatewayWatcher(stopCh <-chan struct{}) error {
 	return nil
 }
 
+func (d *DatastoreSyncer) startClusterWatcher(stopCh <-chan struct{}) error {
+	resourceWatcher, err := watcher.New(&watcher.Config{
+		Scheme:     scheme.Scheme,
+		RestConfig: d.syncerConfig.LocalRestConfig,
+		RestMapper: d.syncerConfig.RestMapper,
+		Client:     d.syncerConfig.LocalClient,
+		ResourceConfigs: []watcher.ResourceConfig{
+			{
+				Name:            "Cluster watcher for datastoresyncer",
+				ResourceType:    &submarinerv1.Cluster{},
+				SourceNamespace: d.syncerConfig.LocalNamespace,
+				SourceFieldSelector: fields.Set(map[string]string{
+					"metadata.name": resource.EnsureValidName(d.localCluster.Spec.ClusterID),
+				}).AsSelector().String(),
+				Handler: watcher.EventHandlerFuncs{
+					OnCreateFunc: d.handleCreateOrUpdateCluster,
+					OnUpdateFunc: d.handleCreateOrUpdateCluster,
+					OnDeleteFunc: nil,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating Cluster resource watcher")
+	}
+
+	err = resourceWatcher.Start(stopCh)
+	if err != nil {
+		return errors.Wrap(err, "error starting the Cluster resource watcher")
+	}
+
+	return nil
+}
+
 func (d *DatastoreSyncer) createLocalCluster(ctx context.Context, federator federate.Federator) error {
 	logger.Infof("Creating local submariner Cluster: %s", resource.ToJSON(d.localCluster))
 
+	d.mutex.Lock()
 	cluster := &submarinerv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: resource.EnsureValidName(d.localCluster.Spec.ClusterID),
 		},
-		Spec: d.localCluster.Spec,
+		Spec: *d.localCluster.Spec.DeepCopy(),
 	}
+	d.mutex.Unlock()
 
 	return federator.Distribute(ctx, cluster) //nolint:wrapcheck  // Let the caller wrap it
 }
@@ -22,11 +22,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/fake"
 	. "github.com/submariner-io/admiral/pkg/gomega"
@@ -89,6 +91,7 @@ type testDriver struct {
 	startCompleted   chan error
 	expectedStartErr error
 	doStart          bool
+	syncStatus       *atomic.Value
 }
 
 func newTestDriver() *testDriver {
@@ -115,6 +118,8 @@ func newTestDriver() *testDriver {
 	BeforeEach(func() {
 		t.expectedStartErr = nil
 		t.doStart = true
+		t.syncStatus = &atomic.Value{}
+		t.syncStatus.Store("")
 
 		t.syncerScheme = runtime.NewScheme()
 		Expect(submarinerv1.AddToScheme(t.syncerScheme)).To(Succeed())
@@ -152,6 +157,8 @@ func newTestDriver() *testDriver {
 }
 
 func (t *testDriver) run() {
+	syncStatus := t.syncStatus
+
 	t.syncer = datastoresyncer.New(&broker.SyncerConfig{
 		LocalClient:     t.localClient,
 		LocalNamespace:  localNamespace,
@@ -160,7 +167,9 @@ func (t *testDriver) run() {
 		BrokerNamespace: brokerNamespace,
 		RestMapper:      t.restMapper,
 		Scheme:          t.syncerScheme,
-	}, t.localCluster, endpoint.NewLocal(t.localEndpoint, t.localClient, localNamespace))
+	}, t.localCluster, endpoint.NewLocal(t.localEndpoint, t.localClient, localNamespace), func(err error) {
+		syncStatus.Store(fmt.Sprint(err))
+	})
 
 	if t.doStart {
 		var ctx context.Context
@@ -174,6 +183,13 @@ func (t *testDriver) run() {
 	}
 }
 
+// awaitSyncStatus awaits the last reported broker sync status, formatted as a string, "<nil>" if synced.
+func (t *testDriver) awaitSyncStatus(matcher gomegatypes.GomegaMatcher) {
+	Eventually(func() string {
+		return t.syncStatus.Load().(string)
+	}, 5).Should(matcher)
+}
+
 func (t *testDriver) stop() {
 	if !t.doStart {
 		return
@@ -0,0 +1,112 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastoresyncer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	resourceSyncer "github.com/submariner-io/admiral/pkg/syncer"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// syncingToBroker is invoked before each attempt to sync a local resource to the broker. The syncer only retries after a
+// failure so a requeued attempt means the resource isn't synced.
+func (d *DatastoreSyncer) syncingToBroker(obj runtime.Object, numRequeues int, _ resourceSyncer.Operation) (runtime.Object, bool) {
+	if numRequeues > 0 {
+		key := syncKey(obj)
+
+		d.statusMutex.Lock()
+		d.syncFailures[key] = errors.Errorf("error syncing %s to the broker, retried %d times", key, numRequeues)
+		d.statusMutex.Unlock()
+
+		d.reportSyncStatus()
+	}
+
+	return obj, false
+}
+
+func (d *DatastoreSyncer) syncedToBroker(obj runtime.Object, _ resourceSyncer.Operation) bool {
+	key := syncKey(obj)
+
+	d.statusMutex.Lock()
+	_, failed := d.syncFailures[key]
+	delete(d.syncFailures, key)
+	d.statusMutex.Unlock()
+
+	if failed {
+		d.reportSyncStatus()
+	}
+
+	return false
+}
+
+func (d *DatastoreSyncer) setStarted(err error) {
+	d.statusMutex.Lock()
+	d.started = true
+	d.startErr = err
+	d.statusMutex.Unlock()
+
+	d.reportSyncStatus()
+}
+
+// reportSyncStatus reports the broker sync status, once started, whenever it changes.
+func (d *DatastoreSyncer) reportSyncStatus() {
+	d.statusMutex.Lock()
+	defer d.statusMutex.Unlock()
+
+	if !d.started || d.onSyncStatus == nil {
+		return
+	}
+
+	err := d.startErr
+	if err == nil && len(d.syncFailures) > 0 {
+		keys := make([]string, 0, len(d.syncFailures))
+		for key := range d.syncFailures {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		err = d.syncFailures[keys[0]]
+	}
+
+	status := ""
+	if err != nil {
+		status = err.Error()
+	}
+
+	if d.reportedStatus != nil && *d.reportedStatus == status {
+		return
+	}
+
+	d.reportedStatus = &status
+	d.onSyncStatus(err)
+}
+
+func syncKey(obj runtime.Object) string {
+	name := ""
+	if objMeta, err := meta.Accessor(obj); err == nil {
+		name = objMeta.GetName()
+	}
+
+	return fmt.Sprintf("%s %q", reflect.TypeOf(obj).Elem().Name(), name)
+}
@@ -52,6 +52,16 @@ func StartController(engine cableengine.Engine, namespace string, config *watche
 			},
 			SourceNamespace: namespace,
 		},
+		{
+			Name:         "Tunnel Controller Clusters",
+			ResourceType: &v1.Cluster{},
+			Handler: watcher.EventHandlerFuncs{
+				OnCreateFunc: c.handleCreatedOrUpdatedCluster,
+				OnUpdateFunc: c.handleCreatedOrUpdatedCluster,
+				OnDeleteFunc: c.handleRemovedCluster,
+			},
+			SourceNamespace: namespace,
+		},
 	}
 
 	if config.ResyncPeriod == 0 {
@@ -60,12 +70,12 @@ func StartController(engine cableengine.Engine, namespace string, config *watche
 
 	endpointWatcher, err := watcher.New(config)
 	if err != nil {
-		return errors.Wrap(err, "error creating the Endpoint watcher")
+		return errors.Wrap(err, "error creating the Endpoint and Cluster watcher")
 	}
 
 	err = endpointWatcher.Start(stopCh)
 	if err != nil {
-		return errors.Wrap(err, "error starting the Endpoint watcher")
+		return errors.Wrap(err, "error starting the Endpoint and Cluster watcher")
 	}
 
 	return nil
@@ -99,3 +109,29 @@ func (c *controller) handleRemovedEndpoint(obj runtime.Object, _ int) bool {
 
 	return false
 }
+
+func (c *controller) handleCreatedOrUpdatedCluster(obj runtime.Object, _ int) bool {
+	cluster := obj.(*v1.Cluster)
+
+	logger.V(log.TRACE).Infof("Tunnel controller processing added or updated submariner Cluster object: %#v", cluster)
+
+	if err := c.engine.SetRemoteCluster(cluster); err != nil {
+		logger.Errorf(err, "Error applying the topology policy of Cluster %q", cluster.Spec.ClusterID)
+		return true
+	}
+
+	return false
+}
+
+func (c *controller) handleRemovedCluster(obj runtime.Object, _ int) bool {
+	cluster := obj.(*v1.Cluster)
+
+	logger.V(log.DEBUG).Infof("Tunnel controller processing removed submariner Cluster object: %#v", cluster)
+
+	if err := c.engine.RemoveRemoteCluster(cluster); err != nil {
+		logger.Errorf(err, "Error removing the topology policy of Cluster %q", cluster.Spec.ClusterID)
+		return true
+	}
+
+	return false
+}
@@ -57,7 +57,7 @@ var fakeDriver *fake.Driver
 
 var _ = BeforeSuite(func() {
 	kzerolog.InitK8sLogging()
-	cable.AddDriver(fake.DriverName, func(_ *submendpoint.Local, _ *types.SubmarinerCluster) (cable.Driver, error) {
+	cable.AddDriver(fake.DriverName, func(_ *submendpoint.Local, _ *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
 		return fakeDriver, nil
 	})
 })
@@ -66,7 +66,9 @@ var _ = Describe("Managing tunnels", func() {
 	var (
 		config    *watcher.Config
 		endpoints dynamic.ResourceInterface
+		clusters  dynamic.ResourceInterface
 		endpoint  *v1.Endpoint
+		cluster   *v1.Cluster
 		stopCh    chan struct{}
 	)
 
@@ -86,6 +88,16 @@ var _ = Describe("Managing tunnels", func() {
 			},
 		}
 
+		cluster = &v1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "east",
+				Namespace: namespace,
+			},
+			Spec: v1.ClusterSpec{
+				ClusterID: "east",
+			},
+		}
+
 		Expect(v1.AddToScheme(kubeScheme.Scheme)).To(Succeed())
 
 		scheme := runtime.NewScheme()
@@ -97,6 +109,7 @@ var _ = Describe("Managing tunnels", func() {
 		gvr := test.GetGroupVersionResourceFor(restMapper, &v1.Endpoint{})
 
 		endpoints = client.Resource(*gvr).Namespace(namespace)
+		clusters = client.Resource(*test.GetGroupVersionResourceFor(restMapper, &v1.Cluster{})).Namespace(namespace)
 
 		config = &watcher.Config{
 			RestMapper: restMapper,
@@ -106,13 +119,17 @@ var _ = Describe("Managing tunnels", func() {
 	})
 
 	JustBeforeEach(func() {
+		if cluster != nil {
+			test.CreateResource(clusters, cluster)
+		}
+
 		localEp := submendpoint.NewLocal(&v1.EndpointSpec{
 			Backend: fake.DriverName,
 		}, fakeClient.NewSimpleDynamicClient(kubeScheme.Scheme), "")
 
-		engine := cableengine.NewEngine(&types.SubmarinerCluster{}, localEp)
+		engine := cableengine.NewEngine(&types.SubmarinerCluster{}, localEp, &types.SubmarinerSpecification{}, nil)
 
-		nat, err := natdiscovery.New(localEp)
+		nat, err := natdiscovery.New(localEp, &types.SubmarinerSpecification{})
 		Expect(err).To(Succeed())
 
 		engine.SetupNATDiscovery(nat)
@@ -169,6 +186,36 @@ var _ = Describe("Managing tunnels", func() {
 		})
 	})
 
+	When("the remote Cluster's topology policy doesn't allow a direct cable", func() {
+		It("should remove the cable", func() {
+			test.CreateResource(endpoints, endpoint)
+			verifyConnectToEndpoint()
+
+			cluster.Spec.Topology = &v1.TopologyPolicy{Mode: v1.TopologyPartialMesh}
+			test.UpdateResource(clusters, cluster)
+
+			verifyDisconnectFromEndpoint()
+		})
+	})
+
+	When("an Endpoint is created before its Cluster", func() {
+		var pendingCluster *v1.Cluster
+
+		BeforeEach(func() {
+			pendingCluster = cluster
+			cluster = nil
+		})
+
+		It("should install the cable and apply the Cluster's topology policy once it's created", func() {
+			test.CreateResource(endpoints, endpoint)
+			verifyConnectToEndpoint()
+
+			pendingCluster.Spec.Topology = &v1.TopologyPolicy{Mode: v1.TopologyPartialMesh}
+			test.CreateResource(clusters, pendingCluster)
+			verifyDisconnectFromEndpoint()
+		})
+	})
+
 	When("install cable initially fails", func() {
 		BeforeEach(func() {
 			config.ResyncPeriod = time.Millisecond * 500
@@ -155,9 +155,8 @@ func GetLocalSpec(ctx context.Context, submSpec *types.SubmarinerSpecification,
 		localSubnets = submSpec.GlobalCidr
 		globalnetEnabled = true
 	} else {
-		// TODO_IPV6: set localSubnets to submSpec.ServiceCidr + submSpec.ClusterCidr
-		localSubnets = append(localSubnets, cidr.ExtractIPv4Subnets(submSpec.ServiceCidr)...)
-		localSubnets = append(localSubnets, cidr.ExtractIPv4Subnets(submSpec.ClusterCidr)...)
+		localSubnets = append(localSubnets, submSpec.ServiceCidr...)
+		localSubnets = append(localSubnets, submSpec.ClusterCidr...)
 	}
 
 	backendConfig, err := getBackendConfig(gwNode)
@@ -169,6 +168,26 @@ func GetLocalSpec(ctx context.Context, submSpec *types.SubmarinerSpecification,
 		backendConfig[submv1.UsingLoadBalancer] = "true"
 	}
 
+	if submSpec.ActiveActive {
+		backendConfig[submv1.ActiveActive] = "true"
+	}
+
+	if submSpec.HealthCheckEnabled && submSpec.HealthCheckProbeEnabled {
+		// The remote gateways probe the connection quality on this port.
+		backendConfig[submv1.HealthCheckProbePortConfig] = strconv.Itoa(submSpec.HealthCheckProbePort)
+	}
+
+	topology, err := submSpec.GetTopologyPolicy()
+	if err != nil {
+		return nil, err //nolint:wrapcheck  // No need to wrap this error
+	}
+
+	localCluster := submv1.ClusterSpec{ClusterID: submSpec.ClusterID, Labels: submSpec.ClusterLabels, Topology: topology}
+	if localCluster.IsHub() {
+		// The hubs relay the traffic between the spokes.
+		backendConfig[submv1.RelayConfig] = "true"
+	}
+
 	endpointSpec := &submv1.EndpointSpec{
 		ClusterID:     submSpec.ClusterID,
 		Hostname:      hostname,
@@ -183,10 +202,16 @@ func GetLocalSpec(ctx context.Context, submSpec *types.SubmarinerSpecification,
 	}
 
 	endpointSpec.CableName = fmt.Sprintf("submariner-cable-%s-%s", submSpec.ClusterID,
-		strings.ReplaceAll(endpointSpec.GetPrivateIP(k8snet.IPv4), ".", "-"))
+		cableNameSuffixFrom(endpointSpec.GetPrivateIP(submSpec.GetIPFamilies()[0])))
 
-	for _, family := range submSpec.GetIPFamilies() {
+	for i, family := range submSpec.GetIPFamilies() {
 		publicIP, resolver, err := getPublicIP(family, submSpec, k8sClient, backendConfig, airGappedDeployment)
+		if err != nil && i > 0 {
+			// Only the primary IP family is mandatory, a secondary family may well not have public connectivity.
+			logger.Warningf("Could not determine public IP%v, the secondary family will only use private IPs: %v", family, err)
+			continue
+		}
+
 		if err != nil {
 			return nil, errors.Wrapf(err, "could not determine public IP%v", family)
 		}
@@ -201,7 +226,7 @@ func GetLocalSpec(ctx context.Context, submSpec *types.SubmarinerSpecification,
 		// and update the endpoint HealthCheckIP (to globalIP) in datastoreSyncer at a later stage. This will trigger
 		// the HealthCheck between the clusters.
 		for _, family := range submSpec.GetIPFamilies() {
-			healthcheckIP, err := getHealthCheckIP(family, submSpec)
+			healthcheckIP, err := GetHealthCheckIP(family, submSpec)
 			if err != nil {
 				return nil, fmt.Errorf("error getting HealthCheckIP%v: %w", family, err)
 			}
@@ -213,19 +238,22 @@ func GetLocalSpec(ctx context.Context, submSpec *types.SubmarinerSpecification,
 	return endpointSpec, nil
 }
 
-func getHealthCheckIP(family k8snet.IPFamily, submSpec *types.SubmarinerSpecification) (string, error) {
+func cableNameSuffixFrom(privateIP string) string {
+	return strings.NewReplacer(".", "-", ":", "-").Replace(privateIP)
+}
+
+// GetHealthCheckIP returns the IP of the given family of the local CNI interface. It's the local endpoint's health check
+// IP or, with globalnet, the IP the global health check IP is translated to.
+func GetHealthCheckIP(family k8snet.IPFamily, submSpec *types.SubmarinerSpecification) (string, error) {
 	switch family {
-	case k8snet.IPv4:
-		cniIface, err := cni.Discover(submSpec.ClusterCidr)
+	case k8snet.IPv4, k8snet.IPv6:
+		cniIface, err := cni.Discover(cidr.ExtractSubnets(family, submSpec.ClusterCidr))
 		if err != nil {
-			return "", errors.Wrapf(err, "error getting CNI Interface IP address."+
-				"Please disable the health check if your CNI does not expose a pod IP on the nodes")
+			return "", errors.Wrapf(err, "error getting CNI Interface IP%v address."+
+				"Please disable the health check if your CNI does not expose a pod IP on the nodes", family)
 		}
 
 		return cniIface.IPAddress, nil
-	case k8snet.IPv6:
-		// TODO_IPV6: add V6 healthcheck IP
-
 	case k8snet.IPFamilyUnknown:
 	}
 
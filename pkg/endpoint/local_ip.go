@@ -25,15 +25,23 @@ import (
 )
 
 func GetLocalIPForDestination(dst string) string {
-	// Revisit when IPv6 support is added.
-	conn, err := net.Dial("udp4", dst+":53")
+	ip, err := getLocalIPForDestination(dst)
 	logger.FatalOnError(err, "Error getting local IP")
 
+	return ip
+}
+
+func getLocalIPForDestination(dst string) (string, error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(dst, "53"))
+	if err != nil {
+		return "", err //nolint:wrapcheck // No need to wrap here
+	}
+
 	defer conn.Close()
 
 	localAddr := conn.LocalAddr().(*net.UDPAddr)
 
-	return localAddr.IP.String()
+	return localAddr.IP.String(), nil
 }
 
 func GetLocalIP(family k8snet.IPFamily) string {
@@ -41,7 +49,13 @@ func GetLocalIP(family k8snet.IPFamily) string {
 	case k8snet.IPv4:
 		return GetLocalIPForDestination("8.8.8.8")
 	case k8snet.IPv6:
-		// TODO_IPV6: add V6 healthcheck IP
+		// Unlike IPv4, it's not unusual for IPv6 to lack a default route, eg on dual-stack nodes, so don't treat it as fatal.
+		ip, err := getLocalIPForDestination("2001:4860:4860::8888")
+		if err != nil {
+			logger.Errorf(err, "Error getting local IPv6 address")
+		}
+
+		return ip
 	case k8snet.IPFamilyUnknown:
 	}
 
@@ -41,24 +41,41 @@ import (
 	k8snet "k8s.io/utils/net"
 )
 
-type publicIPResolverFunction func(clientset kubernetes.Interface, namespace, value string) (string, error)
+type publicIPResolverFunction func(family k8snet.IPFamily, clientset kubernetes.Interface, namespace, value string) (string, error)
 
 var publicIPMethods = map[string]publicIPResolverFunction{
 	v1.API:          publicAPI,
 	v1.IPv4:         publicIP,
+	v1.IPv6:         publicIP,
 	v1.LoadBalancer: publicLoadBalancerIP,
 	v1.DNS:          publicDNSIP,
 }
 
-var IPv4RE = regexp.MustCompile(`(?:\d{1,3}\.){3}\d{1,3}`)
+// staticIPMethodFamilies maps the static resolver methods to the only IP family they can provide.
+var staticIPMethodFamilies = map[string]k8snet.IPFamily{
+	v1.IPv4: k8snet.IPv4,
+	v1.IPv6: k8snet.IPv6,
+}
+
+var (
+	IPv4RE = regexp.MustCompile(`(?:\d{1,3}\.){3}\d{1,3}`)
+	IPv6RE = regexp.MustCompile(`[0-9a-fA-F]{0,4}(?::[0-9a-fA-F]{0,4}){2,7}`)
+)
 
-func getPublicIPResolvers() string {
+func getPublicIPResolvers(family k8snet.IPFamily) string {
 	serverList := []string{
 		"api:ip4.seeip.org", "api:ipecho.net/plain", "api:ifconfig.me",
 		"api:ipinfo.io/ip", "api:4.ident.me", "api:checkip.amazonaws.com", "api:4.icanhazip.com",
 		"api:myexternalip.com/raw", "api:4.tnedi.me", "api:api.ipify.org",
 	}
 
+	if family == k8snet.IPv6 {
+		serverList = []string{
+			"api:ip6.seeip.org", "api:ifconfig.co", "api:6.ident.me", "api:6.icanhazip.com", "api:6.tnedi.me",
+			"api:api6.ipify.org",
+		}
+	}
+
 	rand.Shuffle(len(serverList), func(i, j int) { serverList[i], serverList[j] = serverList[j], serverList[i] })
 
 	return strings.Join(serverList, ",")
@@ -67,76 +84,94 @@ func getPublicIPResolvers() string {
 func getPublicIP(family k8snet.IPFamily, submSpec *types.SubmarinerSpecification, k8sClient kubernetes.Interface,
 	backendConfig map[string]string, airGapped bool,
 ) (string, string, error) {
-	switch family {
-	case k8snet.IPv4:
-		// If the node is annotated with a public-ip, the same is used as the public-ip of local endpoint.
-		config, ok := backendConfig[v1.PublicIP]
-		if !ok {
-			if submSpec.PublicIP != "" {
-				config = submSpec.PublicIP
-			} else {
-				config = getPublicIPResolvers()
-			}
-		}
+	if family != k8snet.IPv4 && family != k8snet.IPv6 {
+		return "", "", nil
+	}
 
-		if airGapped {
-			ip, resolver, err := resolveIPInAirGappedDeployment(k8sClient, submSpec.Namespace, config)
-			if err != nil {
-				logger.Errorf(err, "Error resolving public IP%s in an air-gapped deployment, using empty value: %s", family, config)
-				return "", "", nil
-			}
+	// If the node is annotated with a public-ip, the same is used as the public-ip of local endpoint.
+	config, ok := backendConfig[v1.PublicIP]
+	if !ok {
+		if submSpec.PublicIP != "" {
+			config = submSpec.PublicIP
+		} else {
+			config = getPublicIPResolvers(family)
+		}
+	}
 
-			return ip, resolver, nil
+	if airGapped {
+		ip, resolver, err := resolveIPInAirGappedDeployment(family, k8sClient, submSpec.Namespace, config)
+		if err != nil {
+			logger.Errorf(err, "Error resolving public IP%s in an air-gapped deployment, using empty value: %s", family, config)
+			return "", "", nil
 		}
 
-		resolvers := strings.Split(config, ",")
-		errs := make([]error, 0, len(resolvers))
+		return ip, resolver, nil
+	}
 
-		for _, resolver := range resolvers {
-			resolver = strings.Trim(resolver, " ")
+	resolvers := strings.Split(config, ",")
+	errs := make([]error, 0, len(resolvers))
 
-			parts := strings.Split(resolver, ":")
-			if len(parts) != 2 {
-				return "", "", errors.Errorf("invalid format for %q annotation: %q", v1.GatewayConfigPrefix+v1.PublicIP, config)
-			}
+	for _, resolver := range resolvers {
+		resolver = strings.Trim(resolver, " ")
 
-			ip, err := resolvePublicIP(k8sClient, submSpec.Namespace, parts)
-			if err == nil {
-				return ip, resolver, nil
-			}
+		parts, err := splitResolver(resolver, config)
+		if err != nil {
+			return "", "", err
+		}
 
-			// If this resolver failed, we log it, but we fall back to the next one
-			errs = append(errs, errors.Wrapf(err, "\nResolver[%q]", resolver))
+		if !resolverSupportsFamily(parts[0], family) {
+			continue
 		}
 
-		if len(resolvers) > 0 {
-			return "", "", errors.Wrapf(k8serrors.NewAggregate(errs), "Unable to resolve public IP by any of the resolver methods")
+		ip, err := resolvePublicIP(family, k8sClient, submSpec.Namespace, parts)
+		if err == nil {
+			return ip, resolver, nil
 		}
 
-	case k8snet.IPv6:
-		// TODO_IPV6: add V6 healthcheck IP
-	case k8snet.IPFamilyUnknown:
+		// If this resolver failed, we log it, but we fall back to the next one
+		errs = append(errs, errors.Wrapf(err, "\nResolver[%q]", resolver))
+	}
+
+	if len(errs) > 0 {
+		return "", "", errors.Wrapf(k8serrors.NewAggregate(errs), "Unable to resolve public IP by any of the resolver methods")
 	}
 
 	return "", "", nil
 }
 
-func resolveIPInAirGappedDeployment(k8sClient kubernetes.Interface, namespace, config string) (string, string, error) {
+func splitResolver(resolver, config string) ([]string, error) {
+	// IPv6 addresses contain colons so only split on the first one.
+	parts := strings.SplitN(resolver, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid format for %q annotation: %q", v1.GatewayConfigPrefix+v1.PublicIP, config)
+	}
+
+	return parts, nil
+}
+
+func resolverSupportsFamily(method string, family k8snet.IPFamily) bool {
+	methodFamily, isStatic := staticIPMethodFamilies[method]
+
+	return !isStatic || methodFamily == family
+}
+
+func resolveIPInAirGappedDeployment(family k8snet.IPFamily, k8sClient kubernetes.Interface, namespace, config string,
+) (string, string, error) {
 	resolvers := strings.Split(config, ",")
 
 	for _, resolver := range resolvers {
 		resolver = strings.Trim(resolver, " ")
 
-		parts := strings.Split(resolver, ":")
-		if len(parts) != 2 {
-			return "", "", errors.Errorf("invalid format for %q annotation: %q", v1.GatewayConfigPrefix+v1.PublicIP, config)
+		parts, err := splitResolver(resolver, config)
+		if err != nil {
+			return "", "", err
 		}
 
-		if parts[0] != v1.IPv4 {
+		if methodFamily, isStatic := staticIPMethodFamilies[parts[0]]; !isStatic || methodFamily != family {
 			continue
 		}
 
-		ip, err := resolvePublicIP(k8sClient, namespace, parts)
+		ip, err := resolvePublicIP(family, k8sClient, namespace, parts)
 
 		return ip, resolver, err
 	}
@@ -144,22 +179,33 @@ func resolveIPInAirGappedDeployment(k8sClient kubernetes.Interface, namespace, c
 	return "", "", nil
 }
 
-func resolvePublicIP(k8sClient kubernetes.Interface, namespace string, parts []string) (string, error) {
+func resolvePublicIP(family k8snet.IPFamily, k8sClient kubernetes.Interface, namespace string, parts []string) (string, error) {
 	method, ok := publicIPMethods[parts[0]]
 	if !ok {
 		return "", errors.Errorf("unknown resolver %q in %q annotation", parts[0], v1.GatewayConfigPrefix+v1.PublicIP)
 	}
 
-	return method(k8sClient, namespace, parts[1])
+	return method(family, k8sClient, namespace, parts[1])
 }
 
-func publicAPI(_ kubernetes.Interface, _, value string) (string, error) {
+func publicAPI(family k8snet.IPFamily, _ kubernetes.Interface, _, value string) (string, error) {
 	url := "https://" + value
 
+	// Force the connection over the requested IP family so the API reports the corresponding public IP.
+	network := "tcp4"
+	if family == k8snet.IPv6 {
+		network = "tcp6"
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+
 	httpClient := http.Client{
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
 			Proxy: http.ProxyFromEnvironment,
+			DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
 		},
 	}
 
@@ -175,11 +221,11 @@ func publicAPI(_ kubernetes.Interface, _, value string) (string, error) {
 		return "", errors.Wrapf(err, "reading API response from %s", url)
 	}
 
-	return firstIPv4InString(string(body))
+	return firstIPInString(family, string(body))
 }
 
-func publicIP(_ kubernetes.Interface, _, value string) (string, error) {
-	return firstIPv4InString(value)
+func publicIP(family k8snet.IPFamily, _ kubernetes.Interface, _, value string) (string, error) {
+	return firstIPInString(family, value)
 }
 
 var loadBalancerRetryConfig = wait.Backoff{
@@ -189,7 +235,8 @@ var loadBalancerRetryConfig = wait.Backoff{
 	Steps:    24,
 }
 
-func publicLoadBalancerIP(clientset kubernetes.Interface, namespace, loadBalancerName string) (string, error) {
+func publicLoadBalancerIP(family k8snet.IPFamily, clientset kubernetes.Interface, namespace, loadBalancerName string,
+) (string, error) {
 	ip := ""
 
 	err := retry.OnError(loadBalancerRetryConfig, func(err error) bool {
@@ -205,31 +252,44 @@ func publicLoadBalancerIP(clientset kubernetes.Interface, namespace, loadBalance
 			return errors.Errorf("service %q doesn't contain any LoadBalancer ingress yet", loadBalancerName)
 		}
 
-		ingress := service.Status.LoadBalancer.Ingress[0]
-
-		switch {
-		case ingress.IP != "":
-			ip = ingress.IP
-			return nil
-
-		case ingress.Hostname != "":
-			ip, err = publicDNSIP(clientset, namespace, ingress.Hostname)
-			return err
+		for i := range service.Status.LoadBalancer.Ingress {
+			if k8snet.IPFamilyOfString(service.Status.LoadBalancer.Ingress[i].IP) == family {
+				ip = service.Status.LoadBalancer.Ingress[i].IP
+				return nil
+			}
+		}
 
-		default:
-			return errors.Errorf("no IP or Hostname for service LoadBalancer %q Ingress", loadBalancerName)
+		for i := range service.Status.LoadBalancer.Ingress {
+			if service.Status.LoadBalancer.Ingress[i].Hostname != "" {
+				ip, err = publicDNSIP(family, clientset, namespace, service.Status.LoadBalancer.Ingress[i].Hostname)
+				return err
+			}
 		}
+
+		return errors.Errorf("no IP%v or Hostname for service LoadBalancer %q Ingress", family, loadBalancerName)
 	})
 
 	return ip, err //nolint:wrapcheck  // No need to wrap here
 }
 
-func publicDNSIP(_ kubernetes.Interface, _, fqdn string) (string, error) {
-	ips, err := net.LookupIP(fqdn)
+func publicDNSIP(family k8snet.IPFamily, _ kubernetes.Interface, _, fqdn string) (string, error) {
+	allIPs, err := net.LookupIP(fqdn)
 	if err != nil {
 		return "", errors.Wrapf(err, "error resolving DNS hostname %q for public IP", fqdn)
 	}
 
+	ips := make([]net.IP, 0, len(allIPs))
+
+	for _, ip := range allIPs {
+		if k8snet.IPFamilyOf(ip) == family {
+			ips = append(ips, ip)
+		}
+	}
+
+	if len(ips) == 0 {
+		return "", errors.Errorf("DNS hostname %q has no IPv%s address", fqdn, family)
+	}
+
 	if len(ips) > 1 {
 		sort.Slice(ips, func(i, j int) bool {
 			return bytes.Compare(ips[i], ips[j]) < 0
@@ -239,11 +299,17 @@ func publicDNSIP(_ kubernetes.Interface, _, fqdn string) (string, error) {
 	return ips[0].String(), nil
 }
 
-func firstIPv4InString(body string) (string, error) {
-	matches := IPv4RE.FindAllString(body, -1)
-	if len(matches) == 0 {
-		return "", errors.Errorf("No IPv4 found in: %q", body)
+func firstIPInString(family k8snet.IPFamily, body string) (string, error) {
+	re := IPv4RE
+	if family == k8snet.IPv6 {
+		re = IPv6RE
+	}
+
+	for _, match := range re.FindAllString(body, -1) {
+		if k8snet.IPFamilyOfString(match) == family {
+			return match, nil
+		}
 	}
 
-	return matches[0], nil
+	return "", errors.Errorf("No IPv%s found in: %q", family, body)
 }
@@ -31,13 +31,13 @@ import (
 	k8snet "k8s.io/utils/net"
 )
 
-var _ = Describe("firstIPv4InString", func() {
+var _ = Describe("firstIPInString", func() {
 	When("the content has an IPv4", func() {
 		const testIP = "1.2.3.4"
 		const jsonIP = "{\"ip\": \"" + testIP + "\"}"
 
 		It("should return the IP", func() {
-			ip, err := firstIPv4InString(jsonIP)
+			ip, err := firstIPInString(k8snet.IPv4, jsonIP)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(ip).To(Equal(testIP))
 		})
@@ -45,7 +45,26 @@ var _ = Describe("firstIPv4InString", func() {
 
 	When("the content doesn't have an IPv4", func() {
 		It("should result in error", func() {
-			ip, err := firstIPv4InString("no IPs here")
+			ip, err := firstIPInString(k8snet.IPv4, "no IPs here")
+			Expect(err).To(HaveOccurred())
+			Expect(ip).To(Equal(""))
+		})
+	})
+
+	When("the content has an IPv6", func() {
+		const testIP = "2001:db8::1"
+		const jsonIP = "{\"time\": \"12:30:45\", \"ip\": \"" + testIP + "\"}"
+
+		It("should return the IP", func() {
+			ip, err := firstIPInString(k8snet.IPv6, jsonIP)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ip).To(Equal(testIP))
+		})
+	})
+
+	When("the content only has an IPv4 and an IPv6 is requested", func() {
+		It("should result in error", func() {
+			ip, err := firstIPInString(k8snet.IPv6, "1.2.3.4")
 			Expect(err).To(HaveOccurred())
 			Expect(ip).To(Equal(""))
 		})
@@ -65,6 +84,7 @@ var _ = Describe("public ip resolvers", func() {
 		publicIPConfig = "public-ip"
 		testIPDNS      = "4.3.2.1"
 		testIP         = "1.2.3.4"
+		testIPv6       = "2001:db8::1"
 		dnsHost        = testIPDNS + ".nip.io"
 		ipv4PublicIP   = "ipv4:" + testIP
 		lbPublicIP     = "lb:" + testServiceName
@@ -126,6 +146,43 @@ var _ = Describe("public ip resolvers", func() {
 		})
 	})
 
+	When("an IPv6 entry specified", func() {
+		It("should return the IP for the IPv6 family only", func() {
+			backendConfig[publicIPConfig] = ipv4PublicIP + ",ipv6:" + testIPv6
+			client := fake.NewClientset()
+			ip, resolver, err := getPublicIP(k8snet.IPv6, submSpec, client, backendConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ip).To(Equal(testIPv6))
+			Expect(resolver).To(Equal("ipv6:" + testIPv6))
+
+			ip, resolver, err = getPublicIP(k8snet.IPv4, submSpec, client, backendConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ip).To(Equal(testIP))
+			Expect(resolver).To(Equal(ipv4PublicIP))
+		})
+	})
+
+	When("a LoadBalancer with dual-stack Ingress IPs is specified", func() {
+		It("should return the IP of the requested family", func() {
+			backendConfig[publicIPConfig] = lbPublicIP
+			client := fake.NewClientset(serviceWithIngress(v1.LoadBalancerIngress{IP: testIP},
+				v1.LoadBalancerIngress{IP: testIPv6}))
+			ip, _, err := getPublicIP(k8snet.IPv6, submSpec, client, backendConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ip).To(Equal(testIPv6))
+		})
+	})
+
+	When("no entry applies to the IPv6 family", func() {
+		It("should return an empty IP", func() {
+			backendConfig[publicIPConfig] = ipv4PublicIP
+			client := fake.NewClientset()
+			ip, _, err := getPublicIP(k8snet.IPv6, submSpec, client, backendConfig, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ip).To(BeEmpty())
+		})
+	})
+
 	When("an IPv4 entry specified in air-gapped deployment", func() {
 		It("should return the IP and not an empty value", func() {
 			backendConfig[publicIPConfig] = ipv4PublicIP
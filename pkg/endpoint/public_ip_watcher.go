@@ -24,7 +24,9 @@ import (
 
 	"github.com/pkg/errors"
 	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/types"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 )
@@ -34,6 +36,7 @@ type PublicIPWatcherConfig struct {
 	Interval      time.Duration
 	K8sClient     kubernetes.Interface
 	LocalEndpoint *Local
+	Recorder      *recorder.Recorder
 }
 
 type PublicIPWatcher struct {
@@ -85,6 +88,9 @@ func (p *PublicIPWatcher) syncPublicIP() {
 			logger.Error(err, "Error updating the public IP for local endpoint")
 			return
 		}
+
+		p.config.Recorder.OnEndpoint(localEndpointSpec.CableName, corev1.EventTypeNormal, recorder.PublicIPChanged,
+			"The public IPs of the gateway node %q changed to %q", localEndpointSpec.Hostname, publicIPs)
 	}
 }
 
@@ -0,0 +1,154 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/submariner-io/admiral/pkg/log"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// The reasons of the Events recorded by the gateway.
+const (
+	CableConnected       = "CableConnected"
+	CableFailed          = "CableFailed"
+	CableRemoved         = "CableRemoved"
+	NATDiscoveryTimedOut = "NATDiscoveryTimedOut"
+	BecameActiveGateway  = "BecameActiveGateway"
+	LostActiveGateway    = "LostActiveGateway"
+	PublicIPChanged      = "PublicIPChanged"
+)
+
+var (
+	// RateLimitQPS and RateLimitBurst limit the Events recorded for each reason and subject, e.g. a cable, so a flapping
+	// cable doesn't flood the API server.
+	RateLimitQPS   float32 = 1.0 / 60
+	RateLimitBurst         = 3
+
+	// MaxRateLimiters bounds the number of subjects tracked for rate limiting, since subjects such as cables come and go.
+	MaxRateLimiters = 1024
+)
+
+var logger = log.Logger{Logger: logf.Log.WithName("Events")}
+
+// Recorder records Events on the local Gateway and Endpoint resources. A nil Recorder records nothing.
+type Recorder struct {
+	mutex    sync.Mutex
+	recorder record.EventRecorder
+	gateway  *corev1.ObjectReference
+	endpoint *corev1.ObjectReference
+	limiters map[string]*rateLimiter
+}
+
+type rateLimiter struct {
+	flowcontrol.RateLimiter
+	lastUsed time.Time
+}
+
+func New(recorder record.EventRecorder, namespace, gatewayName, endpointName string) *Recorder {
+	return &Recorder{
+		recorder: recorder,
+		gateway:  objectReference("Gateway", namespace, gatewayName),
+		endpoint: objectReference("Endpoint", namespace, endpointName),
+		limiters: map[string]*rateLimiter{},
+	}
+}
+
+func objectReference(kind, namespace, name string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Kind:       kind,
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+// OnGateway records an Event on the local Gateway. The subject identifies what the Event is about, e.g. the cable name,
+// and is used to rate-limit the Events.
+func (r *Recorder) OnGateway(subject, eventType, reason, messageFmt string, args ...interface{}) {
+	if r != nil {
+		r.record(r.gateway, subject, eventType, reason, messageFmt, args...)
+	}
+}
+
+// OnEndpoint records an Event on the local Endpoint. The subject is used to rate-limit the Events as for OnGateway.
+func (r *Recorder) OnEndpoint(subject, eventType, reason, messageFmt string, args ...interface{}) {
+	if r != nil {
+		r.record(r.endpoint, subject, eventType, reason, messageFmt, args...)
+	}
+}
+
+func (r *Recorder) record(object *corev1.ObjectReference, subject, eventType, reason, messageFmt string,
+	args ...interface{},
+) {
+	if !r.allow(fmt.Sprintf("%s/%s/%s", object.Kind, reason, subject)) {
+		logger.V(log.DEBUG).Infof("Dropping rate-limited %q Event for %q", reason, subject)
+		return
+	}
+
+	r.recorder.Eventf(object, eventType, reason, messageFmt, args...)
+}
+
+func (r *Recorder) allow(key string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+
+	limiter, ok := r.limiters[key]
+	if !ok {
+		if len(r.limiters) >= MaxRateLimiters {
+			r.evictLimiters(now)
+		}
+
+		limiter = &rateLimiter{RateLimiter: flowcontrol.NewTokenBucketRateLimiter(RateLimitQPS, RateLimitBurst)}
+		r.limiters[key] = limiter
+	}
+
+	limiter.lastUsed = now
+
+	return limiter.TryAccept()
+}
+
+// evictLimiters removes the limiters that have been idle long enough to refill their burst, as they're equivalent to new
+// ones. If none are, the least recently used limiter is removed instead.
+func (r *Recorder) evictLimiters(now time.Time) {
+	refill := time.Duration(float64(RateLimitBurst) / float64(RateLimitQPS) * float64(time.Second))
+
+	var lruKey string
+
+	for key, limiter := range r.limiters {
+		if now.Sub(limiter.lastUsed) >= refill {
+			delete(r.limiters, key)
+		} else if lruKey == "" || limiter.lastUsed.Before(r.limiters[lruKey].lastUsed) {
+			lruKey = key
+		}
+	}
+
+	if len(r.limiters) >= MaxRateLimiters {
+		delete(r.limiters, lruKey)
+	}
+}
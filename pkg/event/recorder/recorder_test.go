@@ -0,0 +1,111 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		fakeRecorder  *record.FakeRecorder
+		eventRecorder *recorder.Recorder
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(20)
+		eventRecorder = recorder.New(fakeRecorder, "submariner", "gateway", "endpoint")
+	})
+
+	It("should record the Events", func() {
+		eventRecorder.OnGateway("cable1", corev1.EventTypeNormal, recorder.CableConnected, "Connected cable %q", "cable1")
+		Expect(fakeRecorder.Events).To(Receive(Equal(`Normal CableConnected Connected cable "cable1"`)))
+
+		eventRecorder.OnEndpoint("cable1", corev1.EventTypeWarning, recorder.NATDiscoveryTimedOut, "Timed out")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning NATDiscoveryTimedOut Timed out")))
+	})
+
+	When("the Events for a subject exceed the burst", func() {
+		It("should drop them", func() {
+			for range recorder.RateLimitBurst + 2 {
+				eventRecorder.OnGateway("cable1", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			}
+
+			Expect(fakeRecorder.Events).To(HaveLen(recorder.RateLimitBurst))
+		})
+
+		It("should still record the Events for other subjects and reasons", func() {
+			for range recorder.RateLimitBurst + 2 {
+				eventRecorder.OnGateway("cable1", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			}
+
+			eventRecorder.OnGateway("cable2", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			eventRecorder.OnGateway("cable1", corev1.EventTypeNormal, recorder.CableConnected, "Connected")
+			eventRecorder.OnEndpoint("cable1", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+
+			Expect(fakeRecorder.Events).To(HaveLen(recorder.RateLimitBurst + 3))
+		})
+	})
+
+	When("the number of subjects exceeds the maximum tracked", func() {
+		var prevMax int
+
+		BeforeEach(func() {
+			prevMax = recorder.MaxRateLimiters
+			recorder.MaxRateLimiters = 2
+		})
+
+		AfterEach(func() {
+			recorder.MaxRateLimiters = prevMax
+		})
+
+		It("should still record the Events for new subjects", func() {
+			for _, cable := range []string{"cable1", "cable2", "cable3", "cable4"} {
+				eventRecorder.OnGateway(cable, corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			}
+
+			Expect(fakeRecorder.Events).To(HaveLen(4))
+		})
+
+		It("should stop tracking the least recently used subject", func() {
+			for range recorder.RateLimitBurst + 1 {
+				eventRecorder.OnGateway("cable1", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			}
+
+			eventRecorder.OnGateway("cable2", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			eventRecorder.OnGateway("cable3", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+			eventRecorder.OnGateway("cable1", corev1.EventTypeWarning, recorder.CableFailed, "Failed")
+
+			Expect(fakeRecorder.Events).To(HaveLen(recorder.RateLimitBurst + 3))
+		})
+	})
+
+	Context("that is nil", func() {
+		It("should not record anything", func() {
+			var nilRecorder *recorder.Recorder
+
+			nilRecorder.OnGateway("cable1", corev1.EventTypeNormal, recorder.CableConnected, "Connected")
+			nilRecorder.OnEndpoint("cable1", corev1.EventTypeNormal, recorder.PublicIPChanged, "Changed")
+		})
+	})
+})
@@ -0,0 +1,68 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+
+	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cableengine"
+	"github.com/submariner-io/submariner/pkg/pinger"
+)
+
+// DebugPath is the path on which the gateway serves its debug state, on the metrics/profile HTTP server.
+const DebugPath = "/debug/gateway"
+
+// DebugState is the gateway state served on DebugPath.
+type DebugState struct {
+	LocalEndpoint subv1.EndpointSpec      `json:"localEndpoint"`
+	HAStatus      subv1.HAStatus          `json:"haStatus"`
+	CableEngine   *cableengine.DebugState `json:"cableEngine"`
+	// Latencies are the health checker's latency information, keyed by cable name.
+	Latencies map[string]*pinger.LatencyInfo `json:"latencies,omitempty"`
+}
+
+func (g *gatewayType) serveDebugState(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	state := DebugState{
+		LocalEndpoint: *g.localEndpoint.Spec(),
+		HAStatus:      g.cableEngine.GetHAStatus(),
+		CableEngine:   g.cableEngine.GetDebugState(),
+	}
+
+	if g.cableHealthChecker != nil {
+		state.Latencies = g.cableHealthChecker.ListLatencyInfo()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(&state); err != nil {
+		logger.Errorf(err, "Error writing the debug state")
+	}
+}
@@ -20,6 +20,7 @@ package gateway
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"reflect"
 	"strings"
@@ -36,19 +37,21 @@ import (
 	"github.com/submariner-io/submariner/pkg/cableengine"
 	"github.com/submariner-io/submariner/pkg/cableengine/healthchecker"
 	"github.com/submariner-io/submariner/pkg/cableengine/syncer"
-	"github.com/submariner-io/submariner/pkg/cidr"
 	submclientset "github.com/submariner-io/submariner/pkg/client/clientset/versioned"
 	"github.com/submariner-io/submariner/pkg/controllers/datastoresyncer"
 	"github.com/submariner-io/submariner/pkg/controllers/tunnel"
 	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/pod"
+	"github.com/submariner-io/submariner/pkg/prober"
 	"github.com/submariner-io/submariner/pkg/types"
 	"github.com/submariner-io/submariner/pkg/versions"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
@@ -81,8 +84,10 @@ type Config struct {
 	SubmarinerClient     submclientset.Interface
 	KubeClient           kubernetes.Interface
 	LeaderElectionClient kubernetes.Interface
-	NewCableEngine       func(localCluster *types.SubmarinerCluster, localEndpoint *endpoint.Local) cableengine.Engine
-	NewNATDiscovery      func(localEndpoint *endpoint.Local) (natdiscovery.Interface, error)
+	NewCableEngine       func(*types.SubmarinerCluster, *endpoint.Local, *types.SubmarinerSpecification, kubernetes.Interface) cableengine.Engine
+	NewNATDiscovery      func(*endpoint.Local, *types.SubmarinerSpecification) (natdiscovery.Interface, error)
+	// DebugServeMux, if set, serves the gateway's debug state on DebugPath, typically on the metrics/profile HTTP server.
+	DebugServeMux *http.ServeMux
 }
 
 type gatewayType struct {
@@ -100,6 +105,7 @@ type gatewayType struct {
 	fatalError              chan error
 	leaderComponentsStarted *sync.WaitGroup
 	recorder                record.EventRecorder
+	events                  *recorder.Recorder
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("Gateway")}
@@ -133,7 +139,10 @@ func New(ctx context.Context, config *Config) (Interface, error) {
 
 	logger.Info("Creating the cable engine")
 
-	localCluster := submarinerClusterFrom(&g.Spec)
+	localCluster, err := submarinerClusterFrom(&g.Spec)
+	if err != nil {
+		return nil, err
+	}
 
 	if g.Spec.CableDriver == "" {
 		g.Spec.CableDriver = cable.GetDefaultCableDriver()
@@ -151,9 +160,9 @@ func New(ctx context.Context, config *Config) (Interface, error) {
 
 	g.localEndpoint = endpoint.NewLocal(localEndpointSpec, g.SyncerConfig.LocalClient, g.Spec.Namespace)
 
-	g.cableEngine = g.NewCableEngine(localCluster, g.localEndpoint)
+	g.cableEngine = g.NewCableEngine(localCluster, g.localEndpoint, &g.Spec, g.KubeClient)
 
-	g.natDiscovery, err = g.NewNATDiscovery(g.localEndpoint)
+	g.natDiscovery, err = g.NewNATDiscovery(g.localEndpoint, &g.Spec)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating the NAT discovery handler")
 	}
@@ -162,7 +171,7 @@ func New(ctx context.Context, config *Config) (Interface, error) {
 
 	g.SyncerConfig.LocalNamespace = g.Spec.Namespace
 
-	g.datastoreSyncer = datastoresyncer.New(&g.SyncerConfig, localCluster, g.localEndpoint)
+	g.datastoreSyncer = datastoresyncer.New(&g.SyncerConfig, localCluster, g.localEndpoint, g.setBrokerSyncStatus)
 
 	g.initCableHealthChecker()
 
@@ -175,6 +184,17 @@ func New(ctx context.Context, config *Config) (Interface, error) {
 	eventBroadcaster.StartLogging(logger.V(log.DEBUG).Infof)
 	g.recorder = eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "submariner-controller"})
 
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: g.KubeClient.CoreV1().Events("")})
+
+	g.events = recorder.New(g.recorder, g.Spec.Namespace, resource.EnsureValidName(localEndpointSpec.Hostname),
+		g.localEndpoint.Resource().Name)
+	g.cableEngine.SetEventRecorder(g.events)
+	g.natDiscovery.SetEventRecorder(g.events)
+
+	if g.DebugServeMux != nil {
+		g.DebugServeMux.HandleFunc(DebugPath, g.serveDebugState)
+	}
+
 	return g, nil
 }
 
@@ -210,9 +230,13 @@ func (g *gatewayType) Run(ctx context.Context) error {
 		g.initPublicIPWatcher()
 	}
 
-	err = g.startLeaderElection(ctx)
-	if err != nil {
-		return errors.Wrap(err, "error starting leader election")
+	if g.Spec.ActiveActive {
+		g.startActiveActive(ctx)
+	} else {
+		err = g.startLeaderElection(ctx)
+		if err != nil {
+			return errors.Wrap(err, "error starting leader election")
+		}
 	}
 
 	select {
@@ -243,6 +267,28 @@ func (g *gatewayType) runAsync(waitGroup *sync.WaitGroup, run func()) {
 	}()
 }
 
+// startActiveActive starts the components that otherwise only run on the leader. In active/active mode every gateway
+// holds its own cables so there's no leader election.
+func (g *gatewayType) startActiveActive(ctx context.Context) {
+	logger.Info("Running in active/active mode - skipping leader election")
+
+	g.leaderComponentsStarted = &sync.WaitGroup{}
+
+	g.onStartedLeading(ctx)
+
+	go func() {
+		<-ctx.Done()
+
+		g.leaderComponentsStarted.Wait()
+
+		if g.cableHealthChecker != nil {
+			g.cableHealthChecker.Stop()
+		}
+
+		g.cableEngine.Stop()
+	}()
+}
+
 func (g *gatewayType) startLeaderElection(ctx context.Context) error {
 	logger.Info("Starting leader election")
 
@@ -279,6 +325,9 @@ func (g *gatewayType) startLeaderElection(ctx context.Context) error {
 func (g *gatewayType) onStartedLeading(ctx context.Context) {
 	logger.Info("Leadership acquired - starting controllers")
 
+	g.events.OnGateway(g.hostName, corev1.EventTypeNormal, recorder.BecameActiveGateway,
+		"Gateway %q became the active gateway", g.hostName)
+
 	if err := g.cableEngine.StartEngine(); err != nil {
 		g.fatalError <- errors.Wrap(err, "error starting the cable engine")
 		return
@@ -322,6 +371,9 @@ func (g *gatewayType) onStartedLeading(ctx context.Context) {
 func (g *gatewayType) onStoppedLeading(ctx context.Context) {
 	logger.Info("Leadership lost")
 
+	g.events.OnGateway(g.hostName, corev1.EventTypeWarning, recorder.LostActiveGateway,
+		"Gateway %q is no longer the active gateway", g.hostName)
+
 	// Make sure all the components were at least started before we try to restart.
 	g.leaderComponentsStarted.Wait()
 
@@ -379,6 +431,7 @@ func (g *gatewayType) initPublicIPWatcher() {
 		SubmSpec:      &g.Spec,
 		K8sClient:     g.KubeClient,
 		LocalEndpoint: g.localEndpoint,
+		Recorder:      g.events,
 	}
 
 	g.publicIPWatcher = endpoint.NewPublicIPWatcher(publicIPConfig)
@@ -392,19 +445,57 @@ func (g *gatewayType) initCableHealthChecker() {
 	} else {
 		watcherConfig := g.WatcherConfig
 
-		g.cableHealthChecker, err = healthchecker.New(&healthchecker.Config{
+		config := &healthchecker.Config{
 			WatcherConfig:      &watcherConfig,
 			EndpointNamespace:  g.Spec.Namespace,
 			ClusterID:          g.Spec.ClusterID,
 			PingInterval:       g.Spec.HealthCheckInterval,
 			MaxPacketLossCount: g.Spec.HealthCheckMaxPacketLossCount,
-		})
+			OnProbeResult:      g.recordProbeResult,
+			OnRTT:              g.recordRTT,
+		}
+
+		if g.Spec.HealthCheckProbeEnabled {
+			config.ProbePort = g.Spec.HealthCheckProbePort
+
+			for _, family := range g.Spec.GetIPFamilies() {
+				ip, err := endpoint.GetHealthCheckIP(family, &g.Spec)
+				if err != nil {
+					logger.Errorf(err, "Error getting the IP%v for the probe responder", family)
+					continue
+				}
+
+				config.ProbeIPs = append(config.ProbeIPs, ip)
+			}
+		}
+
+		g.cableHealthChecker, err = healthchecker.New(config)
 		if err != nil {
 			logger.Errorf(err, "Error creating healthChecker")
 		}
 	}
 }
 
+// setBrokerSyncStatus is called by the datastore syncer with the outcome of its start and of its ongoing syncs to the broker.
+func (g *gatewayType) setBrokerSyncStatus(err error) {
+	g.cableEngineSyncer.SetBrokerSyncStatus(context.Background(), err)
+}
+
+func (g *gatewayType) recordProbeResult(remote *subv1.EndpointSpec, result *prober.Result) {
+	localEndpoint := g.localEndpoint.Spec()
+
+	cable.RecordConnectionQuality(localEndpoint.Backend, localEndpoint, remote, result.PacketLoss, result.Jitter.Seconds())
+
+	if result.ThroughputUpdated {
+		cable.RecordConnectionThroughput(localEndpoint.Backend, localEndpoint, remote, result.Throughput)
+	}
+}
+
+func (g *gatewayType) recordRTT(remote *subv1.EndpointSpec, rtt time.Duration) {
+	localEndpoint := g.localEndpoint.Spec()
+	cable.RecordConnectionRTT(localEndpoint.Backend, localEndpoint, remote, rtt)
+}
+
 func (g *gatewayType) uninstall(ctx context.Context) error {
 	err := g.cableEngine.StartEngine()
 	if err != nil {
@@ -425,22 +516,28 @@ func (g *gatewayType) uninstall(ctx context.Context) error {
 	return errors.Wrap(dsErr, "Error cleaning up the datastore")
 }
 
-func submarinerClusterFrom(submSpec *types.SubmarinerSpecification) *types.SubmarinerCluster {
+func submarinerClusterFrom(submSpec *types.SubmarinerSpecification) (*types.SubmarinerCluster, error) {
 	// The Cluster resource requires a value for the GlobalCIDR.
 	globalCIDR := submSpec.GlobalCidr
 	if globalCIDR == nil {
 		globalCIDR = []string{}
 	}
 
+	topology, err := submSpec.GetTopologyPolicy()
+	if err != nil {
+		return nil, err //nolint:wrapcheck  // No need to wrap this error
+	}
+
 	return &types.SubmarinerCluster{
 		ID: submSpec.ClusterID,
 		Spec: subv1.ClusterSpec{
-			ClusterID:  submSpec.ClusterID,
-			ColorCodes: []string{"blue"}, // This is a fake value, used only for upgrade purposes
-			// TODO_IPV6: delete cidr.ExtractIPv4Subnets
-			ServiceCIDR: cidr.ExtractIPv4Subnets(submSpec.ServiceCidr),
-			ClusterCIDR: cidr.ExtractIPv4Subnets(submSpec.ClusterCidr),
+			ClusterID:   submSpec.ClusterID,
+			ColorCodes:  []string{"blue"}, // This is a fake value, used only for upgrade purposes
+			ServiceCIDR: submSpec.ServiceCidr,
+			ClusterCIDR: submSpec.ClusterCidr,
 			GlobalCIDR:  globalCIDR,
+			Labels:      submSpec.ClusterLabels,
+			Topology:    topology,
 		},
-	}
+	}, nil
 }
@@ -44,7 +44,7 @@ var _ = BeforeSuite(func() {
 	kzerolog.InitK8sLogging()
 	Expect(submarinerv1.AddToScheme(scheme.Scheme)).To(Succeed())
 
-	cable.AddDriver(fake.DriverName, func(_ *endpoint.Local, _ *types.SubmarinerCluster) (cable.Driver, error) {
+	cable.AddDriver(fake.DriverName, func(_ *endpoint.Local, _ *types.SubmarinerCluster, _ *cable.DriverConfig) (cable.Driver, error) {
 		return fakeDriver, nil
 	})
 
@@ -20,7 +20,10 @@ package gateway_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"time"
@@ -42,6 +45,7 @@ import (
 	enginefake "github.com/submariner-io/submariner/pkg/cableengine/fake"
 	submfake "github.com/submariner-io/submariner/pkg/client/clientset/versioned/fake"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
 	"github.com/submariner-io/submariner/pkg/gateway"
 	"github.com/submariner-io/submariner/pkg/natdiscovery"
 	"github.com/submariner-io/submariner/pkg/types"
@@ -53,6 +57,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/dynamic"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 )
@@ -77,6 +82,64 @@ var _ = Describe("Run", func() {
 
 		endpoint := t.awaitRemoteEndpointSyncedLocal(t.createRemoteEndpointOnBroker())
 		t.cableEngine.VerifyInstallCable(&endpoint.Spec)
+
+		Eventually(func() []string {
+			list, err := t.kubeClient.CoreV1().Events(t.config.Spec.Namespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).To(Succeed())
+
+			reasons := []string{}
+			for i := range list.Items {
+				reasons = append(reasons, list.Items[i].Reason)
+			}
+
+			return reasons
+		}, 5).Should(ContainElement(recorder.BecameActiveGateway))
+	})
+
+	When("active/active mode is enabled", func() {
+		BeforeEach(func() {
+			t.config.Spec.ActiveActive = true
+		})
+
+		It("should start the controllers without leader election", func() {
+			t.awaitLocalEndpoint()
+			t.awaitHAStatus(submarinerv1.HAStatusActive)
+			t.leaderElection.EnsureLeaseNotAcquired()
+
+			endpoint := t.awaitRemoteEndpointSyncedLocal(t.createRemoteEndpointOnBroker())
+			t.cableEngine.VerifyInstallCable(&endpoint.Spec)
+		})
+	})
+
+	When("the debug state is queried", func() {
+		BeforeEach(func() {
+			t.config.DebugServeMux = http.NewServeMux()
+		})
+
+		It("should return the gateway and cable engine state", func() {
+			t.leaderElection.AwaitLeaseAcquired()
+			t.awaitLocalEndpoint()
+
+			t.cableEngine.Lock()
+			t.cableEngine.HAStatus = submarinerv1.HAStatusActive
+			t.cableEngine.Connections = []submarinerv1.Connection{{Status: submarinerv1.Connected, UsingIP: "5.6.7.8"}}
+			t.cableEngine.Unlock()
+
+			recorder := httptest.NewRecorder()
+			t.config.DebugServeMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, gateway.DebugPath, http.NoBody))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			state := &gateway.DebugState{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), state)).To(Succeed())
+			Expect(state.LocalEndpoint.ClusterID).To(Equal(t.config.Spec.ClusterID))
+			Expect(state.HAStatus).To(Equal(submarinerv1.HAStatusActive))
+			Expect(state.CableEngine.Running).To(BeTrue())
+			Expect(state.CableEngine.DriverConnections).To(Equal(t.cableEngine.Connections))
+
+			recorder = httptest.NewRecorder()
+			t.config.DebugServeMux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, gateway.DebugPath, http.NoBody))
+			Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		})
 	})
 
 	When("starting the Cable Engine fails", func() {
@@ -106,6 +169,9 @@ var _ = Describe("Run", func() {
 			t.config.NewCableEngine = cableengine.NewEngine
 			t.config.RenewDeadline = time.Millisecond * 200
 			t.config.RetryPeriod = time.Millisecond * 20
+
+			// The cable engine defers the cables until their Cluster is known.
+			t.createRemoteClusterOnBroker()
 		})
 
 		JustBeforeEach(func() {
@@ -285,11 +351,13 @@ func newTestDriver() *testDriver {
 			SubmarinerClient:     submfake.NewSimpleClientset(),
 			KubeClient:           t.kubeClient,
 			LeaderElectionClient: t.kubeClient,
-			NewCableEngine: func(_ *types.SubmarinerCluster, lep *submendpoint.Local) cableengine.Engine {
+			NewCableEngine: func(_ *types.SubmarinerCluster, lep *submendpoint.Local, _ *types.SubmarinerSpecification,
+				_ kubernetes.Interface,
+			) cableengine.Engine {
 				t.cableEngine.LocalEndPoint = &types.SubmarinerEndpoint{Spec: *lep.Spec()}
 				return t.cableEngine
 			},
-			NewNATDiscovery: func(_ *submendpoint.Local) (natdiscovery.Interface, error) {
+			NewNATDiscovery: func(_ *submendpoint.Local, _ *types.SubmarinerSpecification) (natdiscovery.Interface, error) {
 				return &fakeNATDiscovery{}, nil
 			},
 		}
@@ -430,6 +498,21 @@ func (t *testDriver) createRemoteEndpointOnBroker() *submarinerv1.Endpoint {
 	return t.createEndpoint(t.config.SyncerConfig.BrokerNamespace, t.newRemoteEndpoint())
 }
 
+func (t *testDriver) createRemoteClusterOnBroker() {
+	cluster := &submarinerv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "west",
+		},
+		Spec: submarinerv1.ClusterSpec{
+			ClusterID: "west",
+		},
+	}
+
+	clusters := t.config.SyncerConfig.LocalClient.Resource(*test.GetGroupVersionResourceFor(t.config.SyncerConfig.RestMapper,
+		&submarinerv1.Cluster{}))
+	test.CreateResource(clusters.Namespace(t.config.SyncerConfig.BrokerNamespace), test.SetClusterIDLabel(cluster, "west"))
+}
+
 func (t *testDriver) awaitRemoteEndpointSyncedLocal(endpoint *submarinerv1.Endpoint) *submarinerv1.Endpoint {
 	return toEndpoint(test.AwaitResource(t.endpoints.Namespace(t.config.Spec.Namespace), endpoint.Name))
 }
@@ -496,6 +579,9 @@ func (n *fakeNATDiscovery) AddEndpoint(ep *submarinerv1.Endpoint) {
 func (n *fakeNATDiscovery) RemoveEndpoint(_ string) {
 }
 
+func (n *fakeNATDiscovery) SetEventRecorder(_ *recorder.Recorder) {
+}
+
 func (n *fakeNATDiscovery) GetReadyChannel() chan *natdiscovery.NATEndpointInfo {
 	n.readyChannel = make(chan *natdiscovery.NATEndpointInfo, 100)
 	return n.readyChannel
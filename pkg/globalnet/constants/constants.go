@@ -31,9 +31,20 @@ const (
 	SmGlobalnetEgressChainForHeadlessSvcPods = "SM-GN-EGRESS-HDLS-PODS"
 	SmGlobalnetEgressChainForHeadlessSvcEPs  = "SM-GN-EGRESS-HDLS-EPS"
 	SmGlobalnetEgressChainForNamespace       = "SM-GN-EGRESS-NS"
+	SmGlobalnetEgressChainForNSSelector      = "SM-GN-EGRESS-NS-SEL"
 	SmGlobalnetEgressChainForCluster         = "SM-GN-EGRESS-CLUSTER"
 
 	NATTable = "nat"
 
 	SmGlobalIP = "submariner.io/globalIp"
+
+	// SmGlobalCIDR is the Service annotation selecting the GlobalCIDR from which its global IPs are allocated.
+	SmGlobalCIDR = "submariner.io/globalCidr"
+
+	// SmRequestedGlobalIP is the ClusterIP Service annotation requesting a specific global IP.
+	SmRequestedGlobalIP = "submariner.io/requestedGlobalIp"
+
+	// SmGlobalIngress is the opt-in annotation, set to "true", requesting a global ingress IP for a non-exported
+	// LoadBalancer or NodePort Service or for a Pod.
+	SmGlobalIngress = "submariner.io/globalIngress"
 )
@@ -19,10 +19,13 @@ limitations under the License.
 package controllers
 
 import (
+	"cmp"
 	"context"
 	"crypto/sha256"
 	"encoding/base32"
 	"fmt"
+	"net"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -60,7 +63,7 @@ func newBaseSyncerController() *baseSyncerController {
 	}
 }
 
-func newBaseIPAllocationController(pool *ipam.IPPool, pfIface pfiface.Interface) *baseIPAllocationController {
+func newBaseIPAllocationController(pool *IPPools, pfIface pfiface.Interface) *baseIPAllocationController {
 	return &baseIPAllocationController{
 		baseSyncerController: newBaseSyncerController(),
 		pool:                 pool,
@@ -164,6 +167,54 @@ func (c *baseIPAllocationController) reserveAllocatedIPs(federator federate.Fede
 	return nil
 }
 
+// allocateIPs allocates the given number of contiguous global IPs from the pool of the given CIDR, or from any pool if
+// it's empty. If specific IPs are requested, they're reserved instead. On failure, the reason for the Allocated status
+// condition is returned with the error.
+func (c *baseIPAllocationController) allocateIPs(globalCIDR string, requestedIPs []string, num int) ([]string, string, error) {
+	if len(requestedIPs) == 0 {
+		ips, err := c.pool.AllocateFrom(globalCIDR, num)
+		return ips, "IPPoolAllocationFailed", err
+	}
+
+	ips := slices.Clone(requestedIPs)
+
+	for _, ip := range ips {
+		parsed := net.ParseIP(ip)
+		if parsed == nil || parsed.To4() == nil {
+			return nil, "InvalidRequestedIP", errors.Errorf("the requested IP %q is not a valid IPv4 address", ip)
+		}
+	}
+
+	slices.SortFunc(ips, func(a, b string) int {
+		return cmp.Compare(ipam.StringIPToInt(a), ipam.StringIPToInt(b))
+	})
+
+	for i := 1; i < len(ips); i++ {
+		if ipam.StringIPToInt(ips[i]) != ipam.StringIPToInt(ips[i-1])+1 {
+			return nil, "RequestedIPsNotContiguous", errors.Errorf("the requested IPs %v are not contiguous", requestedIPs)
+		}
+	}
+
+	for _, ip := range ips {
+		cidr := c.pool.GetCIDRFor([]string{ip})
+		if cidr == "" {
+			return nil, "RequestedIPNotInPool", errors.Errorf("the requested IP %q is not contained in any of the GlobalCIDRs %v",
+				ip, c.pool.GetCIDRs())
+		}
+
+		if globalCIDR != "" && cidr != globalCIDR {
+			return nil, "RequestedIPNotInPool", errors.Errorf("the requested IP %q is not contained in the requested GlobalCIDR %q",
+				ip, globalCIDR)
+		}
+	}
+
+	if err := c.pool.Reserve(ips...); err != nil {
+		return nil, "RequestedIPUnavailable", errors.Wrap(err, "the requested IP(s) are unavailable")
+	}
+
+	return ips, "", nil
+}
+
 func (c *baseIPAllocationController) flushRulesAndReleaseIPs(key string, numRequeues int, flushRules func(allocatedIPs []string) error,
 	allocatedIPs ...string,
 ) bool {
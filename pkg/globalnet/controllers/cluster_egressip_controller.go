@@ -24,7 +24,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
@@ -42,7 +41,7 @@ import (
 )
 
 func NewClusterGlobalEgressIPController(config *syncer.ResourceSyncerConfig, localSubnets []string,
-	pool *ipam.IPPool,
+	pool *IPPools,
 ) (Interface, error) {
 	// We'll panic if config is nil, this is intentional
 	var err error
@@ -91,7 +90,7 @@ func NewClusterGlobalEgressIPController(config *syncer.ResourceSyncerConfig, loc
 
 	if obj != nil {
 		err := controller.reserveAllocatedIPs(federator, obj, func(reservedIPs []string) error {
-			metrics.RecordAllocateClusterGlobalEgressIPs(pool.GetCIDR(), len(reservedIPs))
+			metrics.RecordAllocateClusterGlobalEgressIPs(pool.GetCIDRFor(reservedIPs), len(reservedIPs))
 			return controller.programClusterGlobalEgressRules(reservedIPs)
 		})
 		if err != nil {
@@ -208,7 +207,7 @@ func (c *clusterGlobalEgressIPController) onDelete(key string, status *submarine
 }
 
 func (c *clusterGlobalEgressIPController) flushClusterGlobalEgressRules(allocatedIPs []string) error {
-	metrics.RecordDeallocateClusterGlobalEgressIPs(c.pool.GetCIDR(), len(allocatedIPs))
+	metrics.RecordDeallocateClusterGlobalEgressIPs(c.pool.GetCIDRFor(allocatedIPs), len(allocatedIPs))
 	return c.deleteClusterGlobalEgressRules(c.localSubnets, getTargetSNATIPaddress(allocatedIPs))
 }
 
@@ -278,7 +277,7 @@ func (c *clusterGlobalEgressIPController) allocateGlobalIPs(key string, numberOf
 		return true
 	}
 
-	metrics.RecordAllocateClusterGlobalEgressIPs(c.pool.GetCIDR(), numberOfIPs)
+	metrics.RecordAllocateClusterGlobalEgressIPs(c.pool.GetCIDRFor(allocatedIPs), numberOfIPs)
 
 	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
 		Type:    string(submarinerv1.GlobalEgressIPAllocated),
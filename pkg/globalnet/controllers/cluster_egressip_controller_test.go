@@ -24,7 +24,6 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/syncer/test"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
@@ -366,7 +365,7 @@ func newClusterGlobalEgressIPControllerTestDriver() *clusterGlobalEgressIPContro
 
 		var err error
 
-		t.pool, err = ipam.NewIPPool(t.globalCIDR, metrics.GlobalnetMetricsReporter)
+		t.pool, err = controllers.NewIPPools([]string{t.globalCIDR}, metrics.GlobalnetMetricsReporter)
 		Expect(err).To(Succeed())
 	})
 
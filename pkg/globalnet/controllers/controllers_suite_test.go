@@ -31,7 +31,6 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
 	fakeDynClient "github.com/submariner-io/admiral/pkg/fake"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/log/kzerolog"
 	"github.com/submariner-io/admiral/pkg/resource"
 	"github.com/submariner-io/admiral/pkg/syncer/test"
@@ -105,7 +104,7 @@ type testDriverBase struct {
 	dynClient              *dynamicfake.FakeDynamicClient
 	scheme                 *runtime.Scheme
 	pFilter                *fakePF.PacketFilter
-	pool                   *ipam.IPPool
+	pool                   *controllers.IPPools
 	localSubnets           []string
 	globalCIDR             string
 	hostName               string
@@ -116,6 +115,7 @@ type testDriverBase struct {
 	serviceExports         dynamic.ResourceInterface
 	endpoints              dynamic.ResourceInterface
 	pods                   dynamic.NamespaceableResourceInterface
+	namespaces             dynamic.ResourceInterface
 	gateways               dynamic.ResourceInterface
 	watches                *fakeDynClient.WatchReactor
 }
@@ -123,8 +123,8 @@ type testDriverBase struct {
 func newTestDriverBase() *testDriverBase {
 	t := &testDriverBase{
 		restMapper: test.GetRESTMapperFor(&submarinerv1.Endpoint{}, &corev1.Service{}, &corev1.Pod{}, &corev1.Endpoints{},
-			&submarinerv1.GlobalEgressIP{}, &submarinerv1.ClusterGlobalEgressIP{}, &submarinerv1.GlobalIngressIP{},
-			&submarinerv1.Gateway{}, &mcsv1a1.ServiceExport{}),
+			&corev1.Namespace{}, &submarinerv1.GlobalEgressIP{}, &submarinerv1.ClusterGlobalEgressIP{}, &submarinerv1.GlobalIngressIP{},
+			&submarinerv1.Gateway{}, &submarinerv1.Cluster{}, &mcsv1a1.ServiceExport{}),
 		scheme:       runtime.NewScheme(),
 		pFilter:      fakePF.New(),
 		globalCIDR:   globalCIDR,
@@ -147,6 +147,8 @@ func newTestDriverBase() *testDriverBase {
 
 	t.pods = t.dynClient.Resource(*test.GetGroupVersionResourceFor(t.restMapper, &corev1.Pod{}))
 
+	t.namespaces = t.dynClient.Resource(*test.GetGroupVersionResourceFor(t.restMapper, &corev1.Namespace{}))
+
 	t.endpoints = t.dynClient.Resource(*test.GetGroupVersionResourceFor(t.restMapper, &corev1.Endpoints{})).Namespace(namespace)
 
 	t.services = t.dynClient.Resource(*test.GetGroupVersionResourceFor(t.restMapper, &corev1.Service{})).Namespace(namespace)
@@ -175,6 +177,7 @@ func (t *testDriverBase) initChains() {
 		constants.SmGlobalnetEgressChainForHeadlessSvcPods,
 		constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
 		constants.SmGlobalnetEgressChainForNamespace,
+		constants.SmGlobalnetEgressChainForNSSelector,
 		constants.SmGlobalnetEgressChainForCluster,
 		routeAgent.SmPostRoutingChain,
 		constants.SmGlobalnetMarkChain,
@@ -22,52 +22,81 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/admiral/pkg/watcher"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/globalnet/controllers/packetfilter"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/set"
 )
 
 func startEgressPodWatcher(name, namespace string, namedSet packetfilter.NamedSet, config *watcher.Config,
-	podSelector *metav1.LabelSelector,
+	spec *submarinerv1.GlobalEgressIPSpec,
 ) (*egressPodWatcher, error) {
 	pw := &egressPodWatcher{
-		stopCh:   make(chan struct{}),
-		namedSet: namedSet,
+		stopCh:             make(chan struct{}),
+		namedSet:           namedSet,
+		ownNamespace:       namespace,
+		serviceAccountName: spec.ServiceAccountName,
 	}
 
-	sel, err := metav1.LabelSelectorAsSelector(podSelector)
+	sel, err := metav1.LabelSelectorAsSelector(spec.PodSelector)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting label selector")
 	}
 
 	labelSelector := sel.String()
 
-	w, err := watcher.New(&watcher.Config{
-		RestMapper: config.RestMapper,
-		Client:     config.Client,
-		Scheme:     config.Scheme,
-		ResourceConfigs: []watcher.ResourceConfig{
-			{
-				Name:         "Pod watcher " + name,
-				ResourceType: &corev1.Pod{},
-				Handler: watcher.EventHandlerFuncs{
-					OnCreateFunc: pw.onCreateOrUpdate,
-					OnUpdateFunc: pw.onCreateOrUpdate,
-					OnDeleteFunc: pw.onDelete,
-				},
-				ResourcesEquivalent: pw.arePodsEquivalent,
-				SourceNamespace:     namespace,
-				SourceLabelSelector: labelSelector,
+	resourceConfigs := []watcher.ResourceConfig{
+		{
+			Name:         "Pod watcher " + name,
+			ResourceType: &corev1.Pod{},
+			Handler: watcher.EventHandlerFuncs{
+				OnCreateFunc: pw.onCreateOrUpdate,
+				OnUpdateFunc: pw.onCreateOrUpdate,
+				OnDeleteFunc: pw.onDelete,
 			},
+			ResourcesEquivalent: pw.arePodsEquivalent,
+			SourceNamespace:     namespace,
+			SourceLabelSelector: labelSelector,
 		},
+	}
+
+	if spec.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting namespace selector")
+		}
+
+		// Pods are watched in all namespaces and filtered by the namespaces that match the selector.
+		pw.namespaces = set.New[string]()
+		resourceConfigs[0].SourceNamespace = corev1.NamespaceAll
+
+		resourceConfigs = append(resourceConfigs, watcher.ResourceConfig{
+			Name:         "Namespace watcher " + name,
+			ResourceType: &corev1.Namespace{},
+			Handler: watcher.EventHandlerFuncs{
+				OnCreateFunc: pw.onNamespaceCreated,
+				OnDeleteFunc: pw.onNamespaceDeleted,
+			},
+			SourceLabelSelector: nsSelector.String(),
+		})
+	}
+
+	w, err := watcher.New(&watcher.Config{
+		RestMapper:      config.RestMapper,
+		Client:          config.Client,
+		Scheme:          config.Scheme,
+		ResourceConfigs: resourceConfigs,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating resource watcher")
 	}
 
+	pw.watcher = w
+
 	err = w.Start(pw.stopCh)
 	if err != nil {
 		return nil, errors.Wrap(err, "error starting resource watcher")
@@ -76,6 +105,38 @@ func startEgressPodWatcher(name, namespace string, namedSet packetfilter.NamedSe
 	return pw, nil
 }
 
+// isSelected returns true if the pod runs in a selected namespace under the selected service account, if specified.
+// Other namespaces are only selected while the GlobalEgressIP's own namespace is also selected so a GlobalEgressIP
+// can't claim the pods of namespaces that don't share the labels of its own, which are normally controlled by the
+// cluster admin. The caller must hold the lock.
+func (w *egressPodWatcher) isSelected(pod *corev1.Pod) bool {
+	if w.serviceAccountName != "" && pod.Spec.ServiceAccountName != w.serviceAccountName {
+		return false
+	}
+
+	return w.namespaces == nil || (w.namespaces.Has(w.ownNamespace) && w.namespaces.Has(pod.Namespace))
+}
+
+// podsAffectedBy returns the pods whose selection depends on the given namespace. Selecting or deselecting the
+// GlobalEgressIP's own namespace affects the pods in all the selected namespaces. The caller must hold the lock.
+func (w *egressPodWatcher) podsAffectedBy(namespace string) []*corev1.Pod {
+	namespaces := set.New(namespace)
+	if namespace == w.ownNamespace {
+		namespaces = namespaces.Union(w.namespaces)
+	}
+
+	var pods []*corev1.Pod
+
+	for _, obj := range w.watcher.ListResources(&corev1.Pod{}, nil) {
+		pod := obj.(*corev1.Pod)
+		if namespaces.Has(pod.Namespace) && pod.Status.PodIP != "" {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods
+}
+
 func (w *egressPodWatcher) arePodsEquivalent(oldObj, newObj *unstructured.Unstructured) bool {
 	oldPodIP, _, _ := unstructured.NestedString(oldObj.Object, "status", "podIP")
 	newPodIP, _, _ := unstructured.NestedString(newObj.Object, "status", "podIP")
@@ -91,6 +152,13 @@ func (w *egressPodWatcher) onCreateOrUpdate(obj runtime.Object, _ int) bool {
 		return false
 	}
 
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.isSelected(pod) {
+		return false
+	}
+
 	logger.V(log.DEBUG).Infof("Pod %q with IP %s created/updated", key, pod.Status.PodIP)
 
 	if err := w.namedSet.AddEntry(pod.Status.PodIP, true); err != nil {
@@ -105,6 +173,13 @@ func (w *egressPodWatcher) onDelete(obj runtime.Object, _ int) bool {
 	pod := obj.(*corev1.Pod)
 	key, _ := cache.MetaNamespaceKeyFunc(pod)
 
+	w.Lock()
+	defer w.Unlock()
+
+	if !w.isSelected(pod) {
+		return false
+	}
+
 	logger.V(log.DEBUG).Infof("Pod %q removed", key)
 
 	if err := w.namedSet.DelEntry(pod.Status.PodIP); err != nil {
@@ -114,3 +189,51 @@ func (w *egressPodWatcher) onDelete(obj runtime.Object, _ int) bool {
 
 	return false
 }
+
+func (w *egressPodWatcher) onNamespaceCreated(obj runtime.Object, _ int) bool {
+	namespace := obj.(*corev1.Namespace).Name
+
+	w.Lock()
+	defer w.Unlock()
+
+	logger.V(log.DEBUG).Infof("Namespace %q selected", namespace)
+
+	w.namespaces.Insert(namespace)
+
+	for _, pod := range w.podsAffectedBy(namespace) {
+		if !w.isSelected(pod) {
+			continue
+		}
+
+		if err := w.namedSet.AddEntry(pod.Status.PodIP, true); err != nil {
+			logger.Errorf(err, "Error adding pod IP %q to IP set %q", pod.Status.PodIP, w.namedSetName)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *egressPodWatcher) onNamespaceDeleted(obj runtime.Object, _ int) bool {
+	namespace := obj.(*corev1.Namespace).Name
+
+	w.Lock()
+	defer w.Unlock()
+
+	logger.V(log.DEBUG).Infof("Namespace %q no longer selected", namespace)
+
+	for _, pod := range w.podsAffectedBy(namespace) {
+		if !w.isSelected(pod) {
+			continue
+		}
+
+		if err := w.namedSet.DelEntry(pod.Status.PodIP); err != nil {
+			logger.Errorf(err, "Error deleting pod IP %q from IP set %q", pod.Status.PodIP, w.namedSetName)
+			return true
+		}
+	}
+
+	w.namespaces.Delete(namespace)
+
+	return false
+}
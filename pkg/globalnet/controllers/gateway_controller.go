@@ -23,7 +23,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	admUtil "github.com/submariner-io/admiral/pkg/util"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
@@ -36,7 +35,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-func NewGatewayController(config *syncer.ResourceSyncerConfig, informer cache.SharedInformer, pool *ipam.IPPool, hostName,
+func NewGatewayController(config *syncer.ResourceSyncerConfig, informer cache.SharedInformer, pool *IPPools, hostName,
 	namespace, cniIP string,
 ) (Interface, error) {
 	// We'll panic if config is nil, this is intentional
@@ -21,12 +21,12 @@ package controllers
 import (
 	"context"
 	"os"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/syncer/broker"
@@ -146,6 +146,11 @@ func (g *gatewayMonitorInterface) Start() error {
 		return err
 	}
 
+	err = g.monitor.startClusterWatcher()
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		g.monitor.gatewaySharedInformer.Run(g.monitor.gatewaySharedInformerStopCh)
 	}()
@@ -227,21 +232,21 @@ func (g *gatewayMonitor) RemoteEndpointCreated(endpoint *v1.Endpoint) error {
 	logger.V(log.DEBUG).Infof("Endpoint %q, host: %q belongs to a remote cluster",
 		endpoint.Spec.ClusterID, endpoint.Spec.Hostname)
 
-	globalCIDR := g.GatewayMonitorConfig.Spec.GlobalCIDR[0]
-
-	overlap, err := cidr.IsOverlapping(endpoint.Spec.Subnets, globalCIDR)
-	if err != nil {
-		// Ideally this case will never hit, as the subnets are valid CIDRs
-		logger.Warningf("unable to validate overlapping Service CIDR: %s", err)
-	}
+	for _, globalCIDR := range g.globalCIDRs() {
+		overlap, err := cidr.IsOverlapping(endpoint.Spec.Subnets, globalCIDR)
+		if err != nil {
+			// Ideally this case will never hit, as the subnets are valid CIDRs
+			logger.Warningf("unable to validate overlapping Service CIDR: %s", err)
+		}
 
-	if overlap {
-		// When GlobalNet is used, globalCIDRs allocated to the clusters should not overlap.
-		// If they overlap, skip the endpoint as its an invalid configuration which is not supported.
-		logger.Errorf(nil, "GlobalCIDR %q of local cluster %q overlaps with remote cluster %s",
-			globalCIDR, g.GatewayMonitorConfig.Spec.ClusterID, endpoint.Spec.ClusterID)
+		if overlap {
+			// When GlobalNet is used, globalCIDRs allocated to the clusters should not overlap.
+			// If they overlap, skip the endpoint as its an invalid configuration which is not supported.
+			logger.Errorf(nil, "GlobalCIDR %q of local cluster %q overlaps with remote cluster %s",
+				globalCIDR, g.GatewayMonitorConfig.Spec.ClusterID, endpoint.Spec.ClusterID)
 
-		return nil
+			return nil
+		}
 	}
 
 	g.markRemoteClusterTraffic(AddRules, endpoint.Spec.Subnets...)
@@ -352,9 +357,9 @@ func (g *gatewayMonitor) startControllers() error {
 		return err
 	}
 
-	pool, err := ipam.NewIPPool(g.GatewayMonitorConfig.Spec.GlobalCIDR[0], metrics.GlobalnetMetricsReporter)
+	pool, err := g.newIPPools()
 	if err != nil {
-		return errors.Wrap(err, "error creating the IP pool")
+		return err
 	}
 
 	g.controllers = nil
@@ -423,6 +428,20 @@ func (g *gatewayMonitor) startControllers() error {
 
 	g.controllers = append(g.controllers, c)
 
+	c, err = NewIngressServiceTargetController(g.syncerConfig, gipController.GetSyncer())
+	if err != nil {
+		return errors.Wrap(err, "error creating the ingress Service target controller")
+	}
+
+	g.controllers = append(g.controllers, c)
+
+	c, err = NewIngressPodTargetController(g.syncerConfig)
+	if err != nil {
+		return errors.Wrap(err, "error creating the ingress Pod target controller")
+	}
+
+	g.controllers = append(g.controllers, c)
+
 	if g.cniIP != "" {
 		c, err := NewGatewayController(g.syncerConfig, g.gatewaySharedInformer, pool, g.Hostname, g.Spec.Namespace, g.cniIP)
 		if err != nil {
@@ -432,6 +451,13 @@ func (g *gatewayMonitor) startControllers() error {
 		g.controllers = append(g.controllers, c)
 	}
 
+	c, err = NewIPAuditor(g.syncerConfig, pool, g.Spec.AuditInterval)
+	if err != nil {
+		return errors.Wrap(err, "error creating the IP auditor")
+	}
+
+	g.controllers = append(g.controllers, c)
+
 	for _, c := range g.controllers {
 		err = c.Start()
 		if err != nil {
@@ -455,6 +481,10 @@ func (g *gatewayMonitor) stopControllers(ctx context.Context, clearGlobalnetChai
 
 	g.controllers = nil
 
+	g.globalCIDRsMutex.Lock()
+	g.pools = nil
+	g.globalCIDRsMutex.Unlock()
+
 	if clearGlobalnetChains {
 		g.clearGlobalnetChains()
 	}
@@ -511,6 +541,88 @@ func (g *gatewayMonitor) startLocalGatewayCleanupController() error {
 	return errors.Wrap(rSyncer.Start(g.gatewaySharedInformerStopCh), "error starting gateway resource syncer")
 }
 
+func (g *gatewayMonitor) globalCIDRs() []string {
+	g.globalCIDRsMutex.Lock()
+	defer g.globalCIDRsMutex.Unlock()
+
+	return slices.Clone(g.Spec.GlobalCIDR)
+}
+
+func (g *gatewayMonitor) newIPPools() (*IPPools, error) {
+	g.globalCIDRsMutex.Lock()
+	defer g.globalCIDRsMutex.Unlock()
+
+	pools, err := NewIPPools(g.Spec.GlobalCIDR, metrics.GlobalnetMetricsReporter)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the IP pools")
+	}
+
+	g.pools = pools
+
+	return pools, nil
+}
+
+// startClusterWatcher watches the local Cluster resource for GlobalCIDRs appended to its spec, which are added as new IP
+// pools without affecting the existing allocations.
+func (g *gatewayMonitor) startClusterWatcher() error {
+	rSyncer, err := syncer.NewResourceSyncer(&syncer.ResourceSyncerConfig{
+		Name:            "Cluster syncer",
+		ResourceType:    &v1.Cluster{},
+		SourceClient:    g.Client,
+		SourceNamespace: g.Spec.Namespace,
+		RestMapper:      g.RestMapper,
+		Federator:       federate.NewNoopFederator(),
+		Scheme:          g.Scheme,
+		ShouldProcess: func(obj *unstructured.Unstructured, op syncer.Operation) bool {
+			return op != syncer.Delete && obj.GetName() == g.Spec.ClusterID
+		},
+		Transform: func(from runtime.Object, _ int, _ syncer.Operation) (runtime.Object, bool) {
+			g.updateGlobalCIDRs(from.(*v1.Cluster).Spec.GlobalCIDR)
+			return nil, false
+		},
+		WaitForCacheSync: ptr.To(false),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating the Cluster resource syncer")
+	}
+
+	return errors.Wrap(rSyncer.Start(g.gatewaySharedInformerStopCh), "error starting the Cluster resource syncer")
+}
+
+func (g *gatewayMonitor) updateGlobalCIDRs(globalCIDRs []string) {
+	g.globalCIDRsMutex.Lock()
+	defer g.globalCIDRsMutex.Unlock()
+
+	for _, globalCIDR := range globalCIDRs {
+		if slices.Contains(g.Spec.GlobalCIDR, globalCIDR) {
+			continue
+		}
+
+		if err := validateGlobalCIDR(g.Spec.GlobalCIDR, globalCIDR); err != nil {
+			logger.Errorf(err, "Ignoring invalid GlobalCIDR %q added to Cluster %q", globalCIDR, g.Spec.ClusterID)
+			continue
+		}
+
+		if g.pools != nil {
+			if _, err := g.pools.AddCIDR(globalCIDR); err != nil {
+				logger.Errorf(err, "Error adding the IP pool for GlobalCIDR %q", globalCIDR)
+				continue
+			}
+		}
+
+		logger.Infof("GlobalCIDR %q added to Cluster %q", globalCIDR, g.Spec.ClusterID)
+
+		g.Spec.GlobalCIDR = append(g.Spec.GlobalCIDR, globalCIDR)
+	}
+
+	for _, globalCIDR := range g.Spec.GlobalCIDR {
+		if !slices.Contains(globalCIDRs, globalCIDR) {
+			logger.Warningf("GlobalCIDR %q was removed from Cluster %q but removing a GlobalCIDR isn't supported - "+
+				"its allocated global IPs remain in use", globalCIDR, g.Spec.ClusterID)
+		}
+	}
+}
+
 func (g *gatewayMonitor) createNATChain(chainName string) error {
 	logger.V(log.DEBUG).Infof("Install/ensure chain %q exists", chainName)
 
@@ -553,6 +665,7 @@ func (g *gatewayMonitor) createGlobalnetChains() error {
 		constants.SmGlobalnetEgressChainForHeadlessSvcPods,
 		constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
 		constants.SmGlobalnetEgressChainForNamespace,
+		constants.SmGlobalnetEgressChainForNSSelector,
 		constants.SmGlobalnetEgressChainForCluster,
 	} {
 		if err := g.createNATChain(chain); err != nil {
@@ -590,6 +703,10 @@ func (g *gatewayMonitor) createGlobalnetChains() error {
 			TargetChain: constants.SmGlobalnetEgressChainForNamespace,
 			Action:      packetfilter.RuleActionJump,
 		},
+		&packetfilter.Rule{
+			TargetChain: constants.SmGlobalnetEgressChainForNSSelector,
+			Action:      packetfilter.RuleActionJump,
+		},
 		&packetfilter.Rule{
 			TargetChain: constants.SmGlobalnetEgressChainForCluster,
 			Action:      packetfilter.RuleActionJump,
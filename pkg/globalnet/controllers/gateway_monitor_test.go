@@ -88,6 +88,38 @@ var _ = Describe("Endpoint monitoring", func() {
 			})
 		})
 
+		Context("and a GlobalCIDR is added to the Cluster", func() {
+			const addedGlobalCIDR = "242.10.2.0/24"
+
+			AfterEach(func() {
+				t.awaitGlobalnetChainsCleared()
+			})
+
+			It("should allocate the global IPs requested from it", func() {
+				t.awaitControllersStarted()
+
+				clusters := t.dynClient.Resource(*test.GetGroupVersionResourceFor(t.restMapper, &submarinerv1.Cluster{})).
+					Namespace(namespace)
+				test.CreateResource(clusters, &submarinerv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: clusterID},
+					Spec: submarinerv1.ClusterSpec{
+						ClusterID:  clusterID,
+						GlobalCIDR: []string{globalCIDR, addedGlobalCIDR},
+					},
+				})
+
+				egressIP := newGlobalEgressIP(globalEgressIPName, nil, nil)
+				egressIP.Spec.GlobalCIDR = addedGlobalCIDR
+				t.createGlobalEgressIP(egressIP)
+
+				Eventually(func() []string {
+					return getGlobalEgressIPStatus(t.globalEgressIPs, globalEgressIPName).AllocatedIPs
+				}, 5).Should(HaveExactElements(Satisfy(func(ip string) bool {
+					return isValidIPForCIDR(addedGlobalCIDR, ip)
+				})))
+			})
+		})
+
 		Context("and then deleted and recreated", func() {
 			JustBeforeEach(func() {
 				t.awaitControllersStarted()
@@ -285,6 +317,7 @@ var _ = Describe("Uninstall", func() {
 			constants.SmGlobalnetEgressChainForHeadlessSvcPods,
 			constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
 			constants.SmGlobalnetEgressChainForNamespace,
+			constants.SmGlobalnetEgressChainForNSSelector,
 			constants.SmGlobalnetEgressChainForPods,
 			constants.SmGlobalnetIngressChain,
 			constants.SmGlobalnetMarkChain,
@@ -471,9 +504,25 @@ func (t *gatewayMonitorTestDriver) awaitGlobalnetChains() {
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForHeadlessSvcPods)
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForHeadlessSvcEPs)
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForNamespace)
+	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForNSSelector)
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForCluster)
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, routeAgent.SmPostRoutingChain)
 	t.pFilter.AwaitChain(packetfilter.TableTypeNAT, constants.SmGlobalnetMarkChain)
+
+	// The order of the egress chains decides which rules take precedence.
+	Eventually(func() []string {
+		rules, _ := t.pFilter.List(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChain)
+
+		targets := []string{}
+		for _, rule := range rules {
+			targets = append(targets, rule.TargetChain)
+		}
+
+		return targets
+	}, 5).Should(HaveExactElements(constants.SmGlobalnetMarkChain, constants.SmGlobalnetEgressChainForPods,
+		constants.SmGlobalnetEgressChainForHeadlessSvcPods, constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
+		constants.SmGlobalnetEgressChainForNamespace, constants.SmGlobalnetEgressChainForNSSelector,
+		constants.SmGlobalnetEgressChainForCluster))
 }
 
 func (t *gatewayMonitorTestDriver) awaitGlobalnetChainsCleared() {
@@ -26,7 +26,6 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	"github.com/submariner-io/admiral/pkg/watcher"
@@ -39,9 +38,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/set"
 )
 
-func NewGlobalEgressIPController(config *syncer.ResourceSyncerConfig, pool *ipam.IPPool) (Interface, error) {
+func NewGlobalEgressIPController(config *syncer.ResourceSyncerConfig, pool *IPPools) (Interface, error) {
 	// We'll panic if config is nil, this is intentional
 	var err error
 
@@ -78,14 +78,14 @@ func NewGlobalEgressIPController(config *syncer.ResourceSyncerConfig, pool *ipam
 
 	for i := range list.Items {
 		err = controller.reserveAllocatedIPs(federator, &list.Items[i], func(reservedIPs []string) error {
-			metrics.RecordAllocateGlobalEgressIPs(pool.GetCIDR(), len(reservedIPs))
+			metrics.RecordAllocateGlobalEgressIPs(pool.GetCIDRFor(reservedIPs), len(reservedIPs))
 
 			specObj := util.GetSpec(&list.Items[i])
 			spec := &submarinerv1.GlobalEgressIPSpec{}
 			_ = runtime.DefaultUnstructuredConverter.FromUnstructured(specObj.(map[string]interface{}), spec)
 			key, _ := cache.MetaNamespaceKeyFunc(&list.Items[i])
 
-			return controller.programGlobalEgressRules(key, reservedIPs, spec.PodSelector, controller.newNamedSet(key))
+			return controller.programGlobalEgressRules(key, reservedIPs, spec, controller.newNamedSet(key))
 		})
 		if err != nil {
 			return nil, err
@@ -127,12 +127,15 @@ func (c *globalEgressIPController) process(from runtime.Object, numRequeues int,
 	numberOfIPs := 1
 	if globalEgressIP.Spec.NumberOfIPs != nil {
 		numberOfIPs = *globalEgressIP.Spec.NumberOfIPs
+	} else if len(globalEgressIP.Spec.RequestedIPs) > 0 {
+		numberOfIPs = len(globalEgressIP.Spec.RequestedIPs)
 	}
 
 	key, _ := cache.MetaNamespaceKeyFunc(globalEgressIP)
 
-	logger.Infof("Processing %sd GlobalEgressIP %q, NumberOfIPs: %d, PodSelector: %#v, Status: %#v", op, key,
-		numberOfIPs, globalEgressIP.Spec.PodSelector, globalEgressIP.Status)
+	logger.Infof("Processing %sd GlobalEgressIP %q, NumberOfIPs: %d, PodSelector: %#v, NamespaceSelector: %#v, "+
+		"ServiceAccountName: %q, Status: %#v", op, key, numberOfIPs, globalEgressIP.Spec.PodSelector,
+		globalEgressIP.Spec.NamespaceSelector, globalEgressIP.Spec.ServiceAccountName, globalEgressIP.Status)
 
 	switch op {
 	case syncer.Create, syncer.Update:
@@ -161,6 +164,13 @@ func (c *globalEgressIPController) onCreateOrUpdate(key string, numberOfIPs int,
 	requeue := false
 	if numberOfIPs != len(globalEgressIP.Status.AllocatedIPs) {
 		requeue = c.flushGlobalEgressRulesAndReleaseIPs(key, namedSet.Name(), numRequeues, globalEgressIP)
+	} else if !c.isAllocationUpToDate(globalEgressIP) {
+		logger.Infof("The requested GlobalCIDR or IPs for %q changed - re-allocating the global IP(s)", key)
+
+		requeue = c.flushGlobalEgressRulesAndReleaseIPs(key, namedSet.Name(), numRequeues, globalEgressIP)
+		if !requeue {
+			globalEgressIP.Status.AllocatedIPs = nil
+		}
 	}
 
 	return requeue || c.allocateGlobalIPs(key, numberOfIPs, globalEgressIP, namedSet) ||
@@ -168,8 +178,8 @@ func (c *globalEgressIPController) onCreateOrUpdate(key string, numberOfIPs int,
 }
 
 //nolint:wrapcheck  // No need to wrap these errors.
-func (c *globalEgressIPController) programGlobalEgressRules(key string, allocatedIPs []string, podSelector *metav1.LabelSelector,
-	namedSet packetfilter.NamedSet,
+func (c *globalEgressIPController) programGlobalEgressRules(key string, allocatedIPs []string,
+	spec *submarinerv1.GlobalEgressIPSpec, namedSet packetfilter.NamedSet,
 ) error {
 	err := namedSet.Create(true)
 	if err != nil {
@@ -177,12 +187,19 @@ func (c *globalEgressIPController) programGlobalEgressRules(key string, allocate
 	}
 
 	snatIP := getTargetSNATIPaddress(allocatedIPs)
-	if podSelector != nil {
+
+	switch {
+	case selectsPods(spec):
 		if err := c.pfIface.AddEgressRulesForPods(key, namedSet.Name(), snatIP, globalNetIPTableMark); err != nil {
 			_ = c.pfIface.RemoveEgressRulesForPods(key, namedSet.Name(), snatIP, globalNetIPTableMark)
 			return err
 		}
-	} else {
+	case spec.NamespaceSelector != nil:
+		if err := c.pfIface.AddEgressRulesForNSSelector(key, namedSet.Name(), snatIP, globalNetIPTableMark); err != nil {
+			_ = c.pfIface.RemoveEgressRulesForNSSelector(key, namedSet.Name(), snatIP, globalNetIPTableMark)
+			return err
+		}
+	default:
 		if err := c.pfIface.AddEgressRulesForNamespace(key, namedSet.Name(), snatIP, globalNetIPTableMark); err != nil {
 			_ = c.pfIface.RemoveEgressRulesForNamespace(key, namedSet.Name(), snatIP, globalNetIPTableMark)
 			return err
@@ -216,21 +233,21 @@ func (c *globalEgressIPController) allocateGlobalIPs(key string, numberOfIPs int
 
 	globalEgressIP.Status.AllocatedIPs = nil
 
-	allocatedIPs, err := c.pool.Allocate(numberOfIPs)
+	allocatedIPs, reason, err := c.allocateIPs(globalEgressIP.Spec.GlobalCIDR, globalEgressIP.Spec.RequestedIPs, numberOfIPs)
 	if err != nil {
 		logger.Errorf(err, "Error allocating IPs for %q", key)
 
 		meta.SetStatusCondition(&globalEgressIP.Status.Conditions, metav1.Condition{
 			Type:    string(submarinerv1.GlobalEgressIPAllocated),
 			Status:  metav1.ConditionFalse,
-			Reason:  "IPPoolAllocationFailed",
+			Reason:  reason,
 			Message: fmt.Sprintf("Error allocating %d global IP(s) from the pool: %v", numberOfIPs, err),
 		})
 
 		return true
 	}
 
-	err = c.programGlobalEgressRules(key, allocatedIPs, globalEgressIP.Spec.PodSelector, namedSet)
+	err = c.programGlobalEgressRules(key, allocatedIPs, &globalEgressIP.Spec, namedSet)
 	if err != nil {
 		logger.Errorf(err, "Error programming egress IP table rules for %q", key)
 
@@ -246,7 +263,7 @@ func (c *globalEgressIPController) allocateGlobalIPs(key string, numberOfIPs int
 		return true
 	}
 
-	metrics.RecordAllocateGlobalEgressIPs(c.pool.GetCIDR(), numberOfIPs)
+	metrics.RecordAllocateGlobalEgressIPs(c.pool.GetCIDRFor(allocatedIPs), numberOfIPs)
 
 	meta.SetStatusCondition(&globalEgressIP.Status.Conditions, metav1.Condition{
 		Type:    string(submarinerv1.GlobalEgressIPAllocated),
@@ -262,6 +279,23 @@ func (c *globalEgressIPController) allocateGlobalIPs(key string, numberOfIPs int
 	return false
 }
 
+// isAllocationUpToDate returns true if the allocated IPs are from the requested GlobalCIDR and are the requested IPs, if
+// specified.
+func (c *globalEgressIPController) isAllocationUpToDate(globalEgressIP *submarinerv1.GlobalEgressIP) bool {
+	allocatedIPs := globalEgressIP.Status.AllocatedIPs
+	if len(allocatedIPs) == 0 {
+		return true
+	}
+
+	if globalEgressIP.Spec.GlobalCIDR != "" && c.pool.GetCIDRFor(allocatedIPs) != globalEgressIP.Spec.GlobalCIDR {
+		return false
+	}
+
+	requestedIPs := globalEgressIP.Spec.RequestedIPs
+
+	return len(requestedIPs) == 0 || set.New(requestedIPs...).Equal(set.New(allocatedIPs...))
+}
+
 func (c *globalEgressIPController) validate(numberOfIPs int, egressIP *submarinerv1.GlobalEgressIP) bool {
 	if numberOfIPs < 0 {
 		meta.SetStatusCondition(&egressIP.Status.Conditions, metav1.Condition{
@@ -274,6 +308,17 @@ func (c *globalEgressIPController) validate(numberOfIPs int, egressIP *submarine
 		return false
 	}
 
+	if len(egressIP.Spec.RequestedIPs) > 0 && numberOfIPs != len(egressIP.Spec.RequestedIPs) {
+		meta.SetStatusCondition(&egressIP.Status.Conditions, metav1.Condition{
+			Type:    string(submarinerv1.GlobalEgressIPAllocated),
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidInput",
+			Message: "The NumberOfIPs must match the number of RequestedIPs",
+		})
+
+		return false
+	}
+
 	return true
 }
 
@@ -319,7 +364,7 @@ func (c *globalEgressIPController) onDelete(numRequeues int, globalEgressIP *sub
 	return false
 }
 
-func (c *globalEgressIPController) getIPSetName(key string) string {
+func getEgressIPSetName(key string) string {
 	hash := sha256.Sum256([]byte(key))
 	encoded := base32.StdEncoding.EncodeToString(hash[:])
 	// Max length of IPSet name can be 31
@@ -345,6 +390,28 @@ func (c *globalEgressIPController) createPodWatcher(key string, namedSet packetf
 			})
 		}
 
+		if !equality.Semantic.DeepEqual(prevPodWatcher.namespaceSelector, globalEgressIP.Spec.NamespaceSelector) {
+			logger.Errorf(nil, "NamespaceSelector for %q cannot be updated after creation", key)
+
+			meta.SetStatusCondition(&globalEgressIP.Status.Conditions, metav1.Condition{
+				Type:    string(submarinerv1.GlobalEgressIPUpdated),
+				Status:  metav1.ConditionFalse,
+				Reason:  "NamespaceSelectorUpdateNotSupported",
+				Message: "The NamespaceSelector cannot be updated after creation",
+			})
+		}
+
+		if prevPodWatcher.serviceAccountName != globalEgressIP.Spec.ServiceAccountName {
+			logger.Errorf(nil, "ServiceAccountName for %q cannot be updated after creation", key)
+
+			meta.SetStatusCondition(&globalEgressIP.Status.Conditions, metav1.Condition{
+				Type:    string(submarinerv1.GlobalEgressIPUpdated),
+				Status:  metav1.ConditionFalse,
+				Reason:  "ServiceAccountNameUpdateNotSupported",
+				Message: "The ServiceAccountName cannot be updated after creation",
+			})
+		}
+
 		return true
 	}
 
@@ -352,7 +419,7 @@ func (c *globalEgressIPController) createPodWatcher(key string, namedSet packetf
 		return true
 	}
 
-	podWatcher, err := startEgressPodWatcher(key, globalEgressIP.Namespace, namedSet, &c.watcherConfig, globalEgressIP.Spec.PodSelector)
+	podWatcher, err := startEgressPodWatcher(key, globalEgressIP.Namespace, namedSet, &c.watcherConfig, &globalEgressIP.Spec)
 	if err != nil {
 		logger.Errorf(err, "Error starting pod watcher for %q", key)
 		return false
@@ -360,6 +427,7 @@ func (c *globalEgressIPController) createPodWatcher(key string, namedSet packetf
 
 	c.podWatchers[key] = podWatcher
 	podWatcher.podSelector = globalEgressIP.Spec.PodSelector
+	podWatcher.namespaceSelector = globalEgressIP.Spec.NamespaceSelector
 	podWatcher.allocatedIPs = globalEgressIP.Status.AllocatedIPs
 
 	logger.Infof("Started pod watcher for %q", key)
@@ -371,17 +439,29 @@ func (c *globalEgressIPController) flushGlobalEgressRulesAndReleaseIPs(key, name
 	globalEgressIP *submarinerv1.GlobalEgressIP,
 ) bool {
 	return c.flushRulesAndReleaseIPs(key, numRequeues, func(allocatedIPs []string) error {
-		metrics.RecordDeallocateGlobalEgressIPs(c.pool.GetCIDR(), len(allocatedIPs))
+		metrics.RecordDeallocateGlobalEgressIPs(c.pool.GetCIDRFor(allocatedIPs), len(allocatedIPs))
 
-		if globalEgressIP.Spec.PodSelector != nil {
+		if selectsPods(&globalEgressIP.Spec) {
 			return c.pfIface.RemoveEgressRulesForPods(key, namedSetName,
 				getTargetSNATIPaddress(allocatedIPs), globalNetIPTableMark)
 		}
 
+		if globalEgressIP.Spec.NamespaceSelector != nil {
+			return c.pfIface.RemoveEgressRulesForNSSelector(key, namedSetName,
+				getTargetSNATIPaddress(allocatedIPs), globalNetIPTableMark)
+		}
+
 		return c.pfIface.RemoveEgressRulesForNamespace(key, namedSetName, getTargetSNATIPaddress(allocatedIPs), globalNetIPTableMark)
 	}, globalEgressIP.Status.AllocatedIPs...)
 }
 
+// selectsPods returns true if the GlobalEgressIP selects specific pods, in which case its egress rules take precedence
+// over those for whole namespaces. Those for a single namespace in turn take precedence over those for the namespaces
+// selected by label.
+func selectsPods(spec *submarinerv1.GlobalEgressIPSpec) bool {
+	return spec.PodSelector != nil || spec.ServiceAccountName != ""
+}
+
 func (c *globalEgressIPController) newNamedSet(key string) packetfilter.NamedSet {
-	return c.pfIface.NewNamedSet(c.getIPSetName(key))
+	return c.pfIface.NewNamedSet(getEgressIPSetName(key))
 }
@@ -25,7 +25,6 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	fakeDynClient "github.com/submariner-io/admiral/pkg/fake"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/syncer/test"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
@@ -72,10 +71,12 @@ var _ = Describe("GlobalEgressIP controller", func() {
 
 func testGlobalEgressIPCreated(t *globalEgressIPControllerTestDriver, podSelector *metav1.LabelSelector) {
 	var numberOfIPs *int
+	var requestedIPs []string
 	var egressChain string
 
 	BeforeEach(func() {
 		numberOfIPs = nil
+		requestedIPs = nil
 
 		if podSelector == nil {
 			egressChain = constants.SmGlobalnetEgressChainForNamespace
@@ -86,6 +87,7 @@ func testGlobalEgressIPCreated(t *globalEgressIPControllerTestDriver, podSelecto
 
 	JustBeforeEach(func() {
 		egressIP := newGlobalEgressIP(globalEgressIPName, numberOfIPs, podSelector)
+		egressIP.Spec.RequestedIPs = requestedIPs
 		t.createGlobalEgressIP(egressIP)
 	})
 
@@ -146,6 +148,76 @@ func testGlobalEgressIPCreated(t *globalEgressIPControllerTestDriver, podSelecto
 		})
 	})
 
+	Context("with RequestedIPs specified", func() {
+		BeforeEach(func() {
+			requestedIPs = []string{globalIP2, globalIP1}
+		})
+
+		It("should allocate the requested global IPs and program the necessary IP table rules", func() {
+			t.awaitGlobalEgressIPStatusAllocated(globalEgressIPName, len(requestedIPs))
+			Expect(getGlobalEgressIPStatus(t.globalEgressIPs, globalEgressIPName).AllocatedIPs).To(Equal([]string{globalIP1, globalIP2}))
+			t.verifyIPsReservedInPool(requestedIPs...)
+			t.awaitPacketFilterRules(egressChain, globalIP1, globalIP2)
+		})
+
+		Context("and they're already allocated", func() {
+			BeforeEach(func() {
+				Expect(t.pool.Reserve(globalIP1)).To(Succeed())
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitEgressIPStatus(t.globalEgressIPs, globalEgressIPName, 0, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "RequestedIPUnavailable",
+				})
+			})
+		})
+
+		Context("and they're not contiguous", func() {
+			BeforeEach(func() {
+				requestedIPs = []string{globalIP1, globalIP3}
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitEgressIPStatus(t.globalEgressIPs, globalEgressIPName, 0, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "RequestedIPsNotContiguous",
+				})
+			})
+		})
+
+		Context("and they're not in the GlobalCIDR", func() {
+			BeforeEach(func() {
+				requestedIPs = []string{"242.10.2.100"}
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitEgressIPStatus(t.globalEgressIPs, globalEgressIPName, 0, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "RequestedIPNotInPool",
+				})
+			})
+		})
+
+		Context("and the NumberOfIPs doesn't match", func() {
+			BeforeEach(func() {
+				n := 3
+				numberOfIPs = &n
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitEgressIPStatus(t.globalEgressIPs, globalEgressIPName, 0, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "InvalidInput",
+				})
+			})
+		})
+	})
+
 	Context("and programming the IP table rules initially fails", func() {
 		BeforeEach(func() {
 			t.pFilter.AddFailOnAppendRuleMatcher(Not(BeEmpty()))
@@ -300,6 +372,26 @@ func testExistingGlobalEgressIP(t *globalEgressIPControllerTestDriver, podSelect
 		})
 	})
 
+	Context("and the RequestedIPs changed", func() {
+		BeforeEach(func() {
+			existing.Spec.RequestedIPs = []string{"242.10.1.200", "242.10.1.201", "242.10.1.202"}
+			t.createGlobalEgressIP(existing)
+		})
+
+		It("should reallocate the requested global IPs", func() {
+			Eventually(func() []string {
+				return getGlobalEgressIPStatus(t.globalEgressIPs, globalEgressIPName).AllocatedIPs
+			}, 5).Should(Equal(existing.Spec.RequestedIPs))
+
+			t.awaitPacketFilterRules(egressChain, existing.Spec.RequestedIPs...)
+		})
+
+		It("should release the previously allocated IPs", func() {
+			t.awaitIPsReleasedFromPool(existing.Status.AllocatedIPs...)
+			t.awaitNoPacketFilterRules(egressChain, existing.Status.AllocatedIPs...)
+		})
+	})
+
 	Context("and the allocated IPs are already reserved", func() {
 		BeforeEach(func() {
 			existing.Status.Conditions = []metav1.Condition{
@@ -525,6 +617,33 @@ func testEgressPodEvents(t *globalEgressIPControllerTestDriver) {
 			})
 		})
 
+		Context("and the GlobalEgressIP has a service account name", func() {
+			BeforeEach(func() {
+				egressChain = constants.SmGlobalnetEgressChainForPods
+				egressIP.Spec.ServiceAccountName = "builder"
+			})
+
+			Context("and it matches the Pod's service account", func() {
+				BeforeEach(func() {
+					pod.Spec.ServiceAccountName = egressIP.Spec.ServiceAccountName
+				})
+
+				It("should add the Pod IP to the IP set", func() {
+					t.pFilter.AwaitEntry(ipSet, pod.Status.PodIP)
+				})
+			})
+
+			Context("and it does not match the Pod's service account", func() {
+				BeforeEach(func() {
+					pod.Spec.ServiceAccountName = "default"
+				})
+
+				It("should not add the Pod IP to the IP set", func() {
+					t.pFilter.AwaitNoEntry(ipSet, pod.Status.PodIP)
+				})
+			})
+		})
+
 		Context("and then deleted", func() {
 			JustBeforeEach(func() {
 				t.pFilter.AwaitEntry(ipSet, pod.Status.PodIP)
@@ -583,6 +702,82 @@ func testEgressPodEvents(t *globalEgressIPControllerTestDriver) {
 			t.pFilter.AwaitNoEntry(ipSet, pod.Status.PodIP)
 		})
 	})
+
+	Context("in a namespace selected by the GlobalEgressIP's Namespace selector", func() {
+		var (
+			ns    *corev1.Namespace
+			ownNS *corev1.Namespace
+		)
+
+		BeforeEach(func() {
+			egressChain = constants.SmGlobalnetEgressChainForNSSelector
+			pod.Namespace = "foo"
+			egressIP.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}
+			ns = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   pod.Namespace,
+					Labels: egressIP.Spec.NamespaceSelector.MatchLabels,
+				},
+			}
+			ownNS = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   namespace,
+					Labels: egressIP.Spec.NamespaceSelector.MatchLabels,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			test.CreateResource(t.namespaces, ownNS)
+			test.CreateResource(t.namespaces, ns)
+		})
+
+		It("should add the Pod IP to the IP set", func() {
+			t.pFilter.AwaitEntry(ipSet, pod.Status.PodIP)
+		})
+
+		Context("and the namespace is then deleted", func() {
+			JustBeforeEach(func() {
+				t.pFilter.AwaitEntry(ipSet, pod.Status.PodIP)
+				Expect(t.namespaces.Delete(context.TODO(), ns.Name, metav1.DeleteOptions{})).To(Succeed())
+			})
+
+			It("should remove the Pod IP from the IP set", func() {
+				t.pFilter.AwaitEntryDeleted(ipSet, pod.Status.PodIP)
+			})
+		})
+
+		Context("and the namespace doesn't match", func() {
+			BeforeEach(func() {
+				ns.Labels = map[string]string{"team": "other"}
+			})
+
+			It("should not add the Pod IP to the IP set", func() {
+				t.pFilter.AwaitNoEntry(ipSet, pod.Status.PodIP)
+			})
+		})
+
+		Context("and the GlobalEgressIP's own namespace doesn't match", func() {
+			BeforeEach(func() {
+				ownNS.Labels = map[string]string{"team": "other"}
+			})
+
+			It("should not add the Pod IP to the IP set", func() {
+				t.pFilter.AwaitNoEntry(ipSet, pod.Status.PodIP)
+			})
+		})
+
+		Context("and the GlobalEgressIP's own namespace is then deselected", func() {
+			JustBeforeEach(func() {
+				t.pFilter.AwaitEntry(ipSet, pod.Status.PodIP)
+				Expect(t.namespaces.Delete(context.TODO(), ownNS.Name, metav1.DeleteOptions{})).To(Succeed())
+			})
+
+			It("should remove the Pod IP from the IP set", func() {
+				t.pFilter.AwaitEntryDeleted(ipSet, pod.Status.PodIP)
+			})
+		})
+	})
 }
 
 type globalEgressIPControllerTestDriver struct {
@@ -598,7 +793,7 @@ func newGlobalEgressIPControllerTestDriver() *globalEgressIPControllerTestDriver
 
 		var err error
 
-		t.pool, err = ipam.NewIPPool(t.globalCIDR, metrics.GlobalnetMetricsReporter)
+		t.pool, err = controllers.NewIPPools([]string{t.globalCIDR}, metrics.GlobalnetMetricsReporter)
 		Expect(err).To(Succeed())
 
 		t.watches = fakeDynClient.NewWatchReactor(&t.dynClient.Fake)
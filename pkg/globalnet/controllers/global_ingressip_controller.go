@@ -25,7 +25,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/federate"
 	"github.com/submariner-io/admiral/pkg/finalizer"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/resource"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
@@ -41,7 +40,7 @@ import (
 )
 
 //nolint:revive // Ignore "unexported-return:... which can be annoying to use"; it's only used by unit tests.
-func NewGlobalIngressIPController(config *syncer.ResourceSyncerConfig, pool *ipam.IPPool) (*globalIngressIPController, error) {
+func NewGlobalIngressIPController(config *syncer.ResourceSyncerConfig, pool *IPPools) (*globalIngressIPController, error) {
 	// We'll panic if config is nil, this is intentional
 	var err error
 
@@ -84,23 +83,19 @@ func NewGlobalIngressIPController(config *syncer.ResourceSyncerConfig, pool *ipa
 
 		//nolint:wrapcheck  // No need to wrap these errors.
 		err = controller.reserveAllocatedIPs(federator, obj, func(reservedIPs []string) error {
-			var target string
-			var tType pfiface.TargetType
+			metrics.RecordAllocateGlobalIngressIPs(pool.GetCIDRFor(reservedIPs), len(reservedIPs))
 
-			metrics.RecordAllocateGlobalIngressIPs(pool.GetCIDR(), len(reservedIPs))
-
-			if gip.Spec.Target == submarinerv1.ClusterIPService {
+			if isServiceTarget(gip.Spec.Target) {
 				return controller.ensureInternalServiceExists(gip)
-			} else if gip.Spec.Target == submarinerv1.HeadlessServicePod {
-				target = gip.GetAnnotations()[headlessSvcPodIP]
-				tType = pfiface.PodTarget
-			} else if gip.Spec.Target == submarinerv1.HeadlessServiceEndpoints {
-				target = gip.GetAnnotations()[headlessSvcEndpointsIP]
-				tType = pfiface.EndpointsTarget
-			} else {
+			}
+
+			annotationKey, tType := getIPTarget(gip.Spec.Target)
+			if annotationKey == "" {
 				return nil
 			}
 
+			target := gip.GetAnnotations()[annotationKey]
+
 			err := controller.pfIface.AddIngressRulesForHeadlessSvc(reservedIPs[0], target, tType)
 			if err != nil {
 				return err
@@ -149,34 +144,55 @@ func (c *globalIngressIPController) process(from runtime.Object, numRequeues int
 
 		trimAllocatedStatusCondition(&ingressIP.Status.Conditions)
 
-		requeue := c.onCreate(ingressIP)
+		requeue := c.onCreate(ingressIP, numRequeues)
 
 		return checkStatusChanged(&prevStatus, &ingressIP.Status, ingressIP), requeue
 	case syncer.Delete:
 		return nil, c.onDelete(ingressIP, numRequeues)
 	case syncer.Update:
+		if c.isAllocationUpToDate(ingressIP) {
+			return nil, false
+		}
+
+		prevStatus := ingressIP.Status
+
+		trimAllocatedStatusCondition(&ingressIP.Status.Conditions)
+
+		requeue := c.reallocate(ingressIP, numRequeues)
+
+		return checkStatusChanged(&prevStatus, &ingressIP.Status, ingressIP), requeue
 	}
 
 	return nil, false
 }
 
-func (c *globalIngressIPController) onCreate(ingressIP *submarinerv1.GlobalIngressIP) bool {
+func (c *globalIngressIPController) onCreate(ingressIP *submarinerv1.GlobalIngressIP, numRequeues int) bool {
 	// If the Ingress GlobalIP is already allocated, we may have gotten here due to an underlying service update (eg ports changed) in
-	// which case we need to update the internal service for non-headless.
+	// which case we need to update the internal service for non-headless. If the requested GlobalCIDR or IP changed in the meantime,
+	// the global IP needs to be re-allocated.
 	if ingressIP.Status.AllocatedIP != "" {
+		if !c.isAllocationUpToDate(ingressIP) {
+			return c.reallocate(ingressIP, numRequeues)
+		}
+
 		return c.onUpdate(ingressIP)
 	}
 
 	key, _ := cache.MetaNamespaceKeyFunc(ingressIP)
 
-	ips, err := c.pool.Allocate(1)
+	var requestedIPs []string
+	if ingressIP.Spec.RequestedIP != "" {
+		requestedIPs = []string{ingressIP.Spec.RequestedIP}
+	}
+
+	ips, reason, err := c.allocateIPs(ingressIP.Spec.GlobalCIDR, requestedIPs, 1)
 	if err != nil {
 		logger.Errorf(err, "Error allocating IP for %q", key)
 
 		meta.SetStatusCondition(&ingressIP.Status.Conditions, metav1.Condition{
 			Type:    string(submarinerv1.GlobalEgressIPAllocated),
 			Status:  metav1.ConditionFalse,
-			Reason:  "IPPoolAllocationFailed",
+			Reason:  reason,
 			Message: fmt.Sprintf("Error allocating a global IP from the pool: %v", err),
 		})
 
@@ -185,7 +201,7 @@ func (c *globalIngressIPController) onCreate(ingressIP *submarinerv1.GlobalIngre
 
 	logger.Infof("Allocated global IP %q for %q", ips, key)
 
-	if ingressIP.Spec.Target == submarinerv1.ClusterIPService {
+	if isServiceTarget(ingressIP.Spec.Target) {
 		serviceRef := ingressIP.Spec.ServiceRef
 
 		service, exists, err := getService(serviceRef.Name, ingressIP.Namespace, c.services, c.scheme)
@@ -218,16 +234,7 @@ func (c *globalIngressIPController) onCreate(ingressIP *submarinerv1.GlobalIngre
 			return false
 		}
 	} else {
-		var annotationKey string
-		var tType pfiface.TargetType
-
-		if ingressIP.Spec.Target == submarinerv1.HeadlessServicePod {
-			annotationKey = headlessSvcPodIP
-			tType = pfiface.PodTarget
-		} else if ingressIP.Spec.Target == submarinerv1.HeadlessServiceEndpoints {
-			annotationKey = headlessSvcEndpointsIP
-			tType = pfiface.EndpointsTarget
-		}
+		annotationKey, tType := getIPTarget(ingressIP.Spec.Target)
 
 		target := ingressIP.GetAnnotations()[annotationKey]
 		if target == "" {
@@ -264,7 +271,7 @@ func (c *globalIngressIPController) onCreate(ingressIP *submarinerv1.GlobalIngre
 		}
 	}
 
-	metrics.RecordAllocateGlobalIngressIPs(c.pool.GetCIDR(), 1)
+	metrics.RecordAllocateGlobalIngressIPs(c.pool.GetCIDRFor(ips), 1)
 
 	ingressIP.Status.AllocatedIP = ips[0]
 
@@ -288,7 +295,7 @@ func (c *globalIngressIPController) createOrUpdateInternalService(from *corev1.S
 			Finalizers: []string{InternalServiceFinalizer},
 		},
 		Spec: corev1.ServiceSpec{
-			Ports:                    from.Spec.Ports,
+			Ports:                    internalServicePorts(from.Spec.Ports),
 			Selector:                 from.Spec.Selector,
 			ExternalIPs:              []string{extIP},
 			IPFamilyPolicy:           ptr.To(corev1.IPFamilyPolicySingleStack),
@@ -309,7 +316,7 @@ func (c *globalIngressIPController) createOrUpdateInternalService(from *corev1.S
 }
 
 func (c *globalIngressIPController) onUpdate(ingressIP *submarinerv1.GlobalIngressIP) bool {
-	if ingressIP.Spec.Target != submarinerv1.ClusterIPService {
+	if !isServiceTarget(ingressIP.Spec.Target) {
 		return false
 	}
 
@@ -333,6 +340,35 @@ func (c *globalIngressIPController) onUpdate(ingressIP *submarinerv1.GlobalIngre
 	return false
 }
 
+// isAllocationUpToDate returns true if the allocated IP is from the requested GlobalCIDR and is the requested IP, if specified.
+func (c *globalIngressIPController) isAllocationUpToDate(ingressIP *submarinerv1.GlobalIngressIP) bool {
+	allocatedIP := ingressIP.Status.AllocatedIP
+	if allocatedIP == "" {
+		return true
+	}
+
+	if ingressIP.Spec.GlobalCIDR != "" && c.pool.GetCIDRFor([]string{allocatedIP}) != ingressIP.Spec.GlobalCIDR {
+		return false
+	}
+
+	return ingressIP.Spec.RequestedIP == "" || ingressIP.Spec.RequestedIP == allocatedIP
+}
+
+// reallocate releases the allocated global IP, along with the internal Service or rules using it, and allocates a new one.
+func (c *globalIngressIPController) reallocate(ingressIP *submarinerv1.GlobalIngressIP, numRequeues int) bool {
+	key, _ := cache.MetaNamespaceKeyFunc(ingressIP)
+
+	logger.Infof("The requested GlobalCIDR or IP for %q changed - re-allocating the global IP", key)
+
+	if c.onDelete(ingressIP, numRequeues) {
+		return true
+	}
+
+	ingressIP.Status.AllocatedIP = ""
+
+	return c.onCreate(ingressIP, numRequeues)
+}
+
 //nolint:wrapcheck  // No need to wrap these errors.
 func (c *globalIngressIPController) onDelete(ingressIP *submarinerv1.GlobalIngressIP, numRequeues int) bool {
 	if ingressIP.Status.AllocatedIP == "" {
@@ -341,7 +377,7 @@ func (c *globalIngressIPController) onDelete(ingressIP *submarinerv1.GlobalIngre
 
 	key, _ := cache.MetaNamespaceKeyFunc(ingressIP)
 
-	if ingressIP.Spec.Target == submarinerv1.ClusterIPService {
+	if isServiceTarget(ingressIP.Spec.Target) {
 		intSvcName := GetInternalSvcName(ingressIP.Spec.ServiceRef.Name)
 		logger.Infof("Deleting the service %q/%q created by Globalnet controller", ingressIP.Namespace, intSvcName)
 
@@ -373,20 +409,11 @@ func (c *globalIngressIPController) onDelete(ingressIP *submarinerv1.GlobalIngre
 	}
 
 	return c.flushRulesAndReleaseIPs(key, numRequeues, func(allocatedIPs []string) error {
-		var target string
-		var tType pfiface.TargetType
-
-		metrics.RecordDeallocateGlobalIngressIPs(c.pool.GetCIDR(), len(allocatedIPs))
+		metrics.RecordDeallocateGlobalIngressIPs(c.pool.GetCIDRFor(allocatedIPs), len(allocatedIPs))
 
-		if ingressIP.Spec.Target == submarinerv1.HeadlessServicePod {
-			target = ingressIP.GetAnnotations()[headlessSvcPodIP]
-			tType = pfiface.PodTarget
-		} else if ingressIP.Spec.Target == submarinerv1.HeadlessServiceEndpoints {
-			target = ingressIP.GetAnnotations()[headlessSvcEndpointsIP]
-			tType = pfiface.EndpointsTarget
-		}
+		annotationKey, tType := getIPTarget(ingressIP.Spec.Target)
 
-		if target != "" {
+		if target := ingressIP.GetAnnotations()[annotationKey]; annotationKey != "" && target != "" {
 			if err := c.pfIface.RemoveIngressRulesForHeadlessSvc(ingressIP.Status.AllocatedIP, target, tType); err != nil {
 				return err
 			}
@@ -435,11 +462,46 @@ func (c *globalIngressIPController) getServiceExternalIP(service *corev1.Service
 }
 
 func (c *globalIngressIPController) getTargetReference(giip *submarinerv1.GlobalIngressIP) string {
-	if giip.Spec.Target == submarinerv1.ClusterIPService {
+	if isServiceTarget(giip.Spec.Target) {
 		return giip.Spec.ServiceRef.Name
-	} else if giip.Spec.Target == submarinerv1.HeadlessServicePod {
+	} else if giip.Spec.Target == submarinerv1.HeadlessServicePod || giip.Spec.Target == submarinerv1.Pod {
 		return giip.Spec.PodRef.Name
 	}
 
 	return ""
 }
+
+// isServiceTarget returns true if the target is reached via an internal Service whose external IP is the global IP.
+func isServiceTarget(target submarinerv1.TargetType) bool {
+	return target == submarinerv1.ClusterIPService || target == submarinerv1.LoadBalancerService ||
+		target == submarinerv1.NodePortService
+}
+
+// getIPTarget returns the annotation holding the IP the global IP is translated to, along with the packetfilter
+// target type, for targets reached via explicit DNAT/SNAT rules.
+func getIPTarget(target submarinerv1.TargetType) (string, pfiface.TargetType) {
+	switch target {
+	case submarinerv1.HeadlessServicePod:
+		return headlessSvcPodIP, pfiface.PodTarget
+	case submarinerv1.HeadlessServiceEndpoints:
+		return headlessSvcEndpointsIP, pfiface.EndpointsTarget
+	case submarinerv1.Pod:
+		return ingressPodIP, pfiface.StandalonePodTarget
+	case submarinerv1.ClusterIPService, submarinerv1.LoadBalancerService, submarinerv1.NodePortService:
+	}
+
+	return "", ""
+}
+
+// internalServicePorts returns the ports of the given Service without the node ports, which are only valid for
+// NodePort and LoadBalancer Services.
+func internalServicePorts(ports []corev1.ServicePort) []corev1.ServicePort {
+	internalPorts := make([]corev1.ServicePort, len(ports))
+
+	for i := range ports {
+		internalPorts[i] = ports[i]
+		internalPorts[i].NodePort = 0
+	}
+
+	return internalPorts
+}
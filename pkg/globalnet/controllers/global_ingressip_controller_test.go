@@ -24,8 +24,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/syncer"
+	"github.com/submariner-io/admiral/pkg/syncer/test"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/globalnet/constants"
 	"github.com/submariner-io/submariner/pkg/globalnet/controllers"
@@ -170,6 +170,77 @@ func testGlobalIngressIPCreatedClusterIPSvc(t *globalIngressIPControllerTestDriv
 		})
 	})
 
+	Context("with a RequestedIP specified", func() {
+		BeforeEach(func() {
+			ingressIP.Spec.RequestedIP = globalIP2
+		})
+
+		AfterEach(func() {
+			ingressIP.Spec.RequestedIP = ""
+		})
+
+		It("should allocate the requested global IP", func() {
+			t.awaitIngressIPStatusAllocated(globalIngressIPName)
+			Expect(t.getGlobalIngressIPStatus(globalIngressIPName).AllocatedIP).To(Equal(globalIP2))
+			t.verifyIPsReservedInPool(globalIP2)
+
+			intSvc := t.awaitService(controllers.GetInternalSvcName(serviceName))
+			Expect(intSvc.Spec.ExternalIPs).To(Equal([]string{globalIP2}))
+		})
+
+		Context("and then changed", func() {
+			JustBeforeEach(func() {
+				t.awaitIngressIPStatusAllocated(globalIngressIPName)
+
+				existing := t.awaitGlobalIngressIP(globalIngressIPName)
+				existing.Spec.RequestedIP = globalIP3
+				test.UpdateResource(t.globalIngressIPs, existing)
+			})
+
+			It("should reallocate the requested global IP", func() {
+				Eventually(func() string {
+					return t.getGlobalIngressIPStatus(globalIngressIPName).AllocatedIP
+				}, 5).Should(Equal(globalIP3))
+
+				Eventually(func() []string {
+					return t.awaitService(controllers.GetInternalSvcName(serviceName)).Spec.ExternalIPs
+				}, 5).Should(Equal([]string{globalIP3}))
+			})
+
+			It("should release the previously allocated global IP", func() {
+				t.awaitIPsReleasedFromPool(globalIP2)
+			})
+		})
+
+		Context("and it's already allocated", func() {
+			BeforeEach(func() {
+				Expect(t.pool.Reserve(globalIP2)).To(Succeed())
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitStatusConditions(t.globalIngressIPs, globalIngressIPName, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "RequestedIPUnavailable",
+				})
+			})
+		})
+
+		Context("and it's invalid", func() {
+			BeforeEach(func() {
+				ingressIP.Spec.RequestedIP = "bogus"
+			})
+
+			It("should add an appropriate Status condition", func() {
+				t.awaitStatusConditions(t.globalIngressIPs, globalIngressIPName, metav1.Condition{
+					Type:   string(submarinerv1.GlobalEgressIPAllocated),
+					Status: metav1.ConditionFalse,
+					Reason: "InvalidRequestedIP",
+				})
+			})
+		})
+	})
+
 	Context("with the IP pool exhausted", func() {
 		BeforeEach(func() {
 			_, err := t.pool.Allocate(t.pool.Size())
@@ -256,6 +327,31 @@ func testGlobalIngressIPCreatedHeadlessSvc(t *globalIngressIPControllerTestDrive
 		})
 	})
 
+	Context("and then the RequestedIP is changed", func() {
+		var allocatedIP string
+
+		JustBeforeEach(func() {
+			t.awaitIngressIPStatusAllocated(globalIngressIPName)
+
+			existing := t.awaitGlobalIngressIP(globalIngressIPName)
+			allocatedIP = existing.Status.AllocatedIP
+			existing.Spec.RequestedIP = globalIP3
+			test.UpdateResource(t.globalIngressIPs, existing)
+		})
+
+		It("should reallocate the requested global IP", func() {
+			Eventually(func() string {
+				return t.getGlobalIngressIPStatus(globalIngressIPName).AllocatedIP
+			}, 5).Should(Equal(globalIP3))
+
+			awaitPacketFilterRules(globalIP3)
+		})
+
+		It("should release the previously allocated global IP", func() {
+			t.awaitIPsReleasedFromPool(allocatedIP)
+		})
+	})
+
 	Context("and then removed", func() {
 		var allocatedIP string
 
@@ -525,7 +621,7 @@ func newGlobalIngressIPControllerDriver() *globalIngressIPControllerTestDriver {
 
 		var err error
 
-		t.pool, err = ipam.NewIPPool(t.globalCIDR, metrics.GlobalnetMetricsReporter)
+		t.pool, err = controllers.NewIPPools([]string{t.globalCIDR}, metrics.GlobalnetMetricsReporter)
 		Expect(err).To(Succeed())
 	})
 
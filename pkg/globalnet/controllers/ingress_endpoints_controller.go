@@ -27,6 +27,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -47,6 +48,7 @@ func startIngressEndpointsController(svc *corev1.Service, config *syncer.Resourc
 	controller := &ingressEndpointsController{
 		baseSyncerController: newBaseSyncerController(),
 		svcName:              svc.Name,
+		globalCIDR:           svc.GetAnnotations()[constants.SmGlobalCIDR],
 		namespace:            svc.Namespace,
 		config:               *config,
 		ingressIPs:           config.SourceClient.Resource(*gvr),
@@ -159,6 +161,7 @@ func (c *ingressEndpointsController) onCreateOrUpdate(endpoints *corev1.Endpoint
 		ingressIP.Spec = submarinerv1.GlobalIngressIPSpec{
 			Target:     submarinerv1.HeadlessServiceEndpoints,
 			ServiceRef: &corev1.LocalObjectReference{Name: c.svcName},
+			GlobalCIDR: c.globalCIDR,
 		}
 
 		uIngressIP, _, err := util.ToUnstructuredResource(ingressIP, c.config.RestMapper)
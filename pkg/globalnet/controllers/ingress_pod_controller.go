@@ -26,6 +26,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -46,6 +47,7 @@ func startIngressPodController(svc *corev1.Service, config *syncer.ResourceSynce
 	controller := &ingressPodController{
 		baseSyncerController:     newBaseSyncerController(),
 		svcName:                  svc.Name,
+		globalCIDR:               svc.GetAnnotations()[constants.SmGlobalCIDR],
 		namespace:                svc.Namespace,
 		publishNotReadyAddresses: svc.Spec.PublishNotReadyAddresses,
 		ingressIPMap:             set.New[string](),
@@ -135,6 +137,7 @@ func (c *ingressPodController) process(from runtime.Object, _ int, op syncer.Ope
 		Target:     submarinerv1.HeadlessServicePod,
 		ServiceRef: &corev1.LocalObjectReference{Name: c.svcName},
 		PodRef:     &corev1.LocalObjectReference{Name: pod.Name},
+		GlobalCIDR: c.globalCIDR,
 	}
 
 	c.ingressIPMap.Insert(ingressIP.Name)
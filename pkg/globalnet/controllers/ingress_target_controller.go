@@ -0,0 +1,311 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/federate"
+	"github.com/submariner-io/admiral/pkg/resource"
+	"github.com/submariner-io/admiral/pkg/syncer"
+	"github.com/submariner-io/admiral/pkg/util"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/set"
+)
+
+// NewIngressServiceTargetController creates a controller that maintains a GlobalIngressIP for each non-exported
+// LoadBalancer or NodePort Service with the global ingress annotation.
+func NewIngressServiceTargetController(config *syncer.ResourceSyncerConfig, gipSyncer syncer.Interface) (Interface, error) {
+	logger.Info("Creating ingress Service target controller")
+
+	return newIngressTargetController(config, "Service", &corev1.Service{}, syncer.DefaultResourcesEquivalent,
+		&ingressTargetController{
+			newTarget: func(name, namespace string) runtime.Object {
+				return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+			},
+			ingressIPName: ingressServiceIPName,
+			toIngressIP:   serviceToIngressIP,
+			onUpdate: func(name, namespace string) {
+				// Requeue the GlobalIngressIP to update the internal Service.
+				gipSyncer.RequeueResource(name, namespace)
+			},
+		})
+}
+
+// NewIngressPodTargetController creates a controller that maintains a GlobalIngressIP for each running Pod with the
+// global ingress annotation.
+func NewIngressPodTargetController(config *syncer.ResourceSyncerConfig) (Interface, error) {
+	logger.Info("Creating ingress Pod target controller")
+
+	return newIngressTargetController(config, "Pod", &corev1.Pod{}, areIngressPodsEqual,
+		&ingressTargetController{
+			newTarget: func(name, namespace string) runtime.Object {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+			},
+			ingressIPName: ingressPodIPName,
+			toIngressIP:   podToIngressIP,
+		})
+}
+
+func newIngressTargetController(config *syncer.ResourceSyncerConfig, kind string, resourceType runtime.Object,
+	resourcesEquivalent syncer.ResourceEquivalenceFunc, controller *ingressTargetController,
+) (*ingressTargetController, error) {
+	// We'll panic if config is nil, this is intentional
+	var err error
+
+	_, gvr, err := util.ToUnstructuredResource(&submarinerv1.GlobalIngressIP{}, config.RestMapper)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting resource")
+	}
+
+	controller.baseSyncerController = newBaseSyncerController()
+	controller.kind = kind
+	controller.ingressIPs = config.SourceClient.Resource(*gvr)
+	controller.targets = set.New[string]()
+
+	controller.resourceSyncer, err = syncer.NewResourceSyncer(&syncer.ResourceSyncerConfig{
+		Name:                fmt.Sprintf("Ingress %s target syncer", kind),
+		ResourceType:        resourceType,
+		SourceClient:        config.SourceClient,
+		SourceNamespace:     corev1.NamespaceAll,
+		RestMapper:          config.RestMapper,
+		Federator:           federate.NewCreateFederator(config.SourceClient, config.RestMapper, corev1.NamespaceAll),
+		Scheme:              config.Scheme,
+		Transform:           controller.process,
+		ResourcesEquivalent: resourcesEquivalent,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the syncer")
+	}
+
+	return controller, nil
+}
+
+func (c *ingressTargetController) Start() error {
+	ingressIPs := c.ingressIPs.Namespace(corev1.NamespaceAll)
+	labelSelector := labels.SelectorFromSet(map[string]string{IngressTargetLabel: c.kind}).String()
+
+	// Seed the targets from the existing GlobalIngressIPs so those whose target no longer has the annotation are
+	// deleted.
+	list, err := ingressIPs.List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return errors.Wrap(err, "error listing the GlobalIngressIPs")
+	}
+
+	for i := range list.Items {
+		if name := getIngressTargetName(&list.Items[i]); name != "" {
+			c.targets.Insert(list.Items[i].GetNamespace() + "/" + name)
+		}
+	}
+
+	err = c.baseSyncerController.Start()
+	if err != nil {
+		return err
+	}
+
+	c.reconcile(ingressIPs, labelSelector, "" /* fieldSelector */, func(obj *unstructured.Unstructured) runtime.Object {
+		if name := getIngressTargetName(obj); name != "" {
+			return c.newTarget(name, obj.GetNamespace())
+		}
+
+		return nil
+	})
+
+	return nil
+}
+
+func (c *ingressTargetController) process(from runtime.Object, _ int, op syncer.Operation) (runtime.Object, bool) {
+	key, _ := cache.MetaNamespaceKeyFunc(from)
+
+	var ingressIP *submarinerv1.GlobalIngressIP
+	if op != syncer.Delete {
+		ingressIP = c.toIngressIP(from)
+	}
+
+	if ingressIP == nil {
+		if c.targets.Has(key) {
+			return nil, c.deleteIngressIP(key, resource.MustToMeta(from))
+		}
+
+		return nil, false
+	}
+
+	if c.targets.Has(key) {
+		if op == syncer.Update && c.onUpdate != nil {
+			c.onUpdate(ingressIP.Name, ingressIP.Namespace)
+		}
+	} else {
+		foreign, err := c.isForeignIngressIP(ingressIP.Namespace, ingressIP.Name)
+		if err != nil {
+			logger.Errorf(err, "Error checking the existing GlobalIngressIP for %s %q", c.kind, key)
+			return nil, true
+		}
+
+		if foreign {
+			logger.Warningf("GlobalIngressIP %s/%s for %s %q already exists and wasn't created for it - ignoring",
+				ingressIP.Namespace, ingressIP.Name, c.kind, key)
+			return nil, false
+		}
+
+		logger.Infof("Creating GlobalIngressIP %s/%s for %s %q, Target: %q", ingressIP.Namespace, ingressIP.Name,
+			c.kind, key, ingressIP.Spec.Target)
+
+		c.targets.Insert(key)
+	}
+
+	ingressIP.Labels = map[string]string{IngressTargetLabel: c.kind}
+
+	return ingressIP, false
+}
+
+func (c *ingressTargetController) deleteIngressIP(key string, target metav1.Object) bool {
+	name := c.ingressIPName(target.GetName())
+
+	foreign, err := c.isForeignIngressIP(target.GetNamespace(), name)
+	if err != nil {
+		logger.Errorf(err, "Error checking the existing GlobalIngressIP for %s %q", c.kind, key)
+		return true
+	}
+
+	if foreign {
+		c.targets.Delete(key)
+		return false
+	}
+
+	logger.Infof("Deleting GlobalIngressIP %s/%s for %s %q", target.GetNamespace(), name, c.kind, key)
+
+	err = c.ingressIPs.Namespace(target.GetNamespace()).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Errorf(err, "Error deleting GlobalIngressIP for %s %q", c.kind, key)
+		return true
+	}
+
+	c.targets.Delete(key)
+
+	return false
+}
+
+// isForeignIngressIP returns true if a GlobalIngressIP with the given name exists without this controller's label, e.g.
+// one created for a ServiceExport, in which case it must be left alone.
+func (c *ingressTargetController) isForeignIngressIP(namespace, name string) (bool, error) {
+	obj, err := c.ingressIPs.Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrapf(err, "error retrieving GlobalIngressIP %s/%s", namespace, name)
+	}
+
+	return obj.GetLabels()[IngressTargetLabel] != c.kind, nil
+}
+
+func hasGlobalIngressAnnotation(obj metav1.Object) bool {
+	return obj.GetAnnotations()[constants.SmGlobalIngress] == "true"
+}
+
+func serviceToIngressIP(obj runtime.Object) *submarinerv1.GlobalIngressIP {
+	service := obj.(*corev1.Service)
+
+	var target submarinerv1.TargetType
+
+	switch service.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		target = submarinerv1.LoadBalancerService
+	case corev1.ServiceTypeNodePort:
+		target = submarinerv1.NodePortService
+	case corev1.ServiceTypeClusterIP, corev1.ServiceTypeExternalName:
+	}
+
+	// The internal Service relies on the selector to reach the backends.
+	if target == "" || !hasGlobalIngressAnnotation(service) || len(service.Spec.Selector) == 0 {
+		return nil
+	}
+
+	return &submarinerv1.GlobalIngressIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressServiceIPName(service.Name),
+			Namespace: service.Namespace,
+		},
+		Spec: submarinerv1.GlobalIngressIPSpec{
+			Target:      target,
+			ServiceRef:  &corev1.LocalObjectReference{Name: service.Name},
+			GlobalCIDR:  service.GetAnnotations()[constants.SmGlobalCIDR],
+			RequestedIP: service.GetAnnotations()[constants.SmRequestedGlobalIP],
+		},
+	}
+}
+
+func podToIngressIP(obj runtime.Object) *submarinerv1.GlobalIngressIP {
+	pod := obj.(*corev1.Pod)
+
+	if !hasGlobalIngressAnnotation(pod) || pod.Status.PodIP == "" || pod.Status.Phase != corev1.PodRunning {
+		return nil
+	}
+
+	return &submarinerv1.GlobalIngressIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressPodIPName(pod.Name),
+			Namespace: pod.Namespace,
+			Annotations: map[string]string{
+				ingressPodIP: pod.Status.PodIP,
+			},
+		},
+		Spec: submarinerv1.GlobalIngressIPSpec{
+			Target:      submarinerv1.Pod,
+			PodRef:      &corev1.LocalObjectReference{Name: pod.Name},
+			GlobalCIDR:  pod.GetAnnotations()[constants.SmGlobalCIDR],
+			RequestedIP: pod.GetAnnotations()[constants.SmRequestedGlobalIP],
+		},
+	}
+}
+
+// ingressServiceIPName returns a name distinct from the one used for the GlobalIngressIP of an exported Service.
+func ingressServiceIPName(serviceName string) string {
+	return fmt.Sprintf("ingress-svc-%.51s", serviceName)
+}
+
+func ingressPodIPName(podName string) string {
+	return fmt.Sprintf("ingress-pod-%.51s", podName)
+}
+
+func getIngressTargetName(obj *unstructured.Unstructured) string {
+	if name, exists, _ := unstructured.NestedString(obj.Object, "spec", "serviceRef", "name"); exists {
+		return name
+	}
+
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "podRef", "name")
+
+	return name
+}
+
+func areIngressPodsEqual(obj1, obj2 *unstructured.Unstructured) bool {
+	return arePodsEqual(obj1, obj2) &&
+		obj1.GetAnnotations()[constants.SmGlobalIngress] == obj2.GetAnnotations()[constants.SmGlobalIngress]
+}
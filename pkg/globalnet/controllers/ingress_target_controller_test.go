@@ -0,0 +1,335 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/syncer"
+	"github.com/submariner-io/admiral/pkg/syncer/test"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
+	"github.com/submariner-io/submariner/pkg/globalnet/controllers"
+	"github.com/submariner-io/submariner/pkg/globalnet/metrics"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Ingress Service target controller", func() {
+	t := newIngressTargetControllerTestDriver()
+
+	var (
+		service       *corev1.Service
+		ingressIPName string
+	)
+
+	BeforeEach(func() {
+		service = newLoadBalancerService()
+		ingressIPName = "ingress-svc-" + service.Name
+	})
+
+	When("a LoadBalancer Service with the global ingress annotation is created", func() {
+		JustBeforeEach(func() {
+			t.createService(service)
+		})
+
+		It("should create a GlobalIngressIP and an internal Service with an allocated global IP", func() {
+			ingressIP := t.awaitGlobalIngressIP(ingressIPName)
+			Expect(ingressIP.Spec.Target).To(Equal(submarinerv1.LoadBalancerService))
+			Expect(ingressIP.Spec.ServiceRef).To(Equal(&corev1.LocalObjectReference{Name: service.Name}))
+
+			t.awaitIngressIPStatusAllocated(ingressIPName)
+			allocatedIP := t.getGlobalIngressIPStatus(ingressIPName).AllocatedIP
+
+			intSvc := t.awaitService(controllers.GetInternalSvcName(service.Name))
+			Expect(intSvc.Spec.ExternalIPs).To(Equal([]string{allocatedIP}))
+			Expect(intSvc.Spec.Selector).To(Equal(service.Spec.Selector))
+			Expect(intSvc.Spec.Ports).To(HaveLen(1))
+			Expect(intSvc.Spec.Ports[0].NodePort).To(BeZero())
+			Expect(intSvc.Spec.Ports[0].Port).To(Equal(service.Spec.Ports[0].Port))
+		})
+
+		Context("and the annotation is then removed", func() {
+			JustBeforeEach(func() {
+				t.awaitIngressIPStatusAllocated(ingressIPName)
+
+				service.Annotations = nil
+				test.UpdateResource(t.services, service)
+			})
+
+			It("should delete the GlobalIngressIP and the internal Service", func() {
+				t.awaitNoGlobalIngressIP(ingressIPName)
+				t.awaitNoService(controllers.GetInternalSvcName(service.Name))
+			})
+		})
+
+		Context("and then deleted", func() {
+			JustBeforeEach(func() {
+				t.awaitIngressIPStatusAllocated(ingressIPName)
+				Expect(t.services.Delete(context.TODO(), service.Name, metav1.DeleteOptions{})).To(Succeed())
+			})
+
+			It("should delete the GlobalIngressIP", func() {
+				t.awaitNoGlobalIngressIP(ingressIPName)
+			})
+		})
+	})
+
+	When("a NodePort Service with the global ingress annotation is created", func() {
+		BeforeEach(func() {
+			service.Spec.Type = corev1.ServiceTypeNodePort
+		})
+
+		JustBeforeEach(func() {
+			t.createService(service)
+		})
+
+		It("should create a GlobalIngressIP", func() {
+			Expect(t.awaitGlobalIngressIP(ingressIPName).Spec.Target).To(Equal(submarinerv1.NodePortService))
+			t.awaitIngressIPStatusAllocated(ingressIPName)
+		})
+	})
+
+	When("a LoadBalancer Service without the global ingress annotation is created", func() {
+		BeforeEach(func() {
+			service.Annotations = nil
+		})
+
+		JustBeforeEach(func() {
+			t.createService(service)
+		})
+
+		It("should not create a GlobalIngressIP", func() {
+			t.ensureNoGlobalIngressIP(ingressIPName)
+		})
+	})
+
+	When("a GlobalIngressIP with the same name but without the target label exists", func() {
+		BeforeEach(func() {
+			t.createGlobalIngressIP(&submarinerv1.GlobalIngressIP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ingressIPName,
+				},
+				Spec: submarinerv1.GlobalIngressIPSpec{
+					Target:     submarinerv1.LoadBalancerService,
+					ServiceRef: &corev1.LocalObjectReference{Name: service.Name},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			t.createService(service)
+		})
+
+		Context("and the annotation is removed from the Service", func() {
+			JustBeforeEach(func() {
+				// The Services are processed in order so, once the GlobalIngressIP for this one exists, the first one was too.
+				other := newLoadBalancerService()
+				other.Name = "other-" + service.Name
+				t.createService(other)
+				t.awaitGlobalIngressIP("ingress-svc-" + other.Name)
+
+				service.Annotations = nil
+				test.UpdateResource(t.services, service)
+			})
+
+			It("should not delete the GlobalIngressIP", func() {
+				Consistently(func() error {
+					_, err := t.globalIngressIPs.Get(context.TODO(), ingressIPName, metav1.GetOptions{})
+					return err
+				}, 500*time.Millisecond).Should(Succeed())
+			})
+		})
+	})
+
+	When("a GlobalIngressIP is stale on startup due to a missed delete event", func() {
+		BeforeEach(func() {
+			t.createGlobalIngressIP(&submarinerv1.GlobalIngressIP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   ingressIPName,
+					Labels: map[string]string{controllers.IngressTargetLabel: "Service"},
+				},
+				Spec: submarinerv1.GlobalIngressIPSpec{
+					Target:     submarinerv1.LoadBalancerService,
+					ServiceRef: &corev1.LocalObjectReference{Name: service.Name},
+				},
+			})
+		})
+
+		It("should delete the GlobalIngressIP on reconciliation", func() {
+			t.awaitNoGlobalIngressIP(ingressIPName)
+		})
+	})
+})
+
+var _ = Describe("Ingress Pod target controller", func() {
+	t := newIngressTargetControllerTestDriver()
+
+	var (
+		pod           *corev1.Pod
+		ingressIPName string
+	)
+
+	BeforeEach(func() {
+		pod = newHeadlessServicePod("none")
+		pod.Annotations = map[string]string{constants.SmGlobalIngress: "true"}
+		ingressIPName = "ingress-pod-" + pod.Name
+	})
+
+	When("a running Pod with the global ingress annotation is created", func() {
+		JustBeforeEach(func() {
+			t.createPod(pod)
+		})
+
+		It("should create a GlobalIngressIP and program the ingress and egress rules", func() {
+			ingressIP := t.awaitGlobalIngressIP(ingressIPName)
+			Expect(ingressIP.Spec.Target).To(Equal(submarinerv1.Pod))
+			Expect(ingressIP.Spec.PodRef).To(Equal(&corev1.LocalObjectReference{Name: pod.Name}))
+
+			t.awaitIngressIPStatusAllocated(ingressIPName)
+			allocatedIP := t.getGlobalIngressIPStatus(ingressIPName).AllocatedIP
+
+			t.pFilter.AwaitRule(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain,
+				And(ContainSubstring(pod.Status.PodIP), ContainSubstring(allocatedIP)))
+			t.pFilter.AwaitRule(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForHeadlessSvcPods,
+				And(ContainSubstring(pod.Status.PodIP), ContainSubstring(allocatedIP)))
+		})
+
+		Context("and then deleted", func() {
+			var allocatedIP string
+
+			JustBeforeEach(func() {
+				t.awaitIngressIPStatusAllocated(ingressIPName)
+				allocatedIP = t.getGlobalIngressIPStatus(ingressIPName).AllocatedIP
+
+				t.deletePod(pod)
+			})
+
+			It("should delete the GlobalIngressIP and remove the rules", func() {
+				t.awaitNoGlobalIngressIP(ingressIPName)
+				t.awaitIPsReleasedFromPool(allocatedIP)
+				t.pFilter.AwaitNoRule(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain,
+					Or(ContainSubstring(pod.Status.PodIP), ContainSubstring(allocatedIP)))
+				t.pFilter.AwaitNoRule(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForHeadlessSvcPods,
+					Or(ContainSubstring(pod.Status.PodIP), ContainSubstring(allocatedIP)))
+			})
+		})
+	})
+
+	When("a Pod with the global ingress annotation isn't running yet", func() {
+		BeforeEach(func() {
+			pod.Status.Phase = corev1.PodPending
+		})
+
+		JustBeforeEach(func() {
+			t.createPod(pod)
+			t.ensureNoGlobalIngressIP(ingressIPName)
+
+			pod.Status.Phase = corev1.PodRunning
+			test.UpdateResource(t.pods.Namespace(pod.Namespace), pod)
+		})
+
+		It("should create a GlobalIngressIP once it's running", func() {
+			t.awaitIngressIPStatusAllocated(ingressIPName)
+		})
+	})
+
+	When("a running Pod without the global ingress annotation is created", func() {
+		BeforeEach(func() {
+			pod.Annotations = nil
+		})
+
+		JustBeforeEach(func() {
+			t.createPod(pod)
+		})
+
+		It("should not create a GlobalIngressIP", func() {
+			t.ensureNoGlobalIngressIP(ingressIPName)
+		})
+	})
+})
+
+type ingressTargetControllerTestDriver struct {
+	*testDriverBase
+	targetControllers []controllers.Interface
+}
+
+func newIngressTargetControllerTestDriver() *ingressTargetControllerTestDriver {
+	t := &ingressTargetControllerTestDriver{}
+
+	BeforeEach(func() {
+		t.testDriverBase = newTestDriverBase()
+		t.testDriverBase.initChains()
+
+		var err error
+
+		t.pool, err = controllers.NewIPPools([]string{t.globalCIDR}, metrics.GlobalnetMetricsReporter)
+		Expect(err).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		t.start()
+	})
+
+	AfterEach(func() {
+		for _, c := range t.targetControllers {
+			c.Stop()
+		}
+
+		t.testDriverBase.afterEach()
+	})
+
+	return t
+}
+
+func (t *ingressTargetControllerTestDriver) start() {
+	gipSyncer := (&globalIngressIPControllerTestDriver{testDriverBase: t.testDriverBase}).start()
+
+	config := &syncer.ResourceSyncerConfig{
+		SourceClient: t.dynClient,
+		RestMapper:   t.restMapper,
+		Scheme:       t.scheme,
+	}
+
+	serviceController, err := controllers.NewIngressServiceTargetController(config, gipSyncer)
+	Expect(err).To(Succeed())
+
+	podController, err := controllers.NewIngressPodTargetController(config)
+	Expect(err).To(Succeed())
+
+	t.targetControllers = []controllers.Interface{serviceController, podController}
+
+	for _, c := range t.targetControllers {
+		Expect(c.Start()).To(Succeed())
+	}
+}
+
+func newLoadBalancerService() *corev1.Service {
+	service := newClusterIPService()
+	service.Annotations = map[string]string{constants.SmGlobalIngress: "true"}
+	service.Spec.Type = corev1.ServiceTypeLoadBalancer
+	service.Spec.Selector = map[string]string{"app": service.Name}
+	service.Spec.Ports[0].NodePort = 30080
+
+	return service
+}
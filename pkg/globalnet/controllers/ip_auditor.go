@@ -0,0 +1,457 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/syncer"
+	"github.com/submariner-io/admiral/pkg/util"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
+	"github.com/submariner-io/submariner/pkg/globalnet/metrics"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/utils/set"
+)
+
+const (
+	defaultAuditInterval = 5 * time.Minute
+
+	auditIssueConflict     = "conflict"
+	auditIssueLeaked       = "leaked"
+	auditIssueUnreserved   = "unreserved"
+	auditIssueOrphanedRule = "orphaned-rule"
+
+	globalEgressIPKind        = "GlobalEgressIP"
+	clusterGlobalEgressIPKind = "ClusterGlobalEgressIP"
+	globalIngressIPKind       = "GlobalIngressIP"
+	gatewayKind               = "Gateway"
+)
+
+var auditedChains = []string{
+	constants.SmGlobalnetIngressChain,
+	constants.SmGlobalnetEgressChainForPods,
+	constants.SmGlobalnetEgressChainForHeadlessSvcPods,
+	constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
+	constants.SmGlobalnetEgressChainForNamespace,
+	constants.SmGlobalnetEgressChainForNSSelector,
+	constants.SmGlobalnetEgressChainForCluster,
+}
+
+// ipOwner is a resource with allocated global IPs.
+type ipOwner struct {
+	kind string
+	obj  *unstructured.Unstructured
+}
+
+func (o ipOwner) String() string {
+	if o.obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %q", o.kind, o.obj.GetName())
+	}
+
+	return fmt.Sprintf("%s %q", o.kind, o.obj.GetNamespace()+"/"+o.obj.GetName())
+}
+
+// NewIPAuditor creates a controller that periodically cross-checks the IP pools, the IPs allocated in the status of the
+// resources and the programmed packet filter rules. Allocations in the IP pools only live in memory and are rebuilt
+// from the resources on startup so any drift is otherwise only noticed by accident. Leaked and unreserved IPs and
+// orphaned rules are fixed if they persist across two audits while IPs allocated to more than one resource are
+// reported via a Conflict status condition.
+func NewIPAuditor(config *syncer.ResourceSyncerConfig, pool *IPPools, interval time.Duration) (Interface, error) {
+	// We'll panic if config is nil, this is intentional
+	logger.Info("Creating IP auditor")
+
+	pFilter, err := packetfilter.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the packet filter")
+	}
+
+	if interval <= 0 {
+		interval = defaultAuditInterval
+	}
+
+	auditor := &ipAuditor{
+		baseController: newBaseController(),
+		pool:           pool,
+		pFilter:        pFilter,
+		interval:       interval,
+		resources:      map[string]dynamic.NamespaceableResourceInterface{},
+		leakedIPs:      map[string]uint64{},
+		unreservedIPs:  set.New[string](),
+		orphanedRules:  set.New[string](),
+	}
+
+	for kind, obj := range map[string]runtime.Object{
+		globalEgressIPKind:        &submarinerv1.GlobalEgressIP{},
+		clusterGlobalEgressIPKind: &submarinerv1.ClusterGlobalEgressIP{},
+		globalIngressIPKind:       &submarinerv1.GlobalIngressIP{},
+		gatewayKind:               &submarinerv1.Gateway{},
+	} {
+		_, gvr, err := util.ToUnstructuredResource(obj, config.RestMapper)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error converting %s resource", kind)
+		}
+
+		auditor.resources[kind] = config.SourceClient.Resource(*gvr)
+	}
+
+	return auditor, nil
+}
+
+func (a *ipAuditor) Start() error {
+	logger.Infof("Starting IP auditor with interval %v", a.interval)
+
+	a.wg.Add(1)
+
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stopCh:
+				return
+			case <-ticker.C:
+				a.audit()
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (a *ipAuditor) Stop() {
+	a.baseController.Stop()
+	a.wg.Wait()
+}
+
+func (a *ipAuditor) audit() {
+	// The allocations are snapshotted before listing the owners so the IPs allocated in the meantime aren't seen as leaked.
+	allocations := a.pool.GetAllocations()
+
+	owners, byIP, err := a.getIPOwners()
+	if err != nil {
+		logger.Errorf(err, "Unable to audit the global IP allocations")
+		return
+	}
+
+	a.auditConflicts(owners, byIP)
+	a.auditPool(allocations, byIP)
+	a.auditRules(owners, byIP)
+}
+
+func (a *ipAuditor) getIPOwners() ([]ipOwner, map[string][]ipOwner, error) {
+	owners := []ipOwner{}
+	byIP := map[string][]ipOwner{}
+
+	for _, kind := range []string{globalEgressIPKind, clusterGlobalEgressIPKind, globalIngressIPKind, gatewayKind} {
+		list, err := a.resources[kind].Namespace(corev1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "error listing the %ss", kind)
+		}
+
+		for i := range list.Items {
+			owner := ipOwner{kind: kind, obj: &list.Items[i]}
+			owners = append(owners, owner)
+
+			for _, ip := range getOwnedIPs(owner) {
+				byIP[ip] = append(byIP[ip], owner)
+			}
+		}
+	}
+
+	return owners, byIP, nil
+}
+
+func getOwnedIPs(owner ipOwner) []string {
+	switch owner.kind {
+	case globalEgressIPKind, clusterGlobalEgressIPKind:
+		ips, _, _ := unstructured.NestedStringSlice(owner.obj.Object, "status", "allocatedIPs")
+		return ips
+	case globalIngressIPKind:
+		if ip, _, _ := unstructured.NestedString(owner.obj.Object, "status", "allocatedIP"); ip != "" {
+			return []string{ip}
+		}
+	case gatewayKind:
+		if ip := owner.obj.GetAnnotations()[constants.SmGlobalIP]; ip != "" {
+			return []string{ip}
+		}
+	}
+
+	return nil
+}
+
+func (a *ipAuditor) auditConflicts(owners []ipOwner, byIP map[string][]ipOwner) {
+	conflicts := map[string][]string{}
+	numConflicts := 0
+
+	for ip, ipOwners := range byIP {
+		if len(ipOwners) < 2 {
+			continue
+		}
+
+		numConflicts++
+
+		logger.Warningf("Global IP %s is allocated to more than one resource: %v", ip, ipOwners)
+
+		for i := range ipOwners {
+			for j := range ipOwners {
+				if i != j {
+					conflicts[ipOwners[i].String()] = append(conflicts[ipOwners[i].String()],
+						fmt.Sprintf("IP %s is also allocated to %s", ip, ipOwners[j]))
+				}
+			}
+		}
+	}
+
+	metrics.RecordGlobalIPAuditIssues(auditIssueConflict, numConflicts)
+
+	for _, owner := range owners {
+		if owner.kind == gatewayKind {
+			continue
+		}
+
+		err := a.updateConflictCondition(owner, strings.Join(conflicts[owner.String()], "; "))
+		if err != nil {
+			logger.Errorf(err, "Error updating the %s condition for %s", submarinerv1.GlobalEgressIPConflict, owner)
+		}
+	}
+}
+
+func (a *ipAuditor) updateConflictCondition(owner ipOwner, message string) error {
+	status := struct {
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+	}{}
+
+	rawStatus, _, _ := unstructured.NestedMap(owner.obj.Object, "status")
+
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawStatus, &status)
+	if err != nil {
+		return errors.Wrap(err, "error converting the status")
+	}
+
+	var changed bool
+
+	if message != "" {
+		changed = meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    string(submarinerv1.GlobalEgressIPConflict),
+			Status:  metav1.ConditionTrue,
+			Reason:  "DuplicateGlobalIP",
+			Message: message,
+		})
+	} else {
+		changed = meta.RemoveStatusCondition(&status.Conditions, string(submarinerv1.GlobalEgressIPConflict))
+	}
+
+	if !changed {
+		return nil
+	}
+
+	rawStatus, err = runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return errors.Wrap(err, "error converting the status")
+	}
+
+	if conditions, ok := rawStatus["conditions"]; ok {
+		err = unstructured.SetNestedField(owner.obj.Object, conditions, "status", "conditions")
+		if err != nil {
+			return errors.Wrap(err, "error setting the status conditions")
+		}
+	} else {
+		unstructured.RemoveNestedField(owner.obj.Object, "status", "conditions")
+	}
+
+	_, err = a.resources[owner.kind].Namespace(owner.obj.GetNamespace()).UpdateStatus(context.TODO(), owner.obj,
+		metav1.UpdateOptions{})
+
+	return errors.Wrapf(err, "error updating the status of %s", owner)
+}
+
+func (a *ipAuditor) auditPool(allocations map[string]uint64, byIP map[string][]ipOwner) {
+	referenced := set.New[string]()
+
+	for ip := range byIP {
+		if a.pool.Contains(ip) {
+			referenced.Insert(ip)
+		}
+	}
+
+	leaked := map[string]uint64{}
+
+	for ip, seq := range allocations {
+		if !referenced.Has(ip) {
+			leaked[ip] = seq
+		}
+	}
+
+	unreserved := set.New[string]()
+
+	for ip := range referenced {
+		if !a.pool.IsAllocated(ip) {
+			unreserved.Insert(ip)
+		}
+	}
+
+	metrics.RecordGlobalIPAuditIssues(auditIssueLeaked, len(leaked))
+	metrics.RecordGlobalIPAuditIssues(auditIssueUnreserved, unreserved.Len())
+
+	// Only the IPs leaked by the same allocation in both audits are released, and only if they're still allocated by it.
+	toRelease := map[string]uint64{}
+
+	for ip, seq := range leaked {
+		if prevSeq, ok := a.leakedIPs[ip]; ok && prevSeq == seq {
+			toRelease[ip] = seq
+		}
+	}
+
+	if len(toRelease) > 0 {
+		released, err := a.pool.ReleaseUnchanged(toRelease)
+		if err != nil {
+			logger.Errorf(err, "Error releasing leaked global IPs %v", slices.Sorted(maps.Keys(toRelease)))
+		} else {
+			if len(released) > 0 {
+				logger.Warningf("Released global IPs %v that weren't allocated to any resource", released)
+				metrics.RecordGlobalIPAuditFixes(auditIssueLeaked, len(released))
+			}
+
+			// Those not released were re-allocated since the snapshot.
+			for ip := range toRelease {
+				delete(leaked, ip)
+			}
+		}
+	}
+
+	for _, ip := range unreserved.Intersection(a.unreservedIPs).SortedList() {
+		logger.Warningf("Reserving global IP %s allocated to %v", ip, byIP[ip])
+
+		if err := a.pool.Reserve(ip); err != nil {
+			logger.Errorf(err, "Error reserving global IP %s", ip)
+			continue
+		}
+
+		metrics.RecordGlobalIPAuditFixes(auditIssueUnreserved, 1)
+		unreserved.Delete(ip)
+	}
+
+	a.leakedIPs = leaked
+	a.unreservedIPs = unreserved
+}
+
+func (a *ipAuditor) auditRules(owners []ipOwner, byIP map[string][]ipOwner) {
+	namedSets := set.New[string]()
+
+	for _, owner := range owners {
+		if owner.kind == globalEgressIPKind {
+			namedSets.Insert(getEgressIPSetName(owner.obj.GetNamespace() + "/" + owner.obj.GetName()))
+		}
+	}
+
+	orphaned := set.New[string]()
+	orphanedSets := set.New[string]()
+	numOrphaned := 0
+
+	for _, chain := range auditedChains {
+		rules, err := a.pFilter.List(packetfilter.TableTypeNAT, chain)
+		if err != nil {
+			logger.Errorf(err, "Error listing the rules in chain %q", chain)
+			continue
+		}
+
+		for _, rule := range rules {
+			if !a.isOrphaned(chain, rule, byIP, namedSets) {
+				continue
+			}
+
+			numOrphaned++
+
+			key := chain + ": " + rule.String()
+			if !a.orphanedRules.Has(key) {
+				orphaned.Insert(key)
+				continue
+			}
+
+			logger.Warningf("Deleting orphaned rule %q from chain %q", rule, chain)
+
+			if err := a.pFilter.Delete(packetfilter.TableTypeNAT, chain, rule); err != nil {
+				logger.Errorf(err, "Error deleting orphaned rule %q from chain %q", rule, chain)
+				orphaned.Insert(key)
+
+				continue
+			}
+
+			metrics.RecordGlobalIPAuditFixes(auditIssueOrphanedRule, 1)
+
+			if rule.SrcSetName != "" && !namedSets.Has(rule.SrcSetName) {
+				orphanedSets.Insert(rule.SrcSetName)
+			}
+		}
+	}
+
+	metrics.RecordGlobalIPAuditIssues(auditIssueOrphanedRule, numOrphaned)
+
+	a.orphanedRules = orphaned
+
+	if orphanedSets.Len() > 0 {
+		logger.Warningf("Destroying orphaned named sets %v", orphanedSets.SortedList())
+
+		if err := a.pFilter.DestroySets(orphanedSets.Has); err != nil {
+			logger.Errorf(err, "Error destroying orphaned named sets %v", orphanedSets.SortedList())
+		}
+	}
+}
+
+// isOrphaned returns true if the global IP of the given rule isn't allocated to any resource or if the rule matches a
+// named set that doesn't belong to any GlobalEgressIP.
+func (a *ipAuditor) isOrphaned(chain string, rule *packetfilter.Rule, byIP map[string][]ipOwner,
+	namedSets set.Set[string],
+) bool {
+	var globalIP string
+
+	if chain == constants.SmGlobalnetIngressChain {
+		globalIP = rule.DestCIDR
+	} else {
+		globalIP, _, _ = strings.Cut(rule.SnatCIDR, "-")
+	}
+
+	globalIP = strings.TrimSuffix(globalIP, "/32")
+
+	if globalIP == "" || !a.pool.Contains(globalIP) {
+		return false
+	}
+
+	if _, found := byIP[globalIP]; !found {
+		return true
+	}
+
+	return rule.SrcSetName != "" && !namedSets.Has(rule.SrcSetName)
+}
@@ -0,0 +1,218 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/syncer"
+	"github.com/submariner-io/admiral/pkg/syncer/test"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
+	"github.com/submariner-io/submariner/pkg/globalnet/controllers"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+var _ = Describe("IP auditor", func() {
+	t := newIPAuditorTestDriver()
+
+	When("a global IP is reserved in the pool but not allocated to any resource", func() {
+		BeforeEach(func() {
+			Expect(t.pool.Reserve(globalIP1)).To(Succeed())
+		})
+
+		It("should release it", func() {
+			Eventually(func() bool {
+				return t.pool.IsAllocated(globalIP1)
+			}, 3*time.Second).Should(BeFalse())
+		})
+	})
+
+	When("a global IP not allocated to any resource is re-allocated between audits", func() {
+		BeforeEach(func() {
+			Expect(t.pool.Reserve(globalIP1)).To(Succeed())
+		})
+
+		It("should not release it", func() {
+			Consistently(func() bool {
+				allocated := t.pool.IsAllocated(globalIP1)
+
+				Expect(t.pool.Release(globalIP1)).To(Succeed())
+				Expect(t.pool.Reserve(globalIP1)).To(Succeed())
+
+				return allocated
+			}, time.Second, 10*time.Millisecond).Should(BeTrue())
+		})
+	})
+
+	When("a global IP is allocated to a resource but not reserved in the pool", func() {
+		BeforeEach(func() {
+			t.createGlobalIngressIP(newAllocatedGlobalIngressIP(globalIngressIPName, globalIP1))
+		})
+
+		It("should reserve it", func() {
+			Eventually(func() bool {
+				return t.pool.IsAllocated(globalIP1)
+			}, 3*time.Second).Should(BeTrue())
+		})
+	})
+
+	When("a global IP is allocated to more than one resource", func() {
+		BeforeEach(func() {
+			t.createGlobalIngressIP(newAllocatedGlobalIngressIP(globalIngressIPName, globalIP1))
+
+			egressIP := newGlobalEgressIP(globalEgressIPName, nil, nil)
+			egressIP.Status.AllocatedIPs = []string{globalIP1}
+			t.createGlobalEgressIP(egressIP)
+		})
+
+		It("should set the Conflict condition on both resources", func() {
+			t.awaitConflictCondition(t.globalIngressIPs, globalIngressIPName, true)
+			t.awaitConflictCondition(t.globalEgressIPs, globalEgressIPName, true)
+		})
+
+		Context("and the conflict is then resolved", func() {
+			It("should remove the Conflict condition", func() {
+				t.awaitConflictCondition(t.globalEgressIPs, globalEgressIPName, true)
+
+				egressIP := test.GetResource(t.globalEgressIPs, &submarinerv1.GlobalEgressIP{
+					ObjectMeta: metav1.ObjectMeta{Name: globalEgressIPName},
+				})
+				egressIP.Status.AllocatedIPs = []string{globalIP2}
+				test.UpdateResource(t.globalEgressIPs, egressIP)
+
+				t.awaitConflictCondition(t.globalIngressIPs, globalIngressIPName, false)
+				t.awaitConflictCondition(t.globalEgressIPs, globalEgressIPName, false)
+			})
+		})
+	})
+
+	When("packet filter rules exist for global IPs that aren't allocated to any resource", func() {
+		const orphanedSetName = controllers.IPSetPrefix + "ORPHANED"
+
+		BeforeEach(func() {
+			t.createGlobalIngressIP(newAllocatedGlobalIngressIP(globalIngressIPName, globalIP1))
+
+			Expect(t.pFilter.Append(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain, &packetfilter.Rule{
+				DestCIDR: globalIP1,
+				DnatCIDR: "10.1.2.3",
+				Action:   packetfilter.RuleActionDNAT,
+			})).To(Succeed())
+
+			Expect(t.pFilter.Append(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain, &packetfilter.Rule{
+				DestCIDR: globalIP2,
+				DnatCIDR: "10.1.2.4",
+				Action:   packetfilter.RuleActionDNAT,
+			})).To(Succeed())
+
+			Expect(t.pFilter.NewNamedSet(&packetfilter.SetInfo{Name: orphanedSetName}).Create(true)).To(Succeed())
+
+			Expect(t.pFilter.Append(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForPods, &packetfilter.Rule{
+				SrcSetName: orphanedSetName,
+				SnatCIDR:   globalIP3,
+				Action:     packetfilter.RuleActionSNAT,
+			})).To(Succeed())
+		})
+
+		It("should delete the orphaned rules and named sets", func() {
+			t.pFilter.AwaitNoRule(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain, ContainSubstring(globalIP2))
+			t.pFilter.AwaitNoRule(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForPods, ContainSubstring(globalIP3))
+			t.pFilter.AwaitSetDeleted(orphanedSetName)
+			t.pFilter.AwaitRule(packetfilter.TableTypeNAT, constants.SmGlobalnetIngressChain, ContainSubstring(globalIP1))
+		})
+	})
+})
+
+type ipAuditorTestDriver struct {
+	*testDriverBase
+}
+
+func newIPAuditorTestDriver() *ipAuditorTestDriver {
+	t := &ipAuditorTestDriver{}
+
+	BeforeEach(func() {
+		t.testDriverBase = newTestDriverBase()
+		t.testDriverBase.initChains()
+
+		var err error
+
+		t.pool, err = controllers.NewIPPools([]string{t.globalCIDR}, nil)
+		Expect(err).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		t.start()
+	})
+
+	AfterEach(func() {
+		t.testDriverBase.afterEach()
+	})
+
+	return t
+}
+
+func (t *ipAuditorTestDriver) start() {
+	var err error
+
+	t.controller, err = controllers.NewIPAuditor(&syncer.ResourceSyncerConfig{
+		SourceClient: t.dynClient,
+		RestMapper:   t.restMapper,
+		Scheme:       t.scheme,
+	}, t.pool, 100*time.Millisecond)
+	Expect(err).To(Succeed())
+	Expect(t.controller.Start()).To(Succeed())
+}
+
+func (t *ipAuditorTestDriver) awaitConflictCondition(client dynamic.ResourceInterface, name string, present bool) {
+	Eventually(func() bool {
+		obj, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+		Expect(err).To(Succeed())
+
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for i := range conditions {
+			if conditions[i].(map[string]interface{})["type"] == string(submarinerv1.GlobalEgressIPConflict) {
+				return true
+			}
+		}
+
+		return false
+	}, 3*time.Second).Should(Equal(present), "Unexpected %s condition for %q", submarinerv1.GlobalEgressIPConflict, name)
+}
+
+func newAllocatedGlobalIngressIP(name, allocatedIP string) *submarinerv1.GlobalIngressIP {
+	return &submarinerv1.GlobalIngressIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: submarinerv1.GlobalIngressIPSpec{
+			Target:     submarinerv1.ClusterIPService,
+			ServiceRef: &corev1.LocalObjectReference{Name: serviceName},
+		},
+		Status: submarinerv1.GlobalIngressIPStatus{
+			AllocatedIP: allocatedIP,
+		},
+	}
+}
@@ -0,0 +1,390 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"maps"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/ipam"
+)
+
+// IPPools manages an IP pool per GlobalCIDR assigned to the cluster. GlobalCIDRs can be added at runtime without
+// affecting the IPs already allocated from the existing pools.
+type IPPools struct {
+	mutex   sync.RWMutex
+	pools   []*ipam.IPPool
+	metrics ipam.MetricsReporter
+
+	// allocMutex serializes the allocations and releases so the allocated IPs stay consistent with the pools.
+	allocMutex sync.Mutex
+	// allocated maps the allocated or reserved IPs to the sequence number of their allocation, which tells a re-allocated
+	// IP apart.
+	allocated map[string]uint64
+	allocSeq  uint64
+}
+
+func NewIPPools(cidrs []string, metrics ipam.MetricsReporter) (*IPPools, error) {
+	p := &IPPools{metrics: metrics, allocated: map[string]uint64{}}
+
+	for _, cidr := range cidrs {
+		if _, err := p.AddCIDR(cidr); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.pools) == 0 {
+		return nil, errors.New("no GlobalCIDR configured")
+	}
+
+	return p, nil
+}
+
+// AddCIDR adds a pool for the given CIDR and returns true if it wasn't already present. A CIDR overlapping an existing
+// pool is rejected.
+func (p *IPPools) AddCIDR(cidr string) (bool, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	cidrs := make([]string, len(p.pools))
+	for i, pool := range p.pools {
+		cidrs[i] = pool.GetCIDR()
+	}
+
+	if slices.Contains(cidrs, cidr) {
+		return false, nil
+	}
+
+	if err := validateGlobalCIDR(cidrs, cidr); err != nil {
+		return false, err
+	}
+
+	pool, err := ipam.NewIPPool(cidr, p.metrics)
+	if err != nil {
+		return false, errors.Wrapf(err, "error creating the IP pool for GlobalCIDR %q", cidr)
+	}
+
+	p.pools = append(p.pools, pool)
+
+	return true, nil
+}
+
+// validateGlobalCIDR checks that the given CIDR is valid and doesn't overlap the existing ones.
+func validateGlobalCIDR(existing []string, cidr string) error {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing GlobalCIDR %q", cidr)
+	}
+
+	for _, e := range existing {
+		_, existingNetwork, err := net.ParseCIDR(e)
+		if err != nil {
+			continue
+		}
+
+		if existingNetwork.Contains(network.IP) || network.Contains(existingNetwork.IP) {
+			return errors.Errorf("GlobalCIDR %q overlaps with GlobalCIDR %q", cidr, e)
+		}
+	}
+
+	return nil
+}
+
+// GetCIDRs returns the CIDRs of the pools, in the order they were added.
+func (p *IPPools) GetCIDRs() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	cidrs := make([]string, len(p.pools))
+	for i, pool := range p.pools {
+		cidrs[i] = pool.GetCIDR()
+	}
+
+	return cidrs
+}
+
+// GetCIDRFor returns the CIDR of the pool containing the given IPs, allocated as a block, or an empty string if there's
+// none.
+func (p *IPPools) GetCIDRFor(ips []string) string {
+	if len(ips) == 0 {
+		return ""
+	}
+
+	pool := p.poolFor(ips[0])
+	if pool == nil {
+		return ""
+	}
+
+	return pool.GetCIDR()
+}
+
+// Allocate allocates a contiguous block of IPs from the first pool with enough available IPs, in the order the pools
+// were added.
+func (p *IPPools) Allocate(num int) ([]string, error) {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	return p.allocate(num)
+}
+
+func (p *IPPools) allocate(num int) ([]string, error) {
+	p.mutex.RLock()
+	pools := slices.Clone(p.pools)
+	p.mutex.RUnlock()
+
+	var err error
+
+	for _, pool := range pools {
+		var ips []string
+
+		ips, err = pool.Allocate(num)
+		if err == nil {
+			p.markAllocated(ips)
+
+			return ips, nil
+		}
+	}
+
+	return nil, errors.Wrapf(err, "unable to allocate %d IP(s) from any of the GlobalCIDRs %v", num, p.GetCIDRs())
+}
+
+// AllocateFrom allocates a contiguous block of IPs from the pool of the given CIDR. If the CIDR is empty, the IPs are
+// allocated from any pool.
+func (p *IPPools) AllocateFrom(cidr string, num int) ([]string, error) {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	if cidr == "" {
+		return p.allocate(num)
+	}
+
+	pool := p.poolForCIDR(cidr)
+	if pool == nil {
+		return nil, errors.Errorf("the requested GlobalCIDR %q is not assigned to the cluster", cidr)
+	}
+
+	ips, err := pool.Allocate(num)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to allocate %d IP(s) from GlobalCIDR %q", num, cidr)
+	}
+
+	p.markAllocated(ips)
+
+	return ips, nil
+}
+
+// Reserve reserves the given IPs in their respective pools. Either all the IPs are reserved or none.
+func (p *IPPools) Reserve(ips ...string) error {
+	byPool, err := p.groupByPool(ips)
+	if err != nil {
+		return err
+	}
+
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	reserved := []*ipam.IPPool{}
+
+	for _, pool := range p.orderedPools(byPool) {
+		if err := pool.Reserve(byPool[pool]...); err != nil {
+			for _, r := range reserved {
+				_ = r.Release(byPool[r]...)
+			}
+
+			return err //nolint:wrapcheck  // No need to wrap this error
+		}
+
+		reserved = append(reserved, pool)
+	}
+
+	p.markAllocated(ips)
+
+	return nil
+}
+
+// markAllocated records the IPs as allocated together. It must be called with the allocMutex held.
+func (p *IPPools) markAllocated(ips []string) {
+	p.allocSeq++
+
+	for _, ip := range ips {
+		p.allocated[ip] = p.allocSeq
+	}
+}
+
+// Release releases the given IPs back to their respective pools.
+func (p *IPPools) Release(ips ...string) error {
+	byPool, err := p.groupByPool(ips)
+	if err != nil {
+		return err
+	}
+
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	return p.release(byPool)
+}
+
+// ReleaseUnchanged releases the given IPs which are still allocated by the allocations returned by GetAllocations, ie
+// which weren't released and re-allocated since, and returns them.
+func (p *IPPools) ReleaseUnchanged(allocations map[string]uint64) ([]string, error) {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	var ips []string
+
+	for ip, seq := range allocations {
+		if current, ok := p.allocated[ip]; ok && current == seq {
+			ips = append(ips, ip)
+		}
+	}
+
+	slices.Sort(ips)
+
+	byPool, err := p.groupByPool(ips)
+	if err != nil {
+		return nil, err
+	}
+
+	return ips, p.release(byPool)
+}
+
+// release releases the IPs from their pools. It must be called with the allocMutex held.
+func (p *IPPools) release(byPool map[*ipam.IPPool][]string) error {
+	for _, pool := range p.orderedPools(byPool) {
+		if err := pool.Release(byPool[pool]...); err != nil {
+			return err //nolint:wrapcheck  // No need to wrap this error
+		}
+
+		for _, ip := range byPool[pool] {
+			delete(p.allocated, ip)
+		}
+	}
+
+	return nil
+}
+
+// IsAllocated returns true if the given IP is currently allocated or reserved in its pool.
+func (p *IPPools) IsAllocated(ip string) bool {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	_, ok := p.allocated[ip]
+
+	return ok
+}
+
+// GetAllocated returns the IPs currently allocated or reserved in all the pools.
+func (p *IPPools) GetAllocated() []string {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	return slices.Sorted(maps.Keys(p.allocated))
+}
+
+// GetAllocations returns a snapshot of the IPs currently allocated or reserved in all the pools, mapped to the sequence
+// number of their allocation.
+func (p *IPPools) GetAllocations() map[string]uint64 {
+	p.allocMutex.Lock()
+	defer p.allocMutex.Unlock()
+
+	return maps.Clone(p.allocated)
+}
+
+// Contains returns true if the given IP is contained in any of the GlobalCIDRs.
+func (p *IPPools) Contains(ip string) bool {
+	return p.poolFor(ip) != nil
+}
+
+// Size returns the total number of available IPs in all the pools.
+func (p *IPPools) Size() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	size := 0
+	for _, pool := range p.pools {
+		size += pool.Size()
+	}
+
+	return size
+}
+
+func (p *IPPools) poolFor(ip string) *ipam.IPPool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil
+	}
+
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for _, pool := range p.pools {
+		_, network, _ := net.ParseCIDR(pool.GetCIDR())
+		if network.Contains(parsed) {
+			return pool
+		}
+	}
+
+	return nil
+}
+
+func (p *IPPools) poolForCIDR(cidr string) *ipam.IPPool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for _, pool := range p.pools {
+		if pool.GetCIDR() == cidr {
+			return pool
+		}
+	}
+
+	return nil
+}
+
+func (p *IPPools) groupByPool(ips []string) (map[*ipam.IPPool][]string, error) {
+	byPool := map[*ipam.IPPool][]string{}
+
+	for _, ip := range ips {
+		pool := p.poolFor(ip)
+		if pool == nil {
+			return nil, errors.Errorf("IP %q is not contained in any of the GlobalCIDRs %v", ip, p.GetCIDRs())
+		}
+
+		byPool[pool] = append(byPool[pool], ip)
+	}
+
+	return byPool, nil
+}
+
+func (p *IPPools) orderedPools(byPool map[*ipam.IPPool][]string) []*ipam.IPPool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	pools := make([]*ipam.IPPool, 0, len(byPool))
+
+	for _, pool := range p.pools {
+		if _, ok := byPool[pool]; ok {
+			pools = append(pools, pool)
+		}
+	}
+
+	return pools
+}
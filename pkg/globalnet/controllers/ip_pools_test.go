@@ -0,0 +1,149 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner/pkg/globalnet/controllers"
+)
+
+var _ = Describe("IPPools", func() {
+	const (
+		cidr1 = "242.10.1.0/30"
+		cidr2 = "242.10.2.0/29"
+	)
+
+	var pools *controllers.IPPools
+
+	BeforeEach(func() {
+		var err error
+
+		pools, err = controllers.NewIPPools([]string{cidr1, cidr2}, nil)
+		Expect(err).To(Succeed())
+	})
+
+	It("should return the CIDRs in order", func() {
+		Expect(pools.GetCIDRs()).To(Equal([]string{cidr1, cidr2}))
+		Expect(pools.Size()).To(Equal(8))
+	})
+
+	When("the first pool doesn't have enough available IPs", func() {
+		It("should allocate from the next one", func() {
+			ips, err := pools.Allocate(2)
+			Expect(err).To(Succeed())
+			Expect(pools.GetCIDRFor(ips)).To(Equal(cidr1))
+
+			ips, err = pools.Allocate(1)
+			Expect(err).To(Succeed())
+			Expect(pools.GetCIDRFor(ips)).To(Equal(cidr2))
+
+			_, err = pools.Allocate(6)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("a specific CIDR is requested", func() {
+		It("should allocate from its pool", func() {
+			ips, err := pools.AllocateFrom(cidr2, 3)
+			Expect(err).To(Succeed())
+			Expect(pools.GetCIDRFor(ips)).To(Equal(cidr2))
+
+			_, err = pools.AllocateFrom("242.10.3.0/24", 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("IPs from different pools are reserved", func() {
+		It("should reserve them in their respective pools", func() {
+			Expect(pools.Reserve("242.10.1.1", "242.10.2.1")).To(Succeed())
+			Expect(pools.Size()).To(Equal(6))
+
+			Expect(pools.Release("242.10.1.1", "242.10.2.1")).To(Succeed())
+			Expect(pools.Size()).To(Equal(8))
+		})
+
+		Context("and one of them can't be reserved", func() {
+			It("should not reserve any", func() {
+				Expect(pools.Reserve("242.10.2.1")).To(Succeed())
+				Expect(pools.Reserve("242.10.1.1", "242.10.2.1")).ToNot(Succeed())
+				Expect(pools.Size()).To(Equal(7))
+			})
+		})
+
+		Context("and one of them isn't in any pool", func() {
+			It("should return an error", func() {
+				Expect(pools.Reserve("242.10.1.1", "10.0.0.1")).ToNot(Succeed())
+				Expect(pools.Size()).To(Equal(8))
+			})
+		})
+	})
+
+	It("should track the allocated IPs", func() {
+		ips, err := pools.AllocateFrom(cidr2, 2)
+		Expect(err).To(Succeed())
+		Expect(pools.Reserve("242.10.1.1")).To(Succeed())
+		Expect(pools.GetAllocated()).To(ConsistOf(append(ips, "242.10.1.1")))
+		Expect(pools.IsAllocated(ips[0])).To(BeTrue())
+
+		Expect(pools.Release(ips...)).To(Succeed())
+		Expect(pools.GetAllocated()).To(Equal([]string{"242.10.1.1"}))
+		Expect(pools.IsAllocated(ips[0])).To(BeFalse())
+		Expect(pools.Contains("242.10.2.7")).To(BeTrue())
+		Expect(pools.Contains("10.0.0.1")).To(BeFalse())
+	})
+
+	It("should only release the unchanged allocations", func() {
+		Expect(pools.Reserve("242.10.1.1", "242.10.1.2")).To(Succeed())
+
+		allocations := pools.GetAllocations()
+		Expect(allocations).To(HaveLen(2))
+
+		Expect(pools.Release("242.10.1.2")).To(Succeed())
+		Expect(pools.Reserve("242.10.1.2")).To(Succeed())
+
+		released, err := pools.ReleaseUnchanged(allocations)
+		Expect(err).To(Succeed())
+		Expect(released).To(Equal([]string{"242.10.1.1"}))
+		Expect(pools.GetAllocated()).To(Equal([]string{"242.10.1.2"}))
+	})
+
+	When("a CIDR is added", func() {
+		It("should add its pool without affecting the existing allocations", func() {
+			Expect(pools.Reserve("242.10.1.1")).To(Succeed())
+
+			added, err := pools.AddCIDR("242.10.3.0/29")
+			Expect(err).To(Succeed())
+			Expect(added).To(BeTrue())
+			Expect(pools.GetCIDRs()).To(Equal([]string{cidr1, cidr2, "242.10.3.0/29"}))
+			Expect(pools.Reserve("242.10.1.1")).ToNot(Succeed())
+
+			added, err = pools.AddCIDR(cidr1)
+			Expect(err).To(Succeed())
+			Expect(added).To(BeFalse())
+		})
+
+		Context("that overlaps an existing one", func() {
+			It("should return an error", func() {
+				_, err := pools.AddCIDR("242.10.2.0/24")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
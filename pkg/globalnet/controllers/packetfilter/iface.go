@@ -44,6 +44,8 @@ type Interface interface {
 	RemoveEgressRulesForPods(key, namedSetName, snatIP, globalNetIPTableMark string) error
 	AddEgressRulesForNamespace(namespace, namedSetName, snatIP, globalNetIPTableMark string) error
 	RemoveEgressRulesForNamespace(namespace, namedSetName, snatIP, globalNetIPTableMark string) error
+	AddEgressRulesForNSSelector(key, namedSetName, snatIP, globalNetIPTableMark string) error
+	RemoveEgressRulesForNSSelector(key, namedSetName, snatIP, globalNetIPTableMark string) error
 	FlushNatChain(chainName string) error
 	DeleteNatChain(chainName string) error
 	NewNamedSet(key string) NamedSet
@@ -56,8 +58,9 @@ type pfilter struct {
 type TargetType string
 
 const (
-	PodTarget       TargetType = "Pod"
-	EndpointsTarget TargetType = "Endpoints"
+	PodTarget           TargetType = "Pod"
+	EndpointsTarget     TargetType = "Endpoints"
+	StandalonePodTarget TargetType = "StandalonePod"
 )
 
 var logger = log.Logger{Logger: logf.Log.WithName("PacketFilter")}
@@ -181,13 +184,7 @@ func (i *pfilter) AddEgressRulesForHeadlessSvc(key, sourceIP, snatIP, globalNetI
 	}
 	logger.V(log.DEBUG).Infof("Installing packetfilter egress rules for HDLS SVC %q for %s: %+v", key, targetType, &ruleSpec)
 
-	var chain string
-
-	if targetType == PodTarget {
-		chain = constants.SmGlobalnetEgressChainForHeadlessSvcPods
-	} else if targetType == EndpointsTarget {
-		chain = constants.SmGlobalnetEgressChainForHeadlessSvcEPs
-	}
+	chain := egressChainFor(targetType)
 
 	if err := i.pFilter.AppendUnique(packetfilter.TableTypeNAT, chain, &ruleSpec); err != nil {
 		return errors.Wrapf(err, "error appending packetfilter rule %+v", &ruleSpec)
@@ -196,6 +193,16 @@ func (i *pfilter) AddEgressRulesForHeadlessSvc(key, sourceIP, snatIP, globalNetI
 	return nil
 }
 
+// egressChainFor returns the chain for the egress rules of the given target type. Standalone pods share the chain
+// of the headless Service pods as both take precedence over the namespace and cluster egress rules.
+func egressChainFor(targetType TargetType) string {
+	if targetType == EndpointsTarget {
+		return constants.SmGlobalnetEgressChainForHeadlessSvcEPs
+	}
+
+	return constants.SmGlobalnetEgressChainForHeadlessSvcPods
+}
+
 func (i *pfilter) RemoveEgressRulesForHeadlessSvc(key, sourceIP, snatIP, globalNetIPTableMark string, targetType TargetType) error {
 	ruleSpec := packetfilter.Rule{
 		Proto:     packetfilter.RuleProtoAll,
@@ -206,13 +213,7 @@ func (i *pfilter) RemoveEgressRulesForHeadlessSvc(key, sourceIP, snatIP, globalN
 	}
 	logger.V(log.DEBUG).Infof("Deleting iptable egress rules for HDLS SVC %q for %s: %+v", key, targetType, &ruleSpec)
 
-	var chain string
-
-	if targetType == PodTarget {
-		chain = constants.SmGlobalnetEgressChainForHeadlessSvcPods
-	} else if targetType == EndpointsTarget {
-		chain = constants.SmGlobalnetEgressChainForHeadlessSvcEPs
-	}
+	chain := egressChainFor(targetType)
 
 	return i.deleteNATRule(chain, &ruleSpec)
 }
@@ -289,6 +290,44 @@ func (i *pfilter) RemoveEgressRulesForNamespace(namespace, namedSetName, snatIP,
 	return nil
 }
 
+// AddEgressRulesForNSSelector installs the egress rules for the namespaces selected by label. Their chain follows the
+// one for single namespaces, so a namespace's own GlobalEgressIP takes precedence.
+func (i *pfilter) AddEgressRulesForNSSelector(key, namedSetName, snatIP, globalNetIPTableMark string) error {
+	ruleSpec := &packetfilter.Rule{
+		Proto:      packetfilter.RuleProtoAll,
+		SrcSetName: namedSetName,
+		SnatCIDR:   snatIP,
+		MarkValue:  globalNetIPTableMark,
+		Action:     packetfilter.RuleActionSNAT,
+	}
+
+	logger.V(log.DEBUG).Infof("Installing egress rules for the namespaces selected by %q: %q", key, ruleSpec)
+
+	if err := i.pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForNSSelector, ruleSpec); err != nil {
+		return errors.Wrapf(err, "error appending rule \"%q\" chain:%s", ruleSpec, constants.SmGlobalnetEgressChainForNSSelector)
+	}
+
+	return nil
+}
+
+func (i *pfilter) RemoveEgressRulesForNSSelector(key, namedSetName, snatIP, globalNetIPTableMark string) error {
+	ruleSpec := &packetfilter.Rule{
+		Proto:      packetfilter.RuleProtoAll,
+		SrcSetName: namedSetName,
+		SnatCIDR:   snatIP,
+		MarkValue:  globalNetIPTableMark,
+		Action:     packetfilter.RuleActionSNAT,
+	}
+
+	logger.V(log.DEBUG).Infof("Deleting egress rules for the namespaces selected by %q: %q", key, ruleSpec)
+
+	if err := i.pFilter.Delete(packetfilter.TableTypeNAT, constants.SmGlobalnetEgressChainForNSSelector, ruleSpec); err != nil {
+		return errors.Wrapf(err, "error  rule \"%q\" chain:%s", ruleSpec, constants.SmGlobalnetEgressChainForNSSelector)
+	}
+
+	return nil
+}
+
 func (i *pfilter) FlushNatChain(chainName string) error {
 	logger.Infof("Flushing packetfilter rules in %q chain of table NAT", chainName)
 
@@ -24,6 +24,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/globalnet/constants"
 	gnpacketfilter "github.com/submariner-io/submariner/pkg/globalnet/controllers/packetfilter"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -178,8 +179,10 @@ func (c *serviceExportController) onCreate(serviceExport *mcsv1a1.ServiceExport)
 			Namespace: serviceExport.Namespace,
 		},
 		Spec: submarinerv1.GlobalIngressIPSpec{
-			Target:     submarinerv1.ClusterIPService,
-			ServiceRef: &corev1.LocalObjectReference{Name: serviceExport.Name},
+			Target:      submarinerv1.ClusterIPService,
+			ServiceRef:  &corev1.LocalObjectReference{Name: serviceExport.Name},
+			GlobalCIDR:  service.GetAnnotations()[constants.SmGlobalCIDR],
+			RequestedIP: service.GetAnnotations()[constants.SmRequestedGlobalIP],
 		},
 	}
 
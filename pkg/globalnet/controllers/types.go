@@ -24,10 +24,10 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/submariner-io/admiral/pkg/ipam"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/watcher"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/event"
 	pfIface "github.com/submariner-io/submariner/pkg/globalnet/controllers/packetfilter"
 	"github.com/submariner-io/submariner/pkg/packetfilter"
@@ -55,6 +55,13 @@ const (
 	// This is an internal annotation used between ingress endpoints controller and global-ingress controller.
 	headlessSvcEndpointsIP = "submariner.io/headless-svc-endpoints-ip"
 
+	// This is an internal annotation used between ingress target pod controller and global-ingress controller.
+	ingressPodIP = "submariner.io/ingress-pod-ip"
+
+	// IngressTargetLabel is applied on the GlobalIngressIPs created for Services and Pods with the global ingress
+	// annotation and contains the kind of the target.
+	IngressTargetLabel = "submariner.io/globalIngressTarget"
+
 	ServiceRefLabel = "submariner.io/serviceRef"
 
 	// InternalServicePrefix is a prefix used for internal services.
@@ -90,6 +97,11 @@ type Specification struct {
 	GlobalCIDR  []string
 	MetricsPort int `default:"32781"`
 	Uninstall   bool
+	// PacketFilterDriver is one of "iptables", "nftables" or "auto" (the default).
+	PacketFilterDriver string
+	// AuditInterval is the interval at which the global IP allocations are cross-checked with the resources and the
+	// packet filter rules.
+	AuditInterval time.Duration `default:"5m"`
 }
 
 type LeaderElectionConfig struct {
@@ -139,6 +151,8 @@ type gatewayMonitor struct {
 	cniIP                       string
 	controllersMutex            sync.Mutex // Protects controllers
 	controllers                 []Interface
+	globalCIDRsMutex            sync.Mutex // Protects Spec.GlobalCIDR and pools
+	pools                       *IPPools
 }
 
 type baseSyncerController struct {
@@ -148,7 +162,7 @@ type baseSyncerController struct {
 
 type baseIPAllocationController struct {
 	*baseSyncerController
-	pool    *ipam.IPPool
+	pool    *IPPools
 	pfIface pfIface.Interface
 }
 
@@ -160,11 +174,17 @@ type globalEgressIPController struct {
 }
 
 type egressPodWatcher struct {
-	stopCh       chan struct{}
-	namedSetName string
-	namedSet     packetfilter.NamedSet
-	podSelector  *metav1.LabelSelector
-	allocatedIPs []string
+	sync.Mutex
+	stopCh             chan struct{}
+	watcher            watcher.Interface
+	namedSetName       string
+	namedSet           packetfilter.NamedSet
+	podSelector        *metav1.LabelSelector
+	ownNamespace       string
+	namespaceSelector  *metav1.LabelSelector
+	serviceAccountName string
+	namespaces         set.Set[string]
+	allocatedIPs       []string
 }
 
 type clusterGlobalEgressIPController struct {
@@ -207,10 +227,38 @@ type ingressPodController struct {
 	*baseSyncerController
 	publishNotReadyAddresses bool
 	svcName                  string
+	globalCIDR               string
 	namespace                string
 	ingressIPMap             set.Set[string]
 }
 
+type ingressTargetController struct {
+	*baseSyncerController
+	kind          string
+	ingressIPs    dynamic.NamespaceableResourceInterface
+	targets       set.Set[string]
+	newTarget     func(name, namespace string) runtime.Object
+	ingressIPName func(name string) string
+	toIngressIP   func(obj runtime.Object) *submarinerv1.GlobalIngressIP
+	onUpdate      func(name, namespace string)
+}
+
+type ipAuditor struct {
+	*baseController
+	pool     *IPPools
+	pFilter  packetfilter.Interface
+	interval time.Duration
+	wg       sync.WaitGroup
+	// resources maps the kind of each resource allocating global IPs to its client.
+	resources map[string]dynamic.NamespaceableResourceInterface
+	// The issues found by the previous audit. An issue is only fixed if it persists across two audits to avoid racing
+	// with in-flight allocations. The leaked IPs are mapped to the sequence number of their allocation so a re-allocated
+	// IP isn't released.
+	leakedIPs     map[string]uint64
+	unreservedIPs set.Set[string]
+	orphanedRules set.Set[string]
+}
+
 type IngressPodControllers struct {
 	mutex       sync.Mutex
 	controllers map[string]*ingressPodController
@@ -234,6 +282,7 @@ type ServiceExportEndpointsControllers struct {
 type ingressEndpointsController struct {
 	*baseSyncerController
 	svcName    string
+	globalCIDR string
 	namespace  string
 	config     syncer.ResourceSyncerConfig
 	ingressIPs dynamic.NamespaceableResourceInterface
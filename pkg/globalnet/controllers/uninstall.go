@@ -54,6 +54,7 @@ func UninstallDataPath() {
 		constants.SmGlobalnetEgressChainForHeadlessSvcPods,
 		constants.SmGlobalnetEgressChainForHeadlessSvcEPs,
 		constants.SmGlobalnetEgressChainForNamespace,
+		constants.SmGlobalnetEgressChainForNSSelector,
 		constants.SmGlobalnetEgressChainForPods,
 		constants.SmGlobalnetIngressChain,
 		constants.SmGlobalnetMarkChain,
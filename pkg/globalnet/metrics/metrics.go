@@ -24,7 +24,8 @@ import (
 )
 
 const (
-	cidrLabel = "cidr"
+	cidrLabel  = "cidr"
+	issueLabel = "issue"
 )
 
 var (
@@ -73,6 +74,24 @@ var (
 			cidrLabel,
 		},
 	)
+	globalIPAuditIssuesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_global_IP_audit_issues",
+			Help: "Count of global IP allocation issues found by the last audit per issue type",
+		},
+		[]string{
+			issueLabel,
+		},
+	)
+	globalIPAuditFixesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "submariner_global_IP_audit_fixes_total",
+			Help: "Count of global IP allocation issues fixed by the audit per issue type",
+		},
+		[]string{
+			issueLabel,
+		},
+	)
 )
 
 var GlobalnetMetricsReporter ipam.MetricsReporter = GlobalnetMetrics{}
@@ -81,7 +100,7 @@ type GlobalnetMetrics struct{}
 
 func init() {
 	prometheus.MustRegister(globalIPsAvailabilityGauge, globalIPsAllocatedGauge, globalEgressIPsAllocatedGauge,
-		clusterGlobalEgressIPsAllocatedGauge, globalIngressIPsAllocatedGauge)
+		clusterGlobalEgressIPsAllocatedGauge, globalIngressIPsAllocatedGauge, globalIPAuditIssuesGauge, globalIPAuditFixesCounter)
 }
 
 func RecordAllocateGlobalIP(cidr string) {
@@ -123,6 +142,14 @@ func RecordDeallocateGlobalIngressIPs(cidr string, count int) {
 	globalIngressIPsAllocatedGauge.With(prometheus.Labels{cidrLabel: cidr}).Sub(float64(count))
 }
 
+func RecordGlobalIPAuditIssues(issue string, count int) {
+	globalIPAuditIssuesGauge.With(prometheus.Labels{issueLabel: issue}).Set(float64(count))
+}
+
+func RecordGlobalIPAuditFixes(issue string, count int) {
+	globalIPAuditFixesCounter.With(prometheus.Labels{issueLabel: issue}).Add(float64(count))
+}
+
 func (r GlobalnetMetrics) RecordAvailability(cidr string, count int) {
 	globalIPsAvailabilityGauge.With(prometheus.Labels{cidrLabel: cidr}).Set(float64(count))
 }
@@ -0,0 +1,330 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natdiscovery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cable/psk"
+	"github.com/submariner-io/submariner/pkg/endpoint"
+	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
+	"github.com/submariner-io/submariner/pkg/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// The IPsec environment prefix, ie cable.IPSecEnvPrefix which can't be imported here.
+	ipsecEnvPrefix = "ce_ipsec"
+	nonceLength    = 16
+	// The key file in the dedicated NAT discovery secret.
+	authKeyFile = "key"
+	// Used to derive the HMAC keys from the pre-shared keys, so that these aren't used directly.
+	keyDerivationLabel = "submariner NAT discovery v2"
+)
+
+type ipsecPSKSpec struct {
+	PSK              string
+	PSKSecret        string
+	ClusterPSKSecret string
+}
+
+type authenticator struct {
+	defaultKey   []byte
+	clusterKeys  *psk.Source
+	v1Compat     bool
+	maxClockSkew time.Duration
+	mutex        sync.Mutex
+	seenNonces   map[string]time.Time
+}
+
+// newAuthenticator configures the authentication of the NAT discovery messages. The key shared with a remote cluster is
+// read from the dedicated secret if one is configured, and otherwise derived from the cable driver's pre-shared key, using
+// the remote cluster's own key if per-cluster keys are configured. Messages are only authenticated if a key is available.
+func newAuthenticator(spec *types.SubmarinerSpecification) (*authenticator, error) {
+	a := &authenticator{
+		v1Compat:     spec.NATTDiscoveryV1Compat,
+		maxClockSkew: spec.NATTDiscoveryMaxClockSkew,
+		seenNonces:   map[string]time.Time{},
+	}
+
+	if spec.NATTDiscoveryKeySecret != "" {
+		key, err := os.ReadFile(filepath.Join(psk.MountedSecretsDir, spec.NATTDiscoveryKeySecret, authKeyFile))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading the NAT discovery key from secret %q", spec.NATTDiscoveryKeySecret)
+		}
+
+		a.defaultKey = key
+
+		return a, nil
+	}
+
+	ipsecSpec := ipsecPSKSpec{}
+
+	if err := envconfig.Process(ipsecEnvPrefix, &ipsecSpec); err != nil {
+		return nil, errors.Wrapf(err, "error processing environment config for %s", ipsecEnvPrefix)
+	}
+
+	switch {
+	case ipsecSpec.PSKSecret != "":
+		key, err := os.ReadFile(filepath.Join(psk.MountedSecretsDir, ipsecSpec.PSKSecret, "psk"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading secret %s", ipsecSpec.PSKSecret)
+		}
+
+		a.defaultKey = key
+	case ipsecSpec.PSK != "":
+		key, err := base64.StdEncoding.DecodeString(ipsecSpec.PSK)
+		if err != nil {
+			key = []byte(ipsecSpec.PSK)
+		}
+
+		a.defaultKey = key
+	}
+
+	if ipsecSpec.ClusterPSKSecret != "" {
+		a.clusterKeys = psk.NewSourceForSecret(ipsecSpec.ClusterPSKSecret)
+	}
+
+	return a, nil
+}
+
+// enabled returns true if a key is available to authenticate messages.
+func (a *authenticator) enabled() bool {
+	return a != nil && (a.defaultKey != nil || a.clusterKeys != nil)
+}
+
+// publishAuthenticatedVersion advertises support for authenticated messages in the local endpoint, so that remote
+// endpoints authenticate the messages they send us.
+func publishAuthenticatedVersion(localEndpoint *endpoint.Local) error {
+	err := localEndpoint.Update(context.TODO(), func(existing *v1.EndpointSpec) {
+		if existing.BackendConfig == nil {
+			existing.BackendConfig = map[string]string{}
+		}
+
+		existing.BackendConfig[v1.NATTDiscoveryVersionConfig] = strconv.Itoa(natproto.AuthenticatedVersion)
+	})
+
+	return errors.Wrap(err, "error publishing the NAT discovery version in the local endpoint")
+}
+
+// supportsAuthentication returns true if the remote endpoint advertises support for authenticated messages.
+func supportsAuthentication(endpoint *v1.EndpointSpec) bool {
+	version, err := strconv.Atoi(endpoint.BackendConfig[v1.NATTDiscoveryVersionConfig])
+	return err == nil && version >= natproto.AuthenticatedVersion
+}
+
+func (a *authenticator) keyFor(clusterID string) ([]byte, error) {
+	secret := a.defaultKey
+
+	if a.clusterKeys != nil {
+		key, err := a.clusterKeys.Lookup(clusterID)
+		if err != nil {
+			return nil, errors.Wrap(err, "error retrieving the pre-shared key")
+		}
+
+		if key != nil {
+			secret = key
+		}
+	}
+
+	if secret == nil {
+		return nil, errors.Errorf("no key is available for cluster %q", clusterID)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(keyDerivationLabel))
+
+	return mac.Sum(nil), nil
+}
+
+func (a *authenticator) computeHMAC(clusterID string, payload []byte) ([]byte, error) {
+	key, err := a.keyFor(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+
+	return mac.Sum(nil), nil
+}
+
+// authenticate wraps the given message in an authenticated message for the remote cluster.
+func (a *authenticator) authenticate(message *natproto.SubmarinerNATDiscoveryMessage, localClusterID, remoteClusterID string,
+) (*natproto.SubmarinerNATDiscoveryMessage, error) {
+	nonce := make([]byte, nonceLength)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "error generating a nonce")
+	}
+
+	message.Version = natproto.AuthenticatedVersion
+	message.Nonce = nonce
+	message.Timestamp = time.Now().UnixNano()
+
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling the payload")
+	}
+
+	// The payload is authenticated with the key shared with the remote cluster, which uses our cluster ID to select it.
+	mac, err := a.computeHMAC(remoteClusterID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natproto.SubmarinerNATDiscoveryMessage{
+		Version: natproto.AuthenticatedVersion,
+		Message: &natproto.SubmarinerNATDiscoveryMessage_Authenticated{
+			Authenticated: &natproto.SubmarinerNATDiscoveryAuthenticatedMessage{
+				Payload:         payload,
+				Hmac:            mac,
+				SenderClusterId: localClusterID,
+			},
+		},
+	}, nil
+}
+
+// verify checks the authenticated message and returns the message it carries if it's authentic and not a replay.
+func (a *authenticator) verify(authenticated *natproto.SubmarinerNATDiscoveryAuthenticatedMessage,
+) (*natproto.SubmarinerNATDiscoveryMessage, error) {
+	if !a.enabled() {
+		return nil, errors.New("received an authenticated message but no key is configured")
+	}
+
+	senderClusterID := authenticated.GetSenderClusterId()
+
+	expectedMAC, err := a.computeHMAC(senderClusterID, authenticated.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(expectedMAC, authenticated.GetHmac()) {
+		return nil, errors.Errorf("invalid HMAC for message from cluster %q", senderClusterID)
+	}
+
+	message := &natproto.SubmarinerNATDiscoveryMessage{}
+	if err := proto.Unmarshal(authenticated.GetPayload(), message); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the authenticated payload")
+	}
+
+	var sender *natproto.EndpointDetails
+
+	switch {
+	case message.GetRequest() != nil:
+		sender = message.GetRequest().GetSender()
+	case message.GetResponse() != nil:
+		sender = message.GetResponse().GetSender()
+	default:
+		return nil, errors.Errorf("authenticated payload from cluster %q without response or request", senderClusterID)
+	}
+
+	if sender.GetClusterId() != senderClusterID {
+		return nil, errors.Errorf("authenticated payload from cluster %q claims to be from cluster %q", senderClusterID,
+			sender.GetClusterId())
+	}
+
+	return message, a.checkReplay(message)
+}
+
+// checkUnauthenticated returns an error if an unauthenticated message from the given sender must be rejected. These are
+// only accepted if authentication isn't configured, or in compatibility mode from known senders which don't advertise
+// support for authenticated messages. The sender is nil if the message doesn't claim to be from a known remote endpoint.
+func (a *authenticator) checkUnauthenticated(sender *v1.EndpointSpec) error {
+	if !a.enabled() {
+		return nil
+	}
+
+	if !a.v1Compat {
+		return errors.New("unauthenticated messages are not accepted")
+	}
+
+	if sender == nil {
+		return errors.New("unauthenticated messages from unknown endpoints are not accepted")
+	}
+
+	if supportsAuthentication(sender) {
+		return errors.Errorf("received an unauthenticated message from endpoint %q which supports authentication",
+			sender.CableName)
+	}
+
+	return nil
+}
+
+// marshal marshals the message, authenticating it for the remote cluster if requested.
+func (a *authenticator) marshal(message *natproto.SubmarinerNATDiscoveryMessage, authenticate bool,
+	localClusterID, remoteClusterID string,
+) ([]byte, error) {
+	if authenticate {
+		var err error
+
+		message, err = a.authenticate(message, localClusterID, remoteClusterID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error authenticating the message for cluster %q", remoteClusterID)
+		}
+	}
+
+	buf, err := proto.Marshal(message)
+
+	return buf, errors.Wrap(err, "error marshaling the message")
+}
+
+func (a *authenticator) checkReplay(message *natproto.SubmarinerNATDiscoveryMessage) error {
+	now := time.Now()
+
+	timestamp := time.Unix(0, message.GetTimestamp())
+	if timestamp.Before(now.Add(-a.maxClockSkew)) || timestamp.After(now.Add(a.maxClockSkew)) {
+		return errors.Errorf("message timestamp %s is outside of the allowed clock skew of %s", timestamp, a.maxClockSkew)
+	}
+
+	if len(message.GetNonce()) != nonceLength {
+		return errors.Errorf("invalid nonce length %d", len(message.GetNonce()))
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// Nonces only need to be remembered while their message's timestamp is acceptable.
+	for nonce, expiry := range a.seenNonces {
+		if now.After(expiry) {
+			delete(a.seenNonces, nonce)
+		}
+	}
+
+	nonce := hex.EncodeToString(message.GetNonce())
+	if _, seen := a.seenNonces[nonce]; seen {
+		return errors.New("replayed message")
+	}
+
+	a.seenNonces[nonce] = timestamp.Add(a.maxClockSkew)
+
+	return nil
+}
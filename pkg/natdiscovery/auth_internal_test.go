@@ -0,0 +1,183 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natdiscovery
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
+	"google.golang.org/protobuf/proto"
+	k8snet "k8s.io/utils/net"
+)
+
+var _ = When("NAT discovery messages are authenticated", func() {
+	var request []byte
+
+	t := newDiscoveryTestDriver()
+
+	BeforeEach(func() {
+		atomic.StoreInt64(&recheckTime, 0)
+		atomic.StoreInt64(&totalTimeout, time.Hour.Nanoseconds())
+		atomic.StoreInt64(&publicToPrivateFailoverTimeout, time.Hour.Nanoseconds())
+		t.remoteEndpoint.Spec.PublicIPs = []string{}
+
+		t.localND.auth = newTestAuthenticator("secret")
+		t.remoteND.auth = newTestAuthenticator("secret")
+
+		advertiseAuthentication(&t.localEndpoint)
+		advertiseAuthentication(&t.remoteEndpoint)
+	})
+
+	JustBeforeEach(func() {
+		t.remoteND.AddEndpoint(&t.localEndpoint)
+		t.localND.AddEndpoint(&t.remoteEndpoint)
+		t.localND.checkEndpointList()
+
+		request = awaitChan(t.localUDPSent)
+	})
+
+	Context("with the same key on both sides", func() {
+		It("should authenticate the request and complete the discovery", func() {
+			msg := parseProtocolMessage(request)
+			Expect(msg.GetVersion()).To(Equal(int32(natproto.AuthenticatedVersion)))
+			Expect(msg.GetAuthenticated()).NotTo(BeNil())
+			Expect(msg.GetRequest()).To(BeNil())
+
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).To(Succeed())
+
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint: t.remoteEndpoint,
+				UseNAT:   false,
+				UseIP:    t.remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4),
+			})))
+		})
+
+		It("should reject a replayed request", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).To(Succeed())
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+		})
+	})
+
+	Context("with an authenticated request from an unknown cluster", func() {
+		It("should reject the request", func() {
+			t.remoteND.RemoveEndpoint(t.localEndpoint.Spec.CableName)
+
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+			Expect(t.remoteUDPSent).NotTo(Receive())
+		})
+	})
+
+	Context("with a different key on the receiving side", func() {
+		BeforeEach(func() {
+			t.remoteND.auth = newTestAuthenticator("other")
+		})
+
+		It("should reject the request", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+			Expect(t.remoteUDPSent).NotTo(Receive())
+		})
+	})
+
+	Context("with a request timestamp outside of the allowed clock skew", func() {
+		BeforeEach(func() {
+			t.remoteND.auth.maxClockSkew = -time.Second
+		})
+
+		It("should reject the request", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+		})
+	})
+
+	Context("with an unauthenticated request from an endpoint advertising authentication support", func() {
+		BeforeEach(func() {
+			t.localND.auth = newTestAuthenticator("")
+		})
+
+		It("should reject the request", func() {
+			Expect(parseProtocolMessage(request).GetRequest()).NotTo(BeNil())
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+		})
+	})
+
+	Context("with an unauthenticated request from an endpoint which doesn't support authentication", func() {
+		BeforeEach(func() {
+			t.localND.auth = newTestAuthenticator("")
+			delete(t.localEndpoint.Spec.BackendConfig, submarinerv1.NATTDiscoveryVersionConfig)
+		})
+
+		It("should accept the request and respond unauthenticated", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).To(Succeed())
+
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint: t.remoteEndpoint,
+				UseNAT:   false,
+				UseIP:    t.remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4),
+			})))
+		})
+
+		Context("and the sender is unknown", func() {
+			It("should reject the request", func() {
+				t.remoteND.RemoveEndpoint(t.localEndpoint.Spec.CableName)
+
+				Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+				Expect(t.remoteUDPSent).NotTo(Receive())
+			})
+		})
+
+		Context("and compatibility mode disabled", func() {
+			BeforeEach(func() {
+				t.remoteND.auth.v1Compat = false
+			})
+
+			It("should reject the request", func() {
+				Expect(t.remoteND.parseAndHandleMessageFromAddress(request, t.localUDPAddr)).NotTo(Succeed())
+			})
+		})
+	})
+})
+
+func newTestAuthenticator(key string) *authenticator {
+	a := &authenticator{
+		v1Compat:     true,
+		maxClockSkew: time.Minute,
+		seenNonces:   map[string]time.Time{},
+	}
+
+	if key != "" {
+		a.defaultKey = []byte(key)
+	}
+
+	return a
+}
+
+func advertiseAuthentication(endpoint *submarinerv1.Endpoint) {
+	endpoint.Spec.BackendConfig[submarinerv1.NATTDiscoveryVersionConfig] = strconv.Itoa(natproto.AuthenticatedVersion)
+}
+
+func parseProtocolMessage(buf []byte) *natproto.SubmarinerNATDiscoveryMessage {
+	msg := &natproto.SubmarinerNATDiscoveryMessage{}
+	Expect(proto.Unmarshal(buf, msg)).To(Succeed())
+
+	return msg
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
+	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
 	"google.golang.org/protobuf/proto"
 )
@@ -55,12 +56,13 @@ func (nd *natDiscovery) runListener(stopCh <-chan struct{}) error {
 }
 
 func createServerConnection(port int32) (*net.UDPConn, error) {
-	serverAddress, err := net.ResolveUDPAddr("udp4", ":"+strconv.Itoa(int(port)))
+	// Listen on both IPv4 and IPv6 (if available) so discovery works with IPv6 and dual-stack endpoints.
+	serverAddress, err := net.ResolveUDPAddr("udp", ":"+strconv.Itoa(int(port)))
 	if err != nil {
 		return nil, errors.Wrap(err, "Error resolving UDP address")
 	}
 
-	serverConnection, err := net.ListenUDP("udp4", serverAddress)
+	serverConnection, err := net.ListenUDP("udp", serverAddress)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error listening on udp port %d", port)
 	}
@@ -85,16 +87,72 @@ func (nd *natDiscovery) listenerLoop(serverConnection *net.UDPConn) {
 }
 
 func (nd *natDiscovery) parseAndHandleMessageFromAddress(buf []byte, addr *net.UDPAddr) error {
-	msg := natproto.SubmarinerNATDiscoveryMessage{}
-	if err := proto.Unmarshal(buf, &msg); err != nil {
+	msg := &natproto.SubmarinerNATDiscoveryMessage{}
+	if err := proto.Unmarshal(buf, msg); err != nil {
 		return errors.Wrapf(err, "Error unmarshaling message received on UDP port %d", natproto.DefaultPort)
 	}
 
+	authenticated := false
+
+	if authMsg := msg.GetAuthenticated(); authMsg != nil {
+		// The sender's cluster ID isn't authenticated yet so it's checked against the known remote endpoints before it's
+		// used to look up the key.
+		if !nd.isRemoteClusterKnown(authMsg.GetSenderClusterId()) {
+			return errors.Errorf("Rejecting authenticated message received from %s for unknown cluster %q", addr,
+				authMsg.GetSenderClusterId())
+		}
+
+		var err error
+
+		msg, err = nd.auth.verify(authMsg)
+		if err != nil {
+			return errors.Wrapf(err, "Error authenticating message received from %s", addr)
+		}
+
+		authenticated = true
+	} else if err := nd.auth.checkUnauthenticated(nd.senderEndpointSpec(msg)); err != nil {
+		return errors.Wrapf(err, "Rejecting message received from %s", addr)
+	}
+
 	if request := msg.GetRequest(); request != nil {
-		return nd.handleRequestFromAddress(request, addr)
+		return nd.handleRequestFromAddress(request, addr, authenticated)
 	} else if response := msg.GetResponse(); response != nil {
 		return nd.handleResponseFromAddress(response, addr)
 	}
 
 	return errors.Errorf("Message without response or request received from %#v", addr)
 }
+
+// senderEndpointSpec returns the spec of the known remote endpoint which the message claims to be from, if any.
+func (nd *natDiscovery) senderEndpointSpec(msg *natproto.SubmarinerNATDiscoveryMessage) *v1.EndpointSpec {
+	var sender *natproto.EndpointDetails
+
+	if request := msg.GetRequest(); request != nil {
+		sender = request.GetSender()
+	} else if response := msg.GetResponse(); response != nil {
+		sender = response.GetSender()
+	}
+
+	nd.Lock()
+	defer nd.Unlock()
+
+	if remoteNAT, ok := nd.remoteEndpoints[sender.GetEndpointId()]; ok && remoteNAT.endpoint.Spec.ClusterID == sender.GetClusterId() {
+		return &remoteNAT.endpoint.Spec
+	}
+
+	return nil
+}
+
+// isRemoteClusterKnown returns true if any known remote endpoint belongs to the given cluster.
+func (nd *natDiscovery) isRemoteClusterKnown(clusterID string) bool {
+	nd.Lock()
+	defer nd.Unlock()
+
+	for _, remoteNAT := range nd.remoteEndpoints {
+		if remoteNAT.endpoint.Spec.ClusterID == clusterID {
+			return true
+		}
+	}
+
+	return false
+}
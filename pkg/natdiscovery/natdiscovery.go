@@ -22,6 +22,7 @@ import (
 	"math/rand/v2"
 	"net"
 	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -29,7 +30,11 @@ import (
 	"github.com/submariner-io/admiral/pkg/log"
 	v1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/endpoint"
+	"github.com/submariner-io/submariner/pkg/event/recorder"
+	"github.com/submariner-io/submariner/pkg/types"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	k8snet "k8s.io/utils/net"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -38,6 +43,7 @@ type Interface interface {
 	AddEndpoint(endpoint *v1.Endpoint)
 	RemoveEndpoint(endpointName string)
 	GetReadyChannel() chan *NATEndpointInfo
+	SetEventRecorder(recorder *recorder.Recorder)
 }
 
 type (
@@ -54,20 +60,33 @@ type natDiscovery struct {
 	findSrcIP       findSrcIPFunction
 	serverPort      int32
 	readyChannel    chan *NATEndpointInfo
+	auth            *authenticator
+	recorder        *recorder.Recorder
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("NAT")}
 
-func New(localEndpoint *endpoint.Local) (Interface, error) {
-	return newNATDiscovery(localEndpoint)
+func New(localEndpoint *endpoint.Local, spec *types.SubmarinerSpecification) (Interface, error) {
+	return newNATDiscovery(localEndpoint, spec)
 }
 
-func newNATDiscovery(localEndpoint *endpoint.Local) (*natDiscovery, error) {
+func newNATDiscovery(localEndpoint *endpoint.Local, spec *types.SubmarinerSpecification) (*natDiscovery, error) {
 	ndPort, err := localEndpoint.Spec().GetBackendPort(v1.NATTDiscoveryPortConfig, 0)
 	if err != nil {
 		return nil, errors.Wrap(err, "error parsing nat discovery port")
 	}
 
+	auth, err := newAuthenticator(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.enabled() {
+		if err := publishAuthenticatedVersion(localEndpoint); err != nil {
+			return nil, err
+		}
+	}
+
 	//nolint:gosec // Use of math/rand over crypto/rand is fine here as the request counter is not security-sensitive.
 	return &natDiscovery{
 		localEndpoint:   localEndpoint,
@@ -76,6 +95,7 @@ func newNATDiscovery(localEndpoint *endpoint.Local) (*natDiscovery, error) {
 		findSrcIP:       endpoint.GetLocalIPForDestination,
 		requestCounter:  rand.Uint64(),
 		readyChannel:    make(chan *NATEndpointInfo, 100),
+		auth:            auth,
 	}, nil
 }
 
@@ -98,6 +118,13 @@ func (nd *natDiscovery) GetReadyChannel() chan *NATEndpointInfo {
 	return nd.readyChannel
 }
 
+func (nd *natDiscovery) SetEventRecorder(recorder *recorder.Recorder) {
+	nd.Lock()
+	defer nd.Unlock()
+
+	nd.recorder = recorder
+}
+
 func (nd *natDiscovery) Run(stopCh <-chan struct{}) error {
 	logger.V(log.DEBUG).Infof("NAT discovery server starting on port %d", nd.serverPort)
 
@@ -130,7 +157,7 @@ func (nd *natDiscovery) AddEndpoint(endPoint *v1.Endpoint) {
 		delete(nd.remoteEndpoints, endPoint.Spec.CableName)
 	}
 
-	remoteNAT := newRemoteEndpointNAT(endPoint)
+	remoteNAT := newRemoteEndpointNAT(endPoint, selectIPFamily(nd.localEndpoint.Spec(), &endPoint.Spec))
 
 	// support nat discovery disabled or a remote cluster endpoint which still hasn't implemented this protocol
 	if _, err := extractNATDiscoveryPort(&endPoint.Spec); err != nil || nd.serverPort == 0 {
@@ -139,6 +166,7 @@ func (nd *natDiscovery) AddEndpoint(endPoint *v1.Endpoint) {
 		}
 
 		remoteNAT.useLegacyNATSettings()
+		remoteNAT.discoveryDisabled = true
 		nd.readyChannel <- remoteNAT.toNATEndpointInfo()
 	} else {
 		logger.Infof("Starting NAT discovery for endpoint %q", endPoint.Spec.CableName)
@@ -147,6 +175,28 @@ func (nd *natDiscovery) AddEndpoint(endPoint *v1.Endpoint) {
 	nd.remoteEndpoints[endPoint.Spec.CableName] = remoteNAT
 }
 
+// selectIPFamily returns the IP family to use to reach the remote endpoint. The local endpoint's families are tried in
+// order of preference, ie the order of its private IPs, and the first one which is also available on the remote
+// endpoint is selected. IPv4 is used if there's no common family.
+func selectIPFamily(local, remote *v1.EndpointSpec) k8snet.IPFamily {
+	families := []k8snet.IPFamily{}
+
+	for _, ip := range append(slices.Clone(local.PrivateIPs), local.PrivateIP) {
+		family := k8snet.IPFamilyOfString(ip)
+		if family != k8snet.IPFamilyUnknown && !slices.Contains(families, family) {
+			families = append(families, family)
+		}
+	}
+
+	for _, family := range families {
+		if remote.GetPrivateIP(family) != "" || remote.GetPublicIP(family) != "" {
+			return family
+		}
+	}
+
+	return k8snet.IPv4
+}
+
 func (nd *natDiscovery) RemoveEndpoint(endpointName string) {
 	nd.Lock()
 	defer nd.Unlock()
@@ -161,10 +211,23 @@ func (nd *natDiscovery) checkEndpointList() {
 		name := endpointNAT.endpoint.Spec.CableName
 		logger.V(log.TRACE).Infof("NAT processing remote endpoint %q", name)
 
-		if endpointNAT.shouldCheck() {
+		if endpointNAT.shouldRevalidate() {
+			if endpointNAT.failoverIfStale() {
+				nd.readyChannel <- endpointNAT.toNATEndpointInfo()
+			}
+
+			if err := nd.sendCheckRequest(endpointNAT); err != nil {
+				logger.Errorf(err, "Error sending re-validation request to endpoint %q", name)
+			}
+
+			endpointNAT.revalidationSent()
+		} else if endpointNAT.shouldCheck() {
 			if endpointNAT.hasTimedOut() {
 				logger.Warningf("NAT discovery for endpoint %q has timed out", name)
+				nd.recorder.OnEndpoint(name, corev1.EventTypeWarning, recorder.NATDiscoveryTimedOut,
+					"NAT discovery for endpoint %q has timed out - using the legacy NAT settings", name)
 				endpointNAT.useLegacyNATSettings()
+				endpointNAT.discoveryFailed = true
 				nd.readyChannel <- endpointNAT.toNATEndpointInfo()
 			} else if err := nd.sendCheckRequest(endpointNAT); err != nil {
 				logger.Errorf(err, "Error sending check request to endpoint %q", name)
@@ -67,6 +67,28 @@ var _ = When("a remote Endpoint is added", func() {
 		t.testRemoteEndpointAdded(testRemotePublicIP, natExpected)
 	})
 
+	Context("with only IPv6 private IPs set", func() {
+		BeforeEach(func() {
+			t.localEndpoint.Spec.PrivateIPs = []string{testLocalPrivateIPv6}
+			t.remoteEndpoint.Spec.PrivateIPs = []string{testRemotePrivateIPv6}
+
+			Expect(t.localND.localEndpoint.Update(context.Background(), func(existing *submarinerv1.EndpointSpec) {
+				existing.PrivateIPs = t.localEndpoint.Spec.PrivateIPs
+			})).To(Succeed())
+
+			Expect(t.remoteND.localEndpoint.Update(context.Background(), func(existing *submarinerv1.EndpointSpec) {
+				existing.PrivateIPs = t.remoteEndpoint.Spec.PrivateIPs
+			})).To(Succeed())
+
+			t.localUDPAddr.IP = net.ParseIP(testLocalPrivateIPv6)
+			t.remoteUDPAddr.IP = net.ParseIP(testRemotePrivateIPv6)
+			t.localND.findSrcIP = func(_ string) string { return testLocalPrivateIPv6 }
+			t.remoteND.findSrcIP = func(_ string) string { return testRemotePrivateIPv6 }
+		})
+
+		t.testRemoteEndpointAdded(testRemotePrivateIPv6, natNotExpected)
+	})
+
 	Context("with both the public IP and private IP set", func() {
 		var privateIPReq []byte
 		var publicIPReq []byte
@@ -280,7 +302,7 @@ var _ = When("a remote Endpoint is added", func() {
 			atomic.StoreInt64(&totalTimeout, (100 * time.Millisecond).Nanoseconds())
 		})
 
-		It("should eventually time out and notify with the legacy NATEndpointInfo settings", func() {
+		It("should eventually time out and notify with the legacy NATEndpointInfo settings and the discovery failure", func() {
 			// Drop the request sent out
 			Expect(t.localUDPSent).Should(Receive())
 
@@ -290,11 +312,114 @@ var _ = When("a remote Endpoint is added", func() {
 			t.localND.checkEndpointList()
 			Expect(t.localUDPSent).ToNot(Receive())
 
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint:        t.remoteEndpoint,
+				UseNAT:          true,
+				UseIP:           t.remoteEndpoint.Spec.GetPublicIP(k8snet.IPv4),
+				DiscoveryFailed: true,
+			})))
+		})
+	})
+})
+
+var _ = When("an established remote Endpoint is re-validated", func() {
+	var privateIPReq []byte
+	var publicIPReq []byte
+
+	t := newDiscoveryTestDriver()
+
+	BeforeEach(func() {
+		atomic.StoreInt64(&recheckTime, 0)
+		atomic.StoreInt64(&totalTimeout, time.Hour.Nanoseconds())
+		atomic.StoreInt64(&publicToPrivateFailoverTimeout, 0)
+		atomic.StoreInt64(&revalidationInterval, time.Hour.Nanoseconds())
+		t.remoteEndpoint.Spec.PublicIPs = []string{testRemotePublicIP}
+		t.remoteND.AddEndpoint(&t.localEndpoint)
+	})
+
+	JustBeforeEach(func() {
+		t.localND.AddEndpoint(&t.remoteEndpoint)
+		t.localND.checkEndpointList()
+
+		privateIPReq = awaitChan(t.localUDPSent)
+		publicIPReq = awaitChan(t.localUDPSent)
+	})
+
+	revalidate := func() {
+		atomic.StoreInt64(&revalidationInterval, 1)
+		t.localND.checkEndpointList()
+
+		privateIPReq = awaitChan(t.localUDPSent)
+		publicIPReq = awaitChan(t.localUDPSent)
+	}
+
+	Context("and the private IP becomes reachable after the public IP was selected", func() {
+		It("should notify with the private IP NATEndpointInfo settings", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(publicIPReq, t.localUDPAddr)).To(Succeed())
+
 			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
 				Endpoint: t.remoteEndpoint,
 				UseNAT:   true,
 				UseIP:    t.remoteEndpoint.Spec.GetPublicIP(k8snet.IPv4),
 			})))
+
+			revalidate()
+
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(privateIPReq, t.localUDPAddr)).To(Succeed())
+
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint: t.remoteEndpoint,
+				UseNAT:   false,
+				UseIP:    t.remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4),
+			})))
+		})
+	})
+
+	Context("and the selected private IP stops responding", func() {
+		It("should notify with the public IP NATEndpointInfo settings", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(privateIPReq, t.localUDPAddr)).To(Succeed())
+
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint: t.remoteEndpoint,
+				UseNAT:   false,
+				UseIP:    t.remoteEndpoint.Spec.GetPrivateIP(k8snet.IPv4),
+			})))
+
+			revalidate()
+
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(publicIPReq, t.localUDPAddr)).To(Succeed())
+
+			Eventually(t.readyChannel, 5).Should(Receive(Equal(&NATEndpointInfo{
+				Endpoint: t.remoteEndpoint,
+				UseNAT:   true,
+				UseIP:    t.remoteEndpoint.Spec.GetPublicIP(k8snet.IPv4),
+			})))
+		})
+	})
+
+	Context("and the selected path still responds", func() {
+		It("should not notify", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(privateIPReq, t.localUDPAddr)).To(Succeed())
+			Eventually(t.readyChannel, 5).Should(Receive())
+
+			revalidate()
+			atomic.StoreInt64(&revalidationInterval, time.Hour.Nanoseconds())
+
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(privateIPReq, t.localUDPAddr)).To(Succeed())
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(publicIPReq, t.localUDPAddr)).To(Succeed())
+
+			Consistently(t.readyChannel).ShouldNot(Receive())
+		})
+	})
+
+	Context("and re-validation is disabled", func() {
+		It("should not send requests", func() {
+			Expect(t.remoteND.parseAndHandleMessageFromAddress(privateIPReq, t.localUDPAddr)).To(Succeed())
+			Eventually(t.readyChannel, 5).Should(Receive())
+
+			atomic.StoreInt64(&revalidationInterval, 0)
+			t.localND.checkEndpointList()
+			Expect(t.localUDPSent).ToNot(Receive())
 		})
 	})
 })
@@ -312,6 +437,7 @@ type discoveryTestDriver struct {
 	oldRecheckTime                    int64
 	oldTotalTimeout                   int64
 	oldPublicToPrivateFailoverTimeout int64
+	oldRevalidationInterval           int64
 }
 
 func newDiscoveryTestDriver() *discoveryTestDriver {
@@ -321,6 +447,7 @@ func newDiscoveryTestDriver() *discoveryTestDriver {
 		t.oldRecheckTime = atomic.LoadInt64(&recheckTime)
 		t.oldTotalTimeout = atomic.LoadInt64(&totalTimeout)
 		t.oldPublicToPrivateFailoverTimeout = atomic.LoadInt64(&publicToPrivateFailoverTimeout)
+		t.oldRevalidationInterval = atomic.LoadInt64(&revalidationInterval)
 
 		t.localUDPAddr = &net.UDPAddr{
 			IP:   net.ParseIP(testLocalPrivateIP),
@@ -351,6 +478,7 @@ func newDiscoveryTestDriver() *discoveryTestDriver {
 		atomic.StoreInt64(&recheckTime, t.oldRecheckTime)
 		atomic.StoreInt64(&totalTimeout, t.oldTotalTimeout)
 		atomic.StoreInt64(&publicToPrivateFailoverTimeout, t.oldPublicToPrivateFailoverTimeout)
+		atomic.StoreInt64(&revalidationInterval, t.oldRevalidationInterval)
 	})
 
 	return t
@@ -20,5 +20,8 @@ package proto
 
 const (
 	DefaultPort = 4490
-	Version     = 1
+	// Version is the version of the unauthenticated messages.
+	Version = 1
+	// AuthenticatedVersion is the version of the messages authenticated with an HMAC.
+	AuthenticatedVersion = 2
 )
@@ -41,8 +41,14 @@ var (
 	totalTimeout                   = (60 * time.Second).Nanoseconds()
 	totalTimeoutLoadBalancer       = (6 * time.Second).Nanoseconds()
 	publicToPrivateFailoverTimeout = time.Second.Nanoseconds()
+	// Once a path is selected, both paths are re-validated at this interval so that path changes are detected. A
+	// value of 0 disables the re-validation.
+	revalidationInterval = (30 * time.Second).Nanoseconds()
 )
 
+// The number of consecutive re-validation probes a path can miss before it's considered down.
+const revalidationMaxMissed = 3
+
 type remoteEndpointNAT struct {
 	endpoint               v1.Endpoint
 	state                  endpointState
@@ -53,27 +59,58 @@ type remoteEndpointNAT struct {
 	useIP                  string
 	lastPublicIPRequestID  uint64
 	lastPrivateIPRequestID uint64
+	discoveryFailed        bool
 	useNAT                 bool
 	usingLoadBalancer      bool
+	family                 k8snet.IPFamily
+	lastPublicIPResponse   time.Time
+	lastPrivateIPResponse  time.Time
+	discoveryDisabled      bool
+	revalidating           bool
 }
 
 type NATEndpointInfo struct {
 	Endpoint v1.Endpoint
 	UseNAT   bool
 	UseIP    string
+	// DiscoveryFailed is true if the remote endpoint didn't respond on any IP, in which case the legacy settings are used
+	// but the remote endpoint is likely unreachable.
+	DiscoveryFailed bool
+	// RelayedSubnets are the subnets of other remote clusters whose traffic the local gateway relays to the remote
+	// endpoint. These are set by the cable engine when the local gateway is a relay.
+	RelayedSubnets []string
+}
+
+// UseFamily returns the IP family of the IP to use for connecting to the remote endpoint.
+func (n *NATEndpointInfo) UseFamily() k8snet.IPFamily {
+	if family := k8snet.IPFamilyOfString(n.UseIP); family != k8snet.IPFamilyUnknown {
+		return family
+	}
+
+	return k8snet.IPv4
 }
 
 func (rn *remoteEndpointNAT) toNATEndpointInfo() *NATEndpointInfo {
 	return &NATEndpointInfo{
-		Endpoint: rn.endpoint,
-		UseNAT:   rn.useNAT,
-		UseIP:    rn.useIP,
+		Endpoint:        rn.endpoint,
+		UseNAT:          rn.useNAT,
+		UseIP:           rn.useIP,
+		DiscoveryFailed: rn.discoveryFailed,
 	}
 }
 
-func newRemoteEndpointNAT(endpoint *v1.Endpoint) *remoteEndpointNAT {
+// responseReceived records that the remote endpoint responded and returns true if its discovery had previously failed.
+func (rn *remoteEndpointNAT) responseReceived() bool {
+	failed := rn.discoveryFailed
+	rn.discoveryFailed = false
+
+	return failed
+}
+
+func newRemoteEndpointNAT(endpoint *v1.Endpoint, family k8snet.IPFamily) *remoteEndpointNAT {
 	rnat := &remoteEndpointNAT{
 		endpoint:       *endpoint,
+		family:         family,
 		state:          testingPrivateAndPublicIPs,
 		started:        time.Now(),
 		lastTransition: time.Now(),
@@ -96,6 +133,7 @@ func newRemoteEndpointNAT(endpoint *v1.Endpoint) *remoteEndpointNAT {
 func (rn *remoteEndpointNAT) transitionToState(newState endpointState) {
 	rn.lastTransition = time.Now()
 	rn.state = newState
+	rn.revalidating = false
 }
 
 func (rn *remoteEndpointNAT) sinceLastTransition() time.Duration {
@@ -110,21 +148,21 @@ func (rn *remoteEndpointNAT) useLegacyNATSettings() {
 	switch {
 	case rn.usingLoadBalancer:
 		rn.useNAT = true
-		rn.useIP = rn.endpoint.Spec.GetPublicIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPublicIP(rn.family)
 		rn.transitionToState(selectedPublicIP)
 		logger.V(log.DEBUG).Infof("using NAT for the load balancer backed endpoint %q, using public IP %q", rn.endpoint.Spec.CableName,
 			rn.useIP)
 
 	case rn.endpoint.Spec.NATEnabled:
 		rn.useNAT = true
-		rn.useIP = rn.endpoint.Spec.GetPublicIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPublicIP(rn.family)
 		rn.transitionToState(selectedPublicIP)
 		logger.V(log.DEBUG).Infof("using NAT legacy settings for endpoint %q, using public IP %q", rn.endpoint.Spec.CableName,
 			rn.useIP)
 
 	default:
 		rn.useNAT = false
-		rn.useIP = rn.endpoint.Spec.GetPrivateIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPrivateIP(rn.family)
 		rn.transitionToState(selectedPrivateIP)
 		logger.V(log.DEBUG).Infof("using NAT legacy settings for endpoint %q, using private IP %q", rn.endpoint.Spec.CableName,
 			rn.useIP)
@@ -148,6 +186,58 @@ func (rn *remoteEndpointNAT) shouldCheck() bool {
 	return false
 }
 
+// shouldRevalidate returns true if the paths of an endpoint whose discovery is complete are due to be probed again.
+func (rn *remoteEndpointNAT) shouldRevalidate() bool {
+	interval := toDuration(&revalidationInterval)
+
+	return rn.isDiscoveryComplete() && !rn.discoveryDisabled && interval > 0 && time.Since(rn.lastCheck) > interval
+}
+
+// isRevalidating returns true if the last requests were sent to re-validate the selected path.
+func (rn *remoteEndpointNAT) isRevalidating() bool {
+	return rn.isDiscoveryComplete() && rn.revalidating
+}
+
+func (rn *remoteEndpointNAT) revalidationSent() {
+	rn.revalidating = true
+	rn.lastCheck = time.Now()
+}
+
+func isPathStale(lastResponse time.Time) bool {
+	return time.Since(lastResponse) > toDuration(&revalidationInterval)*revalidationMaxMissed
+}
+
+// failoverIfStale switches to the other path if the selected one stopped responding to the re-validation probes while
+// the other one still responds. It returns true if the selected path changed.
+func (rn *remoteEndpointNAT) failoverIfStale() bool {
+	switch rn.state {
+	case selectedPublicIP:
+		if !isPathStale(rn.lastPublicIPResponse) || isPathStale(rn.lastPrivateIPResponse) {
+			return false
+		}
+
+		// As in the legacy settings, NAT is assumed on the public IP but not on the private IP.
+		rn.useIP = rn.endpoint.Spec.GetPrivateIP(rn.family)
+		rn.useNAT = false
+		rn.transitionToState(selectedPrivateIP)
+	case selectedPrivateIP:
+		if !isPathStale(rn.lastPrivateIPResponse) || isPathStale(rn.lastPublicIPResponse) {
+			return false
+		}
+
+		rn.useIP = rn.endpoint.Spec.GetPublicIP(rn.family)
+		rn.useNAT = true
+		rn.transitionToState(selectedPublicIP)
+	case testingPrivateAndPublicIPs, waitingForResponse:
+		return false
+	}
+
+	logger.Infof("The selected path to endpoint %q stopped responding - failing over to IP %q", rn.endpoint.Spec.CableName,
+		rn.useIP)
+
+	return true
+}
+
 func (rn *remoteEndpointNAT) checkSent() {
 	if rn.state == testingPrivateAndPublicIPs {
 		rn.state = waitingForResponse
@@ -157,18 +247,31 @@ func (rn *remoteEndpointNAT) checkSent() {
 }
 
 func (rn *remoteEndpointNAT) transitionToPublicIP(remoteEndpointID string, useNAT bool) bool {
+	rn.lastPublicIPResponse = time.Now()
+
 	switch rn.state {
 	case waitingForResponse:
-		rn.useIP = rn.endpoint.Spec.GetPublicIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPublicIP(rn.family)
 		rn.useNAT = useNAT
 		rn.transitionToState(selectedPublicIP)
 		logger.V(log.DEBUG).Infof("selected public IP %q for endpoint %q", rn.useIP, rn.endpoint.Spec.CableName)
 
 		return true
 	case selectedPrivateIP:
-		return false
-	case testingPrivateAndPublicIPs:
+		// The private IP is preferred, unless it stopped responding to the re-validation probes.
+		if !rn.isRevalidating() || !isPathStale(rn.lastPrivateIPResponse) {
+			return false
+		}
+
+		rn.useIP = rn.endpoint.Spec.GetPublicIP(rn.family)
+		rn.useNAT = useNAT
+		rn.transitionToState(selectedPublicIP)
+		logger.Infof("Private IP of endpoint %q stopped responding - switching to public IP %q", rn.endpoint.Spec.CableName, rn.useIP)
+
+		return true
 	case selectedPublicIP:
+		return rn.updateNAT(useNAT)
+	case testingPrivateAndPublicIPs:
 	}
 
 	logger.Errorf(nil, "Received unexpected transition from %v to public IP for endpoint %q", rn.state, remoteEndpointID)
@@ -177,9 +280,11 @@ func (rn *remoteEndpointNAT) transitionToPublicIP(remoteEndpointID string, useNA
 }
 
 func (rn *remoteEndpointNAT) transitionToPrivateIP(remoteEndpointID string, useNAT bool) bool {
+	rn.lastPrivateIPResponse = time.Now()
+
 	switch rn.state {
 	case waitingForResponse:
-		rn.useIP = rn.endpoint.Spec.GetPrivateIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPrivateIP(rn.family)
 		rn.useNAT = useNAT
 		rn.transitionToState(selectedPrivateIP)
 		logger.V(log.DEBUG).Infof("selected private IP %q for endpoint %q", rn.useIP, rn.endpoint.Spec.CableName)
@@ -187,21 +292,23 @@ func (rn *remoteEndpointNAT) transitionToPrivateIP(remoteEndpointID string, useN
 		return true
 	case selectedPublicIP:
 		// If a PublicIP was selected, we still allow some time for the privateIP response to arrive, and we always
-		// prefer PrivateIP with no NAT connection, as it will be more likely to work, and more efficient
-		if rn.sinceLastTransition() > toDuration(&publicToPrivateFailoverTimeout) {
+		// prefer PrivateIP with no NAT connection, as it will be more likely to work, and more efficient. A private IP
+		// which becomes reachable later is detected by the re-validation.
+		if !rn.isRevalidating() && rn.sinceLastTransition() > toDuration(&publicToPrivateFailoverTimeout) {
 			logger.V(log.DEBUG).Infof("Response on private IP received too late after response on public IP for endpoint %q",
 				remoteEndpointID)
 			return false
 		}
 
-		rn.useIP = rn.endpoint.Spec.GetPrivateIP(k8snet.IPv4)
+		rn.useIP = rn.endpoint.Spec.GetPrivateIP(rn.family)
 		rn.useNAT = useNAT
 		rn.transitionToState(selectedPrivateIP)
 		logger.V(log.DEBUG).Infof("updated to private IP %q for endpoint %q", rn.useIP, rn.endpoint.Spec.CableName)
 
 		return true
-	case testingPrivateAndPublicIPs:
 	case selectedPrivateIP:
+		return rn.updateNAT(useNAT)
+	case testingPrivateAndPublicIPs:
 	}
 
 	logger.Errorf(nil, "Received unexpected transition from %v to private IP for endpoint %q", rn.state, remoteEndpointID)
@@ -209,6 +316,20 @@ func (rn *remoteEndpointNAT) transitionToPrivateIP(remoteEndpointID string, useN
 	return false
 }
 
+// updateNAT updates the NAT setting of the selected path from a re-validation response and returns true if it changed.
+func (rn *remoteEndpointNAT) updateNAT(useNAT bool) bool {
+	if !rn.isRevalidating() || rn.useNAT == useNAT {
+		return false
+	}
+
+	logger.Infof("NAT on the path to IP %q of endpoint %q changed from %v to %v", rn.useIP, rn.endpoint.Spec.CableName,
+		rn.useNAT, useNAT)
+
+	rn.useNAT = useNAT
+
+	return true
+}
+
 func toDuration(v *int64) time.Duration {
 	return time.Duration(atomic.LoadInt64(v))
 }
@@ -33,7 +33,7 @@ var _ = Describe("remoteEndpointNAT", func() {
 
 	BeforeEach(func() {
 		remoteEndpoint = createTestRemoteEndpoint()
-		rnat = newRemoteEndpointNAT(&remoteEndpoint)
+		rnat = newRemoteEndpointNAT(&remoteEndpoint, k8snet.IPv4)
 	})
 
 	When("first created", func() {
@@ -62,7 +62,7 @@ var _ = Describe("remoteEndpointNAT", func() {
 		When("targeting a load balancer", func() {
 			It("should report as timed out earlier", func() {
 				remoteEndpoint.Spec.BackendConfig[submarinerv1.UsingLoadBalancer] = "true"
-				rnat = newRemoteEndpointNAT(&remoteEndpoint)
+				rnat = newRemoteEndpointNAT(&remoteEndpoint, k8snet.IPv4)
 				rnat.started = time.Now().Add(-toDuration(&totalTimeoutLoadBalancer))
 				Expect(rnat.hasTimedOut()).To(BeTrue())
 			})
@@ -89,7 +89,7 @@ var _ = Describe("remoteEndpointNAT", func() {
 		Context("and targeting a load balancer", func() {
 			It("should select the public IP and NAT", func() {
 				remoteEndpoint.Spec.BackendConfig[submarinerv1.UsingLoadBalancer] = "true"
-				rnat = newRemoteEndpointNAT(&remoteEndpoint)
+				rnat = newRemoteEndpointNAT(&remoteEndpoint, k8snet.IPv4)
 				rnat.endpoint.Spec.NATEnabled = false
 				rnat.useLegacyNATSettings()
 				Expect(rnat.state).To(Equal(selectedPublicIP))
@@ -24,11 +24,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/submariner/pkg/natdiscovery/proto"
-	proto2 "google.golang.org/protobuf/proto"
 	k8snet "k8s.io/utils/net"
 )
 
-func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscoveryRequest, addr *net.UDPAddr) error {
+// handleRequestFromAddress handles the request and sends the response, authenticated if the request was.
+func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscoveryRequest, addr *net.UDPAddr,
+	authenticated bool,
+) error {
 	localEndpointSpec := nd.localEndpoint.Spec()
 
 	response := proto.SubmarinerNATDiscoveryResponse{
@@ -49,7 +51,7 @@ func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscove
 
 		response.Response = proto.ResponseType_MALFORMED
 
-		return nd.sendResponseToAddress(&response, addr)
+		return nd.sendResponseToAddress(&response, addr, authenticated)
 	}
 
 	logger.V(log.DEBUG).Infof("Received request from %s:%d - REQUEST_NUMBER: 0x%x, SENDER: %q, RECEIVER: %q",
@@ -61,7 +63,7 @@ func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscove
 
 		response.Response = proto.ResponseType_UNKNOWN_DST_CLUSTER
 
-		return nd.sendResponseToAddress(&response, addr)
+		return nd.sendResponseToAddress(&response, addr, authenticated)
 	}
 
 	if req.GetReceiver().GetEndpointId() != localEndpointSpec.CableName {
@@ -71,7 +73,7 @@ func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscove
 
 		response.Response = proto.ResponseType_UNKNOWN_DST_ENDPOINT
 
-		return nd.sendResponseToAddress(&response, addr)
+		return nd.sendResponseToAddress(&response, addr, authenticated)
 	}
 
 	if req.GetUsingSrc().GetIP() != "" && req.GetUsingSrc().GetIP() != addr.IP.String() {
@@ -95,7 +97,7 @@ func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscove
 	// Detect DST NAT with a naive implementation that assumes that we always receive on the PrivateIP,
 	// if we will listen at some point on multiple addresses we will need to implement the
 	// unix.IP_RECVORIGDSTADDR on the UDP socket, and the go recvmsg implementation instead of readfrom
-	if req.GetUsingDst().GetIP() != localEndpointSpec.GetPrivateIP(k8snet.IPv4) {
+	if req.GetUsingDst().GetIP() != localEndpointSpec.GetPrivateIP(k8snet.IPFamilyOfString(req.GetUsingDst().GetIP())) {
 		response.DstIpNatDetected = true
 	}
 
@@ -105,14 +107,16 @@ func (nd *natDiscovery) handleRequestFromAddress(req *proto.SubmarinerNATDiscove
 		response.Response = proto.ResponseType_OK
 	}
 
-	return nd.sendResponseToAddress(&response, addr)
+	return nd.sendResponseToAddress(&response, addr, authenticated)
 }
 
-func (nd *natDiscovery) sendResponseToAddress(response *proto.SubmarinerNATDiscoveryResponse, addr *net.UDPAddr) error {
+func (nd *natDiscovery) sendResponseToAddress(response *proto.SubmarinerNATDiscoveryResponse, addr *net.UDPAddr,
+	authenticate bool,
+) error {
 	msgResponse := proto.SubmarinerNATDiscoveryMessage_Response{Response: response}
-	message := proto.SubmarinerNATDiscoveryMessage{Message: &msgResponse}
+	message := &proto.SubmarinerNATDiscoveryMessage{Message: &msgResponse}
 
-	buf, err := proto2.Marshal(&message)
+	buf, err := nd.auth.marshal(message, authenticate, response.GetSender().GetClusterId(), response.GetReceiver().GetClusterId())
 	if err != nil {
 		return errors.Wrapf(err, "error marshaling response %#v", response)
 	}
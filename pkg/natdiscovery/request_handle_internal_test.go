@@ -26,6 +26,7 @@ import (
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
 	"google.golang.org/protobuf/proto"
+	k8snet "k8s.io/utils/net"
 )
 
 var _ = Describe("Request handling", func() {
@@ -60,7 +61,7 @@ var _ = Describe("Request handling", func() {
 	}
 
 	requestResponseFromRemoteToLocal := func(remoteAddr *net.UDPAddr) []*natproto.SubmarinerNATDiscoveryResponse {
-		err := remoteListener.sendCheckRequest(newRemoteEndpointNAT(&localEndpoint))
+		err := remoteListener.sendCheckRequest(newRemoteEndpointNAT(&localEndpoint, k8snet.IPv4))
 		Expect(err).NotTo(HaveOccurred())
 		return []*natproto.SubmarinerNATDiscoveryResponse{
 			parseResponseInLocalListener(awaitChan(remoteUDPSent), remoteAddr), /* Private IP request */
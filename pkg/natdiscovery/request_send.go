@@ -24,23 +24,21 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
 	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
-	"google.golang.org/protobuf/proto"
-	k8snet "k8s.io/utils/net"
 )
 
 func (nd *natDiscovery) sendCheckRequest(remoteNAT *remoteEndpointNAT) error {
 	var errPrivate, errPublic error
 	var reqID uint64
 
-	if remoteNAT.endpoint.Spec.GetPrivateIP(k8snet.IPv4) != "" {
-		reqID, errPrivate = nd.sendCheckRequestToTargetIP(remoteNAT, remoteNAT.endpoint.Spec.GetPrivateIP(k8snet.IPv4))
+	if remoteNAT.endpoint.Spec.GetPrivateIP(remoteNAT.family) != "" {
+		reqID, errPrivate = nd.sendCheckRequestToTargetIP(remoteNAT, remoteNAT.endpoint.Spec.GetPrivateIP(remoteNAT.family))
 		if errPrivate == nil {
 			remoteNAT.lastPrivateIPRequestID = reqID
 		}
 	}
 
-	if remoteNAT.endpoint.Spec.GetPublicIP(k8snet.IPv4) != "" {
-		reqID, errPublic = nd.sendCheckRequestToTargetIP(remoteNAT, remoteNAT.endpoint.Spec.GetPublicIP(k8snet.IPv4))
+	if remoteNAT.endpoint.Spec.GetPublicIP(remoteNAT.family) != "" {
+		reqID, errPublic = nd.sendCheckRequestToTargetIP(remoteNAT, remoteNAT.endpoint.Spec.GetPublicIP(remoteNAT.family))
 		if errPublic == nil {
 			remoteNAT.lastPublicIPRequestID = reqID
 		}
@@ -100,12 +98,14 @@ func (nd *natDiscovery) sendCheckRequestToTargetIP(remoteNAT *remoteEndpointNAT,
 		Request: request,
 	}
 
-	message := natproto.SubmarinerNATDiscoveryMessage{
+	message := &natproto.SubmarinerNATDiscoveryMessage{
 		Version: natproto.Version,
 		Message: msgRequest,
 	}
 
-	buf, err := proto.Marshal(&message)
+	authenticate := nd.auth.enabled() && supportsAuthentication(&remoteNAT.endpoint.Spec)
+
+	buf, err := nd.auth.marshal(message, authenticate, localEndpointSpec.ClusterID, remoteNAT.endpoint.Spec.ClusterID)
 	if err != nil {
 		return request.GetRequestNumber(), errors.Wrapf(err, "error marshaling request %#v", request)
 	}
@@ -25,7 +25,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/submariner-io/submariner/pkg/natdiscovery/proto"
-	k8snet "k8s.io/utils/net"
 )
 
 func (nd *natDiscovery) handleResponseFromAddress(req *proto.SubmarinerNATDiscoveryResponse, addr *net.UDPAddr) error {
@@ -56,10 +55,14 @@ func (nd *natDiscovery) handleResponseFromAddress(req *proto.SubmarinerNATDiscov
 		return errors.Errorf("received response from unknown endpoint %q", req.GetSender().GetEndpointId())
 	}
 
+	// The legacy settings were used after the discovery timed out so the selected path is kept, but the remote endpoint
+	// needs to be reported as reachable.
+	recovered := remoteNAT.responseReceived()
+
 	// response to a PublicIP request
 	if remoteNAT.lastPublicIPRequestID == req.GetRequestNumber() {
 		useNAT := req.GetResponse() == proto.ResponseType_NAT_DETECTED
-		if !remoteNAT.transitionToPublicIP(req.GetSender().GetEndpointId(), useNAT) {
+		if !remoteNAT.transitionToPublicIP(req.GetSender().GetEndpointId(), useNAT) && !recovered {
 			return nil
 		}
 
@@ -70,20 +73,20 @@ func (nd *natDiscovery) handleResponseFromAddress(req *proto.SubmarinerNATDiscov
 
 	// response to a PrivateIP request
 	if remoteNAT.lastPrivateIPRequestID == req.GetRequestNumber() {
-		if addr.IP.String() != remoteNAT.endpoint.Spec.GetPrivateIP(k8snet.IPv4) {
+		if addr.IP.String() != remoteNAT.endpoint.Spec.GetPrivateIP(remoteNAT.family) {
 			return errors.Errorf("response for NAT discovery on endpoint %q private IP %q comes from different IP %q, "+
 				"NAT on private IPs is unlikely and filtered for security reasons",
-				req.GetSender().GetEndpointId(), remoteNAT.endpoint.Spec.GetPrivateIP(k8snet.IPv4), addr.IP)
+				req.GetSender().GetEndpointId(), remoteNAT.endpoint.Spec.GetPrivateIP(remoteNAT.family), addr.IP)
 		}
 
 		if req.GetResponse() == proto.ResponseType_NAT_DETECTED {
 			logger.Warningf("response for NAT discovery on endpoint %q private IP %q says src was modified which is unexpected",
-				req.GetSender().GetEndpointId(), remoteNAT.endpoint.Spec.GetPrivateIP(k8snet.IPv4))
+				req.GetSender().GetEndpointId(), remoteNAT.endpoint.Spec.GetPrivateIP(remoteNAT.family))
 		}
 
 		useNAT := req.GetResponse() == proto.ResponseType_NAT_DETECTED
 
-		if !remoteNAT.transitionToPrivateIP(req.GetSender().GetEndpointId(), useNAT) {
+		if !remoteNAT.transitionToPrivateIP(req.GetSender().GetEndpointId(), useNAT) && !recovered {
 			return nil
 		}
 
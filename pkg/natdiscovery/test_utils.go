@@ -29,6 +29,7 @@ import (
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	submendpoint "github.com/submariner-io/submariner/pkg/endpoint"
 	natproto "github.com/submariner-io/submariner/pkg/natdiscovery/proto"
+	"github.com/submariner-io/submariner/pkg/types"
 	"google.golang.org/protobuf/proto"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -39,12 +40,14 @@ const (
 	testLocalClusterID    = "cluster-a"
 	testLocalPublicIP     = "10.1.1.1"
 	testLocalPrivateIP    = "2.2.2.2"
+	testLocalPrivateIPv6  = "fd00::2"
 
 	testRemoteEndpointName = "cluster-b-ep-1"
 	testRemoteClusterID    = "cluster-b"
 	testRemotePublicIP     = "10.3.3.3"
 	testRemotePrivateIP    = "4.4.4.4"
 	testRemotePrivateIP2   = "5.5.5.5"
+	testRemotePrivateIPv6  = "fd00::4"
 )
 
 var (
@@ -82,7 +85,10 @@ func createTestListener(endpoint *submarinerv1.Endpoint) (*natDiscovery, chan []
 
 	test.CreateResource(dynClient.Resource(submarinerv1.EndpointGVR).Namespace(""), localEndpoint.Resource())
 
-	listener, err := newNATDiscovery(localEndpoint)
+	listener, err := newNATDiscovery(localEndpoint, &types.SubmarinerSpecification{
+		NATTDiscoveryV1Compat:     true,
+		NATTDiscoveryMaxClockSkew: 30 * time.Second,
+	})
 	Expect(err).To(Succeed())
 
 	readyChannel := listener.GetReadyChannel()
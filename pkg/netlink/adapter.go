@@ -93,7 +93,7 @@ func (a *Adapter) DeleteDestinationRoutes(destIPs []net.IPNet, linkIndex, tableI
 			Table:     tableID,
 		}
 
-		err := netlink.RouteDel(route)
+		err := a.RouteDel(route)
 		if err != nil {
 			return errors.Wrapf(err, "unable to delete the route entry %#v", route)
 		}
@@ -47,6 +47,7 @@ type basicType struct {
 	neighbors    map[int][]netlink.Neigh
 	rules        map[int][]netlink.Rule
 	addrs        map[int][]netlink.Addr
+	xfrmStates   []netlink.XfrmState
 	addrUpdateCh atomic.Value
 }
 
@@ -84,6 +85,13 @@ func (n *NetLink) basic() *basicType {
 	return n.Adapter.Basic.(*basicType)
 }
 
+func (n *NetLink) SetXfrmStates(states ...netlink.XfrmState) {
+	n.basic().mutex.Lock()
+	defer n.basic().mutex.Unlock()
+
+	n.basic().xfrmStates = states
+}
+
 func (n *NetLink) SetLinkIndex(name string, index int) {
 	n.basic().mutex.Lock()
 	defer n.basic().mutex.Unlock()
@@ -126,6 +134,18 @@ func (n *basicType) LinkByName(name string) (netlink.Link, error) {
 	return link.Link, nil
 }
 
+func (n *basicType) LinkList() ([]netlink.Link, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	links := make([]netlink.Link, 0, len(n.links))
+	for _, l := range n.links {
+		links = append(links, l.Link)
+	}
+
+	return links, nil
+}
+
 func (n *basicType) LinkSetUp(link netlink.Link) error {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
@@ -354,6 +374,11 @@ func ruleKey(r netlink.Rule) string {
 		k += r.Dst.String()
 	}
 
+	// Rules without a source or destination are only distinguished by their family.
+	if k == "" && r.Family == netlink.FAMILY_V6 {
+		k = "inet6"
+	}
+
 	return k
 }
 
@@ -430,6 +455,13 @@ func (n *basicType) XfrmPolicyList(_ int) ([]netlink.XfrmPolicy, error) {
 	return []netlink.XfrmPolicy{}, nil
 }
 
+func (n *basicType) XfrmStateList(_ int) ([]netlink.XfrmState, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return append([]netlink.XfrmState{}, n.xfrmStates...), nil
+}
+
 func (n *basicType) EnableLooseModeReversePathFilter(_ string) error {
 	return nil
 }
@@ -442,6 +474,10 @@ func (n *basicType) EnableForwarding(_ string) error {
 	return nil
 }
 
+func (n *basicType) EnableIPv6Forwarding(_ string) error {
+	return nil
+}
+
 func (n *basicType) GetReversePathFilter(_ string) ([]byte, error) {
 	return []byte("2"), nil
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
 	"github.com/vishvananda/netlink"
+	k8snet "k8s.io/utils/net"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -39,6 +40,7 @@ type Basic interface {
 	LinkAdd(link netlink.Link) error
 	LinkDel(link netlink.Link) error
 	LinkByName(name string) (netlink.Link, error)
+	LinkList() ([]netlink.Link, error)
 	LinkSetUp(link netlink.Link) error
 	AddrAdd(link netlink.Link, addr *netlink.Addr) error
 	AddrDel(link netlink.Link, addr *netlink.Addr) error
@@ -58,9 +60,11 @@ type Basic interface {
 	XfrmPolicyAdd(policy *netlink.XfrmPolicy) error
 	XfrmPolicyDel(policy *netlink.XfrmPolicy) error
 	XfrmPolicyList(family int) ([]netlink.XfrmPolicy, error)
+	XfrmStateList(family int) ([]netlink.XfrmState, error)
 	EnableLooseModeReversePathFilter(interfaceName string) error
 	EnsureLooseModeIsConfigured(interfaceName string) error
 	EnableForwarding(interfaceName string) error
+	EnableIPv6Forwarding(interfaceName string) error
 	GetReversePathFilter(interfaceName string) ([]byte, error)
 	ConfigureTCPMTUProbe(mtuProbe, baseMss string) error
 }
@@ -105,6 +109,10 @@ func (n *netlinkType) LinkByName(name string) (netlink.Link, error) {
 	return netlink.LinkByName(name)
 }
 
+func (n *netlinkType) LinkList() ([]netlink.Link, error) {
+	return netlink.LinkList()
+}
+
 func (n *netlinkType) LinkSetUp(link netlink.Link) error {
 	return netlink.LinkSetUp(link)
 }
@@ -177,6 +185,10 @@ func (n *netlinkType) XfrmPolicyList(family int) ([]netlink.XfrmPolicy, error) {
 	return netlink.XfrmPolicyList(family)
 }
 
+func (n *netlinkType) XfrmStateList(family int) ([]netlink.XfrmState, error) {
+	return netlink.XfrmStateList(family)
+}
+
 func (n *netlinkType) EnableLooseModeReversePathFilter(interfaceName string) error {
 	// Enable loose mode (rp_filter=2) reverse path filtering on the vxlan interface.
 	err := setSysctl(ipv4ConfPath(interfaceName)+"/rp_filter", []byte("2"))
@@ -212,6 +224,11 @@ func (n *netlinkType) EnableForwarding(interfaceName string) error {
 	return errors.Wrapf(err, "unable to update forwarding on interface %q", interfaceName)
 }
 
+func (n *netlinkType) EnableIPv6Forwarding(interfaceName string) error {
+	err := setSysctl(ipv6ConfPath(interfaceName)+"/forwarding", []byte("1"))
+	return errors.Wrapf(err, "unable to update IPv6 forwarding on interface %q", interfaceName)
+}
+
 func (n *netlinkType) GetReversePathFilter(interfaceName string) ([]byte, error) {
 	path := ipv4ConfPath(interfaceName) + "/rp_filter"
 
@@ -264,6 +281,10 @@ func ipv4ConfPath(interfaceName string) string {
 	return "/proc/sys/net/ipv4/conf/" + interfaceName
 }
 
+func ipv6ConfPath(interfaceName string) string {
+	return "/proc/sys/net/ipv6/conf/" + interfaceName
+}
+
 //nolint:wrapcheck // Let the caller wrap external errors
 func GetDefaultGatewayInterface() (*net.Interface, error) {
 	routes, err := netlink.RouteList(nil, syscall.AF_INET)
@@ -363,3 +384,14 @@ func NewTableRule(tableID int) *netlink.Rule {
 
 	return rule
 }
+
+// NewTableRuleForFamily returns a rule looking up the table tableID for the packets of the given IP family.
+func NewTableRuleForFamily(tableID int, family k8snet.IPFamily) *netlink.Rule {
+	rule := NewTableRule(tableID)
+
+	if family == k8snet.IPv6 {
+		rule.Family = netlink.FAMILY_V6
+	}
+
+	return rule
+}
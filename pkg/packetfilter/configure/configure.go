@@ -0,0 +1,180 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configure
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	goiptables "github.com/coreos/go-iptables/iptables"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	"github.com/submariner-io/submariner/pkg/ipset"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	"github.com/submariner-io/submariner/pkg/packetfilter/iptables"
+	"github.com/submariner-io/submariner/pkg/packetfilter/nftables"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/knftables"
+)
+
+const (
+	// DriverAuto selects the packet filter driver based on the backend that is active on the host.
+	DriverAuto = "auto"
+	// DriverIPTables selects the iptables packet filter driver.
+	DriverIPTables = "iptables"
+	// DriverNFTables selects the nftables packet filter driver.
+	DriverNFTables = "nftables"
+
+	// This is the table used by the nftables driver, see nftables.New.
+	nftablesTable = "submariner"
+
+	iptablesBackendNFTables = "nf_tables"
+	iptablesBackendLegacy   = "legacy"
+)
+
+var logger = log.Logger{Logger: logf.Log.WithName("PacketFilterConfig")}
+
+// LegacyIPTables is the subset of the go-iptables API used to detect and clean up the legacy iptables backend.
+type LegacyIPTables interface {
+	ListChains(table string) ([]string, error)
+	List(table, chain string) ([]string, error)
+	Delete(table, chain string, rulespec ...string) error
+	ClearChain(table, chain string) error
+	DeleteChain(table, chain string) error
+}
+
+// DriverFromConfig registers the IPv4 and IPv6 packet filter drivers named by the driver argument, which is one of
+// DriverIPTables, DriverNFTables or DriverAuto (the default if empty). If nftables is selected and migrateLegacyState is
+// set, any Submariner chains and ipsets left by the iptables driver, eg by a previous version, are migrated to nftables
+// and removed. Only the route agent, which runs on every node, migrates so that globalnet doesn't do so concurrently.
+func DriverFromConfig(driver string, migrateLegacyState bool) error {
+	nft, err := knftables.New(knftables.IPv4Family, nftablesTable)
+	if err != nil {
+		logger.Infof("nftables is not available: %v", err)
+
+		nft = nil
+	}
+
+	ipt := newLegacyIPTables(goiptables.ProtocolIPv4)
+
+	switch strings.ToLower(driver) {
+	case "", DriverAuto:
+		driver = detectDriver(nft, ipt, iptablesBackend())
+		logger.Infof("Auto-detected packet filter driver %q", driver)
+	case DriverIPTables, DriverNFTables:
+		driver = strings.ToLower(driver)
+		logger.Infof("Using configured packet filter driver %q", driver)
+	default:
+		return errors.Errorf("invalid packet filter driver %q - supported values are %q, %q and %q",
+			driver, DriverIPTables, DriverNFTables, DriverAuto)
+	}
+
+	if driver == DriverIPTables {
+		packetfilter.SetNewDriverFn(iptables.New)
+		packetfilter.SetNewDriverFnV6(iptables.NewV6)
+
+		return nil
+	}
+
+	if nft == nil {
+		return errors.New("the nftables packet filter driver was selected but nftables is not available")
+	}
+
+	packetfilter.SetNewDriverFn(nftables.New)
+	packetfilter.SetNewDriverFnV6(nftables.NewV6)
+
+	if !migrateLegacyState {
+		return nil
+	}
+
+	from := &LegacyState{
+		IPTables:    ipt,
+		IP6Tables:   newLegacyIPTables(goiptables.ProtocolIPv6),
+		IPSet:       ipset.New(),
+		NewDriver:   nftables.New,
+		NewDriverV6: nftables.NewV6,
+	}
+
+	if !from.Exists() {
+		return nil
+	}
+
+	return MigrateFromIPTables(from)
+}
+
+func newLegacyIPTables(proto goiptables.Protocol) LegacyIPTables {
+	ipt, err := goiptables.New(goiptables.IPFamily(proto), goiptables.Timeout(5))
+	if err != nil {
+		logger.Infof("iptables for protocol %v is not available: %v", proto, err)
+		return nil
+	}
+
+	return ipt
+}
+
+// iptablesBackend returns the backend used by the host's iptables binary, ie iptablesBackendNFTables for iptables-nft,
+// as on RHEL 9, or iptablesBackendLegacy, or an empty string if it can't be determined.
+func iptablesBackend() string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "iptables", "--version").Output()
+	if err != nil {
+		logger.Infof("Unable to determine the iptables backend: %v", err)
+		return ""
+	}
+
+	// The output is eg "iptables v1.8.8 (nf_tables)", or "iptables v1.8.8 (legacy)" - older versions omit the backend.
+	if strings.Contains(string(out), "("+iptablesBackendNFTables+")") {
+		return iptablesBackendNFTables
+	}
+
+	return iptablesBackendLegacy
+}
+
+// detectDriver returns the driver that matches the host's active backend. An existing nftables submariner table means
+// nftables was previously selected. Otherwise nftables is used if iptables itself uses the nf_tables backend, eg
+// iptables-nft on RHEL 9, and iptables is used if the legacy backend is functional.
+func detectDriver(nft knftables.Interface, ipt LegacyIPTables, backend string) string {
+	if nft == nil {
+		return DriverIPTables
+	}
+
+	if _, err := nft.List(context.TODO(), "chains"); err == nil {
+		return DriverNFTables
+	}
+
+	if ipt == nil {
+		return DriverNFTables
+	}
+
+	if backend == iptablesBackendNFTables {
+		logger.Infof("iptables uses the nf_tables backend - using nftables")
+		return DriverNFTables
+	}
+
+	if _, err := ipt.ListChains("filter"); err != nil {
+		logger.Infof("Unable to list iptables chains - assuming the host uses nftables: %v", err)
+		return DriverNFTables
+	}
+
+	return DriverIPTables
+}
@@ -0,0 +1,309 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configure
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner/pkg/ipset"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	fakePF "github.com/submariner-io/submariner/pkg/packetfilter/fake"
+	"sigs.k8s.io/knftables"
+)
+
+var _ = Describe("detectDriver", func() {
+	var (
+		nft *knftables.Fake
+		ipt *fakeIPTables
+	)
+
+	BeforeEach(func() {
+		nft = knftables.NewFake(knftables.IPv4Family, nftablesTable)
+		ipt = newFakeIPTables()
+	})
+
+	When("nftables is not available", func() {
+		It("should return iptables", func() {
+			Expect(detectDriver(nil, ipt, iptablesBackendLegacy)).To(Equal(DriverIPTables))
+		})
+	})
+
+	When("the nftables submariner table exists", func() {
+		It("should return nftables", func() {
+			tx := nft.NewTransaction()
+			tx.Add(&knftables.Table{})
+			Expect(nft.Run(context.TODO(), tx)).To(Succeed())
+
+			Expect(detectDriver(nft, ipt, iptablesBackendLegacy)).To(Equal(DriverNFTables))
+		})
+	})
+
+	When("iptables is not available", func() {
+		It("should return nftables", func() {
+			Expect(detectDriver(nft, nil, iptablesBackendLegacy)).To(Equal(DriverNFTables))
+		})
+	})
+
+	When("the legacy iptables backend isn't functional", func() {
+		It("should return nftables", func() {
+			ipt.listChainsErr = errors.New("mock error")
+			Expect(detectDriver(nft, ipt, iptablesBackendLegacy)).To(Equal(DriverNFTables))
+		})
+	})
+
+	When("iptables uses the nf_tables backend", func() {
+		It("should return nftables", func() {
+			Expect(detectDriver(nft, ipt, iptablesBackendNFTables)).To(Equal(DriverNFTables))
+		})
+	})
+
+	When("the legacy iptables backend is functional", func() {
+		It("should return iptables", func() {
+			Expect(detectDriver(nft, ipt, iptablesBackendLegacy)).To(Equal(DriverIPTables))
+		})
+	})
+})
+
+var _ = Describe("MigrateFromIPTables", func() {
+	var (
+		ipt   *fakeIPTables
+		ip6t  *fakeIPTables
+		ipSet *fakeIPSet
+		to    *fakePF.PacketFilter
+		toV6  *fakePF.PacketFilter
+		from  *LegacyState
+	)
+
+	BeforeEach(func() {
+		ipt = newFakeIPTables()
+		ip6t = newFakeIPTables()
+		ipSet = &fakeIPSet{sets: map[string][]string{}}
+		to = fakePF.New()
+		toV6 = fakePF.New()
+
+		from = &LegacyState{
+			IPTables:  ipt,
+			IP6Tables: ip6t,
+			IPSet:     ipSet,
+			NewDriver: func() (packetfilter.Driver, error) {
+				return to, nil
+			},
+			NewDriverV6: func() (packetfilter.Driver, error) {
+				return toV6, nil
+			},
+		}
+
+		ipt.chains["nat"] = map[string][]string{
+			"POSTROUTING": {
+				"-A POSTROUTING -j SUBMARINER-POSTROUTING",
+				"-A POSTROUTING -j KUBE-POSTROUTING",
+			},
+			"SUBMARINER-POSTROUTING": {"-A SUBMARINER-POSTROUTING -j SM-GN-EGRESS"},
+			"SM-GN-EGRESS":           {"-A SM-GN-EGRESS -j ACCEPT"},
+			"KUBE-POSTROUTING":       {"-A KUBE-POSTROUTING -j ACCEPT"},
+		}
+
+		ipt.chains["filter"] = map[string][]string{
+			"FORWARD":            {"-A FORWARD -j SUBMARINER-FORWARD"},
+			"SUBMARINER-FORWARD": {},
+		}
+
+		ip6t.chains["filter"] = map[string][]string{
+			"FORWARD":            {"-A FORWARD -j SUBMARINER-FORWARD", "-A FORWARD -j KUBE-FORWARD"},
+			"SUBMARINER-FORWARD": {},
+			"KUBE-FORWARD":       {},
+		}
+
+		ipSet.sets["SUBMARINER-REMOTECIDRS"] = []string{"10.0.0.0/16", "10.1.0.0/16"}
+		ipSet.sets["SUBMARINER-REMOTECIDRS-V6"] = []string{"fd00:1::/64"}
+		ipSet.sets["SM-GN-abcd"] = []string{"169.254.1.1"}
+		ipSet.sets["KUBE-SET"] = []string{"10.2.0.0/16"}
+	})
+
+	It("should remove the Submariner chains and jump rules of both IP families", func() {
+		Expect(MigrateFromIPTables(from)).To(Succeed())
+
+		Expect(ipt.chains["nat"]).To(Equal(map[string][]string{
+			"POSTROUTING":      {"-A POSTROUTING -j KUBE-POSTROUTING"},
+			"KUBE-POSTROUTING": {"-A KUBE-POSTROUTING -j ACCEPT"},
+		}))
+
+		Expect(ipt.chains["filter"]).To(Equal(map[string][]string{
+			"FORWARD": {},
+		}))
+
+		Expect(ip6t.chains["filter"]).To(Equal(map[string][]string{
+			"FORWARD":      {"-A FORWARD -j KUBE-FORWARD"},
+			"KUBE-FORWARD": {},
+		}))
+	})
+
+	It("should copy the Submariner ipsets to named sets of their family and destroy them", func() {
+		Expect(MigrateFromIPTables(from)).To(Succeed())
+
+		Expect(to.NewNamedSet(&packetfilter.SetInfo{Name: "SUBMARINER-REMOTECIDRS"}).ListEntries()).To(
+			ConsistOf("10.0.0.0/16", "10.1.0.0/16"))
+		Expect(to.NewNamedSet(&packetfilter.SetInfo{Name: "SM-GN-abcd"}).ListEntries()).To(ConsistOf("169.254.1.1"))
+		Expect(toV6.NewNamedSet(&packetfilter.SetInfo{Name: "SUBMARINER-REMOTECIDRS-V6"}).ListEntries()).To(
+			ConsistOf("fd00:1::/64"))
+
+		_, err := to.NewNamedSet(&packetfilter.SetInfo{Name: "KUBE-SET"}).ListEntries()
+		Expect(err).To(HaveOccurred())
+
+		_, err = to.NewNamedSet(&packetfilter.SetInfo{Name: "SUBMARINER-REMOTECIDRS-V6"}).ListEntries()
+		Expect(err).To(HaveOccurred())
+
+		Expect(ipSet.sets).To(HaveLen(1))
+		Expect(ipSet.sets).To(HaveKey("KUBE-SET"))
+	})
+
+	When("ip6tables isn't available", func() {
+		It("should migrate the IPv4 state", func() {
+			from.IP6Tables = nil
+
+			Expect(MigrateFromIPTables(from)).To(Succeed())
+			Expect(ipt.chains["filter"]).To(Equal(map[string][]string{
+				"FORWARD": {},
+			}))
+		})
+	})
+
+	When("listing the ipsets fails", func() {
+		It("should skip the set migration", func() {
+			ipSet.listErr = errors.New("mock error")
+
+			Expect(MigrateFromIPTables(from)).To(Succeed())
+			Expect(ipSet.sets).To(HaveLen(4))
+		})
+	})
+
+	Describe("Exists", func() {
+		It("should return true if there are Submariner chains or ipsets", func() {
+			Expect(from.Exists()).To(BeTrue())
+
+			ipt.chains = map[string]map[string][]string{}
+			ip6t.chains = map[string]map[string][]string{}
+			Expect(from.Exists()).To(BeTrue())
+
+			ipSet.sets = map[string][]string{"KUBE-SET": {}}
+			Expect(from.Exists()).To(BeFalse())
+
+			ip6t.chains["mangle"] = map[string][]string{"SUBMARINER-PREROUTING": {}}
+			Expect(from.Exists()).To(BeTrue())
+		})
+
+		It("should return false once migrated", func() {
+			Expect(MigrateFromIPTables(from)).To(Succeed())
+			Expect(from.Exists()).To(BeFalse())
+		})
+	})
+})
+
+type fakeIPTables struct {
+	chains        map[string]map[string][]string
+	listChainsErr error
+}
+
+func newFakeIPTables() *fakeIPTables {
+	return &fakeIPTables{chains: map[string]map[string][]string{}}
+}
+
+func (f *fakeIPTables) ListChains(table string) ([]string, error) {
+	if f.listChainsErr != nil {
+		return nil, f.listChainsErr
+	}
+
+	chains := []string{}
+	for name := range f.chains[table] {
+		chains = append(chains, name)
+	}
+
+	slices.Sort(chains)
+
+	return chains, nil
+}
+
+func (f *fakeIPTables) List(table, chain string) ([]string, error) {
+	return slices.Clone(f.chains[table][chain]), nil
+}
+
+func (f *fakeIPTables) Delete(table, chain string, rulespec ...string) error {
+	rule := strings.Join(append([]string{"-A", chain}, rulespec...), " ")
+	f.chains[table][chain] = slices.DeleteFunc(f.chains[table][chain], func(r string) bool {
+		return r == rule
+	})
+
+	return nil
+}
+
+func (f *fakeIPTables) ClearChain(table, chain string) error {
+	f.chains[table][chain] = []string{}
+	return nil
+}
+
+func (f *fakeIPTables) DeleteChain(table, chain string) error {
+	for _, rules := range f.chains[table] {
+		for _, rule := range rules {
+			if strings.HasSuffix(rule, "-j "+chain) {
+				return errors.Errorf("chain %q is still referenced", chain)
+			}
+		}
+	}
+
+	if len(f.chains[table][chain]) > 0 {
+		return errors.Errorf("chain %q is not empty", chain)
+	}
+
+	delete(f.chains[table], chain)
+
+	return nil
+}
+
+type fakeIPSet struct {
+	ipset.Interface
+	sets    map[string][]string
+	listErr error
+}
+
+func (f *fakeIPSet) ListSets() ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+
+	names := []string{}
+	for name := range f.sets {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (f *fakeIPSet) ListEntries(set string) ([]string, error) {
+	return f.sets[set], nil
+}
+
+func (f *fakeIPSet) DestroySet(set string) error {
+	delete(f.sets, set)
+	return nil
+}
@@ -0,0 +1,251 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configure
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner/pkg/ipset"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// These are the prefixes of the chains and sets created by the route agent and globalnet.
+var legacyPrefixes = []string{"SUBMARINER-", "SM-GN-"}
+
+var legacyTables = []string{"filter", "nat", "mangle"}
+
+func isLegacyName(name string) bool {
+	return slices.ContainsFunc(legacyPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// LegacyState is the Submariner state programmed by the iptables driver, for both IP families, and the drivers it's
+// migrated to.
+type LegacyState struct {
+	// IPTables and IP6Tables access the IPv4 and IPv6 tables; either may be nil if unavailable.
+	IPTables  LegacyIPTables
+	IP6Tables LegacyIPTables
+	IPSet     ipset.Interface
+	// NewDriver and NewDriverV6 create the IPv4 and IPv6 drivers to copy the ipsets to, if there are any.
+	NewDriver   func() (packetfilter.Driver, error)
+	NewDriverV6 func() (packetfilter.Driver, error)
+}
+
+// Exists returns true if any Submariner chain or ipset is left by the iptables driver.
+func (l *LegacyState) Exists() bool {
+	for _, ipt := range []LegacyIPTables{l.IPTables, l.IP6Tables} {
+		if ipt == nil {
+			continue
+		}
+
+		for _, table := range legacyTables {
+			chains, err := ipt.ListChains(table)
+			if err == nil && slices.ContainsFunc(chains, isLegacyName) {
+				return true
+			}
+		}
+	}
+
+	sets, err := l.IPSet.ListSets()
+
+	return err == nil && slices.ContainsFunc(sets, func(name string) bool {
+		return isLegacyName(strings.TrimSpace(name))
+	})
+}
+
+// MigrateFromIPTables moves the Submariner state programmed by the iptables driver to the drivers of the legacy state.
+// The contents of the Submariner ipsets are copied to named sets of the same name and family, after which the ipsets are
+// destroyed. The Submariner iptables and ip6tables chains, and any rules jumping to them, are removed - the chain rules
+// themselves are re-created by their owners using the new drivers.
+func MigrateFromIPTables(from *LegacyState) error {
+	logger.Info("Migrating the Submariner state left by the iptables packet filter driver")
+
+	var errs []error
+
+	for _, ipt := range []LegacyIPTables{from.IPTables, from.IP6Tables} {
+		if ipt == nil {
+			continue
+		}
+
+		for _, table := range legacyTables {
+			errs = append(errs, removeLegacyChains(ipt, table))
+		}
+	}
+
+	errs = append(errs, migrateLegacySets(from))
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func removeLegacyChains(ipt LegacyIPTables, table string) error {
+	chains, err := ipt.ListChains(table)
+	if err != nil {
+		return errors.Wrapf(err, "error listing iptables chains in table %q", table)
+	}
+
+	var errs []error
+
+	// First remove the rules that jump to our chains from the other chains, eg the built-in chains.
+	for _, chain := range chains {
+		if isLegacyName(chain) {
+			continue
+		}
+
+		rules, err := ipt.List(table, chain)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error listing rules for chain %q in table %q", chain, table))
+			continue
+		}
+
+		for _, rule := range rules {
+			spec := strings.Fields(rule)
+			if len(spec) < 2 || spec[0] != "-A" {
+				continue
+			}
+
+			jumpIdx := slices.Index(spec, "-j")
+			if jumpIdx < 0 || jumpIdx+1 >= len(spec) || !isLegacyName(spec[jumpIdx+1]) {
+				continue
+			}
+
+			logger.Infof("Migration: deleting iptables rule %q from table %q", rule, table)
+
+			err = ipt.Delete(table, chain, spec[2:]...)
+			if err != nil {
+				errs = append(errs, errors.Wrapf(err, "error deleting rule %q in table %q", rule, table))
+			}
+		}
+	}
+
+	// Our chains may reference each other so flush them all before deleting them.
+	var legacyChains []string
+
+	for _, chain := range chains {
+		if !isLegacyName(chain) {
+			continue
+		}
+
+		err = ipt.ClearChain(table, chain)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error flushing chain %q in table %q", chain, table))
+			continue
+		}
+
+		legacyChains = append(legacyChains, chain)
+	}
+
+	for _, chain := range legacyChains {
+		logger.Infof("Migration: deleting iptables chain %q from table %q", chain, table)
+
+		err = ipt.DeleteChain(table, chain)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error deleting chain %q in table %q", chain, table))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func migrateLegacySets(from *LegacyState) error {
+	sets, err := from.IPSet.ListSets()
+	if err != nil {
+		// This typically means the ipset utility or kernel module isn't present, in which case there's nothing to migrate.
+		logger.Infof("Unable to list ipsets - skipping migration: %v", err)
+		return nil
+	}
+
+	drivers := map[packetfilter.SetFamily]packetfilter.Driver{}
+
+	var errs []error
+
+	for _, name := range sets {
+		name = strings.TrimSpace(name)
+		if !isLegacyName(name) {
+			continue
+		}
+
+		err = migrateLegacySet(from, drivers, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		logger.Infof("Migration: destroying ipset %q", name)
+
+		err = from.IPSet.DestroySet(name)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error destroying ipset %q", name))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// migrateLegacySet copies the entries of the given ipset to the driver of their family, created on first use. Empty sets
+// aren't copied since their family is unknown; their owners re-create them.
+func migrateLegacySet(from *LegacyState, drivers map[packetfilter.SetFamily]packetfilter.Driver, name string) error {
+	entries, err := from.IPSet.ListEntries(name)
+	if err != nil {
+		return errors.Wrapf(err, "error listing entries for ipset %q", name)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	family, newDriver := packetfilter.SetFamilyV4, from.NewDriver
+	if strings.Contains(entries[0], ":") {
+		family, newDriver = packetfilter.SetFamilyV6, from.NewDriverV6
+	}
+
+	to, ok := drivers[family]
+	if !ok {
+		to, err = newDriver()
+		if err != nil {
+			return errors.Wrapf(err, "error creating the packet filter driver to migrate ipset %q", name)
+		}
+
+		drivers[family] = to
+	}
+
+	namedSet := to.NewNamedSet(&packetfilter.SetInfo{
+		Name:   name,
+		Family: family,
+	})
+
+	err = namedSet.Create(true)
+	if err != nil {
+		return errors.Wrapf(err, "error creating named set %q", name)
+	}
+
+	logger.Infof("Migration: copying %d entries from ipset %q", len(entries), name)
+
+	for _, entry := range entries {
+		err = namedSet.AddEntry(entry, true)
+		if err != nil {
+			return errors.Wrapf(err, "error adding entry %q to named set %q", entry, name)
+		}
+	}
+
+	return nil
+}
@@ -45,10 +45,7 @@ type PacketFilter struct {
 }
 
 func New() *PacketFilter {
-	pf := &PacketFilter{
-		chainRules: map[string][]string{},
-		sets:       map[string]set.Set[string]{},
-	}
+	pf := newPacketFilter()
 
 	packetfilter.SetNewDriverFn(func() (packetfilter.Driver, error) {
 		return pf, nil
@@ -57,6 +54,24 @@ func New() *PacketFilter {
 	return pf
 }
 
+// NewV6 returns a fake packet filter which is registered as the IPv6 driver.
+func NewV6() *PacketFilter {
+	pf := newPacketFilter()
+
+	packetfilter.SetNewDriverFnV6(func() (packetfilter.Driver, error) {
+		return pf, nil
+	})
+
+	return pf
+}
+
+func newPacketFilter() *PacketFilter {
+	return &PacketFilter{
+		chainRules: map[string][]string{},
+		sets:       map[string]set.Set[string]{},
+	}
+}
+
 func (i *PacketFilter) ChainExists(table packetfilter.TableType, chain string) (bool, error) {
 	return i.chainExists(uint32(table), chain)
 }
@@ -29,7 +29,10 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-const matchsetArg = "--match-set"
+const (
+	matchsetArg        = "--match-set"
+	ctStateEstablished = "RELATED,ESTABLISHED"
+)
 
 var (
 	tableTypeToStr = map[packetfilter.TableType]string{
@@ -58,6 +61,7 @@ var (
 		packetfilter.RuleActionMark:   "MARK",
 		packetfilter.RuleActionSNAT:   "SNAT",
 		packetfilter.RuleActionDNAT:   "DNAT",
+		packetfilter.RuleActionDrop:   "DROP",
 	}
 
 	logger = log.Logger{Logger: logf.Log.WithName("IPTables")}
@@ -293,6 +297,10 @@ func ToRuleSpec(rule *packetfilter.Rule) RuleSpec {
 
 	setToRuleSpec(&ruleSpec, rule.SrcSetName, rule.DestSetName)
 
+	if rule.CtState == packetfilter.ConnTrackStateEstablished {
+		ruleSpec = append(ruleSpec, "-m", "conntrack", "--ctstate", ctStateEstablished)
+	}
+
 	if rule.OutInterface != "" {
 		ruleSpec = append(ruleSpec, "-o", rule.OutInterface)
 	}
@@ -402,6 +410,14 @@ func parseRuleMatch(spec []string, i int, rule *packetfilter.Rule) int {
 	case "mark":
 		if i+2 < len(spec) && spec[i+1] == "--mark" {
 			rule.MarkValue = parseMark(spec[i+2])
+			i += 2
+		}
+	case "conntrack":
+		if i+2 < len(spec) && spec[i+1] == "--ctstate" {
+			if spec[i+2] == ctStateEstablished {
+				rule.CtState = packetfilter.ConnTrackStateEstablished
+			}
+
 			i += 2
 		}
 	case "set":
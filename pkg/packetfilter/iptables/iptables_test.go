@@ -105,6 +105,19 @@ var _ = Describe("Rule conversion", func() {
 			Action:      packetfilter.RuleActionJump,
 		})
 
+		// -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT
+		testRuleConversion(&packetfilter.Rule{
+			CtState: packetfilter.ConnTrackStateEstablished,
+			Action:  packetfilter.RuleActionAccept,
+		})
+
+		// -m set --match-set src-set src -m set --match-set dest-set dst -j DROP
+		testRuleConversion(&packetfilter.Rule{
+			SrcSetName:  "src-set",
+			DestSetName: "dest-set",
+			Action:      packetfilter.RuleActionDrop,
+		})
+
 		// The actual iptables command returns the TCPMSS rule parts in a different order than we write it out so ensure we
 		// can parse it correctly.
 		rs := "-s 1.2.3.4/32 -p tcp -m tcp --tcp-flags SYN,RST SYN -j TCPMSS --set-mss 1500"
@@ -35,11 +35,16 @@ type namedSet struct {
 
 func (p *packetFilter) NewNamedSet(set *packetfilter.SetInfo) packetfilter.NamedSet {
 	setType := "ipv4_addr"
+	if p.family == knftables.IPv6Family {
+		setType = "ipv6_addr"
+	}
 
 	return &namedSet{
 		set: knftables.Set{
 			Name: set.Name,
 			Type: setType,
+			// The sets may contain CIDRs as well as addresses.
+			Flags: []knftables.SetFlag{knftables.IntervalFlag},
 		},
 		nftables: p.nftables,
 	}
@@ -34,6 +34,8 @@ import (
 const (
 	/* Single table named 'submariner' is used for nftables configuration.*/
 	submarinerTable = "submariner"
+
+	ctStateEstablished = "established,related"
 )
 
 var (
@@ -64,6 +66,7 @@ var (
 		packetfilter.RuleActionSNAT:   {"snat"},
 		packetfilter.RuleActionDNAT:   {"dnat"},
 		packetfilter.RuleActionJump:   {"jump"},
+		packetfilter.RuleActionDrop:   {"drop"},
 	}
 
 	logger = log.Logger{Logger: logf.Log.WithName("NFTables")}
@@ -77,20 +80,34 @@ func (r RuleSpec) String() string {
 
 type packetFilter struct {
 	nftables knftables.Interface
+	family   knftables.Family
 }
 
 func New() (packetfilter.Driver, error) {
-	nft, err := knftables.New(knftables.IPv4Family, submarinerTable)
+	return newNftables(knftables.IPv4Family)
+}
+
+func NewV6() (packetfilter.Driver, error) {
+	return newNftables(knftables.IPv6Family)
+}
+
+func newNftables(family knftables.Family) (packetfilter.Driver, error) {
+	nft, err := knftables.New(family, submarinerTable)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating knftables")
+		return nil, errors.Wrapf(err, "error creating knftables for family %q", family)
 	}
 
-	return NewWithNft(nft), nil
+	return NewWithNftFamily(nft, family), nil
 }
 
 func NewWithNft(nft knftables.Interface) packetfilter.Driver {
+	return NewWithNftFamily(nft, knftables.IPv4Family)
+}
+
+func NewWithNftFamily(nft knftables.Interface, family knftables.Family) packetfilter.Driver {
 	return &packetFilter{
 		nftables: nft,
+		family:   family,
 	}
 }
 
@@ -265,12 +282,13 @@ func (p *packetFilter) Append(_ packetfilter.TableType, chain string, rule *pack
 }
 
 func (p *packetFilter) insertRuleAtPosition(chain string, rule *packetfilter.Rule, pos int) error {
-	ruleSpec := ToRuleSpec(rule).String()
+	ruleSpec := ToRuleSpec(rule)
 
+	// The comment always holds the IPv4 form of the rule spec so it can be parsed back regardless of the family.
 	knftRule := knftables.Rule{
 		Chain:   chain,
-		Rule:    ruleSpec,
-		Comment: ptr.To(ruleSpec),
+		Rule:    p.toFamilyRuleSpec(ruleSpec).String(),
+		Comment: ptr.To(ruleSpec.String()),
 	}
 
 	tx := p.newTransactionWithTable()
@@ -291,6 +309,39 @@ func (p *packetFilter) insertRuleAtPosition(chain string, rule *packetfilter.Rul
 	return errors.Wrap(err, "error inserting rule")
 }
 
+// toFamilyRuleSpec converts the IPv4 address and protocol matches in the given rule spec to their equivalent for the
+// driver's family.
+func (p *packetFilter) toFamilyRuleSpec(ruleSpec RuleSpec) RuleSpec {
+	if p.family != knftables.IPv6Family {
+		return ruleSpec
+	}
+
+	converted := make(RuleSpec, 0, len(ruleSpec))
+
+	for i := 0; i < len(ruleSpec); i++ {
+		if ruleSpec[i] != "ip" {
+			converted = append(converted, ruleSpec[i])
+			continue
+		}
+
+		if i+2 < len(ruleSpec) && ruleSpec[i+1] == "protocol" {
+			proto := ruleSpec[i+2]
+			if proto == "icmp" {
+				proto = "ipv6-icmp"
+			}
+
+			converted = append(converted, "meta", "l4proto", proto)
+			i += 2
+
+			continue
+		}
+
+		converted = append(converted, "ip6")
+	}
+
+	return converted
+}
+
 func protoToRuleSpec(ruleSpec *RuleSpec, proto packetfilter.RuleProto, dPort string) {
 	switch proto {
 	case packetfilter.RuleProtoUDP:
@@ -349,6 +400,10 @@ func ToRuleSpec(rule *packetfilter.Rule) RuleSpec {
 
 	setToRuleSpec(&ruleSpec, rule.SrcSetName, rule.DestSetName)
 
+	if rule.CtState == packetfilter.ConnTrackStateEstablished {
+		ruleSpec = append(ruleSpec, "ct", "state", ctStateEstablished)
+	}
+
 	if rule.OutInterface != "" {
 		ruleSpec = append(ruleSpec, "oifname", rule.OutInterface)
 	}
@@ -420,6 +475,14 @@ func FromRuleSpec(spec RuleSpec) *packetfilter.Rule {
 			}
 		case "mark":
 			rule.MarkValue, i = parseMark(spec, i)
+		case "ct":
+			if i+2 < len(spec) && spec[i+1] == "state" {
+				if spec[i+2] == ctStateEstablished {
+					rule.CtState = packetfilter.ConnTrackStateEstablished
+				}
+
+				i += 2
+			}
 		}
 
 		i++
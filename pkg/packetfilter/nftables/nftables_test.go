@@ -97,6 +97,19 @@ var _ = Describe("Rule conversion", func() {
 			TargetChain: "target-chain",
 			Action:      packetfilter.RuleActionJump,
 		})
+
+		// ct state established,related counter accept
+		testRuleConversion(&packetfilter.Rule{
+			CtState: packetfilter.ConnTrackStateEstablished,
+			Action:  packetfilter.RuleActionAccept,
+		})
+
+		// ip saddr @src-set ip daddr @dest-set counter drop
+		testRuleConversion(&packetfilter.Rule{
+			SrcSetName:  "src-set",
+			DestSetName: "dest-set",
+			Action:      packetfilter.RuleActionDrop,
+		})
 	})
 })
 
@@ -365,6 +378,42 @@ var _ = Describe("Interface", func() {
 		Expect(err).To(Succeed())
 		assertEntries(set)
 	})
+
+	When("the driver is created for IPv6", func() {
+		var fakeV6 *knftables.Fake
+
+		BeforeEach(func() {
+			fakeV6 = knftables.NewFake(knftables.IPv6Family, "submariner")
+			pf = nftables.NewWithNftFamily(fakeV6, knftables.IPv6Family)
+		})
+
+		It("should program IPv6 matches and parse the rules back", func() {
+			err := pf.CreateChainIfNotExists(packetfilter.TableTypeNAT, &packetfilter.Chain{
+				Name: chainName,
+			})
+			Expect(err).To(Succeed())
+
+			rule := &packetfilter.Rule{
+				Proto:    packetfilter.RuleProtoICMP,
+				SrcCIDR:  "fd00:1::/64",
+				DestCIDR: "fd00:2::/64",
+				Action:   packetfilter.RuleActionAccept,
+			}
+
+			Expect(pf.Append(packetfilter.TableTypeNAT, chainName, rule)).To(Succeed())
+
+			Expect(fakeV6.Dump()).To(ContainSubstring("meta l4proto ipv6-icmp ip6 saddr fd00:1::/64 ip6 daddr fd00:2::/64"))
+
+			rules, err := pf.List(packetfilter.TableTypeNAT, chainName)
+			Expect(err).To(Succeed())
+			Expect(rules).To(Equal([]*packetfilter.Rule{rule}))
+		})
+
+		It("should create IPv6 address sets", func() {
+			Expect(pf.NewNamedSet(setInfo).Create(true)).To(Succeed())
+			Expect(fakeV6.Dump()).To(ContainSubstring("type ipv6_addr"))
+		})
+	})
 })
 
 func testRuleConversion(rule *packetfilter.Rule) {
@@ -60,6 +60,7 @@ const (
 	RuleActionMark
 	RuleActionSNAT
 	RuleActionDNAT
+	RuleActionDrop
 )
 
 func (r RuleAction) String() string {
@@ -76,6 +77,8 @@ func (r RuleAction) String() string {
 		return "SNAT"
 	case RuleActionDNAT:
 		return "DNAT"
+	case RuleActionDrop:
+		return "Drop"
 	}
 
 	return unknown
@@ -108,6 +111,25 @@ func (r RuleProto) String() string {
 	return unknown
 }
 
+type ConnTrackState uint32
+
+const (
+	ConnTrackStateUndefined ConnTrackState = iota
+	// ConnTrackStateEstablished matches the packets of established connections and the related ones.
+	ConnTrackStateEstablished
+)
+
+func (c ConnTrackState) String() string {
+	switch c {
+	case ConnTrackStateUndefined:
+		return "Undefined"
+	case ConnTrackStateEstablished:
+		return "Established"
+	}
+
+	return unknown
+}
+
 type MssClampType uint32
 
 const (
@@ -233,6 +255,7 @@ type Rule struct {
 	Action    RuleAction
 	Proto     RuleProto
 	ClampType MssClampType
+	CtState   ConnTrackState
 }
 
 // Supported policy values are accept (which is the default) or drop.
@@ -357,6 +380,11 @@ func (r *Rule) String() string {
 		b.WriteString(r.Proto.String())
 	}
 
+	if r.CtState != ConnTrackStateUndefined {
+		b.WriteString(", CtState: ")
+		b.WriteString(r.CtState.String())
+	}
+
 	if r.ClampType != UndefinedMSS {
 		b.WriteString(", ClampType: ")
 		b.WriteString(r.ClampType.String())
@@ -30,6 +30,7 @@ import (
 type Pinger struct {
 	ip          string
 	latencyInfo atomic.Value
+	onRTT       atomic.Value
 	start       chan struct{}
 	stop        chan struct{}
 }
@@ -68,6 +69,17 @@ func (p *Pinger) SetLatencyInfo(info *pinger.LatencyInfo) {
 	p.latencyInfo.Store(*info)
 }
 
+// RecordRTT reports the RTT of a received ping reply to the configured OnRTT callback, if any.
+func (p *Pinger) RecordRTT(rtt time.Duration) {
+	if onRTT, ok := p.onRTT.Load().(func(time.Duration)); ok && onRTT != nil {
+		onRTT(rtt)
+	}
+}
+
+func (p *Pinger) SetOnRTT(onRTT func(time.Duration)) {
+	p.onRTT.Store(onRTT)
+}
+
 func (p *Pinger) GetIP() string {
 	return p.ip
 }
@@ -79,6 +79,8 @@ type Config struct {
 	Interval           time.Duration
 	Timeout            time.Duration
 	MaxPacketLossCount int
+	// OnRTT, if set, is called with the RTT of each received ping reply.
+	OnRTT func(rtt time.Duration)
 }
 
 type pingerImpl struct {
@@ -88,6 +90,7 @@ type pingerImpl struct {
 	pingTimeout        time.Duration
 	maxPacketLossCount int
 	statistics         statistics
+	onRTT              func(rtt time.Duration)
 	failureMsg         string
 	connectionStatus   ConnectionStatus
 	stopCh             chan struct{}
@@ -99,6 +102,7 @@ func NewPinger(config Config) Interface {
 		pingInterval:       config.Interval,
 		pingTimeout:        config.Timeout,
 		maxPacketLossCount: config.MaxPacketLossCount,
+		onRTT:              config.OnRTT,
 		statistics: statistics{
 			size:         size,
 			previousRtts: make([]int64, size),
@@ -189,19 +193,14 @@ func (p *pingerImpl) doPing() error {
 	}
 
 	pinger.OnRecv = func(packet *probing.Packet) {
-		p.Lock()
-		defer p.Unlock()
-
-		if p.connectionStatus != Connected {
-			logger.Infof("Ping to remote endpoint IP %q is successful", p.ip)
-		}
-
-		p.connectionStatus = Connected
-		p.failureMsg = ""
-		p.statistics.update(packet.Rtt.Nanoseconds())
+		p.recordReply(packet.Rtt)
 
 		pinger.PacketsSent = 0
 		pinger.PacketsRecv = 0
+
+		if p.onRTT != nil {
+			p.onRTT(packet.Rtt)
+		}
 	}
 
 	err = pinger.Run()
@@ -215,6 +214,19 @@ func (p *pingerImpl) doPing() error {
 	return nil
 }
 
+func (p *pingerImpl) recordReply(rtt time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.connectionStatus != Connected {
+		logger.Infof("Ping to remote endpoint IP %q is successful", p.ip)
+	}
+
+	p.connectionStatus = Connected
+	p.failureMsg = ""
+	p.statistics.update(rtt.Nanoseconds())
+}
+
 func (p *pingerImpl) GetIP() string {
 	return p.ip
 }
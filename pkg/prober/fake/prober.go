@@ -0,0 +1,95 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner/pkg/prober"
+)
+
+type Prober struct {
+	ip       string
+	result   atomic.Value
+	onResult atomic.Value
+	start    chan struct{}
+	stop     chan struct{}
+}
+
+func NewProber(ip string) *Prober {
+	return &Prober{
+		ip:    ip,
+		start: make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+func (p *Prober) Start() {
+	defer GinkgoRecover()
+	Expect(p.start).ToNot(BeClosed())
+	close(p.start)
+}
+
+func (p *Prober) Stop() {
+	defer GinkgoRecover()
+	Expect(p.stop).ToNot(BeClosed())
+	close(p.stop)
+}
+
+func (p *Prober) GetResult() *prober.Result {
+	o := p.result.Load()
+	if o != nil {
+		result := o.(prober.Result)
+		return &result
+	}
+
+	return nil
+}
+
+// SetResult sets the result and reports it to the configured OnResult callback, if any.
+func (p *Prober) SetResult(result *prober.Result) {
+	p.result.Store(*result)
+
+	if onResult, ok := p.onResult.Load().(func(*prober.Result)); ok && onResult != nil {
+		onResult(result)
+	}
+}
+
+func (p *Prober) SetOnResult(onResult func(*prober.Result)) {
+	p.onResult.Store(onResult)
+}
+
+func (p *Prober) GetIP() string {
+	return p.ip
+}
+
+func (p *Prober) AwaitStart() {
+	Eventually(p.start, 5).Should(BeClosed(), "Start was not called")
+}
+
+func (p *Prober) AwaitNoStart() {
+	Consistently(p.start, 500*time.Millisecond).ShouldNot(BeClosed(), "Start was unexpectedly called")
+}
+
+func (p *Prober) AwaitStop() {
+	Eventually(p.stop, 5).Should(BeClosed(), "Stop was not called")
+}
@@ -0,0 +1,363 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prober
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	DefaultPort = 4801
+
+	maxPacketSize = 9000
+
+	// The jitter is smoothed as specified in RFC 3550.
+	jitterSmoothing = 16
+)
+
+var (
+	defaultInterval           = 100 * time.Millisecond
+	defaultReportInterval     = 10 * time.Second
+	defaultTimeout            = time.Second
+	defaultThroughputInterval = time.Minute
+	defaultBurstCount         = 100
+	defaultBurstPacketSize    = 1200
+
+	logger = log.Logger{Logger: logf.Log.WithName("Prober")}
+)
+
+type Interface interface {
+	Start()
+	Stop()
+	GetResult() *Result
+	GetIP() string
+}
+
+type Config struct {
+	IP   string
+	Port int
+	// Interval is the interval between the probes measuring the loss and jitter.
+	Interval time.Duration
+	// ReportInterval is the interval over which the loss is computed and after which a Result is reported.
+	ReportInterval time.Duration
+	// Timeout is the time after which a probe without reply is considered lost.
+	Timeout time.Duration
+	// ThroughputInterval is the interval between the bursts sampling the throughput.
+	ThroughputInterval time.Duration
+	BurstCount         int
+	BurstPacketSize    int
+	// OnResult, if set, is called with each new Result.
+	OnResult func(result *Result)
+}
+
+type Result struct {
+	IP string
+	// PacketLoss is the ratio of the probes lost during the last report interval.
+	PacketLoss float64
+	Jitter     time.Duration
+	// Throughput is the last throughput sample in bytes per second, 0 if none was sampled yet.
+	Throughput float64
+	// ThroughputUpdated is true if the throughput was sampled during the last report interval.
+	ThroughputUpdated bool
+}
+
+type prober struct {
+	sync.Mutex
+	config   Config
+	conn     *net.UDPConn
+	stopCh   chan struct{}
+	sequence uint32
+	pending  map[uint32]time.Time
+	received int
+	lost     int
+	lastRTT  time.Duration
+	jitter   float64
+	burstID  uint32
+	burst    *packet
+	result   *Result
+}
+
+func NewProber(config Config) Interface {
+	p := &prober{
+		config:  config,
+		stopCh:  make(chan struct{}),
+		pending: map[uint32]time.Time{},
+	}
+
+	setDefault(&p.config.Interval, defaultInterval)
+	setDefault(&p.config.ReportInterval, defaultReportInterval)
+	setDefault(&p.config.Timeout, defaultTimeout)
+	setDefault(&p.config.ThroughputInterval, defaultThroughputInterval)
+	setDefault(&p.config.BurstCount, defaultBurstCount)
+	setDefault(&p.config.BurstPacketSize, defaultBurstPacketSize)
+
+	p.config.BurstPacketSize = min(max(p.config.BurstPacketSize, headerSize), maxPacketSize)
+
+	return p
+}
+
+func setDefault[T time.Duration | int](value *T, defaultValue T) {
+	if *value == 0 {
+		*value = defaultValue
+	}
+}
+
+func (p *prober) GetIP() string {
+	return p.config.IP
+}
+
+func (p *prober) GetResult() *Result {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.result == nil {
+		return nil
+	}
+
+	result := *p.result
+
+	return &result
+}
+
+func (p *prober) Start() {
+	addr := net.JoinHostPort(p.config.IP, strconv.Itoa(p.config.Port))
+
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err == nil {
+		p.conn, err = net.DialUDP("udp", nil, udpAddr)
+	}
+
+	if err != nil {
+		logger.Errorf(err, "Error creating the UDP socket to probe %q", addr)
+		return
+	}
+
+	go p.receive()
+	go p.run()
+
+	logger.Infof("Probing %q every %v", addr, p.config.Interval)
+}
+
+func (p *prober) Stop() {
+	close(p.stopCh)
+
+	if p.conn != nil {
+		p.conn.Close()
+	}
+
+	logger.Infof("Stopped probing %q", p.config.IP)
+}
+
+func (p *prober) run() {
+	probeTicker := time.NewTicker(p.config.Interval)
+	defer probeTicker.Stop()
+
+	reportTicker := time.NewTicker(p.config.ReportInterval)
+	defer reportTicker.Stop()
+
+	throughputTicker := time.NewTicker(p.config.ThroughputInterval)
+	defer throughputTicker.Stop()
+
+	p.sendBurst()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-probeTicker.C:
+			p.sendProbe()
+		case <-throughputTicker.C:
+			p.sendBurst()
+		case <-reportTicker.C:
+			p.report()
+		}
+	}
+}
+
+func (p *prober) sendProbe() {
+	p.Lock()
+	p.sequence++
+	request := &packet{packetType: probeRequest, sequence: p.sequence, timestamp: time.Now().UnixNano()}
+	p.pending[request.sequence] = time.Now()
+	p.Unlock()
+
+	p.send(request, headerSize)
+}
+
+func (p *prober) sendBurst() {
+	p.Lock()
+	p.burstID++
+	p.burst = nil
+	request := &packet{packetType: burstRequest, sequence: p.burstID}
+	p.Unlock()
+
+	for range p.config.BurstCount {
+		request.timestamp = time.Now().UnixNano()
+		if !p.send(request, p.config.BurstPacketSize) {
+			return
+		}
+	}
+}
+
+func (p *prober) send(request *packet, size int) bool {
+	_, err := p.conn.Write(request.marshal(size))
+	if errors.Is(err, net.ErrClosed) {
+		return false
+	}
+
+	if err != nil {
+		logger.V(log.TRACE).Infof("Error sending a probe to %q: %v", p.config.IP, err)
+	}
+
+	return true
+}
+
+func (p *prober) receive() {
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, err := p.conn.Read(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+
+		if err != nil {
+			// Typically the ICMP port unreachable errors while the remote responder isn't running.
+			logger.V(log.TRACE).Infof("Error receiving a probe reply from %q: %v", p.config.IP, err)
+			continue
+		}
+
+		reply, err := unmarshal(buf[:n])
+		if err != nil {
+			logger.V(log.DEBUG).Infof("Ignoring a packet from %q: %v", p.config.IP, err)
+			continue
+		}
+
+		p.handleReply(reply, time.Now())
+	}
+}
+
+func (p *prober) handleReply(reply *packet, now time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	switch reply.packetType {
+	case probeReply:
+		sent, ok := p.pending[reply.sequence]
+		if !ok {
+			// A duplicate, or a reply received after the timeout.
+			return
+		}
+
+		delete(p.pending, reply.sequence)
+		p.received++
+
+		rtt := now.Sub(sent)
+		if p.lastRTT != 0 {
+			p.jitter += (math.Abs(float64(rtt-p.lastRTT)) - p.jitter) / jitterSmoothing
+		}
+
+		p.lastRTT = rtt
+	case burstReply:
+		if reply.sequence == p.burstID && (p.burst == nil || reply.burstBytes > p.burst.burstBytes) {
+			p.burst = reply
+		}
+	case probeRequest, burstRequest:
+	}
+}
+
+func (p *prober) report() {
+	p.Lock()
+
+	deadline := time.Now().Add(-p.config.Timeout)
+
+	for sequence, sent := range p.pending {
+		if sent.Before(deadline) {
+			delete(p.pending, sequence)
+			p.lost++
+		}
+	}
+
+	if p.received+p.lost == 0 {
+		p.Unlock()
+		return
+	}
+
+	result := &Result{
+		IP:         p.config.IP,
+		PacketLoss: float64(p.lost) / float64(p.received+p.lost),
+		Jitter:     time.Duration(p.jitter),
+	}
+
+	if p.result != nil {
+		result.Throughput = p.result.Throughput
+	}
+
+	if p.burst != nil && p.burst.burstElapsed > 0 {
+		result.Throughput = float64(p.burst.burstBytes) / time.Duration(p.burst.burstElapsed).Seconds()
+		result.ThroughputUpdated = true
+		p.burst = nil
+	}
+
+	p.received = 0
+	p.lost = 0
+	p.result = result
+
+	p.Unlock()
+
+	if p.config.OnResult != nil {
+		p.config.OnResult(result)
+	}
+}
+
+// ToSpec returns the connection quality status corresponding to the result.
+func (r *Result) ToSpec() *submarinerv1.ConnectionQualitySpec {
+	spec := &submarinerv1.ConnectionQualitySpec{
+		PacketLoss: fmt.Sprintf("%.2f%%", r.PacketLoss*100),
+		Jitter:     r.Jitter.String(),
+	}
+
+	if r.Throughput > 0 {
+		spec.Throughput = formatBitRate(r.Throughput * 8)
+	}
+
+	return spec
+}
+
+func formatBitRate(bitsPerSecond float64) string {
+	units := []string{"bit/s", "kbit/s", "Mbit/s", "Gbit/s"}
+
+	unit := 0
+	for bitsPerSecond >= 1000 && unit < len(units)-1 {
+		bitsPerSecond /= 1000
+		unit++
+	}
+
+	return fmt.Sprintf("%.2f %s", bitsPerSecond, units[unit])
+}
@@ -0,0 +1,148 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prober_test
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/prober"
+)
+
+var _ = Describe("Prober", func() {
+	var (
+		config  prober.Config
+		results chan *prober.Result
+	)
+
+	BeforeEach(func() {
+		results = make(chan *prober.Result, 100)
+		config = prober.Config{
+			IP:                 "127.0.0.1",
+			Interval:           10 * time.Millisecond,
+			ReportInterval:     300 * time.Millisecond,
+			Timeout:            100 * time.Millisecond,
+			ThroughputInterval: time.Hour,
+			BurstCount:         20,
+			OnResult: func(result *prober.Result) {
+				results <- result
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		p := prober.NewProber(config)
+		p.Start()
+		DeferCleanup(p.Stop)
+	})
+
+	When("the remote responder is running", func() {
+		BeforeEach(func() {
+			stopCh := make(chan struct{})
+			DeferCleanup(func() {
+				close(stopCh)
+			})
+
+			responder := prober.NewResponder(0, config.IP)
+			Expect(responder.Run(stopCh)).To(Succeed())
+
+			config.Port = responder.Port()
+		})
+
+		It("should report no loss and a throughput sample", func() {
+			var result *prober.Result
+			Eventually(results, 5).Should(Receive(&result))
+
+			Expect(result.IP).To(Equal(config.IP))
+			Expect(result.PacketLoss).To(BeNumerically("<", 0.5))
+			Expect(result.ThroughputUpdated).To(BeTrue())
+			Expect(result.Throughput).To(BeNumerically(">", 0))
+
+			Eventually(results, 5).Should(Receive(&result))
+			Expect(result.ThroughputUpdated).To(BeFalse())
+			Expect(result.Throughput).To(BeNumerically(">", 0))
+		})
+	})
+
+	When("the remote responder is running on IPv6", func() {
+		BeforeEach(func() {
+			config.IP = "::1"
+
+			stopCh := make(chan struct{})
+			DeferCleanup(func() {
+				close(stopCh)
+			})
+
+			responder := prober.NewResponder(0, config.IP)
+			Expect(responder.Run(stopCh)).To(Succeed())
+
+			config.Port = responder.Port()
+		})
+
+		It("should report no loss", func() {
+			var result *prober.Result
+			Eventually(results, 5).Should(Receive(&result))
+
+			Expect(result.IP).To(Equal(config.IP))
+			Expect(result.PacketLoss).To(BeNumerically("<", 0.5))
+		})
+	})
+
+	When("the remote responder isn't running", func() {
+		BeforeEach(func() {
+			conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.ParseIP(config.IP)})
+			Expect(err).To(Succeed())
+
+			DeferCleanup(conn.Close)
+
+			// Nothing replies on this port.
+			config.Port = conn.LocalAddr().(*net.UDPAddr).Port
+		})
+
+		It("should report all the probes as lost", func() {
+			var result *prober.Result
+			Eventually(results, 5).Should(Receive(&result))
+
+			Expect(result.PacketLoss).To(Equal(1.0))
+			Expect(result.ThroughputUpdated).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("Result", func() {
+	It("should convert to the connection quality status", func() {
+		result := &prober.Result{
+			PacketLoss: 0.0125,
+			Jitter:     1500 * time.Microsecond,
+			Throughput: 12500000,
+		}
+
+		Expect(result.ToSpec()).To(Equal(&submarinerv1.ConnectionQualitySpec{
+			PacketLoss: "1.25%",
+			Jitter:     "1.5ms",
+			Throughput: "100.00 Mbit/s",
+		}))
+
+		result.Throughput = 0
+		Expect(result.ToSpec().Throughput).To(BeEmpty())
+	})
+})
@@ -0,0 +1,106 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prober
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// The probe protocol is a simple UDP request/reply protocol. The prober sends probe packets which the responder echoes
+// to measure the loss and jitter, and periodically sends a burst of larger packets whose reception the responder
+// reports to measure the throughput.
+//
+// All the packets start with the following header, in network byte order:
+//
+//	magic        [4]byte  "SMPB"
+//	version      uint8
+//	type         uint8
+//	reserved     [2]byte
+//	sequence     uint32   the probe sequence number or the burst ID
+//	timestamp    int64    the sender's timestamp in nanoseconds, echoed by the responder
+//	burstBytes   uint64   the number of bytes received in the burst after its first packet, in burst replies
+//	burstElapsed int64    the nanoseconds elapsed between the first and last packets of the burst, in burst replies
+//
+// Burst packets are padded to the configured burst packet size.
+
+const (
+	protocolVersion = 1
+	headerSize      = 36
+)
+
+var protocolMagic = [4]byte{'S', 'M', 'P', 'B'}
+
+type packetType uint8
+
+const (
+	probeRequest packetType = iota + 1
+	probeReply
+	burstRequest
+	burstReply
+)
+
+type packet struct {
+	packetType   packetType
+	sequence     uint32
+	timestamp    int64
+	burstBytes   uint64
+	burstElapsed int64
+}
+
+var errInvalidPacket = errors.New("invalid probe packet")
+
+func (p *packet) marshal(size int) []byte {
+	b := make([]byte, max(size, headerSize))
+
+	copy(b[0:4], protocolMagic[:])
+	b[4] = protocolVersion
+	b[5] = byte(p.packetType)
+	binary.BigEndian.PutUint32(b[8:12], p.sequence)
+	binary.BigEndian.PutUint64(b[12:20], uint64(p.timestamp)) //nolint:gosec // Timestamps are positive
+	binary.BigEndian.PutUint64(b[20:28], p.burstBytes)
+	binary.BigEndian.PutUint64(b[28:36], uint64(p.burstElapsed)) //nolint:gosec // Durations are positive
+
+	return b
+}
+
+func unmarshal(b []byte) (*packet, error) {
+	if len(b) < headerSize || [4]byte(b[0:4]) != protocolMagic {
+		return nil, errInvalidPacket
+	}
+
+	if b[4] != protocolVersion {
+		return nil, errors.Wrapf(errInvalidPacket, "unsupported version %d", b[4])
+	}
+
+	p := &packet{
+		packetType:   packetType(b[5]),
+		sequence:     binary.BigEndian.Uint32(b[8:12]),
+		timestamp:    int64(binary.BigEndian.Uint64(b[12:20])), //nolint:gosec // Timestamps are positive
+		burstBytes:   binary.BigEndian.Uint64(b[20:28]),
+		burstElapsed: int64(binary.BigEndian.Uint64(b[28:36])), //nolint:gosec // Durations are positive
+	}
+
+	if p.packetType < probeRequest || p.packetType > burstReply {
+		return nil, errors.Wrapf(errInvalidPacket, "unknown type %d", p.packetType)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,201 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prober
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+)
+
+const (
+	// The bursts are keyed by the unauthenticated source address, so their number is bounded and the stale ones expire.
+	maxTrackedBursts = 256
+	burstExpiry      = 30 * time.Second
+)
+
+// Responder answers the probes sent by the probers of the remote gateways.
+type Responder struct {
+	port   int
+	ips    []string
+	conns  []*net.UDPConn
+	mutex  sync.Mutex
+	bursts map[string]*burstState
+}
+
+type burstState struct {
+	id       uint32
+	first    time.Time
+	last     time.Time
+	received uint64
+}
+
+// NewResponder creates a responder listening on the given port of each of the given local IPs, typically the local
+// endpoint's health check IPs.
+func NewResponder(port int, ips ...string) *Responder {
+	return &Responder{
+		port:   port,
+		ips:    ips,
+		bursts: map[string]*burstState{},
+	}
+}
+
+// Run starts listening for probes and answering them until the stop channel is closed.
+func (r *Responder) Run(stopCh <-chan struct{}) error {
+	if len(r.ips) == 0 {
+		return errors.New("no IP to listen on for probes")
+	}
+
+	for _, ip := range r.ips {
+		addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(ip, strconv.Itoa(r.port)))
+		if err == nil {
+			var conn *net.UDPConn
+
+			conn, err = net.ListenUDP("udp", addr)
+			if err == nil {
+				r.conns = append(r.conns, conn)
+				continue
+			}
+		}
+
+		r.close()
+
+		return errors.Wrapf(err, "error listening on %s UDP port %d for probes", ip, r.port)
+	}
+
+	go func() {
+		<-stopCh
+		r.close()
+	}()
+
+	for _, conn := range r.conns {
+		go r.serve(conn)
+
+		logger.Infof("Probe responder listening on %s", conn.LocalAddr())
+	}
+
+	return nil
+}
+
+func (r *Responder) close() {
+	for _, conn := range r.conns {
+		conn.Close()
+	}
+}
+
+// Port returns the port the responder listens on, which is only assigned once it's running if it was configured as 0.
+func (r *Responder) Port() int {
+	return r.conns[0].LocalAddr().(*net.UDPAddr).Port
+}
+
+func (r *Responder) serve(conn *net.UDPConn) {
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+
+		if err != nil {
+			logger.Errorf(err, "Error reading a probe")
+			continue
+		}
+
+		request, err := unmarshal(buf[:n])
+		if err != nil {
+			logger.V(log.DEBUG).Infof("Ignoring a packet from %s: %v", addr, err)
+			continue
+		}
+
+		reply := r.handle(request, addr.String(), n)
+		if reply == nil {
+			continue
+		}
+
+		if _, err := conn.WriteToUDP(reply.marshal(headerSize), addr); err != nil {
+			logger.V(log.DEBUG).Infof("Error replying to a probe from %s: %v", addr, err)
+		}
+	}
+}
+
+func (r *Responder) handle(request *packet, from string, size int) *packet {
+	switch request.packetType {
+	case probeRequest:
+		return &packet{
+			packetType: probeReply,
+			sequence:   request.sequence,
+			timestamp:  request.timestamp,
+		}
+	case burstRequest:
+		burst := r.trackBurst(request.sequence, from, size)
+
+		return &packet{
+			packetType:   burstReply,
+			sequence:     request.sequence,
+			timestamp:    request.timestamp,
+			burstBytes:   burst.received,
+			burstElapsed: burst.last.Sub(burst.first).Nanoseconds(),
+		}
+	case probeReply, burstReply:
+	}
+
+	return nil
+}
+
+// trackBurst accounts for a burst packet and returns the burst's state. Only the latest burst from each prober is
+// tracked; once the maximum number of bursts is tracked, the bursts from new probers aren't accounted for.
+func (r *Responder) trackBurst(id uint32, from string, size int) burstState {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+
+	burst := r.bursts[from]
+	if burst == nil && len(r.bursts) >= maxTrackedBursts {
+		r.expireBursts(now)
+
+		if len(r.bursts) >= maxTrackedBursts {
+			return burstState{id: id, first: now, last: now}
+		}
+	}
+
+	if burst == nil || burst.id != id {
+		burst = &burstState{id: id, first: now}
+		r.bursts[from] = burst
+	} else {
+		burst.received += uint64(size) //nolint:gosec // The size is positive
+	}
+
+	burst.last = now
+
+	return *burst
+}
+
+func (r *Responder) expireBursts(now time.Time) {
+	for from, burst := range r.bursts {
+		if now.Sub(burst.last) > burstExpiry {
+			delete(r.bursts, from)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prober
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Responder bursts", func() {
+	var responder *Responder
+
+	BeforeEach(func() {
+		responder = NewResponder(0, "127.0.0.1")
+	})
+
+	burst := func(id uint32, from string) *packet {
+		return responder.handle(&packet{packetType: burstRequest, sequence: id}, from, 100)
+	}
+
+	It("should account for the packets of the latest burst from each prober", func() {
+		burst(1, "10.0.0.1:1000")
+		Expect(burst(1, "10.0.0.1:1000").burstBytes).To(Equal(uint64(100)))
+
+		Expect(burst(2, "10.0.0.1:1000").burstBytes).To(BeZero())
+		Expect(burst(2, "10.0.0.1:1000").burstBytes).To(Equal(uint64(100)))
+	})
+
+	It("should bound the number of tracked bursts", func() {
+		for i := range maxTrackedBursts + 10 {
+			burst(1, fmt.Sprintf("10.0.0.1:%d", i))
+		}
+
+		Expect(responder.bursts).To(HaveLen(maxTrackedBursts))
+
+		from := fmt.Sprintf("10.0.0.1:%d", maxTrackedBursts+1)
+		burst(1, from)
+		Expect(burst(1, from).burstBytes).To(BeZero())
+	})
+
+	It("should expire the stale bursts to track new ones", func() {
+		for i := range maxTrackedBursts {
+			burst(1, fmt.Sprintf("10.0.0.1:%d", i))
+		}
+
+		for _, state := range responder.bursts {
+			state.last = state.last.Add(-burstExpiry - time.Second)
+		}
+
+		burst(1, "10.0.0.2:1000")
+		Expect(burst(1, "10.0.0.2:1000").burstBytes).To(Equal(uint64(100)))
+		Expect(responder.bursts).To(HaveLen(1))
+	})
+})
@@ -47,5 +47,10 @@ func (h *vxlanCleanup) GetName() string {
 func (h *vxlanCleanup) TransitionToNonGateway() error {
 	logger.Infof("Cleaning up the routes")
 
-	return netlink.DeleteIfaceAndAssociatedRoutes(vxlan.VxlanIface, vxlan.TableID) //nolint:wrapcheck  // No need to wrap this error
+	err := netlink.DeleteIfaceAndAssociatedRoutes(vxlan.VxlanIface, vxlan.TableID)
+	if err != nil {
+		return err //nolint:wrapcheck  // No need to wrap this error
+	}
+
+	return netlink.DeleteIfaceAndAssociatedRoutes(vxlan.VxlanIfaceV6, vxlan.TableID) //nolint:wrapcheck  // No need to wrap this error
 }
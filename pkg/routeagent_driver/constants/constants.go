@@ -30,6 +30,17 @@ const (
 	RemoteCIDRIPSet    = "SUBMARINER-REMOTECIDRS"
 	LocalCIDRIPSet     = "SUBMARINER-LOCALCIDRS"
 
+	// IPTable chains and sets used to enforce the ConnectivityPolicies.
+	SmConnectivityPolicyChain       = "SUBMARINER-CONNPOLICY"
+	SmConnectivityPolicyEgressChain = "SUBMARINER-CONNPOLICY-EGRESS"
+	SmConnectivityPolicyAllowChain  = "SUBMARINER-CONNPOLICY-ALLOW"
+	ConnectivityPolicyPodIPSet      = "SUBMARINER-CONNPOLICY-PODS"
+	ConnectivityPolicyRemoteIPSet   = "SUBMARINER-CONNPOLICY-REMOTE"
+	ConnectivityPolicyIPSetPrefix   = "SM-CP-"
+	ConnectivityPolicyPodIPSetV6    = "SUBMARINER-CONNPOLICY-PODS-V6"
+	ConnectivityPolicyRemoteIPSetV6 = "SUBMARINER-CONNPOLICY-REMOTE-V6"
+	ConnectivityPolicyIPSetPrefixV6 = "SM-CP6-"
+
 	RouteAgentInterClusterNetworkTableID = 149
 
 	// To support connectivity for Pods with HostNetworking on the GatewayNode, we program
@@ -26,4 +26,6 @@ type Specification struct {
 	GlobalCidr  []string
 	ProfilePort int `default:"32782"`
 	Uninstall   bool
+	// PacketFilterDriver is one of "iptables", "nftables" or "auto" (the default).
+	PacketFilterDriver string
 }
@@ -0,0 +1,428 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connectivitypolicy enforces the ConnectivityPolicies on the gateway node, through which all the traffic to the
+// remote clusters goes. The traffic from the pods selected by any policy to the remote clusters' subnets is jumped to
+// the egress chain, which accepts the established connections and the traffic allowed by the policies' egress rules, and
+// drops the rest. The IPv6 traffic is enforced the same way, through the IPv6 packet filter and its own named sets, when
+// the local cluster has IPv6 pod CIDRs.
+package connectivitypolicy
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	"github.com/submariner-io/admiral/pkg/watcher"
+	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cidr"
+	"github.com/submariner-io/submariner/pkg/event"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	"github.com/submariner-io/submariner/pkg/routeagent_driver/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8snet "k8s.io/utils/net"
+	"k8s.io/utils/set"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type handler struct {
+	event.HandlerBase
+	watcherConfig watcher.Config
+	ipv6          bool
+	filters       []*familyFilter
+	mutex         sync.Mutex
+	// Closed to stop the policy and pod watchers, which only run while this node is the gateway. Nil when not running.
+	stopCh   chan struct{}
+	policies map[string]*submV1.ConnectivityPolicy
+	pods     map[string]*corev1.Pod
+}
+
+// familyFilter enforces the policies on the traffic of an IP family, through the family's packet filter. The IPv6 named
+// sets have their own names since the iptables driver's ipsets are shared by both families.
+type familyFilter struct {
+	family      k8snet.IPFamily
+	pFilter     packetfilter.Interface
+	podIPSet    string
+	remoteIPSet string
+	setPrefix   string
+	// The programmed named sets and their entries.
+	sets map[string]set.Set[string]
+}
+
+var logger = log.Logger{Logger: logf.Log.WithName("ConnectivityPolicy")}
+
+func NewHandler(watcherConfig *watcher.Config, localClusterCIDRs []string) event.Handler {
+	return &handler{
+		watcherConfig: *watcherConfig,
+		ipv6:          len(cidr.ExtractIPv6Subnets(localClusterCIDRs)) > 0,
+		policies:      map[string]*submV1.ConnectivityPolicy{},
+		pods:          map[string]*corev1.Pod{},
+	}
+}
+
+func (h *handler) GetNetworkPlugins() []string {
+	return []string{event.AnyNetworkPlugin}
+}
+
+func (h *handler) GetName() string {
+	return "Connectivity policy handler"
+}
+
+func (h *handler) Init(_ context.Context) error {
+	pFilter, err := packetfilter.New()
+	if err != nil {
+		return errors.Wrap(err, "error initializing the packet filter")
+	}
+
+	h.filters = []*familyFilter{{
+		family:      k8snet.IPv4,
+		pFilter:     pFilter,
+		podIPSet:    constants.ConnectivityPolicyPodIPSet,
+		remoteIPSet: constants.ConnectivityPolicyRemoteIPSet,
+		setPrefix:   constants.ConnectivityPolicyIPSetPrefix,
+		sets:        map[string]set.Set[string]{},
+	}}
+
+	if h.ipv6 {
+		pFilter, err = packetfilter.NewV6()
+		if err != nil {
+			return errors.Wrap(err, "error initializing the IPv6 packet filter")
+		}
+
+		h.filters = append(h.filters, &familyFilter{
+			family:      k8snet.IPv6,
+			pFilter:     pFilter,
+			podIPSet:    constants.ConnectivityPolicyPodIPSetV6,
+			remoteIPSet: constants.ConnectivityPolicyRemoteIPSetV6,
+			setPrefix:   constants.ConnectivityPolicyIPSetPrefixV6,
+			sets:        map[string]set.Set[string]{},
+		})
+	}
+
+	for _, f := range h.filters {
+		if err := f.createPFilterChains(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startWatchers starts watching the policies and the pods in all the namespaces. It must be called with the lock held.
+func (h *handler) startWatchers() error {
+	if h.stopCh != nil {
+		return nil
+	}
+
+	stopCh := make(chan struct{})
+	config := h.watcherConfig
+
+	config.ResourceConfigs = []watcher.ResourceConfig{
+		{
+			Name:         "ConnectivityPolicy watcher",
+			ResourceType: &submV1.ConnectivityPolicy{},
+			Handler: watcher.EventHandlerFuncs{
+				OnCreateFunc: h.onEvent(stopCh, h.policyCreatedOrUpdated),
+				OnUpdateFunc: h.onEvent(stopCh, h.policyCreatedOrUpdated),
+				OnDeleteFunc: h.onEvent(stopCh, h.policyDeleted),
+			},
+			SourceNamespace: metav1.NamespaceAll,
+		},
+		{
+			Name:         "ConnectivityPolicy Pod watcher",
+			ResourceType: &corev1.Pod{},
+			Handler: watcher.EventHandlerFuncs{
+				OnCreateFunc: h.onEvent(stopCh, h.podCreatedOrUpdated),
+				OnUpdateFunc: h.onEvent(stopCh, h.podCreatedOrUpdated),
+				OnDeleteFunc: h.onEvent(stopCh, h.podDeleted),
+			},
+			ResourcesEquivalent: podsEquivalent,
+			SourceNamespace:     metav1.NamespaceAll,
+		},
+	}
+
+	policyWatcher, err := watcher.New(&config)
+	if err != nil {
+		return errors.Wrap(err, "error creating the ConnectivityPolicy watcher")
+	}
+
+	if err := policyWatcher.Start(stopCh); err != nil {
+		close(stopCh)
+		return errors.Wrap(err, "error starting the ConnectivityPolicy watcher")
+	}
+
+	h.stopCh = stopCh
+
+	return nil
+}
+
+// stopWatchers stops the watchers and forgets the observed policies and pods, which are re-listed once the watchers are
+// started again. It must be called with the lock held.
+func (h *handler) stopWatchers() {
+	if h.stopCh == nil {
+		return
+	}
+
+	close(h.stopCh)
+	h.stopCh = nil
+
+	h.policies = map[string]*submV1.ConnectivityPolicy{}
+	h.pods = map[string]*corev1.Pod{}
+}
+
+// onEvent returns a watcher event handler which processes the event with the lock held and syncs, unless the watchers
+// were stopped in the meantime.
+func (h *handler) onEvent(stopCh <-chan struct{}, process func(obj runtime.Object)) func(obj runtime.Object, _ int) bool {
+	return func(obj runtime.Object, _ int) bool {
+		h.mutex.Lock()
+		defer h.mutex.Unlock()
+
+		select {
+		case <-stopCh:
+			return false
+		default:
+		}
+
+		process(obj)
+
+		return h.syncAndRequeueOnError()
+	}
+}
+
+func (f *familyFilter) createPFilterChains() error {
+	if err := f.pFilter.CreateIPHookChainIfNotExists(&packetfilter.ChainIPHook{
+		Name:     constants.SmConnectivityPolicyChain,
+		Type:     packetfilter.ChainTypeFilter,
+		Hook:     packetfilter.ChainHookForward,
+		Priority: packetfilter.ChainPriorityFirst,
+	}); err != nil {
+		return errors.Wrapf(err, "error creating IPHook chain %q", constants.SmConnectivityPolicyChain)
+	}
+
+	for _, chain := range []string{constants.SmConnectivityPolicyEgressChain, constants.SmConnectivityPolicyAllowChain} {
+		if err := f.pFilter.CreateChainIfNotExists(packetfilter.TableTypeFilter, &packetfilter.Chain{Name: chain}); err != nil {
+			return errors.Wrapf(err, "error creating chain %q", chain)
+		}
+	}
+
+	// The rules and sets of the policies from a previous run are re-created on the next sync.
+	if err := f.pFilter.ClearChain(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain); err != nil {
+		return errors.Wrapf(err, "error flushing chain %q", constants.SmConnectivityPolicyAllowChain)
+	}
+
+	if err := f.pFilter.DestroySets(f.isPolicySet); err != nil {
+		return errors.Wrap(err, "error deleting the ConnectivityPolicy named sets")
+	}
+
+	for _, setName := range []string{f.podIPSet, f.remoteIPSet} {
+		namedSet := f.newNamedSet(setName)
+
+		if err := namedSet.Create(true); err != nil {
+			return errors.Wrapf(err, "error creating named set %q", setName)
+		}
+
+		if err := namedSet.Flush(); err != nil {
+			return errors.Wrapf(err, "error flushing named set %q", setName)
+		}
+
+		f.sets[setName] = set.New[string]()
+	}
+
+	rules := []struct {
+		chain string
+		rule  *packetfilter.Rule
+	}{
+		{constants.SmConnectivityPolicyChain, &packetfilter.Rule{
+			SrcSetName:  f.podIPSet,
+			DestSetName: f.remoteIPSet,
+			Action:      packetfilter.RuleActionJump,
+			TargetChain: constants.SmConnectivityPolicyEgressChain,
+		}},
+		{constants.SmConnectivityPolicyEgressChain, &packetfilter.Rule{
+			CtState: packetfilter.ConnTrackStateEstablished,
+			Action:  packetfilter.RuleActionAccept,
+		}},
+		{constants.SmConnectivityPolicyEgressChain, &packetfilter.Rule{
+			Action:      packetfilter.RuleActionJump,
+			TargetChain: constants.SmConnectivityPolicyAllowChain,
+		}},
+		{constants.SmConnectivityPolicyEgressChain, &packetfilter.Rule{
+			Action: packetfilter.RuleActionDrop,
+		}},
+	}
+
+	for i := range rules {
+		if err := f.pFilter.AppendUnique(packetfilter.TableTypeFilter, rules[i].chain, rules[i].rule); err != nil {
+			return errors.Wrapf(err, "error appending rule %q to chain %q", rules[i].rule, rules[i].chain)
+		}
+	}
+
+	return nil
+}
+
+func (h *handler) Stop() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.stopWatchers()
+
+	return nil
+}
+
+func (h *handler) TransitionToGateway() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := h.startWatchers(); err != nil {
+		return err
+	}
+
+	return h.sync()
+}
+
+func (h *handler) TransitionToNonGateway() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.stopWatchers()
+
+	return h.sync()
+}
+
+func (h *handler) RemoteEndpointCreated(_ *submV1.Endpoint) error {
+	return h.syncWithLock()
+}
+
+func (h *handler) RemoteEndpointUpdated(_ *submV1.Endpoint) error {
+	return h.syncWithLock()
+}
+
+func (h *handler) RemoteEndpointRemoved(_ *submV1.Endpoint) error {
+	return h.syncWithLock()
+}
+
+func (h *handler) policyCreatedOrUpdated(obj runtime.Object) {
+	policy := obj.(*submV1.ConnectivityPolicy)
+
+	logger.V(log.DEBUG).Infof("ConnectivityPolicy %s/%s created or updated", policy.Namespace, policy.Name)
+
+	h.policies[policy.Namespace+"/"+policy.Name] = policy
+}
+
+func (h *handler) policyDeleted(obj runtime.Object) {
+	policy := obj.(*submV1.ConnectivityPolicy)
+
+	logger.V(log.DEBUG).Infof("ConnectivityPolicy %s/%s deleted", policy.Namespace, policy.Name)
+
+	delete(h.policies, policy.Namespace+"/"+policy.Name)
+}
+
+func (h *handler) podCreatedOrUpdated(obj runtime.Object) {
+	pod := obj.(*corev1.Pod)
+	h.pods[pod.Namespace+"/"+pod.Name] = pod
+}
+
+func (h *handler) podDeleted(obj runtime.Object) {
+	pod := obj.(*corev1.Pod)
+	delete(h.pods, pod.Namespace+"/"+pod.Name)
+}
+
+func (h *handler) syncAndRequeueOnError() bool {
+	if err := h.sync(); err != nil {
+		logger.Errorf(err, "Error enforcing the ConnectivityPolicies")
+		return true
+	}
+
+	return false
+}
+
+func (h *handler) syncWithLock() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.sync()
+}
+
+func (f *familyFilter) newNamedSet(name string) packetfilter.NamedSet {
+	family := packetfilter.SetFamilyV4
+	if f.family == k8snet.IPv6 {
+		family = packetfilter.SetFamilyV6
+	}
+
+	return f.pFilter.NewNamedSet(&packetfilter.SetInfo{
+		Name:   name,
+		Family: family,
+	})
+}
+
+// podsEquivalent ignores the pod updates which don't change the policies' selection.
+func podsEquivalent(obj1, obj2 *unstructured.Unstructured) bool {
+	return reflect.DeepEqual(obj1.GetLabels(), obj2.GetLabels()) &&
+		reflect.DeepEqual(nestedField(obj1, "status", "podIPs"), nestedField(obj2, "status", "podIPs")) &&
+		reflect.DeepEqual(nestedField(obj1, "status", "phase"), nestedField(obj2, "status", "phase"))
+}
+
+func nestedField(obj *unstructured.Unstructured, fields ...string) interface{} {
+	value, _, _ := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	return value
+}
+
+func (h *handler) Uninstall() error {
+	logger.Info("Uninstalling the ConnectivityPolicy packetfilter chains and sets")
+
+	for _, f := range h.filters {
+		f.uninstall()
+	}
+
+	return nil
+}
+
+func (f *familyFilter) uninstall() {
+	for _, chain := range []string{
+		constants.SmConnectivityPolicyChain, constants.SmConnectivityPolicyEgressChain,
+		constants.SmConnectivityPolicyAllowChain,
+	} {
+		logError(f.pFilter.ClearChain(packetfilter.TableTypeFilter, chain), "Error flushing chain %q", chain)
+	}
+
+	logError(f.pFilter.DeleteIPHookChain(&packetfilter.ChainIPHook{
+		Name:     constants.SmConnectivityPolicyChain,
+		Type:     packetfilter.ChainTypeFilter,
+		Hook:     packetfilter.ChainHookForward,
+		Priority: packetfilter.ChainPriorityFirst,
+	}), "Error deleting IP hook chain %q", constants.SmConnectivityPolicyChain)
+
+	for _, chain := range []string{constants.SmConnectivityPolicyEgressChain, constants.SmConnectivityPolicyAllowChain} {
+		logError(f.pFilter.DeleteChain(packetfilter.TableTypeFilter, chain), "Error deleting chain %q", chain)
+	}
+
+	logError(f.pFilter.DestroySets(func(name string) bool {
+		return name == f.podIPSet || name == f.remoteIPSet || f.isPolicySet(name)
+	}), "Error deleting the ConnectivityPolicy named sets")
+}
+
+func logError(err error, format string, args ...interface{}) {
+	if err != nil {
+		logger.Errorf(err, format, args...)
+	}
+}
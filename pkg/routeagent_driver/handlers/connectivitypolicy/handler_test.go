@@ -0,0 +1,309 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectivitypolicy_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/syncer/test"
+	"github.com/submariner-io/admiral/pkg/watcher"
+	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/event"
+	eventtesting "github.com/submariner-io/submariner/pkg/event/testing"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	fakePF "github.com/submariner-io/submariner/pkg/packetfilter/fake"
+	"github.com/submariner-io/submariner/pkg/routeagent_driver/constants"
+	"github.com/submariner-io/submariner/pkg/routeagent_driver/handlers/connectivitypolicy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	policyNamespace = "apps"
+	remoteClusterID = "remote-cluster"
+)
+
+var _ = Describe("ConnectivityPolicy Handler", func() {
+	t := newTestDriver()
+
+	Specify("Init should create the chains, sets and fixed rules", func() {
+		t.pFilter.AwaitSet(Equal(constants.ConnectivityPolicyPodIPSet))
+		t.pFilter.AwaitSet(Equal(constants.ConnectivityPolicyRemoteIPSet))
+
+		t.pFilter.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyChain, And(
+			ContainSubstring("\"SrcSetName\":%q", constants.ConnectivityPolicyPodIPSet),
+			ContainSubstring("\"DestSetName\":%q", constants.ConnectivityPolicyRemoteIPSet),
+			ContainSubstring("\"TargetChain\":%q", constants.SmConnectivityPolicyEgressChain)))
+		t.pFilter.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyEgressChain,
+			ContainSubstring("\"CtState\":%d", packetfilter.ConnTrackStateEstablished))
+		t.pFilter.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyEgressChain,
+			ContainSubstring("\"TargetChain\":%q", constants.SmConnectivityPolicyAllowChain))
+		t.pFilter.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyEgressChain,
+			ContainSubstring("\"Action\":%d", packetfilter.RuleActionDrop))
+	})
+
+	When("a ConnectivityPolicy selects pods on the gateway node", func() {
+		var (
+			policy        *submV1.ConnectivityPolicy
+			localEndpoint *submV1.Endpoint
+		)
+
+		BeforeEach(func() {
+			policy = &submV1.ConnectivityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web",
+					Namespace: policyNamespace,
+				},
+				Spec: submV1.ConnectivityPolicySpec{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					Egress: []submV1.ConnectivityPolicyEgressRule{{
+						ClusterIDs: []string{remoteClusterID},
+						CIDRs:      []string{"192.0.4.0/25", "10.0.0.0/8"},
+						Ports:      []submV1.ConnectivityPolicyPort{{Protocol: corev1.ProtocolUDP, Port: 53}},
+					}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			localEndpoint = t.CreateLocalHostEndpoint()
+			t.CreateEndpoint(eventtesting.NewEndpoint(remoteClusterID, "host", "192.0.4.0/24", "192.0.5.0/24"))
+
+			t.createPod("web", "10.1.0.5", map[string]string{"app": "web"})
+			t.createPod("db", "10.1.0.6", map[string]string{"app": "db"})
+
+			test.CreateResource(t.policies.Namespace(policyNamespace), policy)
+		})
+
+		It("should only allow the traffic to the egress rules' destinations", func() {
+			t.pFilter.AwaitEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+			t.pFilter.AwaitEntry(constants.ConnectivityPolicyRemoteIPSet, "192.0.4.0/24")
+			t.pFilter.AwaitEntry(constants.ConnectivityPolicyRemoteIPSet, "192.0.5.0/24")
+			t.pFilter.AwaitNoEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.6")
+
+			t.pFilter.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain, And(
+				ContainSubstring("\"DPort\":\"53\""),
+				ContainSubstring("\"Proto\":%d", packetfilter.RuleProtoUDP)))
+
+			rule := t.allowRule()
+			t.pFilter.AwaitEntry(rule.SrcSetName, "10.1.0.5")
+			t.pFilter.AwaitEntry(rule.DestSetName, "192.0.4.0/25")
+			t.pFilter.AwaitNoEntry(rule.DestSetName, "192.0.5.0/24")
+		})
+
+		Context("and the ConnectivityPolicy is deleted", func() {
+			It("should remove its rules and sets", func() {
+				t.pFilter.AwaitEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+				rule := t.allowRule()
+
+				Expect(t.policies.Namespace(policyNamespace).Delete(context.TODO(), policy.Name,
+					metav1.DeleteOptions{})).To(Succeed())
+
+				t.pFilter.AwaitEntryDeleted(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+				t.pFilter.AwaitNoRules(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain)
+				t.pFilter.AwaitSetDeleted(rule.SrcSetName)
+				t.pFilter.AwaitSetDeleted(rule.DestSetName)
+			})
+		})
+
+		Context("and the node transitions to non-gateway", func() {
+			It("should remove the rules and stop watching", func() {
+				t.pFilter.AwaitEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+
+				t.DeleteEndpoint(localEndpoint.Name)
+
+				t.pFilter.AwaitEntryDeleted(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+				t.pFilter.AwaitNoRules(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain)
+
+				Expect(t.policies.Namespace(policyNamespace).Delete(context.TODO(), policy.Name,
+					metav1.DeleteOptions{})).To(Succeed())
+				t.CreateLocalHostEndpoint()
+
+				t.pFilter.AwaitNoEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+			})
+		})
+	})
+
+	When("the local cluster is dual-stack", func() {
+		BeforeEach(func() {
+			t.localClusterCIDRs = []string{"10.1.0.0/16", "fd00:1::/64"}
+		})
+
+		JustBeforeEach(func() {
+			t.CreateLocalHostEndpoint()
+			t.CreateEndpoint(eventtesting.NewEndpoint(remoteClusterID, "host", "192.0.4.0/24", "fd00:4::/64"))
+
+			t.createPod("web", "10.1.0.5", map[string]string{"app": "web"}, "fd00:1::5")
+
+			test.CreateResource(t.policies.Namespace(policyNamespace), &submV1.ConnectivityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web",
+					Namespace: policyNamespace,
+				},
+				Spec: submV1.ConnectivityPolicySpec{
+					Egress: []submV1.ConnectivityPolicyEgressRule{{
+						CIDRs: []string{"192.0.4.0/25", "fd00:4::/80"},
+					}},
+				},
+			})
+		})
+
+		It("should enforce the ConnectivityPolicy for both IP families", func() {
+			t.pFilterV6.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyChain, And(
+				ContainSubstring("\"SrcSetName\":%q", constants.ConnectivityPolicyPodIPSetV6),
+				ContainSubstring("\"DestSetName\":%q", constants.ConnectivityPolicyRemoteIPSetV6)))
+			t.pFilterV6.AwaitRule(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyEgressChain,
+				ContainSubstring("\"Action\":%d", packetfilter.RuleActionDrop))
+
+			t.pFilter.AwaitEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+			t.pFilter.AwaitNoEntry(constants.ConnectivityPolicyPodIPSet, "fd00:1::5")
+			t.pFilter.AwaitEntry(t.allowRule().DestSetName, "192.0.4.0/25")
+
+			t.pFilterV6.AwaitEntry(constants.ConnectivityPolicyPodIPSetV6, "fd00:1::5")
+			t.pFilterV6.AwaitEntry(constants.ConnectivityPolicyRemoteIPSetV6, "fd00:4::/64")
+			t.pFilterV6.AwaitNoEntry(constants.ConnectivityPolicyRemoteIPSetV6, "192.0.4.0/24")
+
+			rule := t.allowRuleV6()
+			t.pFilterV6.AwaitEntry(rule.SrcSetName, "fd00:1::5")
+			t.pFilterV6.AwaitEntry(rule.DestSetName, "fd00:4::/80")
+		})
+	})
+
+	When("a ConnectivityPolicy selects pods on a non-gateway node", func() {
+		It("should not enforce it", func() {
+			t.createPod("web", "10.1.0.5", nil)
+			test.CreateResource(t.policies.Namespace(policyNamespace), &submV1.ConnectivityPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "all",
+					Namespace: policyNamespace,
+				},
+			})
+
+			t.pFilter.AwaitNoEntry(constants.ConnectivityPolicyPodIPSet, "10.1.0.5")
+			Expect(t.pFilter.List(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain)).To(BeEmpty())
+			Consistently(t.watchedResources).ShouldNot(ContainElement("pods"))
+		})
+	})
+
+	Specify("Uninstall should remove the chains and sets", func() {
+		Expect(t.handler.Uninstall()).To(Succeed())
+
+		t.pFilter.AwaitSetDeleted(constants.ConnectivityPolicyPodIPSet)
+		t.pFilter.AwaitSetDeleted(constants.ConnectivityPolicyRemoteIPSet)
+		t.pFilter.AwaitNoIPHookChain(packetfilter.ChainTypeFilter, Equal(constants.SmConnectivityPolicyChain))
+	})
+})
+
+type testDriver struct {
+	*eventtesting.ControllerSupport
+	pFilter           *fakePF.PacketFilter
+	pFilterV6         *fakePF.PacketFilter
+	localClusterCIDRs []string
+	handler           event.Handler
+	client            *dynamicfake.FakeDynamicClient
+	pods              dynamic.NamespaceableResourceInterface
+	policies          dynamic.NamespaceableResourceInterface
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{
+		ControllerSupport: eventtesting.NewControllerSupport(),
+	}
+
+	BeforeEach(func() {
+		t.pFilter = fakePF.New()
+		t.pFilterV6 = fakePF.NewV6()
+		t.localClusterCIDRs = []string{"10.1.0.0/16"}
+	})
+
+	JustBeforeEach(func() {
+		restMapper := test.GetRESTMapperFor(&corev1.Pod{}, &submV1.ConnectivityPolicy{})
+		t.client = dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+
+		t.pods = t.client.Resource(*test.GetGroupVersionResourceFor(restMapper, &corev1.Pod{}))
+		t.policies = t.client.Resource(*test.GetGroupVersionResourceFor(restMapper, &submV1.ConnectivityPolicy{}))
+
+		t.handler = connectivitypolicy.NewHandler(&watcher.Config{
+			RestMapper: restMapper,
+			Client:     t.client,
+			Scheme:     scheme.Scheme,
+		}, t.localClusterCIDRs)
+
+		t.Start(t.handler)
+	})
+
+	return t
+}
+
+func (t *testDriver) createPod(name, ip string, labels map[string]string, moreIPs ...string) {
+	podIPs := []corev1.PodIP{{IP: ip}}
+	for _, podIP := range moreIPs {
+		podIPs = append(podIPs, corev1.PodIP{IP: podIP})
+	}
+
+	test.CreateResource(t.pods.Namespace(policyNamespace), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: policyNamespace,
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			PodIPs: podIPs,
+		},
+	})
+}
+
+func (t *testDriver) watchedResources() []string {
+	var resources []string
+
+	for _, action := range t.client.Actions() {
+		if action.GetVerb() == "watch" {
+			resources = append(resources, action.GetResource().Resource)
+		}
+	}
+
+	return resources
+}
+
+func (t *testDriver) allowRule() *packetfilter.Rule {
+	return awaitAllowRule(t.pFilter, constants.ConnectivityPolicyIPSetPrefix)
+}
+
+func (t *testDriver) allowRuleV6() *packetfilter.Rule {
+	return awaitAllowRule(t.pFilterV6, constants.ConnectivityPolicyIPSetPrefixV6)
+}
+
+func awaitAllowRule(pFilter *fakePF.PacketFilter, setPrefix string) *packetfilter.Rule {
+	var rules []*packetfilter.Rule
+
+	Eventually(func() []*packetfilter.Rule {
+		rules, _ = pFilter.List(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain)
+		return rules
+	}, 5).Should(HaveLen(1))
+
+	Expect(strings.HasPrefix(rules[0].SrcSetName, setPrefix)).To(BeTrue())
+
+	return rules[0]
+}
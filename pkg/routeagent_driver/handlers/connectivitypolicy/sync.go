@@ -0,0 +1,282 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectivitypolicy
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cidr"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	"github.com/submariner-io/submariner/pkg/routeagent_driver/constants"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8snet "k8s.io/utils/net"
+	"k8s.io/utils/set"
+)
+
+// sync programs the named sets and the rules of the allow chain of each IP family for the current policies, pods and
+// remote endpoints. Nothing is enforced on the non-gateway nodes. It must be called with the lock held.
+func (h *handler) sync() error {
+	for _, f := range h.filters {
+		if err := h.syncFamily(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *handler) syncFamily(f *familyFilter) error {
+	desiredSets := map[string]set.Set[string]{
+		f.podIPSet:    set.New[string](),
+		f.remoteIPSet: set.New[string](),
+	}
+
+	var rules []*packetfilter.Rule
+
+	if h.State().IsOnGateway() {
+		remoteSubnets := h.remoteSubnets(f.family)
+
+		for _, subnets := range remoteSubnets {
+			desiredSets[f.remoteIPSet].Insert(subnets...)
+		}
+
+		for key, policy := range h.policies {
+			srcSet := f.policySetName(key)
+			desiredSets[srcSet] = h.selectedPodIPs(policy, f.family)
+			desiredSets[f.podIPSet] = desiredSets[f.podIPSet].Union(desiredSets[srcSet])
+
+			for i := range policy.Spec.Egress {
+				destSet := f.policySetName(fmt.Sprintf("%s/%d", key, i))
+				desiredSets[destSet] = destinationsFor(&policy.Spec.Egress[i], remoteSubnets, f.family)
+				rules = append(rules, allowRulesFor(srcSet, destSet, policy.Spec.Egress[i].Ports)...)
+			}
+		}
+	}
+
+	for name, entries := range desiredSets {
+		if err := f.updateNamedSet(name, entries); err != nil {
+			return err
+		}
+	}
+
+	if err := f.pFilter.UpdateChainRules(packetfilter.TableTypeFilter, constants.SmConnectivityPolicyAllowChain,
+		rules); err != nil {
+		return errors.Wrapf(err, "error updating the rules of chain %q", constants.SmConnectivityPolicyAllowChain)
+	}
+
+	// The stale sets are deleted once they aren't referenced by the rules anymore.
+	for name := range f.sets {
+		if _, ok := desiredSets[name]; ok {
+			continue
+		}
+
+		if err := f.newNamedSet(name).Destroy(); err != nil {
+			return errors.Wrapf(err, "error deleting named set %q", name)
+		}
+
+		delete(f.sets, name)
+	}
+
+	return nil
+}
+
+func (f *familyFilter) updateNamedSet(name string, entries set.Set[string]) error {
+	namedSet := f.newNamedSet(name)
+
+	current, ok := f.sets[name]
+	if !ok {
+		if err := namedSet.Create(true); err != nil {
+			return errors.Wrapf(err, "error creating named set %q", name)
+		}
+
+		current = set.New[string]()
+		f.sets[name] = current
+	}
+
+	for _, entry := range current.Difference(entries).UnsortedList() {
+		if err := namedSet.DelEntry(entry); err != nil {
+			return errors.Wrapf(err, "error deleting entry %q from named set %q", entry, name)
+		}
+
+		current.Delete(entry)
+	}
+
+	for _, entry := range entries.Difference(current).UnsortedList() {
+		if err := namedSet.AddEntry(entry, true); err != nil {
+			return errors.Wrapf(err, "error adding entry %q to named set %q", entry, name)
+		}
+
+		current.Insert(entry)
+	}
+
+	return nil
+}
+
+// remoteSubnets returns the subnets of the given IP family of the remote clusters by cluster ID.
+func (h *handler) remoteSubnets(family k8snet.IPFamily) map[string][]string {
+	subnets := map[string][]string{}
+
+	for _, endpoint := range h.State().GetRemoteEndpoints() {
+		subnets[endpoint.Spec.ClusterID] = append(subnets[endpoint.Spec.ClusterID],
+			cidr.ExtractSubnets(family, endpoint.Spec.Subnets)...)
+	}
+
+	return subnets
+}
+
+func (h *handler) selectedPodIPs(policy *submV1.ConnectivityPolicy, family k8snet.IPFamily) set.Set[string] {
+	podIPs := set.New[string]()
+
+	selector := labels.Everything()
+
+	if policy.Spec.PodSelector != nil {
+		var err error
+
+		selector, err = metav1.LabelSelectorAsSelector(policy.Spec.PodSelector)
+		if err != nil {
+			logger.Errorf(err, "Invalid pod selector in ConnectivityPolicy %s/%s - no pods are selected",
+				policy.Namespace, policy.Name)
+			return podIPs
+		}
+	}
+
+	for _, pod := range h.pods {
+		if pod.Namespace != policy.Namespace || pod.Spec.HostNetwork || pod.Status.Phase == corev1.PodSucceeded ||
+			pod.Status.Phase == corev1.PodFailed || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+
+		for _, podIP := range pod.Status.PodIPs {
+			if k8snet.IPFamilyOfString(podIP.IP) == family {
+				podIPs.Insert(podIP.IP)
+			}
+		}
+	}
+
+	return podIPs
+}
+
+// destinationsFor returns the subnets of the remote clusters selected by the egress rule, restricted to its CIDRs of the
+// given IP family.
+func destinationsFor(rule *submV1.ConnectivityPolicyEgressRule, remoteSubnets map[string][]string,
+	family k8snet.IPFamily,
+) set.Set[string] {
+	var subnets []string
+
+	for clusterID, clusterSubnets := range remoteSubnets {
+		if len(rule.ClusterIDs) == 0 || slices.Contains(rule.ClusterIDs, clusterID) {
+			subnets = append(subnets, clusterSubnets...)
+		}
+	}
+
+	if len(rule.CIDRs) == 0 {
+		return set.New(subnets...)
+	}
+
+	destinations := set.New[string]()
+
+	for _, ruleCIDR := range rule.CIDRs {
+		_, ruleNet, err := net.ParseCIDR(ruleCIDR)
+		if err != nil {
+			logger.V(log.DEBUG).Infof("Ignoring CIDR %q which isn't a valid CIDR", ruleCIDR)
+			continue
+		}
+
+		if k8snet.IPFamilyOfCIDR(ruleNet) != family {
+			continue
+		}
+
+		for _, subnet := range subnets {
+			if _, subnetNet, err := net.ParseCIDR(subnet); err == nil {
+				if overlap := intersection(ruleNet, subnetNet); overlap != nil {
+					destinations.Insert(overlap.String())
+				}
+			}
+		}
+	}
+
+	return destinations
+}
+
+// intersection returns the intersection of the given CIDRs, ie the smaller one if it's contained in the other.
+func intersection(a, b *net.IPNet) *net.IPNet {
+	aOnes, _ := a.Mask.Size()
+	bOnes, _ := b.Mask.Size()
+
+	if aOnes >= bOnes && b.Contains(a.IP) {
+		return a
+	}
+
+	if bOnes >= aOnes && a.Contains(b.IP) {
+		return b
+	}
+
+	return nil
+}
+
+func allowRulesFor(srcSet, destSet string, ports []submV1.ConnectivityPolicyPort) []*packetfilter.Rule {
+	if len(ports) == 0 {
+		return []*packetfilter.Rule{{
+			SrcSetName:  srcSet,
+			DestSetName: destSet,
+			Action:      packetfilter.RuleActionAccept,
+		}}
+	}
+
+	rules := make([]*packetfilter.Rule, 0, len(ports))
+
+	for _, port := range ports {
+		proto := packetfilter.RuleProtoTCP
+		if port.Protocol == corev1.ProtocolUDP {
+			proto = packetfilter.RuleProtoUDP
+		}
+
+		rules = append(rules, &packetfilter.Rule{
+			Proto:       proto,
+			SrcSetName:  srcSet,
+			DestSetName: destSet,
+			DPort:       strconv.Itoa(int(port.Port)),
+			Action:      packetfilter.RuleActionAccept,
+		})
+	}
+
+	return rules
+}
+
+func (f *familyFilter) policySetName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	encoded := base32.StdEncoding.EncodeToString(hash[:])
+	// Max length of IPSet name can be 31
+	return f.setPrefix + encoded[:31-len(f.setPrefix)]
+}
+
+func (f *familyFilter) isPolicySet(name string) bool {
+	return strings.HasPrefix(name, f.setPrefix)
+}
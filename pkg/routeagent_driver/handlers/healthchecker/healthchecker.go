@@ -21,6 +21,7 @@ package healthchecker
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/submariner-io/submariner/pkg/event"
 	"github.com/submariner-io/submariner/pkg/pinger"
 	apiError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -278,10 +280,19 @@ func (h *controller) syncRouteAgentStatus() {
 
 		routeAgent.Status.RemoteEndpoints = append(routeAgent.Status.RemoteEndpoints, remoteEndpoint)
 	}
+
+	healthCheckCondition := h.healthCheckCondition(routeAgent.Status.RemoteEndpoints)
+	meta.SetStatusCondition(&routeAgent.Status.Conditions, healthCheckCondition)
+
 	// Use CreateOrUpdate to handle the RouteAgent resource
 	_, err := util.CreateOrUpdate(context.TODO(), h.routeAgentResourceInterface(), routeAgent,
 		func(existing *submarinerv1.RouteAgent) (*submarinerv1.RouteAgent, error) {
+			conditions := existing.Status.Conditions
 			existing.Status = routeAgent.Status
+			existing.Status.Conditions = conditions
+
+			// Preserve the transition time if the status didn't change.
+			meta.SetStatusCondition(&existing.Status.Conditions, healthCheckCondition)
 
 			return existing, nil
 		})
@@ -291,6 +302,46 @@ func (h *controller) syncRouteAgentStatus() {
 	}
 }
 
+func (h *controller) healthCheckCondition(remoteEndpoints []submarinerv1.RemoteEndpoint) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   string(submarinerv1.RouteAgentHealthCheckPassing),
+		Status: metav1.ConditionTrue,
+		Reason: "Passing",
+	}
+
+	if !h.config.HealthCheckerEnabled {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "HealthCheckDisabled"
+		condition.Message = "Health check is not enabled"
+
+		return condition
+	}
+
+	if h.State().IsOnGateway() {
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "GatewayNode"
+		condition.Message = "Health check is not performed on gateway nodes"
+
+		return condition
+	}
+
+	var failing []string
+
+	for i := range remoteEndpoints {
+		if remoteEndpoints[i].Status == submarinerv1.ConnectionError {
+			failing = append(failing, fmt.Sprintf("%q", remoteEndpoints[i].Spec.CableName))
+		}
+	}
+
+	if len(failing) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ConnectionError"
+		condition.Message = "Health check failed for " + strings.Join(failing, ", ")
+	}
+
+	return condition
+}
+
 func (h *controller) generateRouteAgentObject() *submarinerv1.RouteAgent {
 	return &submarinerv1.RouteAgent{
 		ObjectMeta: metav1.ObjectMeta{
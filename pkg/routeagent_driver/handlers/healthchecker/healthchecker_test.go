@@ -35,6 +35,7 @@ import (
 	"github.com/submariner-io/submariner/pkg/pinger/fake"
 	"github.com/submariner-io/submariner/pkg/routeagent_driver/handlers/healthchecker"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
@@ -103,6 +104,8 @@ var _ = Describe("RemoteEndpoint latency info", func() {
 
 			Expect(remoteEndpoint.Status).To(Equal(submarinerv1.Connected))
 			Expect(remoteEndpoint.LatencyRTT).To(Equal(latencyInfo.Spec))
+
+			t.awaitHealthCheckCondition(metav1.ConditionTrue, "Passing")
 		})
 
 		Context("with no HealthCheckIP", func() {
@@ -131,6 +134,8 @@ var _ = Describe("RemoteEndpoint latency info", func() {
 
 				Expect(remoteEndpoint.Status).To(Equal(submarinerv1.ConnectionNone))
 				Expect(remoteEndpoint.Spec).To(Equal(endpoint1.Spec))
+
+				t.awaitHealthCheckCondition(metav1.ConditionUnknown, "GatewayNode")
 			})
 		})
 
@@ -149,6 +154,8 @@ var _ = Describe("RemoteEndpoint latency info", func() {
 
 				Expect(remoteEndpoint.Status).To(Equal(submarinerv1.ConnectionNone))
 				Expect(remoteEndpoint.Spec).To(Equal(endpoint1.Spec))
+
+				t.awaitHealthCheckCondition(metav1.ConditionUnknown, "HealthCheckDisabled")
 			})
 		})
 	})
@@ -213,6 +220,8 @@ var _ = Describe("RemoteEndpoint latency info", func() {
 
 			Expect(remoteEndpoint.Status).To(Equal(submarinerv1.ConnectionError))
 			Expect(remoteEndpoint.StatusMessage).To(Equal(latencyInfo.ConnectionError))
+
+			t.awaitHealthCheckCondition(metav1.ConditionFalse, "ConnectionError")
 		})
 	})
 })
@@ -371,3 +380,17 @@ func (t *testDriver) awaitRemoteEndpoint(verify func(*submarinerv1.RemoteEndpoin
 
 	return &routeAgent.Status.RemoteEndpoints[0]
 }
+
+func (t *testDriver) awaitHealthCheckCondition(status metav1.ConditionStatus, reason string) {
+	var condition *metav1.Condition
+
+	t.awaitRouteAgent(func(ra *submarinerv1.RouteAgent) bool {
+		condition = meta.FindStatusCondition(ra.Status.Conditions, string(submarinerv1.RouteAgentHealthCheckPassing))
+		return condition != nil && condition.Status == status
+	})
+
+	Expect(condition).ToNot(BeNil())
+	Expect(condition.Status).To(Equal(status))
+	Expect(condition.Reason).To(Equal(reason))
+	Expect(condition.LastTransitionTime.IsZero()).To(BeFalse())
+}
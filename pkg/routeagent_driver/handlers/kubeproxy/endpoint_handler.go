@@ -19,7 +19,9 @@ limitations under the License.
 package kubeproxy
 
 import (
+	"maps"
 	"net"
+	"slices"
 
 	"github.com/pkg/errors"
 	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
@@ -32,8 +34,21 @@ import (
 func (kp *SyncHandler) LocalEndpointCreated(endpoint *submV1.Endpoint) error {
 	kp.localEndpointIfaceName = endpoint.Spec.BackendConfig[cable.InterfaceNameConfig]
 
+	if kp.localEndpointIsRelay != endpoint.Spec.IsRelay() {
+		kp.localEndpointIsRelay = endpoint.Spec.IsRelay()
+		kp.updateRelayRules()
+	}
+
 	// We are on nonGateway node
 	if !kp.State().IsOnGateway() {
+		localClusterGwNodeIP := net.ParseIP(endpoint.Spec.GetPrivateIP(k8snet.IPv4))
+
+		// In active/active mode traffic is spread across all the gateways so an additional gateway is added to the
+		// existing vxlan interface rather than replacing it.
+		if endpoint.Spec.IsActiveActive() && kp.vxlanDevice != nil && kp.activeEndpointHostname != endpoint.Spec.Hostname {
+			return kp.addActiveActiveGateway(endpoint.Spec.Hostname, localClusterGwNodeIP)
+		}
+
 		// If the node already has a vxLAN interface that points to an oldEndpoint
 		// (i.e., during gateway migration), delete it.
 		if kp.vxlanDevice != nil && kp.activeEndpointHostname != endpoint.Spec.Hostname {
@@ -45,35 +60,78 @@ func (kp *SyncHandler) LocalEndpointCreated(endpoint *submV1.Endpoint) error {
 
 			kp.vxlanDevice = nil
 			kp.activeEndpointHostname = ""
+			kp.activeActiveGateways = map[string]activeActiveGateway{}
 		}
 
-		localClusterGwNodeIP := net.ParseIP(endpoint.Spec.GetPrivateIP(k8snet.IPv4))
+		return kp.connectToGateway(endpoint.Spec.Hostname, localClusterGwNodeIP)
+	}
 
-		remoteVtepIP, err := vxlan.GetVtepIPAddressFrom(localClusterGwNodeIP.String(), VxLANVTepNetworkPrefix)
-		if err != nil {
-			return errors.Wrap(err, "failed to derive the remoteVtepIP")
-		}
+	return nil
+}
 
-		logger.Infof("Creating the vxlan interface %s with gateway node IP %s", VxLANIface, localClusterGwNodeIP)
+func (kp *SyncHandler) connectToGateway(hostname string, localClusterGwNodeIP net.IP) error {
+	remoteVtepIP, err := vxlan.GetVtepIPAddressFrom(localClusterGwNodeIP.String(), VxLANVTepNetworkPrefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to derive the remoteVtepIP")
+	}
 
-		err = kp.createVxLANInterface(VxInterfaceWorker, localClusterGwNodeIP)
-		if err != nil {
-			logger.Fatalf("Unable to create VxLAN interface on non-GatewayNode (%s): %v", endpoint.Spec.Hostname, err)
-		}
+	logger.Infof("Creating the vxlan interface %s with gateway node IP %s", VxLANIface, localClusterGwNodeIP)
 
-		kp.vxlanGwIP = &remoteVtepIP
-		kp.activeEndpointHostname = endpoint.Spec.Hostname
+	err = kp.createVxLANInterface(VxInterfaceWorker, localClusterGwNodeIP)
+	if err != nil {
+		logger.Fatalf("Unable to create VxLAN interface on non-GatewayNode (%s): %v", hostname, err)
+	}
 
-		err = kp.reconcileRoutes(remoteVtepIP)
-		if err != nil {
-			return errors.Wrap(err, "error while reconciling routes")
-		}
+	kp.vxlanGwIP = &remoteVtepIP
+	kp.activeEndpointHostname = hostname
+
+	err = kp.reconcileRoutes(remoteVtepIP)
+	if err != nil {
+		return errors.Wrap(err, "error while reconciling routes")
 	}
 
 	return nil
 }
 
+func (kp *SyncHandler) addActiveActiveGateway(hostname string, localClusterGwNodeIP net.IP) error {
+	if existing, found := kp.activeActiveGateways[hostname]; found && existing.nodeIP.Equal(localClusterGwNodeIP) {
+		return nil
+	}
+
+	vtepIP, err := vxlan.GetVtepIPAddressFrom(localClusterGwNodeIP.String(), VxLANVTepNetworkPrefix)
+	if err != nil {
+		return errors.Wrap(err, "failed to derive the remoteVtepIP")
+	}
+
+	logger.Infof("Adding active/active gateway node IP %s to the vxlan interface %s", localClusterGwNodeIP, VxLANIface)
+
+	err = kp.vxlanDevice.AddFDB(localClusterGwNodeIP, "00:00:00:00:00:00")
+	if err != nil {
+		return errors.Wrapf(err, "failed to add an FDB entry for gateway node IP %s", localClusterGwNodeIP)
+	}
+
+	kp.activeActiveGateways[hostname] = activeActiveGateway{nodeIP: localClusterGwNodeIP, vtepIP: vtepIP}
+
+	return errors.Wrap(kp.reconcileRoutes(*kp.vxlanGwIP), "error while reconciling routes")
+}
+
 func (kp *SyncHandler) LocalEndpointRemoved(endpoint *submV1.Endpoint) error {
+	if gw, found := kp.activeActiveGateways[endpoint.Spec.Hostname]; found {
+		delete(kp.activeActiveGateways, endpoint.Spec.Hostname)
+
+		if kp.vxlanDevice == nil {
+			return nil
+		}
+
+		logger.Infof("Removing active/active gateway node IP %s from the vxlan interface %s", gw.nodeIP, VxLANIface)
+
+		if err := kp.vxlanDevice.DelFDB(gw.nodeIP, "00:00:00:00:00:00"); err != nil {
+			return errors.Wrapf(err, "failed to delete the FDB entry for gateway node IP %s", gw.nodeIP)
+		}
+
+		return errors.Wrap(kp.reconcileRoutes(*kp.vxlanGwIP), "error while reconciling routes")
+	}
+
 	// If the vxLAN device exists and it points to the same endpoint, delete it.
 	if kp.vxlanDevice != nil && kp.activeEndpointHostname == endpoint.Spec.Hostname {
 		err := kp.vxlanDevice.DeleteLinkDevice()
@@ -84,6 +142,34 @@ func (kp *SyncHandler) LocalEndpointRemoved(endpoint *submV1.Endpoint) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to delete the vxlan interface on Endpoint removal")
 		}
+
+		return kp.promoteActiveActiveGateway()
+	}
+
+	return nil
+}
+
+// promoteActiveActiveGateway recreates the vxlan interface to point to one of the remaining active/active gateways, if
+// any, after the gateway it pointed to was removed.
+func (kp *SyncHandler) promoteActiveActiveGateway() error {
+	remaining := kp.activeActiveGateways
+	kp.activeActiveGateways = map[string]activeActiveGateway{}
+
+	hostnames := slices.Sorted(maps.Keys(remaining))
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	err := kp.connectToGateway(hostnames[0], remaining[hostnames[0]].nodeIP)
+	if err != nil {
+		return err
+	}
+
+	for _, hostname := range hostnames[1:] {
+		err = kp.addActiveActiveGateway(hostname, remaining[hostname].nodeIP)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -115,6 +201,9 @@ func (kp *SyncHandler) RemoteEndpointCreated(endpoint *submV1.Endpoint) error {
 	kp.updateRoutingRulesForHostNetworkSupport(endpoint.Spec.Subnets, Add)
 	kp.updateIptableRulesForInterClusterTraffic(endpoint.Spec.Subnets, Add)
 
+	kp.remoteEndpoints[endpoint.Spec.CableName] = endpoint.Spec
+	kp.updateRelayRules()
+
 	return nil
 }
 
@@ -133,6 +222,9 @@ func (kp *SyncHandler) RemoteEndpointRemoved(endpoint *submV1.Endpoint) error {
 	kp.updateRoutingRulesForHostNetworkSupport(endpoint.Spec.Subnets, Delete)
 	kp.updateIptableRulesForInterClusterTraffic(endpoint.Spec.Subnets, Delete)
 
+	delete(kp.remoteEndpoints, endpoint.Spec.CableName)
+	kp.updateRelayRules()
+
 	return nil
 }
 
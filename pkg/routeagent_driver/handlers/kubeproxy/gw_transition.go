@@ -48,6 +48,7 @@ func (kp *SyncHandler) TransitionToGateway() error {
 	logger.Infof("Creating the vxlan interface: %s on the gateway node", VxLANIface)
 
 	kp.activeEndpointHostname = kp.hostname
+	kp.activeActiveGateways = map[string]activeActiveGateway{}
 
 	err := kp.createVxLANInterface(VxInterfaceGateway, nil)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
+	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cidr"
 	cni "github.com/submariner-io/submariner/pkg/cni"
 	"github.com/submariner-io/submariner/pkg/event"
@@ -45,12 +46,14 @@ type SyncHandler struct {
 	localEndpointIfaceName string
 	localClusterCidr       []string
 	localServiceCidr       []string
+	localClusterCidrV6     []string
 
 	remoteSubnets          set.Set[string]
 	remoteSubnetGw         map[string]net.IP
 	remoteVTEPs            set.Set[string]
 	routeCacheGWNode       set.Set[string]
 	pFilter                packetfilter.Interface
+	pFilterV6              packetfilter.Interface
 	netLink                netlink.Interface
 	vxlanDevice            *vxlan.Interface
 	vxlanGwIP              *net.IP
@@ -58,6 +61,16 @@ type SyncHandler struct {
 	cniIface               *cni.Interface
 	defaultHostIface       *net.Interface
 	activeEndpointHostname string
+	activeActiveGateways   map[string]activeActiveGateway
+	localEndpointIsRelay   bool
+	remoteEndpoints        map[string]submV1.EndpointSpec
+	relayRules             map[relayRule]bool
+}
+
+// activeActiveGateway holds the addresses of an additional local gateway when the gateways run in active/active mode.
+type activeActiveGateway struct {
+	nodeIP net.IP
+	vtepIP net.IP
 }
 
 var logger = log.Logger{Logger: logf.Log.WithName("KubeProxy")}
@@ -66,16 +79,27 @@ func NewSyncHandler(localClusterCidr, localServiceCidr []string) *SyncHandler {
 	pFilter, err := packetfilter.New()
 	utilruntime.Must(err)
 
-	return &SyncHandler{
-		localClusterCidr: cidr.ExtractIPv4Subnets(localClusterCidr),
-		localServiceCidr: cidr.ExtractIPv4Subnets(localServiceCidr),
-		remoteSubnets:    set.New[string](),
-		remoteSubnetGw:   map[string]net.IP{},
-		remoteVTEPs:      set.New[string](),
-		routeCacheGWNode: set.New[string](),
-		netLink:          netlink.New(),
-		pFilter:          pFilter,
+	kp := &SyncHandler{
+		localClusterCidr:     cidr.ExtractIPv4Subnets(localClusterCidr),
+		localServiceCidr:     cidr.ExtractIPv4Subnets(localServiceCidr),
+		localClusterCidrV6:   cidr.ExtractIPv6Subnets(localClusterCidr),
+		remoteSubnets:        set.New[string](),
+		remoteSubnetGw:       map[string]net.IP{},
+		remoteVTEPs:          set.New[string](),
+		activeActiveGateways: map[string]activeActiveGateway{},
+		remoteEndpoints:      map[string]submV1.EndpointSpec{},
+		relayRules:           map[relayRule]bool{},
+		routeCacheGWNode:     set.New[string](),
+		netLink:              netlink.New(),
+		pFilter:              pFilter,
 	}
+
+	if len(kp.localClusterCidrV6) > 0 {
+		kp.pFilterV6, err = packetfilter.NewV6()
+		utilruntime.Must(err)
+	}
+
+	return kp
 }
 
 func (kp *SyncHandler) GetName() string {
@@ -26,6 +26,7 @@ import (
 	"github.com/submariner-io/submariner/pkg/packetfilter"
 	"github.com/submariner-io/submariner/pkg/port"
 	"github.com/submariner-io/submariner/pkg/routeagent_driver/constants"
+	k8snet "k8s.io/utils/net"
 )
 
 func (kp *SyncHandler) createPFilterChains() error {
@@ -101,9 +102,52 @@ func (kp *SyncHandler) createPFilterChains() error {
 		}
 	}
 
+	return kp.createPFilterChainsV6()
+}
+
+// createPFilterChainsV6 creates the chains needed for IPv6 inter-cluster traffic. The intra-cluster vxlan tunnel only
+// carries IPv4 so the vxlan rules aren't needed here.
+func (kp *SyncHandler) createPFilterChainsV6() error {
+	if kp.pFilterV6 == nil {
+		return nil
+	}
+
+	ipHookChains := []packetfilter.ChainIPHook{
+		{
+			Name:     constants.SmPostRoutingChain,
+			Type:     packetfilter.ChainTypeNAT,
+			Hook:     packetfilter.ChainHookPostrouting,
+			Priority: packetfilter.ChainPriorityFirst,
+		},
+		{
+			Name:     constants.SmForwardChain,
+			Type:     packetfilter.ChainTypeFilter,
+			Hook:     packetfilter.ChainHookForward,
+			Priority: packetfilter.ChainPriorityFirst,
+		},
+	}
+
+	for i := range ipHookChains {
+		logger.V(log.DEBUG).Infof("Install/ensure %q IPv6 IPHook chain exists", ipHookChains[i].Name)
+
+		if err := kp.pFilterV6.CreateIPHookChainIfNotExists(&ipHookChains[i]); err != nil {
+			return errors.Wrapf(err, "error installing IPv6 IPHook chain %q", ipHookChains[i].Name)
+		}
+	}
+
 	return nil
 }
 
+// pFilterFor returns the packet filter and local cluster CIDRs for the IP family of the given CIDR. The returned
+// packet filter is nil if the local cluster doesn't have CIDRs of that family.
+func (kp *SyncHandler) pFilterFor(cidrBlock string) (packetfilter.Interface, []string) {
+	if k8snet.IsIPv6CIDRString(cidrBlock) {
+		return kp.pFilterV6, kp.localClusterCidrV6
+	}
+
+	return kp.pFilter, kp.localClusterCidr
+}
+
 func (kp *SyncHandler) updateIptableRulesForInterClusterTraffic(inputCidrBlocks []string, operation Operation) {
 	for _, inputCidrBlock := range inputCidrBlocks {
 		err := kp.programIptableRulesForInterClusterTraffic(inputCidrBlock, operation)
@@ -114,7 +158,13 @@ func (kp *SyncHandler) updateIptableRulesForInterClusterTraffic(inputCidrBlocks
 }
 
 func (kp *SyncHandler) programIptableRulesForInterClusterTraffic(remoteCidrBlock string, operation Operation) error {
-	for _, localClusterCidr := range kp.localClusterCidr {
+	pFilter, localClusterCidrs := kp.pFilterFor(remoteCidrBlock)
+	if pFilter == nil {
+		logger.V(log.DEBUG).Infof("No local cluster CIDRs match the IP family of remote CIDR %q", remoteCidrBlock)
+		return nil
+	}
+
+	for _, localClusterCidr := range localClusterCidrs {
 		outboundRule := packetfilter.Rule{
 			Action:   packetfilter.RuleActionAccept,
 			SrcCIDR:  localClusterCidr,
@@ -129,25 +179,25 @@ func (kp *SyncHandler) programIptableRulesForInterClusterTraffic(remoteCidrBlock
 		if operation == Add {
 			logger.V(log.DEBUG).Infof("Installing packetfilter rule for outgoing traffic: %+v", outboundRule)
 
-			if err := kp.pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &outboundRule); err != nil {
+			if err := pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &outboundRule); err != nil {
 				return errors.Wrapf(err, "error appending packetfilter rule %+v", outboundRule)
 			}
 
 			logger.V(log.DEBUG).Infof("Installing packetfilter rule for incoming traffic: %+v", incomingRule)
 
-			if err := kp.pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &incomingRule); err != nil {
+			if err := pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &incomingRule); err != nil {
 				return errors.Wrapf(err, "error appending packetfilter rule %+v", incomingRule)
 			}
 		} else if operation == Delete {
 			logger.V(log.DEBUG).Infof("Deleting packetfilter rule for outgoing traffic: %+v", outboundRule)
 
-			if err := kp.pFilter.Delete(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &outboundRule); err != nil {
+			if err := pFilter.Delete(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &outboundRule); err != nil {
 				return errors.Wrapf(err, "error deleting packetfilter rule %+v", outboundRule)
 			}
 
 			logger.V(log.DEBUG).Infof("Deleting packetfilter rule for incoming traffic: %+v", incomingRule)
 
-			if err := kp.pFilter.Delete(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &incomingRule); err != nil {
+			if err := pFilter.Delete(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &incomingRule); err != nil {
 				return errors.Wrapf(err, "error deleting packetfilter rule %+v", incomingRule)
 			}
 		}
@@ -0,0 +1,129 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeproxy
+
+import (
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log"
+	submV1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/packetfilter"
+	"github.com/submariner-io/submariner/pkg/routeagent_driver/constants"
+	k8snet "k8s.io/utils/net"
+)
+
+// relayRule accepts the traffic from a remote cluster's subnet to another remote cluster's subnet, which a relay gateway
+// forwards between the clusters which can't reach each other, so that it isn't masqueraded.
+type relayRule struct {
+	srcCIDR  string
+	destCIDR string
+}
+
+func (kp *SyncHandler) LocalEndpointUpdated(endpoint *submV1.Endpoint) error {
+	if kp.localEndpointIsRelay != endpoint.Spec.IsRelay() {
+		kp.localEndpointIsRelay = endpoint.Spec.IsRelay()
+		kp.updateRelayRules()
+	}
+
+	return nil
+}
+
+func (kp *SyncHandler) RemoteEndpointUpdated(endpoint *submV1.Endpoint) error {
+	if _, found := kp.remoteEndpoints[endpoint.Spec.CableName]; found {
+		kp.remoteEndpoints[endpoint.Spec.CableName] = endpoint.Spec
+		kp.updateRelayRules()
+	}
+
+	return nil
+}
+
+// updateRelayRules programs the rules for the traffic between the remote clusters relayed by the local gateway, and
+// removes those for the clusters which aren't relayed anymore.
+func (kp *SyncHandler) updateRelayRules() {
+	desired := map[relayRule]bool{}
+
+	if kp.localEndpointIsRelay {
+		for _, a := range kp.remoteEndpoints {
+			for _, b := range kp.remoteEndpoints {
+				if a.ClusterID == b.ClusterID || !a.IsRelayedWith(&b) {
+					continue
+				}
+
+				for _, srcCIDR := range a.Subnets {
+					for _, destCIDR := range b.Subnets {
+						if k8snet.IPFamilyOfCIDRString(srcCIDR) == k8snet.IPFamilyOfCIDRString(destCIDR) {
+							desired[relayRule{srcCIDR: srcCIDR, destCIDR: destCIDR}] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for rule := range kp.relayRules {
+		if desired[rule] {
+			continue
+		}
+
+		if err := kp.programRelayRule(rule, Delete); err != nil {
+			logger.Errorf(err, "Failed to delete the relay rule")
+			continue
+		}
+
+		delete(kp.relayRules, rule)
+	}
+
+	for rule := range desired {
+		if kp.relayRules[rule] {
+			continue
+		}
+
+		if err := kp.programRelayRule(rule, Add); err != nil {
+			logger.Errorf(err, "Failed to add the relay rule")
+			continue
+		}
+
+		kp.relayRules[rule] = true
+	}
+}
+
+func (kp *SyncHandler) programRelayRule(rule relayRule, operation Operation) error {
+	pFilter, _ := kp.pFilterFor(rule.srcCIDR)
+	if pFilter == nil {
+		logger.V(log.DEBUG).Infof("No local cluster CIDRs match the IP family of relayed CIDR %q", rule.srcCIDR)
+		return nil
+	}
+
+	pfRule := packetfilter.Rule{
+		Action:   packetfilter.RuleActionAccept,
+		SrcCIDR:  rule.srcCIDR,
+		DestCIDR: rule.destCIDR,
+	}
+
+	if operation == Add {
+		logger.V(log.DEBUG).Infof("Installing packetfilter rule for relayed traffic: %+v", pfRule)
+
+		return errors.Wrapf(pFilter.AppendUnique(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &pfRule),
+			"error appending packetfilter rule %+v", pfRule)
+	}
+
+	logger.V(log.DEBUG).Infof("Deleting packetfilter rule for relayed traffic: %+v", pfRule)
+
+	return errors.Wrapf(pFilter.Delete(packetfilter.TableTypeNAT, constants.SmPostRoutingChain, &pfRule),
+		"error deleting packetfilter rule %+v", pfRule)
+}
@@ -19,8 +19,10 @@ limitations under the License.
 package kubeproxy
 
 import (
+	"maps"
 	"net"
 	"os"
+	"slices"
 	"syscall"
 
 	"github.com/pkg/errors"
@@ -218,12 +220,16 @@ func (kp *SyncHandler) reconcileRoutes(vxlanGw net.IP) error {
 			break
 		}
 
-		route := netlink.Route{
-			Dst:       dst,
-			Gw:        vxlanGw,
-			Scope:     unix.RT_SCOPE_UNIVERSE,
-			LinkIndex: link.Attrs().Index,
-			Protocol:  4,
+		route := kp.newVxLANRoute(dst, link.Attrs().Index)
+
+		// Multipath routes aren't reported when listing the routes for a link so just replace them.
+		if len(route.MultiPath) > 0 {
+			err = kp.netLink.RouteAddOrReplace(&route)
+			if err != nil {
+				logger.Errorf(err, "Error adding route %s", route)
+			}
+
+			continue
 		}
 
 		found := false
@@ -238,7 +244,7 @@ func (kp *SyncHandler) reconcileRoutes(vxlanGw net.IP) error {
 		}
 
 		if !found {
-			err = kp.netLink.RouteAdd(&route)
+			err = kp.netLink.RouteAddOrReplace(&route)
 			if err != nil {
 				logger.Errorf(err, "Error adding route %s", route)
 			}
@@ -288,13 +294,7 @@ func (kp *SyncHandler) updateRoutingRulesForInterClusterSupport(remoteCIDRs []st
 				return errors.Wrapf(err, "error parsing cidr block %s", cidrBlock)
 			}
 
-			route := netlink.Route{
-				Dst:       dst,
-				Gw:        *kp.vxlanGwIP,
-				Scope:     unix.RT_SCOPE_UNIVERSE,
-				LinkIndex: link.Attrs().Index,
-				Protocol:  4,
-			}
+			route := kp.newVxLANRoute(dst, link.Attrs().Index)
 
 			if operation == Add {
 				err = kp.netLink.RouteAddOrReplace(&route)
@@ -312,3 +312,31 @@ func (kp *SyncHandler) updateRoutingRulesForInterClusterSupport(remoteCIDRs []st
 
 	return nil
 }
+
+// newVxLANRoute returns the route to the given remote subnet via the local gateway's VTEP. If there are several
+// gateways in active/active mode, a multipath route is returned so flows are spread across all of them.
+func (kp *SyncHandler) newVxLANRoute(dst *net.IPNet, linkIndex int) netlink.Route {
+	route := netlink.Route{
+		Dst:       dst,
+		Gw:        *kp.vxlanGwIP,
+		Scope:     unix.RT_SCOPE_UNIVERSE,
+		LinkIndex: linkIndex,
+		Protocol:  4,
+	}
+
+	if len(kp.activeActiveGateways) == 0 {
+		return route
+	}
+
+	route.Gw = nil
+	route.MultiPath = []*netlink.NexthopInfo{{LinkIndex: linkIndex, Gw: *kp.vxlanGwIP}}
+
+	for _, hostname := range slices.Sorted(maps.Keys(kp.activeActiveGateways)) {
+		route.MultiPath = append(route.MultiPath, &netlink.NexthopInfo{
+			LinkIndex: linkIndex,
+			Gw:        kp.activeActiveGateways[hostname].vtepIP,
+		})
+	}
+
+	return route
+}
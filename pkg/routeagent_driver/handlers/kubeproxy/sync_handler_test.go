@@ -57,6 +57,7 @@ const (
 var _ = Describe("SyncHandler", func() {
 	Describe("Endpoints", testEndpoints)
 	Describe("Gateway transition", testGatewayTransition)
+	Describe("Relay", testRelay)
 	Describe("Nodes", testNodes)
 	Describe("Uninstall", testUninstall)
 })
@@ -146,6 +147,50 @@ func testEndpoints() {
 		})
 	})
 
+	When("local Endpoints are created for active/active gateways while on a non-gateway node", func() {
+		var otherEndpoint *submarinerv1.Endpoint
+
+		BeforeEach(func() {
+			t.localEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.ActiveActive: "true"}
+
+			otherEndpoint = newLocalEndpoint(localNodeName2)
+			otherEndpoint.Spec.CableName = "submariner-cable-local-192-68-1-3"
+			otherEndpoint.Spec.PrivateIPs = []string{"192.68.1.3"}
+			otherEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.ActiveActive: "true"}
+
+			t.CreateEndpoint(t.remoteEndpoint)
+			t.CreateEndpoint(t.localEndpoint)
+			t.netLink.AwaitLink(kubeproxy.VxLANIface)
+			t.CreateEndpoint(otherEndpoint)
+		})
+
+		It("should add an FDB entry for the additional gateway and multipath VxLAN routes", func() {
+			t.netLink.AwaitNeighbors(t.vxLanInterfaceIndex, "192.68.1.3")
+			t.awaitMultipathVxLANRoutes("240.68.1.2", "240.68.1.3")
+		})
+
+		Context("and the additional gateway's Endpoint is removed", func() {
+			It("should remove its FDB entry and route via the remaining gateway", func() {
+				t.netLink.AwaitNeighbors(t.vxLanInterfaceIndex, "192.68.1.3")
+				t.DeleteEndpoint(otherEndpoint.Name)
+
+				t.netLink.AwaitNoNeighbors(t.vxLanInterfaceIndex, "192.68.1.3")
+				t.netLink.AwaitGwRoutes(t.vxLanInterfaceIndex, 0, "240.68.1.2")
+			})
+		})
+
+		Context("and the first gateway's Endpoint is removed", func() {
+			It("should recreate the VxLAN interface for the remaining gateway", func() {
+				t.netLink.AwaitNeighbors(t.vxLanInterfaceIndex, "192.68.1.3")
+				t.DeleteEndpoint(t.localEndpoint.Name)
+
+				Eventually(func() string {
+					return toVxlan(t.netLink.AwaitLink(kubeproxy.VxLANIface)).Group.String()
+				}).Should(Equal("192.68.1.3"))
+			})
+		})
+	})
+
 	When("a local Endpoint is created while on a gateway node", func() {
 		It("should not add the VxLAN interface", func() {
 			t.localEndpoint.Spec.Hostname = t.Hostname
@@ -239,6 +284,73 @@ func testEndpoints() {
 	})
 }
 
+func testRelay() {
+	t := newTestDriver()
+
+	const otherRemoteSubnet = "172.250.1.0/24"
+
+	var otherRemoteEndpoint *submarinerv1.Endpoint
+
+	awaitRelayRules := func() {
+		for _, remoteCIDR := range t.remoteEndpoint.Spec.Subnets {
+			t.pFilter.AwaitRule(packetfilter.TableTypeNAT, constants.SmPostRoutingChain,
+				And(ContainSubstring(remoteCIDR), ContainSubstring(otherRemoteSubnet)))
+		}
+	}
+
+	awaitNoRelayRules := func() {
+		for _, remoteCIDR := range t.remoteEndpoint.Spec.Subnets {
+			t.pFilter.AwaitNoRule(packetfilter.TableTypeNAT, constants.SmPostRoutingChain,
+				And(ContainSubstring(remoteCIDR), ContainSubstring(otherRemoteSubnet)))
+		}
+	}
+
+	BeforeEach(func() {
+		otherRemoteEndpoint = newRemoteEndpoint()
+		otherRemoteEndpoint.Spec.CableName = "submariner-cable-other-192-68-1-3"
+		otherRemoteEndpoint.Spec.ClusterID = "other"
+		otherRemoteEndpoint.Spec.Subnets = []string{otherRemoteSubnet}
+		otherRemoteEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.RelayedClustersConfig: t.remoteEndpoint.Spec.ClusterID}
+	})
+
+	JustBeforeEach(func() {
+		t.CreateEndpoint(t.localEndpoint)
+		t.CreateEndpoint(t.remoteEndpoint)
+		t.CreateEndpoint(otherRemoteEndpoint)
+	})
+
+	When("the local Endpoint is a relay and a remote Endpoint is relayed to another", func() {
+		BeforeEach(func() {
+			t.localEndpoint.Spec.Hostname = t.Hostname
+			t.localEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.RelayConfig: "true"}
+		})
+
+		It("should add IP table rules for the relayed traffic between their subnets", func() {
+			awaitRelayRules()
+		})
+
+		Context("and the remote Endpoint is subsequently no longer relayed", func() {
+			JustBeforeEach(func() {
+				awaitRelayRules()
+
+				otherRemoteEndpoint.Spec.BackendConfig = nil
+				t.UpdateEndpoint(otherRemoteEndpoint)
+			})
+
+			It("should remove the IP table rules for the relayed traffic", func() {
+				awaitNoRelayRules()
+			})
+		})
+	})
+
+	When("the local Endpoint isn't a relay", func() {
+		It("should not add IP table rules for the relayed traffic", func() {
+			t.verifyRemoteSubnetIPTableRules()
+			awaitNoRelayRules()
+		})
+	})
+}
+
 func testGatewayTransition() {
 	t := newTestDriver()
 
@@ -410,6 +522,30 @@ func (t *testDriver) verifyVxLANRoutes() {
 	t.netLink.AwaitDstRoutes(t.netLink.AwaitLink(kubeproxy.VxLANIface).Attrs().Index, 0, t.remoteEndpoint.Spec.Subnets...)
 }
 
+func (t *testDriver) awaitMultipathVxLANRoutes(gwIPs ...string) {
+	for _, subnet := range t.remoteEndpoint.Spec.Subnets {
+		Eventually(func() []string {
+			routes, err := t.netLink.RouteList(&netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Index: t.vxLanInterfaceIndex}}, 0)
+			Expect(err).To(Succeed())
+
+			for i := range routes {
+				if routes[i].Dst == nil || routes[i].Dst.String() != subnet || len(routes[i].MultiPath) == 0 {
+					continue
+				}
+
+				var nextHops []string
+				for _, nh := range routes[i].MultiPath {
+					nextHops = append(nextHops, nh.Gw.String())
+				}
+
+				return nextHops
+			}
+
+			return nil
+		}, 5).Should(Equal(gwIPs), "Multipath route for %q not found", subnet)
+	}
+}
+
 func (t *testDriver) verifyNoVxLANRoutes() {
 	time.Sleep(200 * time.Millisecond)
 	t.netLink.AwaitNoDstRoutes(t.vxLanInterfaceIndex, 0, t.remoteEndpoint.Spec.Subnets...)
@@ -122,4 +122,49 @@ func deleteIPTableChains() {
 		logger.Errorf(err, "Error deleting IPHook chain %q of %q table", constants.InputChain,
 			constants.FilterTable)
 	}
+
+	deleteIPv6Chains()
+}
+
+func deleteIPv6Chains() {
+	pFilter, err := packetfilter.NewV6()
+	if err != nil {
+		logger.V(log.DEBUG).Infof("IPv6 packetfilter interface is not available - nothing to delete: %v", err)
+		return
+	}
+
+	chains := []packetfilter.ChainIPHook{
+		{
+			Name:     constants.SmPostRoutingChain,
+			Type:     packetfilter.ChainTypeNAT,
+			Hook:     packetfilter.ChainHookPostrouting,
+			Priority: packetfilter.ChainPriorityFirst,
+		},
+		{
+			Name:     constants.SmForwardChain,
+			Type:     packetfilter.ChainTypeFilter,
+			Hook:     packetfilter.ChainHookForward,
+			Priority: packetfilter.ChainPriorityFirst,
+		},
+	}
+
+	for i := range chains {
+		logger.Infof("Deleting IPv6 IPHook chain %q", chains[i].Name)
+
+		if err := pFilter.ClearChain(ipHookChainTypeToTableType(chains[i].Type), chains[i].Name); err != nil {
+			logger.Errorf(err, "Error flushing IPv6 packetfilter chain %q", chains[i].Name)
+		}
+
+		if err := pFilter.DeleteIPHookChain(&chains[i]); err != nil {
+			logger.Errorf(err, "Error deleting IPv6 IPHook chain %q", chains[i].Name)
+		}
+	}
+}
+
+func ipHookChainTypeToTableType(chainType packetfilter.ChainType) packetfilter.TableType {
+	if chainType == packetfilter.ChainTypeNAT {
+		return packetfilter.TableTypeNAT
+	}
+
+	return packetfilter.TableTypeFilter
 }
@@ -34,8 +34,9 @@ import (
 
 type GatewayRouteHandler struct {
 	event.HandlerBase
-	smClient  submarinerClientset.Interface
-	nextHopIP string
+	smClient     submarinerClientset.Interface
+	nextHopIP    string
+	activeActive bool
 }
 
 func NewGatewayRouteHandler(smClientSet submarinerClientset.Interface) *GatewayRouteHandler {
@@ -65,12 +66,17 @@ func (h *GatewayRouteHandler) GetNetworkPlugins() []string {
 	return []string{cni.OVNKubernetes}
 }
 
+func (h *GatewayRouteHandler) LocalEndpointCreated(endpoint *submarinerv1.Endpoint) error {
+	h.activeActive = endpoint.Spec.IsActiveActive()
+	return nil
+}
+
 func (h *GatewayRouteHandler) RemoteEndpointCreated(endpoint *submarinerv1.Endpoint) error {
 	if h.State().IsOnGateway() {
 		gwr := h.newGatewayRoute(endpoint)
 
 		result, err := util.CreateOrUpdate(context.TODO(), GatewayResourceInterface(h.smClient, endpoint.Namespace),
-			gwr, util.Replace(gwr))
+			gwr, h.mergeGatewayRoute(gwr))
 		if err != nil {
 			return errors.Wrapf(err, "error processing the remote endpoint creation for %q", endpoint.Name)
 		}
@@ -83,6 +89,18 @@ func (h *GatewayRouteHandler) RemoteEndpointCreated(endpoint *submarinerv1.Endpo
 
 func (h *GatewayRouteHandler) RemoteEndpointRemoved(endpoint *submarinerv1.Endpoint) error {
 	if h.State().IsOnGateway() {
+		if remaining := remainingEndpointFor(h.State(), endpoint); remaining != nil {
+			logger.Infof("Retaining GatewayRoute %s for remaining remote endpoint %s", endpoint.Spec.ClusterID, remaining.Name)
+			return h.RemoteEndpointCreated(remaining)
+		}
+
+		if h.activeActive {
+			retained, err := h.removeNextHop(endpoint)
+			if err != nil || retained {
+				return err
+			}
+		}
+
 		if err := h.smClient.SubmarinerV1().GatewayRoutes(endpoint.Namespace).Delete(context.TODO(),
 			endpoint.Spec.ClusterID, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
 			return errors.Wrapf(err, "error deleting gatewayRoute %q", endpoint.Name)
@@ -108,7 +126,7 @@ func (h *GatewayRouteHandler) TransitionToGateway() error {
 		gwr := h.newGatewayRoute(&endpoints[i])
 
 		result, err := util.CreateOrUpdate(context.TODO(), GatewayResourceInterface(h.smClient, endpoints[i].Namespace),
-			gwr, util.Replace(gwr))
+			gwr, h.mergeGatewayRoute(gwr))
 		if err != nil {
 			return errors.Wrapf(err, "error creating/updating GatewayRoute")
 		}
@@ -131,6 +149,40 @@ func (h *GatewayRouteHandler) newGatewayRoute(endpoint *submarinerv1.Endpoint) *
 	}
 }
 
+func (h *GatewayRouteHandler) mergeGatewayRoute(gwr *submarinerv1.GatewayRoute) util.MutateFn[*submarinerv1.GatewayRoute] {
+	if !h.activeActive {
+		return util.Replace(gwr)
+	}
+
+	return func(existing *submarinerv1.GatewayRoute) (*submarinerv1.GatewayRoute, error) {
+		existing.RoutePolicySpec.RemoteCIDRs = gwr.RoutePolicySpec.RemoteCIDRs
+		existing.RoutePolicySpec.NextHops = mergeNextHop(existing.RoutePolicySpec.NextHops, h.nextHopIP, true)
+
+		return existing, nil
+	}
+}
+
+// removeNextHop removes this gateway's next hop from the remote endpoint's GatewayRoute and returns true if the
+// GatewayRoute was retained for the other active/active gateways.
+func (h *GatewayRouteHandler) removeNextHop(endpoint *submarinerv1.Endpoint) (bool, error) {
+	retained := false
+
+	err := util.Update(context.TODO(), GatewayResourceInterface(h.smClient, endpoint.Namespace), h.newGatewayRoute(endpoint),
+		func(existing *submarinerv1.GatewayRoute) (*submarinerv1.GatewayRoute, error) {
+			nextHops := removeNextHop(existing.RoutePolicySpec.NextHops, h.nextHopIP)
+			if len(nextHops) == 0 {
+				return existing, nil
+			}
+
+			retained = true
+			existing.RoutePolicySpec.NextHops = nextHops
+
+			return existing, nil
+		})
+
+	return retained, errors.Wrapf(err, "error removing the next hop from GatewayRoute %q", endpoint.Spec.ClusterID)
+}
+
 func GatewayResourceInterface(smClient submarinerClientset.Interface, namespace string) resource.Interface[*submarinerv1.GatewayRoute] {
 	return &resource.InterfaceFuncs[*submarinerv1.GatewayRoute]{
 		GetFunc:    smClient.SubmarinerV1().GatewayRoutes(namespace).Get,
@@ -19,6 +19,7 @@ limitations under the License.
 package ovn_test
 
 import (
+	"context"
 	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -28,6 +29,7 @@ import (
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/event/testing"
 	"github.com/submariner-io/submariner/pkg/routeagent_driver/handlers/ovn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("GatewayRouteHandler", func() {
@@ -56,6 +58,30 @@ var _ = Describe("GatewayRouteHandler", func() {
 			test.AwaitNoResource(ovn.GatewayResourceInterface(t.submClient, testing.Namespace), endpoint.Spec.ClusterID)
 		})
 
+		Context("and the remote cluster has another active/active gateway", func() {
+			awaitRemoteCIDRs := func(ep *submarinerv1.Endpoint) {
+				Eventually(func() []string {
+					return test.AwaitResource(ovn.GatewayResourceInterface(t.submClient, testing.Namespace),
+						ep.Spec.ClusterID).RoutePolicySpec.RemoteCIDRs
+				}).Should(Equal(ep.Spec.Subnets))
+			}
+
+			It("should only delete the GatewayRoute when the last remote Endpoint is deleted", func() {
+				endpoint1 := t.CreateEndpoint(testing.NewEndpoint("remote-cluster1", "host1", "192.0.4.0/24"))
+				awaitGatewayRoute(endpoint1)
+
+				endpoint2 := t.CreateEndpoint(testing.NewEndpoint("remote-cluster1", "host2", "192.0.4.0/24", "192.0.5.0/24"))
+				awaitRemoteCIDRs(endpoint2)
+
+				t.DeleteEndpoint(endpoint2.Name)
+				test.EnsureNoActionsForResource(&t.submClient.Fake, "gatewayroutes", "delete")
+				awaitRemoteCIDRs(endpoint1)
+
+				t.DeleteEndpoint(endpoint1.Name)
+				test.AwaitNoResource(ovn.GatewayResourceInterface(t.submClient, testing.Namespace), endpoint1.Spec.ClusterID)
+			})
+		})
+
 		Context("and the GatewayRoute operations initially fail", func() {
 			JustBeforeEach(func() {
 				r := fake.NewFailingReactorForResource(&t.submClient.Fake, "gatewayroutes")
@@ -74,6 +100,41 @@ var _ = Describe("GatewayRouteHandler", func() {
 		})
 	})
 
+	When("a remote Endpoint is created and deleted on an active/active gateway", func() {
+		const otherNextHop = "100.1.1.1"
+
+		JustBeforeEach(func() {
+			_, err := t.submClient.SubmarinerV1().GatewayRoutes(testing.Namespace).Create(context.TODO(), &submarinerv1.GatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "remote-cluster1"},
+				RoutePolicySpec: submarinerv1.RoutePolicySpec{
+					RemoteCIDRs: []string{"192.0.4.0/24"},
+					NextHops:    []string{otherNextHop},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).To(Succeed())
+
+			localEndpoint := testing.NewEndpoint(testing.LocalClusterID, t.Hostname)
+			localEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.ActiveActive: "true"}
+			t.CreateEndpoint(localEndpoint)
+		})
+
+		It("should add/remove its next hop to/from the other gateway's GatewayRoute", func() {
+			endpoint := t.CreateEndpoint(testing.NewEndpoint("remote-cluster1", "host", "192.0.4.0/24"))
+
+			Eventually(func() []string {
+				return test.AwaitResource(ovn.GatewayResourceInterface(t.submClient, testing.Namespace),
+					endpoint.Spec.ClusterID).RoutePolicySpec.NextHops
+			}).Should(ConsistOf(otherNextHop, t.mgmntIntfIP))
+
+			t.DeleteEndpoint(endpoint.Name)
+
+			Eventually(func() []string {
+				return test.AwaitResource(ovn.GatewayResourceInterface(t.submClient, testing.Namespace),
+					endpoint.Spec.ClusterID).RoutePolicySpec.NextHops
+			}).Should(Equal([]string{otherNextHop}))
+		})
+	})
+
 	Context("on transition to gateway", func() {
 		It("should create GatewayRoutes for all remote Endpoints", func() {
 			endpoint := t.CreateEndpoint(testing.NewEndpoint("remote-cluster1", "host", "192.0.4.0/24"))
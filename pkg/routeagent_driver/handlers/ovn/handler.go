@@ -214,6 +214,11 @@ func (ovn *Handler) RemoteEndpointRemoved(endpoint *submV1.Endpoint) error {
 	}
 
 	if ovn.State().IsOnGateway() {
+		// The subnets are still in use if another active/active gateway of the remote cluster remains.
+		if remainingEndpointFor(ovn.State(), endpoint) != nil {
+			return ovn.updateGatewayDataplane()
+		}
+
 		for _, subnet := range endpoint.Spec.Subnets {
 			if err = ovn.removeNoMasqueradeIPTables(subnet); err != nil {
 				return errors.Wrapf(err, "error removing no-masquerade rules for subnet %q", subnet)
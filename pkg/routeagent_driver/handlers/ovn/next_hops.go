@@ -0,0 +1,66 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovn
+
+import (
+	"slices"
+
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/event"
+)
+
+// mergeNextHop returns the next hops of a GatewayRoute or NonGatewayRoute updated with the given next hop. With
+// active/active gateways, each gateway adds its own next hop so traffic is spread across all of them, otherwise the
+// next hop of the single active gateway replaces any existing ones.
+func mergeNextHop(nextHops []string, nextHop string, activeActive bool) []string {
+	if !activeActive {
+		return []string{nextHop}
+	}
+
+	if slices.Contains(nextHops, nextHop) {
+		return nextHops
+	}
+
+	merged := append(slices.Clone(nextHops), nextHop)
+	slices.Sort(merged)
+
+	return merged
+}
+
+// removeNextHop returns the given next hops without nextHop.
+func removeNextHop(nextHops []string, nextHop string) []string {
+	return slices.DeleteFunc(slices.Clone(nextHops), func(s string) bool {
+		return s == nextHop
+	})
+}
+
+// remainingEndpointFor returns another remote Endpoint of the removed Endpoint's cluster, if any. An active/active remote
+// cluster has an Endpoint per gateway, all sharing the cluster's subnets, so the routes to these must only be removed
+// along with the cluster's last Endpoint.
+func remainingEndpointFor(state event.HandlerState, removed *submarinerv1.Endpoint) *submarinerv1.Endpoint {
+	endpoints := state.GetRemoteEndpoints()
+
+	for i := range endpoints {
+		if endpoints[i].Spec.ClusterID == removed.Spec.ClusterID && endpoints[i].Name != removed.Name {
+			return &endpoints[i]
+		}
+	}
+
+	return nil
+}
@@ -38,6 +38,7 @@ type NonGatewayRouteHandler struct {
 	event.NodeHandlerBase
 	smClient        submarinerClientset.Interface
 	transitSwitchIP TransitSwitchIP
+	activeActive    bool
 }
 
 func NewNonGatewayRouteHandler(smClient submarinerClientset.Interface, transitSwitchIP TransitSwitchIP,
@@ -61,6 +62,11 @@ func (h *NonGatewayRouteHandler) GetNetworkPlugins() []string {
 	return []string{cni.OVNKubernetes}
 }
 
+func (h *NonGatewayRouteHandler) LocalEndpointCreated(endpoint *submarinerv1.Endpoint) error {
+	h.activeActive = endpoint.Spec.IsActiveActive()
+	return nil
+}
+
 func (h *NonGatewayRouteHandler) RemoteEndpointCreated(endpoint *submarinerv1.Endpoint) error {
 	if !h.State().IsOnGateway() || h.transitSwitchIP.Get() == "" {
 		return nil
@@ -69,7 +75,7 @@ func (h *NonGatewayRouteHandler) RemoteEndpointCreated(endpoint *submarinerv1.En
 	ngwr := h.newNonGatewayRoute(endpoint)
 
 	result, err := util.CreateOrUpdate(context.TODO(), NonGatewayResourceInterface(h.smClient, endpoint.Namespace),
-		ngwr, util.Replace(ngwr))
+		ngwr, h.mergeNonGatewayRoute(ngwr))
 	if err != nil {
 		return errors.Wrapf(err, "error processing the remote endpoint create event for %q", endpoint.Name)
 	}
@@ -84,6 +90,18 @@ func (h *NonGatewayRouteHandler) RemoteEndpointRemoved(endpoint *submarinerv1.En
 		return nil
 	}
 
+	if remaining := remainingEndpointFor(h.State(), endpoint); remaining != nil {
+		logger.Infof("Retaining NonGatewayRoute %s for remaining remote endpoint %s", endpoint.Spec.ClusterID, remaining.Name)
+		return h.RemoteEndpointCreated(remaining)
+	}
+
+	if h.activeActive {
+		retained, err := h.removeNextHop(endpoint)
+		if err != nil || retained {
+			return err
+		}
+	}
+
 	if err := h.smClient.SubmarinerV1().NonGatewayRoutes(endpoint.Namespace).Delete(context.TODO(),
 		endpoint.Spec.ClusterID, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
 		return errors.Wrapf(err, "error deleting nonGatewayRoute %q", endpoint.Name)
@@ -112,7 +130,7 @@ func (h *NonGatewayRouteHandler) TransitionToGateway() error {
 		ngwr := h.newNonGatewayRoute(&endpoints[i])
 
 		result, err := util.CreateOrUpdate(context.TODO(), NonGatewayResourceInterface(h.smClient, endpoints[i].Namespace),
-			ngwr, util.Replace(ngwr))
+			ngwr, h.mergeNonGatewayRoute(ngwr))
 		if err != nil {
 			return errors.Wrapf(err, "error creating/updating NonGatewayRoute")
 		}
@@ -124,6 +142,8 @@ func (h *NonGatewayRouteHandler) TransitionToGateway() error {
 }
 
 func (h *NonGatewayRouteHandler) NodeUpdated(node *corev1.Node) error {
+	prevTransitSwitchIP := h.transitSwitchIP.Get()
+
 	updated, err := h.transitSwitchIP.UpdateFrom(node)
 	if err != nil {
 		logger.Errorf(err, "Error updating transit switch IP from node: %s", resource.ToJSON(node))
@@ -144,7 +164,8 @@ func (h *NonGatewayRouteHandler) NodeUpdated(node *corev1.Node) error {
 	for i := range endpoints {
 		err = util.Update(context.TODO(), NonGatewayResourceInterface(h.smClient, endpoints[i].Namespace),
 			h.newNonGatewayRoute(&endpoints[i]), func(existing *submarinerv1.NonGatewayRoute) (*submarinerv1.NonGatewayRoute, error) {
-				existing.RoutePolicySpec.NextHops = []string{h.transitSwitchIP.Get()}
+				existing.RoutePolicySpec.NextHops = mergeNextHop(removeNextHop(existing.RoutePolicySpec.NextHops, prevTransitSwitchIP),
+					h.transitSwitchIP.Get(), h.activeActive)
 				return existing, nil
 			})
 		if err != nil {
@@ -168,6 +189,41 @@ func (h *NonGatewayRouteHandler) newNonGatewayRoute(endpoint *submarinerv1.Endpo
 	}
 }
 
+func (h *NonGatewayRouteHandler) mergeNonGatewayRoute(ngwr *submarinerv1.NonGatewayRoute,
+) util.MutateFn[*submarinerv1.NonGatewayRoute] {
+	if !h.activeActive {
+		return util.Replace(ngwr)
+	}
+
+	return func(existing *submarinerv1.NonGatewayRoute) (*submarinerv1.NonGatewayRoute, error) {
+		existing.RoutePolicySpec.RemoteCIDRs = ngwr.RoutePolicySpec.RemoteCIDRs
+		existing.RoutePolicySpec.NextHops = mergeNextHop(existing.RoutePolicySpec.NextHops, h.transitSwitchIP.Get(), true)
+
+		return existing, nil
+	}
+}
+
+// removeNextHop removes this gateway's next hop from the remote endpoint's NonGatewayRoute and returns true if the
+// NonGatewayRoute was retained for the other active/active gateways.
+func (h *NonGatewayRouteHandler) removeNextHop(endpoint *submarinerv1.Endpoint) (bool, error) {
+	retained := false
+
+	err := util.Update(context.TODO(), NonGatewayResourceInterface(h.smClient, endpoint.Namespace), h.newNonGatewayRoute(endpoint),
+		func(existing *submarinerv1.NonGatewayRoute) (*submarinerv1.NonGatewayRoute, error) {
+			nextHops := removeNextHop(existing.RoutePolicySpec.NextHops, h.transitSwitchIP.Get())
+			if len(nextHops) == 0 {
+				return existing, nil
+			}
+
+			retained = true
+			existing.RoutePolicySpec.NextHops = nextHops
+
+			return existing, nil
+		})
+
+	return retained, errors.Wrapf(err, "error removing the next hop from NonGatewayRoute %q", endpoint.Spec.ClusterID)
+}
+
 func NonGatewayResourceInterface(smClient submarinerClientset.Interface, namespace string,
 ) resource.Interface[*submarinerv1.NonGatewayRoute] {
 	return &resource.InterfaceFuncs[*submarinerv1.NonGatewayRoute]{
@@ -63,6 +63,30 @@ var _ = Describe("NonGatewayRouteHandler", func() {
 			test.AwaitNoResource(ovn.NonGatewayResourceInterface(t.submClient, testing.Namespace), endpoint.Spec.ClusterID)
 		})
 
+		Context("and the remote cluster has another active/active gateway", func() {
+			awaitRemoteCIDRs := func(ep *submarinerv1.Endpoint) {
+				Eventually(func() []string {
+					return test.AwaitResource(ovn.NonGatewayResourceInterface(t.submClient, testing.Namespace),
+						ep.Spec.ClusterID).RoutePolicySpec.RemoteCIDRs
+				}).Should(Equal(ep.Spec.Subnets))
+			}
+
+			It("should only delete the NonGatewayRoute when the last remote Endpoint is deleted", func() {
+				endpoint1 := t.CreateEndpoint(testing.NewEndpoint("remote-cluster", "host1", "193.0.4.0/24"))
+				awaitNonGatewayRoute(endpoint1)
+
+				endpoint2 := t.CreateEndpoint(testing.NewEndpoint("remote-cluster", "host2", "193.0.4.0/24", "193.0.5.0/24"))
+				awaitRemoteCIDRs(endpoint2)
+
+				t.DeleteEndpoint(endpoint2.Name)
+				test.EnsureNoActionsForResource(&t.submClient.Fake, "nongatewayroutes", "delete")
+				awaitRemoteCIDRs(endpoint1)
+
+				t.DeleteEndpoint(endpoint1.Name)
+				test.AwaitNoResource(ovn.NonGatewayResourceInterface(t.submClient, testing.Namespace), endpoint1.Spec.ClusterID)
+			})
+		})
+
 		Context("and the NonGatewayRoute operations initially fail", func() {
 			JustBeforeEach(func() {
 				r := fake.NewFailingReactorForResource(&t.submClient.Fake, "nongatewayroutes")
@@ -96,6 +120,41 @@ var _ = Describe("NonGatewayRouteHandler", func() {
 		})
 	})
 
+	When("a remote Endpoint is created and deleted on an active/active gateway", func() {
+		const otherNextHop = "100.88.0.9"
+
+		JustBeforeEach(func() {
+			_, err := t.submClient.SubmarinerV1().NonGatewayRoutes(testing.Namespace).Create(context.TODO(), &submarinerv1.NonGatewayRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "remote-cluster", Namespace: testing.Namespace},
+				RoutePolicySpec: submarinerv1.RoutePolicySpec{
+					RemoteCIDRs: []string{"193.0.4.0/24"},
+					NextHops:    []string{otherNextHop},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).To(Succeed())
+
+			localEndpoint := testing.NewEndpoint(testing.LocalClusterID, t.Hostname)
+			localEndpoint.Spec.BackendConfig = map[string]string{submarinerv1.ActiveActive: "true"}
+			t.CreateEndpoint(localEndpoint)
+		})
+
+		It("should add/remove its next hop to/from the other gateway's NonGatewayRoute", func() {
+			endpoint := t.CreateEndpoint(testing.NewEndpoint("remote-cluster", "host", "193.0.4.0/24"))
+
+			Eventually(func() []string {
+				return test.AwaitResource(ovn.NonGatewayResourceInterface(t.submClient, testing.Namespace),
+					endpoint.Spec.ClusterID).RoutePolicySpec.NextHops
+			}).Should(ConsistOf(otherNextHop, t.transitSwitchIP))
+
+			t.DeleteEndpoint(endpoint.Name)
+
+			Eventually(func() []string {
+				return test.AwaitResource(ovn.NonGatewayResourceInterface(t.submClient, testing.Namespace),
+					endpoint.Spec.ClusterID).RoutePolicySpec.NextHops
+			}).Should(Equal([]string{otherNextHop}))
+		})
+	})
+
 	Context("on transition to gateway", func() {
 		It("should create NonGatewayRoutes for all remote Endpoints", func() {
 			endpoint := t.CreateEndpoint(testing.NewEndpoint("remote-cluster", "host", "193.0.4.0/24"))
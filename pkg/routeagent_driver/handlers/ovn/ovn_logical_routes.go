@@ -20,6 +20,7 @@ package ovn
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdbops"
@@ -81,11 +82,24 @@ func buildLRSRsFromSubnets(subnetsToAdd []string, nextHop string) []*nbdb.Logica
 	return toAdd
 }
 
-func (c *ConnectionHandler) reconcileSubOvnLogicalRouterPolicies(remoteSubnets sets.Set[string], nextHop string) error {
+// lrpNextHops returns the next hop fields for a reroute policy. A single next hop uses the Nexthop field, as always,
+// whereas multiple next hops, from active/active gateways, use the Nexthops field so OVN spreads flows across them.
+func lrpNextHops(nextHops []string) (*string, []string) {
+	if len(nextHops) == 1 {
+		return ptr.To(nextHops[0]), nil
+	}
+
+	return nil, nextHops
+}
+
+func (c *ConnectionHandler) reconcileSubOvnLogicalRouterPolicies(remoteSubnets sets.Set[string], nextHops []string) error {
+	nextHop, ecmpNextHops := lrpNextHops(nextHops)
+
 	lrpStalePredicate := func(item *nbdb.LogicalRouterPolicy) bool {
 		subnet := strings.Split(item.Match, " ")[2]
 
-		return item.Priority == ovnRoutePoliciesPrio && (!remoteSubnets.Has(subnet) || !reflect.DeepEqual(item.Nexthop, &nextHop))
+		return item.Priority == ovnRoutePoliciesPrio && (!remoteSubnets.Has(subnet) || !reflect.DeepEqual(item.Nexthop, nextHop) ||
+			!slices.Equal(item.Nexthops, ecmpNextHops))
 	}
 
 	// Cleanup any existing lrps not representing the correct set of remote subnets
@@ -94,7 +108,7 @@ func (c *ConnectionHandler) reconcileSubOvnLogicalRouterPolicies(remoteSubnets s
 		return errors.Wrapf(err, "failed to delete stale submariner logical route policies")
 	}
 
-	expectedLRPs := buildLRPsFromSubnets(remoteSubnets.UnsortedList(), nextHop)
+	expectedLRPs := buildLRPsFromSubnets(remoteSubnets.UnsortedList(), nextHops)
 
 	for _, lrp := range expectedLRPs {
 		lrpSubPredicate := func(item *nbdb.LogicalRouterPolicy) bool {
@@ -116,15 +130,17 @@ func (c *ConnectionHandler) reconcileSubOvnLogicalRouterPolicies(remoteSubnets s
 // getNorthSubnetsToAddAndRemove receives the existing state for the north (other clusters) routes in the OVN
 // database, and based on the known remote endpoints it will return the elements that need
 // to be added and removed.
-func buildLRPsFromSubnets(subnetsToAdd []string, nextHop string) []*nbdb.LogicalRouterPolicy {
+func buildLRPsFromSubnets(subnetsToAdd, nextHops []string) []*nbdb.LogicalRouterPolicy {
 	toAdd := []*nbdb.LogicalRouterPolicy{}
+	nextHop, ecmpNextHops := lrpNextHops(nextHops)
 
 	for _, subnet := range subnetsToAdd {
 		toAdd = append(toAdd, &nbdb.LogicalRouterPolicy{
 			Priority: ovnRoutePoliciesPrio,
 			Action:   "reroute",
 			Match:    "ip4.dst == " + subnet,
-			Nexthop:  ptr.To(nextHop),
+			Nexthop:  nextHop,
+			Nexthops: ecmpNextHops,
 			ExternalIDs: map[string]string{
 				"submariner": versions.Submariner(),
 			},
@@ -19,7 +19,13 @@ limitations under the License.
 package types
 
 import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
 	subv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"github.com/submariner-io/submariner/pkg/cidr"
 	k8snet "k8s.io/utils/net"
 )
 
@@ -49,13 +55,54 @@ type SubmarinerSpecification struct {
 	HaltOnCertError               bool `split_words:"true"`
 	HealthCheckInterval           int
 	HealthCheckMaxPacketLossCount int
-	MetricsPort                   int `default:"32780"`
+	HealthCheckProbeEnabled       bool              `split_words:"true"`
+	HealthCheckProbePort          int               `split_words:"true" default:"4801"`
+	MetricsPort                   int               `default:"32780"`
+	ActiveActive                  bool              `split_words:"true"`
+	ClusterLabels                 map[string]string `split_words:"true"`
+	TopologyPolicy                string            `split_words:"true"`
+	// The connection retries and flap damping of the cables. Flap damping is disabled if the flap threshold is 0.
+	CableRetryInitialBackoff time.Duration `split_words:"true" default:"1s"`
+	CableRetryMaxBackoff     time.Duration `split_words:"true" default:"5m"`
+	CableFlapThreshold       int           `split_words:"true" default:"5"`
+	CableFlapWindow          time.Duration `split_words:"true" default:"5m"`
+	CableSuppressDuration    time.Duration `split_words:"true" default:"10m"`
+	// The authentication of the NAT discovery messages: the mounted secret containing the key, whether unauthenticated
+	// messages are allowed from peers which don't advertise authentication support, and the maximum clock skew.
+	NATTDiscoveryKeySecret    string        `envconfig:"NATT_DISCOVERY_KEY_SECRET"`
+	NATTDiscoveryV1Compat     bool          `envconfig:"NATT_DISCOVERY_V1_COMPAT" default:"true"`
+	NATTDiscoveryMaxClockSkew time.Duration `envconfig:"NATT_DISCOVERY_MAX_CLOCK_SKEW" default:"30s"`
+}
+
+// GetTopologyPolicy returns the cluster's topology policy, parsed from its JSON representation, or nil if none is
+// configured, ie the cluster is connected to all the other clusters.
+func (subSpec *SubmarinerSpecification) GetTopologyPolicy() (*subv1.TopologyPolicy, error) {
+	if subSpec.TopologyPolicy == "" {
+		return nil, nil //nolint:nilnil // Intentional
+	}
+
+	policy := &subv1.TopologyPolicy{}
+	if err := json.Unmarshal([]byte(subSpec.TopologyPolicy), policy); err != nil {
+		return nil, errors.Wrap(err, "error parsing the topology policy")
+	}
+
+	switch policy.Mode {
+	case "", subv1.TopologyFullMesh, subv1.TopologyHubSpoke, subv1.TopologyPartialMesh:
+	default:
+		return nil, errors.Errorf("invalid topology mode %q", policy.Mode)
+	}
+
+	return policy, nil
 }
 
-func (subSpec *SubmarinerSpecification) GetIPFamilies() [2]k8snet.IPFamily {
-	var ipFamilies [2]k8snet.IPFamily
-	// TODO_IPV6: set ipFamilies according to ClusterCidr content
-	ipFamilies[0] = k8snet.IPv4
+// GetIPFamilies returns the IP families configured for the cluster, based on the cluster and service CIDRs. The
+// families are returned in the order they first appear, so the primary family of a dual-stack cluster comes first.
+// IPv4 is assumed if no CIDR is configured.
+func (subSpec *SubmarinerSpecification) GetIPFamilies() []k8snet.IPFamily {
+	ipFamilies := cidr.ExtractIPFamilies(append(slices.Clone(subSpec.ClusterCidr), subSpec.ServiceCidr...))
+	if len(ipFamilies) == 0 {
+		return []k8snet.IPFamily{k8snet.IPv4}
+	}
 
 	return ipFamilies
 }
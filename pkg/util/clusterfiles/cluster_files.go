@@ -27,6 +27,9 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/log"
+	"github.com/submariner-io/admiral/pkg/resource"
+	"github.com/submariner-io/admiral/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,6 +37,9 @@ import (
 
 var logger = log.Logger{Logger: logf.Log.WithName("ClusterFiles")}
 
+// ErrDataNotFound is returned, wrapped, by Get when the secret or configmap exists but does not contain the requested data.
+var ErrDataNotFound = errors.New("cluster file data not found")
+
 // Get retrieves a config from a secret, configmap or file within the k8s cluster
 // using an url schema that supports configmap://<namespace>/<configmap-name>/<data-file>
 // secret://<namespace>/<secret-name>/<data-file> and file:///<path> returning
@@ -70,7 +76,7 @@ func Get(ctx context.Context, k8sClient kubernetes.Interface, urlAddress string)
 
 		data, ok = secret.Data[pathFile]
 		if !ok {
-			return "", errors.Errorf("cluster file data %q not found in secret %s", pathFile, secret.Name)
+			return "", errors.Wrapf(ErrDataNotFound, "data %q in secret %s", pathFile, secret.Name)
 		}
 
 	case "configmap":
@@ -85,7 +91,7 @@ func Get(ctx context.Context, k8sClient kubernetes.Interface, urlAddress string)
 		if !ok {
 			dataStr, ok := configMap.Data[pathFile]
 			if !ok {
-				return "", errors.Errorf("cluster file data %q not found in %#v", pathFile, configMap)
+				return "", errors.Wrapf(ErrDataNotFound, "data %q in %#v", pathFile, configMap)
 			}
 
 			data = []byte(dataStr)
@@ -98,6 +104,54 @@ func Get(ctx context.Context, k8sClient kubernetes.Interface, urlAddress string)
 	return storeToDisk(pathContainerObject, parsedURL, data)
 }
 
+// Put stores data in the cluster file referenced by a secret://<namespace>/<secret-name>/<data-file> URL, creating the
+// secret if necessary. The other schemes supported by Get are read-only.
+func Put(ctx context.Context, k8sClient kubernetes.Interface, urlAddress string, data []byte) error {
+	logger.V(log.DEBUG).Infof("Writing cluster_file: %s", urlAddress)
+
+	parsedURL, err := url.Parse(urlAddress)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing cluster file URL %q", urlAddress)
+	}
+
+	namespace := parsedURL.Host
+	secretName, pathFile := path.Split(parsedURL.Path)
+	secretName = strings.Trim(secretName, "/")
+
+	if secretName == "" || pathFile == "" {
+		return errors.Errorf("cluster file URL %q is not well formed", urlAddress)
+	}
+
+	if parsedURL.Scheme != "secret" {
+		return errors.Errorf("the scheme %q in cluster file URL %q does not support writing", parsedURL.Scheme, urlAddress)
+	}
+
+	secrets := &resource.InterfaceFuncs[*corev1.Secret]{
+		GetFunc:    k8sClient.CoreV1().Secrets(namespace).Get,
+		CreateFunc: k8sClient.CoreV1().Secrets(namespace).Create,
+		UpdateFunc: k8sClient.CoreV1().Secrets(namespace).Update,
+		DeleteFunc: k8sClient.CoreV1().Secrets(namespace).Delete,
+	}
+
+	_, err = util.CreateOrUpdate(ctx, secrets, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{pathFile: data},
+	}, func(existing *corev1.Secret) (*corev1.Secret, error) {
+		if existing.Data == nil {
+			existing.Data = map[string][]byte{}
+		}
+
+		existing.Data[pathFile] = data
+
+		return existing, nil
+	})
+
+	return errors.Wrapf(err, "error writing secret %q in namespace %q", secretName, namespace)
+}
+
 func storeToDisk(pathContainerObject string, parsedURL *url.URL, data []byte) (string, error) {
 	storageDirectory, err := os.MkdirTemp("", "cluster_files")
 	if err != nil {
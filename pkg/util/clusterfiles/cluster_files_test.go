@@ -88,9 +88,9 @@ var _ = Describe("Cluster Files Get", func() {
 	})
 
 	When("the content inside the file does not exist", func() {
-		It("should return an error", func() {
+		It("should return an ErrDataNotFound error", func() {
 			_, err := clusterfiles.Get(ctx, client, "secret://ns1/my-secret/data1-does-not-exist")
-			Expect(err).To(HaveOccurred())
+			Expect(err).To(MatchError(clusterfiles.ErrDataNotFound))
 		})
 	})
 
@@ -146,3 +146,53 @@ func TestClusterFiles(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Cluster Files Suite")
 }
+
+var _ = Describe("Cluster Files Put", func() {
+	ctx := context.TODO()
+
+	var client kubernetes.Interface
+	BeforeEach(func() {
+		client = fake.NewClientset(
+			&v1.Secret{
+				ObjectMeta: v1meta.ObjectMeta{Namespace: "ns1", Name: "my-secret"},
+				Data: map[string][]byte{
+					"data1": theData,
+				},
+			})
+	})
+
+	getSecretData := func(name string) map[string][]byte {
+		secret, err := client.CoreV1().Secrets("ns1").Get(ctx, name, v1meta.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		return secret.Data
+	}
+
+	When("the secret exists", func() {
+		It("should add the data to the secret", func() {
+			Expect(clusterfiles.Put(ctx, client, "secret://ns1/my-secret/data2", []byte("other"))).To(Succeed())
+			Expect(getSecretData("my-secret")).To(Equal(map[string][]byte{
+				"data1": theData,
+				"data2": []byte("other"),
+			}))
+		})
+	})
+
+	When("the secret does not exist", func() {
+		It("should create the secret with the data", func() {
+			Expect(clusterfiles.Put(ctx, client, "secret://ns1/new-secret/data1", theData)).To(Succeed())
+			Expect(getSecretData("new-secret")).To(Equal(map[string][]byte{"data1": theData}))
+
+			file, err := clusterfiles.Get(ctx, client, "secret://ns1/new-secret/data1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.ReadFile(file)).To(Equal(theData))
+		})
+	})
+
+	When("the scheme is not writable", func() {
+		It("should return an error", func() {
+			Expect(clusterfiles.Put(ctx, client, "configmap://ns1/my-configmap/data1", theData)).NotTo(Succeed())
+			Expect(clusterfiles.Put(ctx, client, "file:///dir/file", theData)).NotTo(Succeed())
+		})
+	})
+})
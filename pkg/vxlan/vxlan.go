@@ -19,6 +19,7 @@ limitations under the License.
 package vxlan
 
 import (
+	"encoding/binary"
 	"net"
 	"os"
 	"strconv"
@@ -47,7 +48,11 @@ type Interface struct {
 	link    *netlink.Vxlan
 }
 
-const MTUOverhead = 50
+const (
+	MTUOverhead = 50
+	// MTUOverheadIPv6 is the overhead of the vxlan encapsulation over IPv6, whose header is 20 bytes longer.
+	MTUOverheadIPv6 = 70
+)
 
 var logger = log.Logger{Logger: logf.Log.WithName("VxlanAPI")}
 
@@ -64,10 +69,16 @@ func NewInterface(attrs *Attributes, netLink netlinkAPI.Interface) (*Interface,
 }
 
 func createLinkDevice(attrs *Attributes, netLink netlinkAPI.Interface) (*netlink.Vxlan, error) {
+	// The underlay family of the device is that of its source address, IPv4 if it has none.
+	overhead := MTUOverhead
+	if attrs.SrcAddr != nil && attrs.SrcAddr.To4() == nil {
+		overhead = MTUOverheadIPv6
+	}
+
 	link := &netlink.Vxlan{
 		LinkAttrs: netlink.LinkAttrs{
 			Name:  attrs.Name,
-			MTU:   attrs.Mtu - MTUOverhead,
+			MTU:   attrs.Mtu - overhead,
 			Flags: net.FlagUp,
 		},
 		VxlanId: attrs.VxlanID,
@@ -145,6 +156,21 @@ func GetVtepIPAddressFrom(ipAddr string, networkPrefix int) (net.IP, error) {
 	return net.ParseIP(strings.Join(ipSlice, ".")), nil
 }
 
+// GetVtepIPv6AddressFrom derives the IPv6 VTEP address from the IPv6 address ipAddr by replacing its first 16 bits
+// with networkPrefix.
+func GetVtepIPv6AddressFrom(ipAddr string, networkPrefix uint16) (net.IP, error) {
+	ip := net.ParseIP(ipAddr)
+	if ip == nil || ip.To4() != nil {
+		return nil, errors.Errorf("invalid IPv6 ipAddr %q", ipAddr)
+	}
+
+	vtepIP := make(net.IP, net.IPv6len)
+	copy(vtepIP, ip)
+	binary.BigEndian.PutUint16(vtepIP, networkPrefix)
+
+	return vtepIP, nil
+}
+
 func (i *Interface) ConfigureIPAddress(ipAddress net.IP, mask net.IPMask) error {
 	ipConfig := &netlink.Addr{IPNet: &net.IPNet{
 		IP:   ipAddress,
@@ -108,6 +108,19 @@ var _ = Describe("GetVtepIPAddressFrom", func() {
 	})
 })
 
+var _ = Describe("GetVtepIPv6AddressFrom", func() {
+	It("should return the correct IP", func() {
+		vtepIP, err := vxlan.GetVtepIPv6AddressFrom("fd00:17:2::3", 0xfdf1)
+		Expect(err).To(Succeed())
+		Expect(vtepIP).To(Equal(net.ParseIP("fdf1:17:2::3")))
+	})
+
+	Specify("should return an error if the input IP isn't an IPv6 address", func() {
+		_, err := vxlan.GetVtepIPv6AddressFrom("10.17.2.3", 0xfdf1)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("Interface", func() {
 	t := newTestDriver()
 